@@ -0,0 +1,26 @@
+// decimal 示例：按交易对步长与精度处理下单数量和价格
+// go run ./examples/decimal
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+func main() {
+	quantity, err := exchange.ParseDecimal("0.00823197123123")
+	if err != nil {
+		log.Fatalf("解析数量失败: %v", err)
+	}
+	stepSize := exchange.ToDecimal("0.000010")
+	tickSize := exchange.ToDecimal("0.01")
+	price := exchange.ToDecimal("64321.2378")
+
+	// 数量按步长向下取整，避免超出可用余额；价格按最小变动价位四舍五入
+	precision := exchange.StepPrecision(stepSize)
+	fmt.Println("数量:", exchange.FloorToStep(quantity, stepSize).StringFixed(precision))
+	fmt.Println("价格:", exchange.RoundToStep(price, tickSize).StringFixed(exchange.StepPrecision(tickSize)))
+	fmt.Println("名义价值:", quantity.Mul(price).RoundDown(2).StringFixed(2))
+}
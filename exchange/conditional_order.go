@@ -0,0 +1,138 @@
+package exchange
+
+import (
+	"fmt"
+)
+
+// 条件单类型
+type ConditionalOrderType string
+
+// 条件单状态
+type ConditionalOrderStatus string
+
+const (
+	ConditionalOrderTypeStopMarket       ConditionalOrderType = "STOP_MARKET"          // 市价止损，价格触及触发价后按市价下单
+	ConditionalOrderTypeStopLimit        ConditionalOrderType = "STOP_LIMIT"           // 限价止损，价格触及触发价后按委托价下单
+	ConditionalOrderTypeTakeProfitMarket ConditionalOrderType = "TAKE_PROFIT_MARKET"   // 市价止盈
+	ConditionalOrderTypeTakeProfitLimit  ConditionalOrderType = "TAKE_PROFIT_LIMIT"    // 限价止盈
+	ConditionalOrderTypeTrailingStop     ConditionalOrderType = "TRAILING_STOP_MARKET" // 跟踪止损，价格从极值回撤回调幅度后按市价下单
+	ConditionalOrderTypeOCO              ConditionalOrderType = "OCO"                  // 二选一，限价止盈与止损任一成交或触发后另一个自动撤销，仅现货
+
+	ConditionalOrderStatusUntriggered ConditionalOrderStatus = "UNTRIGGERED" // 未触发
+	ConditionalOrderStatusTriggered   ConditionalOrderStatus = "TRIGGERED"   // 已触发并下单
+	ConditionalOrderStatusCanceled    ConditionalOrderStatus = "CANCELED"    // 已撤销
+	ConditionalOrderStatusFailed      ConditionalOrderStatus = "FAILED"      // 触发后下单失败或已过期
+)
+
+// ConditionalOrderRequest 条件单请求
+type ConditionalOrderRequest struct {
+	Market         Market               `json:"market"`         // 市场类型
+	Symbol         string               `json:"symbol"`         // 交易对
+	Side           OrderSide            `json:"side"`           // 方向
+	Type           ConditionalOrderType `json:"type"`           // 类型
+	Quantity       string               `json:"quantity"`       // 数量，单位为基础资产
+	TriggerPrice   string               `json:"triggerPrice"`   // 触发价，跟踪止损为激活价（可为空），OCO 为止损触发价
+	Price          string               `json:"price"`          // 委托价，仅限价止损、限价止盈与 OCO 的限价止盈
+	StopLimitPrice string               `json:"stopLimitPrice"` // OCO 止损触发后的委托价，为空表示市价
+	CallbackRate   string               `json:"callbackRate"`   // 跟踪止损回调幅度，百分比，如 1 表示 1%
+	ReduceOnly     bool                 `json:"reduceOnly"`     // 只减仓，仅合约
+	PositionSide   PositionSide         `json:"positionSide"`   // 持仓方向，仅合约双向持仓模式
+	ClientOrderID  string               `json:"clientOrderId"`  // 客户端订单ID，为空时自动生成
+}
+
+// ConditionalOrder 条件单
+type ConditionalOrder struct {
+	ID             string                 `json:"id"`             // 条件单ID，撤销时原样传入
+	ClientOrderID  string                 `json:"clientOrderId"`  // 客户端订单ID
+	Market         Market                 `json:"market"`         // 市场类型
+	Symbol         string                 `json:"symbol"`         // 交易对
+	Side           OrderSide              `json:"side"`           // 方向
+	Type           ConditionalOrderType   `json:"type"`           // 类型
+	Status         ConditionalOrderStatus `json:"status"`         // 状态
+	Quantity       string                 `json:"quantity"`       // 数量
+	TriggerPrice   string                 `json:"triggerPrice"`   // 触发价
+	Price          string                 `json:"price"`          // 委托价
+	StopLimitPrice string                 `json:"stopLimitPrice"` // OCO 止损委托价
+	CallbackRate   string                 `json:"callbackRate"`   // 跟踪止损回调幅度，百分比
+	OrderID        string                 `json:"orderId"`        // 触发后生成的订单ID，未触发时为空
+	CreateTime     int64                  `json:"createTime"`     // 创建时间
+	UpdateTime     int64                  `json:"updateTime"`     // 更新时间
+}
+
+// IsLimit 触发后是否按委托价下单
+func (t ConditionalOrderType) IsLimit() bool {
+	return t == ConditionalOrderTypeStopLimit || t == ConditionalOrderTypeTakeProfitLimit
+}
+
+// IsTakeProfit 是否止盈类型
+func (t ConditionalOrderType) IsTakeProfit() bool {
+	return t == ConditionalOrderTypeTakeProfitMarket || t == ConditionalOrderTypeTakeProfitLimit
+}
+
+// Validate 校验与交易所无关的条件单参数，校验失败返回包装 ErrInvalidOrder 的错误
+func (r *ConditionalOrderRequest) Validate() error {
+	if r.Market != MarketSpot && r.Market != MarketFutures {
+		return fmt.Errorf("%w: 不支持的市场类型 %s", ErrInvalidOrder, r.Market)
+	}
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: 交易对不能为空", ErrInvalidOrder)
+	}
+	if r.Side != OrderSideBuy && r.Side != OrderSideSell {
+		return fmt.Errorf("%w: 不支持的方向 %s", ErrInvalidOrder, r.Side)
+	}
+	if !isPositive(r.Quantity) {
+		return fmt.Errorf("%w: 无效的数量 %s", ErrInvalidOrder, r.Quantity)
+	}
+
+	switch r.Type {
+	case ConditionalOrderTypeStopMarket, ConditionalOrderTypeTakeProfitMarket:
+		if !isPositive(r.TriggerPrice) || r.Price != "" {
+			return fmt.Errorf("%w: %s 需指定触发价且不能指定委托价", ErrInvalidOrder, r.Type)
+		}
+	case ConditionalOrderTypeStopLimit, ConditionalOrderTypeTakeProfitLimit:
+		if !isPositive(r.TriggerPrice) || !isPositive(r.Price) {
+			return fmt.Errorf("%w: %s 需指定触发价与委托价", ErrInvalidOrder, r.Type)
+		}
+	case ConditionalOrderTypeTrailingStop:
+		if !isPositive(r.CallbackRate) || r.Price != "" {
+			return fmt.Errorf("%w: 跟踪止损需指定回调幅度且不能指定委托价", ErrInvalidOrder)
+		}
+		if r.TriggerPrice != "" && !isPositive(r.TriggerPrice) {
+			return fmt.Errorf("%w: 无效的激活价 %s", ErrInvalidOrder, r.TriggerPrice)
+		}
+	case ConditionalOrderTypeOCO:
+		if r.Market != MarketSpot {
+			return fmt.Errorf("%w: OCO 仅支持现货", ErrInvalidOrder)
+		}
+		if !isPositive(r.TriggerPrice) || !isPositive(r.Price) {
+			return fmt.Errorf("%w: OCO 需指定止盈委托价与止损触发价", ErrInvalidOrder)
+		}
+		if r.StopLimitPrice != "" && !isPositive(r.StopLimitPrice) {
+			return fmt.Errorf("%w: 无效的止损委托价 %s", ErrInvalidOrder, r.StopLimitPrice)
+		}
+	default:
+		return fmt.Errorf("%w: 不支持的条件单类型 %s", ErrInvalidOrder, r.Type)
+	}
+	if r.Type != ConditionalOrderTypeOCO && r.StopLimitPrice != "" {
+		return fmt.Errorf("%w: 止损委托价仅支持 OCO", ErrInvalidOrder)
+	}
+	if r.Type != ConditionalOrderTypeTrailingStop && r.CallbackRate != "" {
+		return fmt.Errorf("%w: 回调幅度仅支持跟踪止损", ErrInvalidOrder)
+	}
+
+	if r.Market == MarketSpot && (r.ReduceOnly || r.PositionSide != "") {
+		return fmt.Errorf("%w: 现货不支持只减仓与持仓方向", ErrInvalidOrder)
+	}
+	switch r.PositionSide {
+	case "", PositionSideLong, PositionSideShort, PositionSideBoth:
+	default:
+		return fmt.Errorf("%w: 不支持的持仓方向 %s", ErrInvalidOrder, r.PositionSide)
+	}
+	return nil
+}
+
+// isPositive 是否为大于 0 的十进制数
+func isPositive(value string) bool {
+	d, err := ParseDecimal(value)
+	return err == nil && d.IsPositive()
+}
@@ -0,0 +1,37 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestConditionalOrderRequestValidate 按条件单类型校验触发价、委托价与回调幅度
+// go test -v ./exchange -run "^TestConditionalOrderRequestValidate$"
+func TestConditionalOrderRequestValidate(t *testing.T) {
+	valid := []ConditionalOrderRequest{
+		{Market: MarketFutures, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeStopMarket, Quantity: "1", TriggerPrice: "90", ReduceOnly: true},
+		{Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeTakeProfitLimit, Quantity: "1", TriggerPrice: "110", Price: "109"},
+		{Market: MarketFutures, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeTrailingStop, Quantity: "1", CallbackRate: "1"},
+		{Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeOCO, Quantity: "1", Price: "110", TriggerPrice: "90", StopLimitPrice: "89"},
+	}
+	for _, req := range valid {
+		if err := req.Validate(); err != nil {
+			t.Errorf("%s 应通过校验: %v", req.Type, err)
+		}
+	}
+
+	invalid := map[string]ConditionalOrderRequest{
+		"市价止损指定委托价":   {Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeStopMarket, Quantity: "1", TriggerPrice: "90", Price: "89"},
+		"限价止损缺少委托价":   {Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeStopLimit, Quantity: "1", TriggerPrice: "90"},
+		"跟踪止损缺少回调幅度":  {Market: MarketFutures, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeTrailingStop, Quantity: "1"},
+		"合约 OCO":      {Market: MarketFutures, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeOCO, Quantity: "1", Price: "110", TriggerPrice: "90"},
+		"现货只减仓":       {Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeStopMarket, Quantity: "1", TriggerPrice: "90", ReduceOnly: true},
+		"非 OCO 止损委托价": {Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeStopMarket, Quantity: "1", TriggerPrice: "90", StopLimitPrice: "89"},
+		"缺少数量":        {Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideSell, Type: ConditionalOrderTypeStopMarket, TriggerPrice: "90"},
+	}
+	for name, req := range invalid {
+		if err := req.Validate(); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("%s: 应返回 ErrInvalidOrder, got %v", name, err)
+		}
+	}
+}
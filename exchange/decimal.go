@@ -0,0 +1,63 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decimal 精确十进制数，JSON 序列化为字符串，支持比较（Cmp、Equal、LessThan 等）与舍入（Round、RoundDown、Truncate 等）
+type Decimal = decimal.Decimal
+
+// ParseDecimal 解析十进制数，空字符串视为 0
+func ParseDecimal(value string) (Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("无效的数值: %s", value)
+	}
+	return d, nil
+}
+
+// ToDecimal 解析十进制数，空字符串或无法解析时返回 0
+func ToDecimal(value string) Decimal {
+	d, _ := ParseDecimal(value)
+	return d
+}
+
+// FloorToStep 按步长向下取整，即 floor(value / step) * step，step <= 0 时原样返回
+func FloorToStep(value, step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return value
+	}
+	quotient, remainder := value.QuoRem(step, 0)
+	if remainder.Sign() < 0 {
+		quotient = quotient.Sub(decimal.NewFromInt(1))
+	}
+	return quotient.Mul(step)
+}
+
+// RoundToStep 按步长四舍五入，step <= 0 时原样返回
+func RoundToStep(value, step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return value
+	}
+	return FloorToStep(value.Add(step.Div(decimal.NewFromInt(2))), step)
+}
+
+// StepPrecision 步长的小数位数，如 0.0010 为 3，10 为 0
+func StepPrecision(step Decimal) int32 {
+	s := step.String()
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return int32(len(s) - i - 1)
+	}
+	return 0
+}
+
+// NewDecimalFromInt 整数转换为十进制数
+func NewDecimalFromInt(value int64) Decimal {
+	return decimal.NewFromInt(value)
+}
@@ -0,0 +1,96 @@
+package exchange
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestFloorToStep 按步长向下取整
+// go test -v ./exchange -run "^TestFloorToStep$"
+func TestFloorToStep(t *testing.T) {
+	cases := []struct {
+		value, step, want string
+	}{
+		{"0.00823197", "0.0001", "0.0082"},
+		{"1.23", "0.1", "1.2"},
+		{"1.2", "0.1", "1.2"},
+		{"25", "10", "20"},
+		{"-1.25", "0.1", "-1.3"},
+		{"1.23", "0", "1.23"},
+	}
+	for _, c := range cases {
+		if got := FloorToStep(ToDecimal(c.value), ToDecimal(c.step)); !got.Equal(ToDecimal(c.want)) {
+			t.Errorf("FloorToStep(%s, %s) = %s, want %s", c.value, c.step, got, c.want)
+		}
+	}
+}
+
+// TestRoundToStep 按步长四舍五入
+// go test -v ./exchange -run "^TestRoundToStep$"
+func TestRoundToStep(t *testing.T) {
+	cases := []struct {
+		value, step, want string
+	}{
+		{"1.24", "0.1", "1.2"},
+		{"1.25", "0.1", "1.3"},
+		{"104.9", "5", "105"},
+		{"0.5", "0", "0.5"},
+	}
+	for _, c := range cases {
+		if got := RoundToStep(ToDecimal(c.value), ToDecimal(c.step)); !got.Equal(ToDecimal(c.want)) {
+			t.Errorf("RoundToStep(%s, %s) = %s, want %s", c.value, c.step, got, c.want)
+		}
+	}
+}
+
+// TestStepPrecision 步长的小数位数
+// go test -v ./exchange -run "^TestStepPrecision$"
+func TestStepPrecision(t *testing.T) {
+	cases := map[string]int32{"0.0010": 3, "0.01": 2, "1": 0, "10": 0}
+	for step, want := range cases {
+		if got := StepPrecision(ToDecimal(step)); got != want {
+			t.Errorf("StepPrecision(%s) = %d, want %d", step, got, want)
+		}
+	}
+}
+
+// TestParseDecimal 解析十进制数
+// go test -v ./exchange -run "^TestParseDecimal$"
+func TestParseDecimal(t *testing.T) {
+	if d, err := ParseDecimal(""); err != nil || !d.IsZero() {
+		t.Errorf("空字符串应为 0: %s, %v", d, err)
+	}
+	if _, err := ParseDecimal("abc"); err == nil {
+		t.Error("无效数值应返回错误")
+	}
+	// 0.1 + 0.2 精确等于 0.3
+	if sum := ToDecimal("0.1").Add(ToDecimal("0.2")); !sum.Equal(ToDecimal("0.3")) {
+		t.Errorf("0.1 + 0.2 = %s", sum)
+	}
+}
+
+// TestDecimalJSON 十进制数 JSON 序列化为字符串并可还原
+// go test -v ./exchange -run "^TestDecimalJSON$"
+func TestDecimalJSON(t *testing.T) {
+	order := &Order{Price: "0.10000000", Quantity: "12.5"}
+	data, err := json.Marshal(struct {
+		Price    Decimal `json:"price"`
+		Quantity Decimal `json:"quantity"`
+	}{order.PriceDecimal(), order.QuantityDecimal()})
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+	if string(data) != `{"price":"0.1","quantity":"12.5"}` {
+		t.Errorf("序列化结果错误: %s", data)
+	}
+
+	var decoded struct {
+		Price Decimal `json:"price"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("反序列化失败: %v", err)
+	}
+	if !decoded.Price.Equal(order.PriceDecimal()) {
+		t.Errorf("反序列化结果错误: %s", decoded.Price)
+	}
+}
@@ -0,0 +1,58 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 错误分类，交易所返回的错误映射为 *APIError，可通过 errors.Is 判断分类，通过 errors.As 获取原生错误码
+var (
+	ErrInsufficientBalance = errors.New("余额不足")
+	ErrOrderNotFound       = errors.New("订单不存在")
+	ErrRateLimited         = errors.New("请求频率超限")
+	ErrInvalidPrecision    = errors.New("价格或数量精度错误")
+	ErrMinNotional         = errors.New("下单数量或金额低于最小限制")
+	ErrAuth                = errors.New("认证失败")
+	ErrTimestampSkew       = errors.New("请求时间戳超出允许范围")
+	ErrMaintenance         = errors.New("交易所维护中")
+	ErrTemporary           = errors.New("交易所临时错误，请求结果未知")
+	ErrDuplicateOrder      = errors.New("客户端订单ID重复")
+	ErrInvalidOrder        = errors.New("无效的下单请求")
+	ErrInvalidTransfer     = errors.New("无效的划转请求")
+	ErrAmendOrderCanceled  = errors.New("改单失败，原订单已撤销")
+	ErrAmendOrderKept      = errors.New("改单失败，原订单未撤销")
+)
+
+// ErrClosed 连接已关闭，关闭后调用开始监听或订阅方法时返回
+var ErrClosed = errors.New("连接已关闭")
+
+// APIError 交易所接口返回的错误
+type APIError struct {
+	Kind    error  // 错误分类，如 ErrRateLimited，无法识别时为空
+	Code    string // 交易所原生错误码，Gate 为错误标签
+	Message string // 交易所原生错误信息
+	Err     error  // 原始错误
+}
+
+// NewAPIError 创建交易所接口错误，kind 为空表示无法识别的错误
+func NewAPIError(kind error, code, message string, err error) *APIError {
+	return &APIError{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+// Error 错误信息
+func (e *APIError) Error() string {
+	if e.Kind != nil {
+		return fmt.Sprintf("%s: code=%s, msg=%s", e.Kind, e.Code, e.Message)
+	}
+	return fmt.Sprintf("API 返回错误: code=%s, msg=%s", e.Code, e.Message)
+}
+
+// Is 判断错误分类
+func (e *APIError) Is(target error) bool {
+	return e.Kind != nil && e.Kind == target
+}
+
+// Unwrap 返回原始错误
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestAPIError 错误分类可通过 errors.Is 判断，原生错误码可通过 errors.As 获取
+// go test -v ./exchange -run "^TestAPIError$"
+func TestAPIError(t *testing.T) {
+	cause := errors.New("sdk error")
+	err := fmt.Errorf("合约下单失败: %w", NewAPIError(ErrInsufficientBalance, "-2019", "Margin is insufficient.", cause))
+
+	if !errors.Is(err, ErrInsufficientBalance) || errors.Is(err, ErrRateLimited) {
+		t.Errorf("错误分类判断错误: %v", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("应包含原始错误: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "-2019" || apiErr.Message != "Margin is insufficient." {
+		t.Errorf("原生错误码错误: %+v", apiErr)
+	}
+	if got := err.Error(); got != "合约下单失败: 余额不足: code=-2019, msg=Margin is insufficient." {
+		t.Errorf("Error() = %s", got)
+	}
+
+	// 无法识别的错误不属于任何分类
+	unknown := NewAPIError(nil, "-1000", "unknown", nil)
+	if errors.Is(unknown, ErrAuth) || unknown.Error() != "API 返回错误: code=-1000, msg=unknown" {
+		t.Errorf("未知错误判断错误: %v", unknown)
+	}
+}
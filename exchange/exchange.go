@@ -1,6 +1,9 @@
 package exchange
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // 余额
 type Balance struct {
@@ -10,28 +13,117 @@ type Balance struct {
 	Total  string `json:"total"`  // 总余额
 }
 
+// FreeDecimal 可用余额，无法解析时返回 0
+func (b Balance) FreeDecimal() Decimal {
+	return ToDecimal(b.Free)
+}
+
+// LockedDecimal 锁定余额，无法解析时返回 0
+func (b Balance) LockedDecimal() Decimal {
+	return ToDecimal(b.Locked)
+}
+
+// TotalDecimal 总余额，无法解析时返回 0
+func (b Balance) TotalDecimal() Decimal {
+	return ToDecimal(b.Total)
+}
+
 type Exchange interface {
+	///////////////////////////////// 公共 /////////////////////////////////////////
+	// ListInstruments 获取市场全部交易对元数据
+	ListInstruments(ctx context.Context, market Market) ([]*Instrument, error)
+	// GetInstrument 获取交易对元数据
+	GetInstrument(ctx context.Context, market Market, symbol string) (*Instrument, error)
+	// PlaceOrder 下单，参数按交易所规则校验，不支持的参数返回包装 ErrInvalidOrder 的错误；
+	// 客户端订单ID为空时使用 WithClientOrderID 设置的值或自动生成，遇到结果未知的临时错误时按客户端订单ID查询对账，确认订单不存在后重试
+	PlaceOrder(ctx context.Context, req OrderRequest) (*Order, error)
+	// PlaceOrders 批量下单，使用交易所批量接口并按数量限制分批发送，结果与请求一一对应，单个订单的错误记录在 OrderResult.Err；
+	// 批量下单不做对账重试，可按客户端订单ID查询确认结果未知的订单；上下文取消时返回的 error 不为空
+	PlaceOrders(ctx context.Context, reqs []OrderRequest) ([]OrderResult, error)
+	// CancelOrders 批量撤单，结果与订单ID一一对应，错误处理同 PlaceOrders
+	CancelOrders(ctx context.Context, market Market, symbol string, orderIDs []string) ([]OrderResult, error)
+	// AmendOrder 修改未完成的限价单，newPrice/newQty 为空表示不修改，数量为包含已成交部分的委托总数量，返回修改后的订单；
+	// Binance 现货没有改单接口，使用撤单重下接口原子执行，撤单失败时不下新单，返回的新订单ID与原订单不同；
+	// 新订单下单失败时返回包装 ErrInvalidOrder 的错误，原订单已撤销时同时包装 ErrAmendOrderCanceled，未撤销时包装 ErrAmendOrderKept
+	AmendOrder(ctx context.Context, market Market, symbol, orderID, newPrice, newQty string) (*Order, error)
+	// ListOpenOrders 获取交易对全部未完成的普通订单，不包括未触发的条件单
+	ListOpenOrders(ctx context.Context, market Market, symbol string) ([]*Order, error)
+	// CancelAllOrders 撤销交易对全部未完成的订单，没有挂单时不返回错误；Binance 会同时撤销未触发的条件单
+	CancelAllOrders(ctx context.Context, market Market, symbol string) error
+	// ListOrderHistory 分页查询已结束的历史订单，排序与查询范围受交易所限制，单页数量可能少于 Limit，
+	// 以 NextCursor 为空判断是否结束；订单不计算扣除手续费后的实际数量
+	ListOrderHistory(ctx context.Context, query OrderQuery) (*OrderPage, error)
+	// ListMyTrades 分页查询账户成交明细，手续费正数表示支出，合约数量单位为基础资产；
+	// 排序与查询范围受交易所限制，单页数量可能少于 Limit，以 NextCursor 为空判断是否结束
+	ListMyTrades(ctx context.Context, market Market, query FillQuery) (*FillPage, error)
+	// PlaceConditionalOrder 下条件单，不支持的类型或参数返回包装 ErrInvalidOrder 的错误
+	PlaceConditionalOrder(ctx context.Context, req ConditionalOrderRequest) (*ConditionalOrder, error)
+	// ListConditionalOrders 获取交易对未触发的条件单
+	ListConditionalOrders(ctx context.Context, market Market, symbol string) ([]*ConditionalOrder, error)
+	// GetConditionalOrder 获取条件单，包括已触发、已撤销与失败的条件单，id 为 ConditionalOrder.ID；Binance 现货 OCO 不支持查询
+	GetConditionalOrder(ctx context.Context, market Market, symbol string, id string) (*ConditionalOrder, error)
+	// CancelConditionalOrder 撤销未触发的条件单，id 为 ConditionalOrder.ID
+	CancelConditionalOrder(ctx context.Context, market Market, symbol string, id string) error
+	// Transfer 在现货、合约与资金账户之间划转资金，返回划转ID；参数无效或交易所不支持的账户组合返回包装 ErrInvalidTransfer 的错误
+	Transfer(ctx context.Context, asset, amount string, from, to AccountType) (string, error)
+	// ListTransfers 分页查询账户间划转记录，排序与查询范围受交易所限制，单页数量可能少于 Limit，以 NextCursor 为空判断是否结束
+	ListTransfers(ctx context.Context, query TransferQuery) (*TransferPage, error)
+
 	///////////////////////////////// 现货 /////////////////////////////////////////
 	// GetSpotSymbolTickers 获取现货交易对行情
 	GetSpotSymbolTickers(ctx context.Context, symbols ...string) (*Tickers, error)
+	// GetSpotOrderBook 获取现货深度快照，limit 为档位数量，0 使用交易所默认值
+	GetSpotOrderBook(ctx context.Context, symbol string, limit int) (*OrderBook, error)
+	// GetSpotKlines 获取现货K线，按开盘时间升序，start/end 为零值表示不限制，limit 为 0 时返回区间内全部K线
+	GetSpotKlines(ctx context.Context, symbol string, interval KlineInterval, start, end time.Time, limit int) ([]*Kline, error)
+	// GetSpotRecentTrades 获取现货最近成交，按时间升序，limit 为 0 使用交易所默认值
+	GetSpotRecentTrades(ctx context.Context, symbol string, limit int) ([]*Trade, error)
+	// GetSpotAggTrades 获取现货历史成交，Binance 为归集成交，返回 [start, end] 内从 start 开始按时间升序的成交，
+	// start 必填，end 为零值表示当前时间，limit 为 0 时返回区间内全部成交
+	GetSpotAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*Trade, error)
 	// GetSpotBalance 获取现货余额
 	GetSpotBalance(ctx context.Context) ([]Balance, error)
-	// CreateSpotOrder 现货下单
+	// CreateSpotOrder 现货下单，价格为空或 0 时为市价单，其他为 GTC 限价单，等同于 PlaceOrder(NewOrderRequest(...))
 	CreateSpotOrder(ctx context.Context, symbol string, side OrderSide, limitPrice, quantity string) (*Order, error)
 	// GetSpotOrder 获取现货订单
 	GetSpotOrder(ctx context.Context, symbol string, orderID string) (*Order, error)
+	// GetSpotOrderByClientID 按客户端订单ID获取现货订单
+	GetSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*Order, error)
 	// CancelSpotOrder 现货取消订单
 	CancelSpotOrder(ctx context.Context, symbol string, orderID string) (*Order, error)
+	// CancelSpotOrderByClientID 按客户端订单ID取消现货订单
+	CancelSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*Order, error)
 
 	///////////////////////////////// 合约 /////////////////////////////////////////
 	// GetFuturesSymbolTickers 获取合约交易对行情
 	GetFuturesSymbolTickers(ctx context.Context, symbols ...string) (*Tickers, error)
+	// GetFuturesOrderBook 获取合约深度快照，limit 为档位数量，0 使用交易所默认值，数量单位为基础资产
+	GetFuturesOrderBook(ctx context.Context, symbol string, limit int) (*OrderBook, error)
+	// GetFuturesKlines 获取合约K线，按开盘时间升序，start/end 为零值表示不限制，limit 为 0 时返回区间内全部K线
+	GetFuturesKlines(ctx context.Context, symbol string, interval KlineInterval, start, end time.Time, limit int) ([]*Kline, error)
+	// GetFuturesRecentTrades 获取合约最近成交，按时间升序，limit 为 0 使用交易所默认值
+	GetFuturesRecentTrades(ctx context.Context, symbol string, limit int) ([]*Trade, error)
+	// GetFuturesAggTrades 获取合约历史成交，Binance 为归集成交，返回 [start, end] 内从 start 开始按时间升序的成交，
+	// start 必填，end 为零值表示当前时间，limit 为 0 时返回区间内全部成交
+	GetFuturesAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*Trade, error)
+	// GetFundingRate 获取合约当前资金费率与下次结算时间
+	GetFundingRate(ctx context.Context, symbol string) (*FundingRate, error)
+	// GetFundingRateHistory 获取合约历史资金费率，返回 [start, end] 内按结算时间升序的记录，start/end 为零值表示不限制，
+	// limit 为 0 使用交易所默认值，单次数量受交易所限制
+	GetFundingRateHistory(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*FundingRate, error)
+	// GetMarkPrice 获取合约标记价格、指数价格与资金费率，symbols 为空表示全部交易对
+	GetMarkPrice(ctx context.Context, symbols ...string) ([]*MarkPrice, error)
 	// GetFuturesBalance 获取合约余额
 	GetFuturesBalance(ctx context.Context) ([]Balance, error)
-	// CreateFuturesOrder 合约下单
+	// GetIncomeHistory 分页查询合约账户资金流水，包括资金费、手续费、已实现盈亏、划转、清算费与返佣；
+	// 排序与查询范围受交易所限制，单页数量可能少于 Limit，以 NextCursor 为空判断是否结束
+	GetIncomeHistory(ctx context.Context, query IncomeQuery) (*IncomePage, error)
+	// CreateFuturesOrder 合约下单，价格规则同 CreateSpotOrder，按双向持仓开仓，买入开多、卖出开空
 	CreateFuturesOrder(ctx context.Context, symbol string, side OrderSide, limitPrice, quantity string) (*Order, error)
 	// GetFuturesOrder 获取合约订单
 	GetFuturesOrder(ctx context.Context, symbol string, orderID string) (*Order, error)
+	// GetFuturesOrderByClientID 按客户端订单ID获取合约订单
+	GetFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*Order, error)
 	// GetFuturesPositionRisk 获取合约持仓风险
 	GetFuturesPositionRisk(ctx context.Context, symbol string) (*SymbolPositionRisk, error)
 	// CloseFuturesPositionRisk 平仓合约持仓风险
@@ -48,4 +140,6 @@ type Exchange interface {
 	CancelFuturesSLTP(ctx context.Context, symbol string) error
 	// CancelFuturesOrder 撤销合约订单
 	CancelFuturesOrder(ctx context.Context, symbol string, orderID string) (*Order, error)
+	// CancelFuturesOrderByClientID 按客户端订单ID撤销合约订单
+	CancelFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*Order, error)
 }
@@ -0,0 +1,38 @@
+package exchange
+
+// FundingRate 合约资金费率
+type FundingRate struct {
+	Symbol      string `json:"symbol"`      // 交易对
+	FundingRate string `json:"fundingRate"` // 资金费率，当前费率为本期预测费率，历史费率为已结算费率
+	FundingTime int64  `json:"fundingTime"` // 结算时间，毫秒，当前费率为下次结算时间
+}
+
+// FundingRateDecimal 资金费率，无法解析时返回 0
+func (f *FundingRate) FundingRateDecimal() Decimal {
+	return ToDecimal(f.FundingRate)
+}
+
+// MarkPrice 合约标记价格
+type MarkPrice struct {
+	Symbol          string `json:"symbol"`          // 交易对
+	MarkPrice       string `json:"markPrice"`       // 标记价格
+	IndexPrice      string `json:"indexPrice"`      // 指数价格，交易所未提供时为空
+	FundingRate     string `json:"fundingRate"`     // 本期预测资金费率，交易所未提供时为空
+	NextFundingTime int64  `json:"nextFundingTime"` // 下次资金费结算时间，毫秒，交易所未提供时为 0
+	Time            int64  `json:"time"`            // 数据时间，毫秒
+}
+
+// MarkPriceDecimal 标记价格，无法解析时返回 0
+func (m *MarkPrice) MarkPriceDecimal() Decimal {
+	return ToDecimal(m.MarkPrice)
+}
+
+// IndexPriceDecimal 指数价格，无法解析时返回 0
+func (m *MarkPrice) IndexPriceDecimal() Decimal {
+	return ToDecimal(m.IndexPrice)
+}
+
+// FundingRateDecimal 资金费率，无法解析时返回 0
+func (m *MarkPrice) FundingRateDecimal() Decimal {
+	return ToDecimal(m.FundingRate)
+}
@@ -0,0 +1,49 @@
+package exchange
+
+import "time"
+
+// 资金流水类型
+type IncomeType string
+
+const (
+	IncomeTypeFundingFee     IncomeType = "FUNDING_FEE"     // 资金费
+	IncomeTypeCommission     IncomeType = "COMMISSION"      // 交易手续费
+	IncomeTypeRealizedPnl    IncomeType = "REALIZED_PNL"    // 已实现盈亏
+	IncomeTypeTransfer       IncomeType = "TRANSFER"        // 划转、充值与提现
+	IncomeTypeLiquidationFee IncomeType = "LIQUIDATION_FEE" // 强平清算费
+	IncomeTypeRebate         IncomeType = "REBATE"          // 手续费返佣
+	IncomeTypeOther          IncomeType = "OTHER"           // 其他类型，原始类型见 RawType
+)
+
+// Income 合约账户资金流水
+type Income struct {
+	ID      string     `json:"id"`      // 流水ID，同一笔账单拆分的多条流水ID相同
+	Symbol  string     `json:"symbol"`  // 交易对，与交易对无关的流水为空
+	Type    IncomeType `json:"type"`    // 流水类型
+	RawType string     `json:"rawType"` // 交易所原始流水类型
+	Amount  string     `json:"amount"`  // 金额，正数表示收入，负数表示支出
+	Asset   string     `json:"asset"`   // 币种
+	TradeID string     `json:"tradeId"` // 关联成交ID，没有时为空
+	Time    int64      `json:"time"`    // 时间，毫秒
+}
+
+// AmountDecimal 金额，无法解析时返回 0
+func (i *Income) AmountDecimal() Decimal {
+	return ToDecimal(i.Amount)
+}
+
+// IncomeQuery 资金流水查询条件
+type IncomeQuery struct {
+	Symbol    string     `json:"symbol"`    // 交易对，为空表示不限制
+	Type      IncomeType `json:"type"`      // 流水类型，为空表示不限制
+	StartTime time.Time  `json:"startTime"` // 开始时间，零值表示不限制
+	EndTime   time.Time  `json:"endTime"`   // 结束时间，零值表示不限制
+	Limit     int        `json:"limit"`     // 每页数量，0 使用交易所默认值
+	Cursor    string     `json:"cursor"`    // 分页游标，为空查询第一页，之后传入上一页的 NextCursor
+}
+
+// IncomePage 资金流水分页结果
+type IncomePage struct {
+	Incomes    []*Income `json:"incomes"`    // 资金流水
+	NextCursor string    `json:"nextCursor"` // 下一页游标，为空表示没有更多数据
+}
@@ -0,0 +1,73 @@
+package exchange
+
+// 交易对状态
+type InstrumentStatus string
+
+const (
+	InstrumentStatusPreTrading InstrumentStatus = "PRE_TRADING" // 预上线
+	InstrumentStatusTrading    InstrumentStatus = "TRADING"     // 交易中
+	InstrumentStatusSuspended  InstrumentStatus = "SUSPENDED"   // 暂停交易
+	InstrumentStatusDelisting  InstrumentStatus = "DELISTING"   // 下架中
+	InstrumentStatusDelisted   InstrumentStatus = "DELISTED"    // 已下架
+)
+
+// Instrument 交易对元数据，数量单位均为基础资产，合约按 ContractSize 由张数换算，交易所未提供的字段为空
+type Instrument struct {
+	Market       Market           `json:"market"`       // 市场类型
+	Symbol       string           `json:"symbol"`       // 统一格式交易对，如 BTC/USDT:USDT
+	ID           string           `json:"id"`           // 交易所原生交易对，如 BTCUSDT
+	Base         string           `json:"base"`         // 基础资产
+	Quote        string           `json:"quote"`        // 计价资产
+	Settle       string           `json:"settle"`       // 结算资产，现货为空
+	TickSize     string           `json:"tickSize"`     // 最小价格变动
+	StepSize     string           `json:"stepSize"`     // 数量步长
+	MinQty       string           `json:"minQty"`       // 最小下单数量
+	MaxQty       string           `json:"maxQty"`       // 最大下单数量
+	MinNotional  string           `json:"minNotional"`  // 最小下单金额
+	ContractSize string           `json:"contractSize"` // 合约乘数，每张合约对应的基础资产数量，现货为空
+	MaxLeverage  int              `json:"maxLeverage"`  // 最大杠杆倍数
+	Status       InstrumentStatus `json:"status"`       // 状态
+	DelistTime   int64            `json:"delistTime"`   // 下架时间，毫秒，0 表示未计划下架
+	MakerFeeRate string           `json:"makerFeeRate"` // 挂单手续费率，如 0.001 表示 0.1%
+	TakerFeeRate string           `json:"takerFeeRate"` // 吃单手续费率
+}
+
+// TickSizeDecimal 最小价格变动，无法解析时返回 0
+func (i *Instrument) TickSizeDecimal() Decimal {
+	return ToDecimal(i.TickSize)
+}
+
+// StepSizeDecimal 数量步长，无法解析时返回 0
+func (i *Instrument) StepSizeDecimal() Decimal {
+	return ToDecimal(i.StepSize)
+}
+
+// MinQtyDecimal 最小下单数量，无法解析时返回 0
+func (i *Instrument) MinQtyDecimal() Decimal {
+	return ToDecimal(i.MinQty)
+}
+
+// MaxQtyDecimal 最大下单数量，无法解析时返回 0
+func (i *Instrument) MaxQtyDecimal() Decimal {
+	return ToDecimal(i.MaxQty)
+}
+
+// MinNotionalDecimal 最小下单金额，无法解析时返回 0
+func (i *Instrument) MinNotionalDecimal() Decimal {
+	return ToDecimal(i.MinNotional)
+}
+
+// ContractSizeDecimal 合约乘数，无法解析时返回 0
+func (i *Instrument) ContractSizeDecimal() Decimal {
+	return ToDecimal(i.ContractSize)
+}
+
+// RoundPrice 价格按最小价格变动四舍五入
+func (i *Instrument) RoundPrice(price Decimal) Decimal {
+	return RoundToStep(price, i.TickSizeDecimal())
+}
+
+// FloorQuantity 数量按数量步长向下取整
+func (i *Instrument) FloorQuantity(quantity Decimal) Decimal {
+	return FloorToStep(quantity, i.StepSizeDecimal())
+}
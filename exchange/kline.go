@@ -0,0 +1,83 @@
+package exchange
+
+import (
+	"fmt"
+	"time"
+)
+
+// K线周期
+type KlineInterval string
+
+const (
+	KlineInterval1m  KlineInterval = "1m"  // 1分钟
+	KlineInterval3m  KlineInterval = "3m"  // 3分钟
+	KlineInterval5m  KlineInterval = "5m"  // 5分钟
+	KlineInterval15m KlineInterval = "15m" // 15分钟
+	KlineInterval30m KlineInterval = "30m" // 30分钟
+	KlineInterval1h  KlineInterval = "1h"  // 1小时
+	KlineInterval2h  KlineInterval = "2h"  // 2小时
+	KlineInterval4h  KlineInterval = "4h"  // 4小时
+	KlineInterval6h  KlineInterval = "6h"  // 6小时
+	KlineInterval8h  KlineInterval = "8h"  // 8小时
+	KlineInterval12h KlineInterval = "12h" // 12小时
+	KlineInterval1d  KlineInterval = "1d"  // 1天
+	KlineInterval3d  KlineInterval = "3d"  // 3天
+	KlineInterval1w  KlineInterval = "1w"  // 1周
+	KlineInterval1M  KlineInterval = "1M"  // 1月
+)
+
+// klineIntervalDurations K线周期时长，月线按 31 天估算，仅用于分页
+var klineIntervalDurations = map[KlineInterval]time.Duration{
+	KlineInterval1m:  time.Minute,
+	KlineInterval3m:  3 * time.Minute,
+	KlineInterval5m:  5 * time.Minute,
+	KlineInterval15m: 15 * time.Minute,
+	KlineInterval30m: 30 * time.Minute,
+	KlineInterval1h:  time.Hour,
+	KlineInterval2h:  2 * time.Hour,
+	KlineInterval4h:  4 * time.Hour,
+	KlineInterval6h:  6 * time.Hour,
+	KlineInterval8h:  8 * time.Hour,
+	KlineInterval12h: 12 * time.Hour,
+	KlineInterval1d:  24 * time.Hour,
+	KlineInterval3d:  3 * 24 * time.Hour,
+	KlineInterval1w:  7 * 24 * time.Hour,
+	KlineInterval1M:  31 * 24 * time.Hour,
+}
+
+// Duration K线周期时长，月线按 31 天估算
+func (i KlineInterval) Duration() time.Duration {
+	return klineIntervalDurations[i]
+}
+
+// Validate 验证K线周期
+func (i KlineInterval) Validate() error {
+	if _, ok := klineIntervalDurations[i]; !ok {
+		return fmt.Errorf("无效的K线周期: %s", i)
+	}
+	return nil
+}
+
+// Kline K线
+type Kline struct {
+	Symbol      string        `json:"symbol"`      // 交易对
+	Interval    KlineInterval `json:"interval"`    // 周期
+	OpenTime    int64         `json:"openTime"`    // 开盘时间，毫秒
+	CloseTime   int64         `json:"closeTime"`   // 收盘时间，毫秒
+	Open        string        `json:"open"`        // 开盘价
+	High        string        `json:"high"`        // 最高价
+	Low         string        `json:"low"`         // 最低价
+	Close       string        `json:"close"`       // 收盘价
+	Volume      string        `json:"volume"`      // 成交量，单位：基础资产
+	QuoteVolume string        `json:"quoteVolume"` // 成交额，单位：计价资产
+	TradeCount  int64         `json:"tradeCount"`  // 成交笔数，交易所不提供时为 0
+	Closed      bool          `json:"closed"`      // 是否已收盘
+}
+
+// CloseTime 根据开盘时间计算收盘时间，毫秒，月线按 UTC 自然月计算
+func (i KlineInterval) CloseTime(openTime int64) int64 {
+	if i == KlineInterval1M {
+		return time.UnixMilli(openTime).UTC().AddDate(0, 1, 0).UnixMilli() - 1
+	}
+	return openTime + i.Duration().Milliseconds() - 1
+}
@@ -0,0 +1,135 @@
+package exchange
+
+import (
+	"net/http"
+	"time"
+)
+
+// Options 交易所实例配置
+type Options struct {
+	Environment         Environment     // 交易环境，默认正式网
+	SpotBaseURL         string          // 现货接口地址，为空则使用交易所默认地址
+	FuturesBaseURL      string          // 合约接口地址，为空则使用交易所默认地址
+	SpotWebsocketURL    string          // 现货 Websocket 地址，为空则使用交易所默认地址
+	FuturesWebsocketURL string          // 合约 Websocket 地址，为空则使用交易所默认地址
+	HTTPClient          *http.Client    // 自定义 HTTP 客户端
+	Timeout             time.Duration   // HTTP 请求超时时间，HTTPClient 为空时生效
+	RateLimiter         RateLimiter     // 限频器，为空时每个实例使用独立的交易所默认限频器
+	RateLimitPolicy     RateLimitPolicy // 默认限频器的超限策略，默认等待
+	MaxRetries          int             // 下单遇到结果未知的临时错误时的最大重试次数，0 使用默认值，小于 0 不重试
+}
+
+const (
+	DefaultMaxRetries = 2 // 下单默认最大重试次数
+)
+
+// Option 交易所实例配置项
+type Option func(*Options)
+
+// NewOptions 创建交易所实例配置
+func NewOptions(opts ...Option) *Options {
+	options := &Options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// NewHTTPClient 返回配置的 HTTP 客户端，未配置时返回 nil 使用交易所默认客户端
+func (o *Options) NewHTTPClient() *http.Client {
+	if o.HTTPClient != nil {
+		return o.HTTPClient
+	}
+	if o.Timeout > 0 {
+		return &http.Client{Timeout: o.Timeout}
+	}
+	return nil
+}
+
+// Retries 下单最大重试次数
+func (o *Options) Retries() int {
+	switch {
+	case o.MaxRetries < 0:
+		return 0
+	case o.MaxRetries == 0:
+		return DefaultMaxRetries
+	}
+	return o.MaxRetries
+}
+
+// IsTestnet 是否使用测试网
+func (o *Options) IsTestnet() bool {
+	return o.Environment == EnvironmentTestnet
+}
+
+// WithEnvironment 设置交易环境，接口地址覆盖项对所选环境生效
+func WithEnvironment(environment Environment) Option {
+	return func(o *Options) {
+		o.Environment = environment
+	}
+}
+
+// WithSpotBaseURL 设置现货接口地址
+func WithSpotBaseURL(baseURL string) Option {
+	return func(o *Options) {
+		o.SpotBaseURL = baseURL
+	}
+}
+
+// WithFuturesBaseURL 设置合约接口地址
+func WithFuturesBaseURL(baseURL string) Option {
+	return func(o *Options) {
+		o.FuturesBaseURL = baseURL
+	}
+}
+
+// WithSpotWebsocketURL 设置现货 Websocket 地址
+func WithSpotWebsocketURL(websocketURL string) Option {
+	return func(o *Options) {
+		o.SpotWebsocketURL = websocketURL
+	}
+}
+
+// WithFuturesWebsocketURL 设置合约 Websocket 地址
+func WithFuturesWebsocketURL(websocketURL string) Option {
+	return func(o *Options) {
+		o.FuturesWebsocketURL = websocketURL
+	}
+}
+
+// WithHTTPClient 设置自定义 HTTP 客户端
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.HTTPClient = client
+	}
+}
+
+// WithTimeout 设置 HTTP 请求超时时间
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = timeout
+	}
+}
+
+// WithRateLimiter 设置限频器，共用同一 API Key 的多个实例应传入同一个限频器
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(o *Options) {
+		o.RateLimiter = limiter
+	}
+}
+
+// WithRateLimitPolicy 设置默认限频器的超限策略
+func WithRateLimitPolicy(policy RateLimitPolicy) Option {
+	return func(o *Options) {
+		o.RateLimitPolicy = policy
+	}
+}
+
+// WithMaxRetries 设置下单遇到临时错误时的最大重试次数，小于 0 不重试
+func WithMaxRetries(maxRetries int) Option {
+	return func(o *Options) {
+		o.MaxRetries = maxRetries
+	}
+}
@@ -1,8 +1,14 @@
 package exchange
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
 // 订单
 type Order struct {
 	OrderID       string           `json:"orderId"`       // 订单ID
+	ClientOrderID string           `json:"clientOrderId"` // 客户端订单ID
 	Symbol        string           `json:"symbol"`        // 交易对
 	Side          OrderSide        `json:"side"`          // 方向
 	Type          OrderType        `json:"type"`          // 类型
@@ -17,6 +23,44 @@ type Order struct {
 	UpdateTime    int64            `json:"updateTime"`    // 更新时间
 }
 
+// OrderResult 批量下单或撤单中单个订单的结果，成功时 Order 不为空，失败时 Err 不为空
+type OrderResult struct {
+	Order *Order `json:"order"` // 订单
+	Err   error  `json:"-"`     // 错误
+}
+
+// NewClientOrderID 生成客户端订单ID，24 位十六进制字符，满足各交易所的格式与长度限制
+func NewClientOrderID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// PriceDecimal 价格，无法解析时返回 0
+func (o *Order) PriceDecimal() Decimal {
+	return ToDecimal(o.Price)
+}
+
+// QuantityDecimal 数量，无法解析时返回 0
+func (o *Order) QuantityDecimal() Decimal {
+	return ToDecimal(o.Quantity)
+}
+
+// ExecutedQtyDecimal 已执行数量，无法解析时返回 0
+func (o *Order) ExecutedQtyDecimal() Decimal {
+	return ToDecimal(o.ExecutedQty)
+}
+
+// ActualQtyDecimal 实际数量，无法解析时返回 0
+func (o *Order) ActualQtyDecimal() Decimal {
+	return ToDecimal(o.ActualQty)
+}
+
+// QuoteQuantityDecimal 成交金额，无法解析时返回 0
+func (o *Order) QuoteQuantityDecimal() Decimal {
+	return ToDecimal(o.QuoteQuantity)
+}
+
 // SymbolPositionRisk 交易对持仓风险
 type SymbolPositionRisk struct {
 	Data []*PositionRisk `json:"data"`
@@ -46,3 +90,43 @@ type PositionRisk struct {
 	IsolatedMargin   string       `json:"isolated_margin"`   // 逐仓保证金金额（USDT；币安：IsolatedMargin，欧易：Margin，芝麻：Margin）
 	Notional         string       `json:"notional"`          // 名义价值（持仓总价值，单位 USDT；币安：Notional，欧易：NotionalUsd，芝麻：Value）
 }
+
+// PositionAmtDecimal 持仓数量，无法解析时返回 0
+func (p *PositionRisk) PositionAmtDecimal() Decimal {
+	return ToDecimal(p.PositionAmt)
+}
+
+// EntryPriceDecimal 平均开仓价格，无法解析时返回 0
+func (p *PositionRisk) EntryPriceDecimal() Decimal {
+	return ToDecimal(p.EntryPrice)
+}
+
+// MarkPriceDecimal 标记价格，无法解析时返回 0
+func (p *PositionRisk) MarkPriceDecimal() Decimal {
+	return ToDecimal(p.MarkPrice)
+}
+
+// UnRealizedProfitDecimal 未实现盈亏，无法解析时返回 0
+func (p *PositionRisk) UnRealizedProfitDecimal() Decimal {
+	return ToDecimal(p.UnRealizedProfit)
+}
+
+// LeverageDecimal 杠杆倍数，无法解析时返回 0
+func (p *PositionRisk) LeverageDecimal() Decimal {
+	return ToDecimal(p.Leverage)
+}
+
+// LiquidationPriceDecimal 爆仓价格，无法解析时返回 0
+func (p *PositionRisk) LiquidationPriceDecimal() Decimal {
+	return ToDecimal(p.LiquidationPrice)
+}
+
+// IsolatedMarginDecimal 逐仓保证金，无法解析时返回 0
+func (p *PositionRisk) IsolatedMarginDecimal() Decimal {
+	return ToDecimal(p.IsolatedMargin)
+}
+
+// NotionalDecimal 名义价值，无法解析时返回 0
+func (p *PositionRisk) NotionalDecimal() Decimal {
+	return ToDecimal(p.Notional)
+}
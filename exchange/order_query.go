@@ -0,0 +1,35 @@
+package exchange
+
+import "time"
+
+// OrderQuery 历史订单查询条件
+type OrderQuery struct {
+	Market    Market    `json:"market"`    // 市场类型
+	Symbol    string    `json:"symbol"`    // 交易对
+	StartTime time.Time `json:"startTime"` // 开始时间，零值表示不限制
+	EndTime   time.Time `json:"endTime"`   // 结束时间，零值表示不限制
+	Limit     int       `json:"limit"`     // 每页数量，0 使用交易所默认值
+	Cursor    string    `json:"cursor"`    // 分页游标，为空查询第一页，之后传入上一页的 NextCursor
+}
+
+// OrderPage 历史订单分页结果
+type OrderPage struct {
+	Orders     []*Order `json:"orders"`     // 订单列表
+	NextCursor string   `json:"nextCursor"` // 下一页游标，为空表示没有更多数据
+}
+
+// FillQuery 成交明细查询条件
+type FillQuery struct {
+	Symbol    string    `json:"symbol"`    // 交易对
+	OrderID   string    `json:"orderId"`   // 订单ID，为空表示不限制
+	StartTime time.Time `json:"startTime"` // 开始时间，零值表示不限制
+	EndTime   time.Time `json:"endTime"`   // 结束时间，零值表示不限制
+	Limit     int       `json:"limit"`     // 每页数量，0 使用交易所默认值
+	Cursor    string    `json:"cursor"`    // 分页游标，为空查询第一页，之后传入上一页的 NextCursor
+}
+
+// FillPage 成交明细分页结果
+type FillPage struct {
+	Fills      []*Fill `json:"fills"`      // 成交明细
+	NextCursor string  `json:"nextCursor"` // 下一页游标，为空表示没有更多数据
+}
@@ -0,0 +1,128 @@
+package exchange
+
+import (
+	"fmt"
+)
+
+// OrderRequest 下单请求
+type OrderRequest struct {
+	Market        Market           `json:"market"`        // 市场类型
+	Symbol        string           `json:"symbol"`        // 交易对
+	Side          OrderSide        `json:"side"`          // 方向
+	Type          OrderType        `json:"type"`          // 类型，LIMIT 或 MARKET
+	Price         string           `json:"price"`         // 价格，仅限价单
+	Quantity      string           `json:"quantity"`      // 数量，单位为基础资产，与 QuoteQty 二选一
+	QuoteQty      string           `json:"quoteQty"`      // 金额，单位为计价资产，仅市价单
+	TimeInForce   OrderTimeInForce `json:"timeInForce"`   // 时间类型，仅限价单，默认 GTC
+	PostOnly      bool             `json:"postOnly"`      // 只做挂单，等同于 TimeInForce 为 GTX
+	ReduceOnly    bool             `json:"reduceOnly"`    // 只减仓，仅合约
+	PositionSide  PositionSide     `json:"positionSide"`  // 持仓方向，仅合约双向持仓模式，为空表示单向持仓
+	IcebergQty    string           `json:"icebergQty"`    // 冰山单每次显示的数量，仅限价单
+	ClientOrderID string           `json:"clientOrderId"` // 客户端订单ID，为空时自动生成
+}
+
+// NewOrderRequest 按价格构造下单请求，价格为空或 0 时为市价单，其他为 GTC 限价单
+func NewOrderRequest(market Market, symbol string, side OrderSide, price, quantity string) OrderRequest {
+	req := OrderRequest{
+		Market:   market,
+		Symbol:   symbol,
+		Side:     side,
+		Type:     OrderTypeMarket,
+		Quantity: quantity,
+	}
+	if price != "" && price != "0" {
+		req.Type = OrderTypeLimit
+		req.Price = price
+		req.TimeInForce = OrderTimeInForceGTC
+	}
+	return req
+}
+
+// Validate 校验与交易所无关的下单参数并补全默认值：限价单默认 GTC，PostOnly 与 GTX 互相补全。
+// 校验失败返回包装 ErrInvalidOrder 的错误
+func (r *OrderRequest) Validate() error {
+	if r.Market != MarketSpot && r.Market != MarketFutures {
+		return fmt.Errorf("%w: 不支持的市场类型 %s", ErrInvalidOrder, r.Market)
+	}
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: 交易对不能为空", ErrInvalidOrder)
+	}
+	if r.Side != OrderSideBuy && r.Side != OrderSideSell {
+		return fmt.Errorf("%w: 不支持的方向 %s", ErrInvalidOrder, r.Side)
+	}
+
+	// 数量与金额二选一
+	if (r.Quantity == "") == (r.QuoteQty == "") {
+		return fmt.Errorf("%w: 数量与金额必须且只能指定一个", ErrInvalidOrder)
+	}
+	for name, value := range map[string]string{"数量": r.Quantity, "金额": r.QuoteQty, "冰山数量": r.IcebergQty} {
+		if value == "" {
+			continue
+		}
+		if d, err := ParseDecimal(value); err != nil || d.Sign() <= 0 {
+			return fmt.Errorf("%w: 无效的%s %s", ErrInvalidOrder, name, value)
+		}
+	}
+
+	if r.TimeInForce == OrderTimeInForceGTX {
+		r.PostOnly = true
+	}
+	switch r.Type {
+	case OrderTypeLimit:
+		if price, err := ParseDecimal(r.Price); err != nil || price.Sign() <= 0 {
+			return fmt.Errorf("%w: 限价单价格无效 %s", ErrInvalidOrder, r.Price)
+		}
+		if r.QuoteQty != "" {
+			return fmt.Errorf("%w: 按金额下单仅支持市价单", ErrInvalidOrder)
+		}
+		if r.PostOnly {
+			if r.TimeInForce != "" && r.TimeInForce != OrderTimeInForceGTX {
+				return fmt.Errorf("%w: 只做挂单不能指定时间类型 %s", ErrInvalidOrder, r.TimeInForce)
+			}
+			r.TimeInForce = OrderTimeInForceGTX
+		}
+		switch r.TimeInForce {
+		case "":
+			r.TimeInForce = OrderTimeInForceGTC
+		case OrderTimeInForceGTC, OrderTimeInForceIOC, OrderTimeInForceFOK, OrderTimeInForceGTX:
+		default:
+			return fmt.Errorf("%w: 不支持的时间类型 %s", ErrInvalidOrder, r.TimeInForce)
+		}
+		if r.IcebergQty != "" && ToDecimal(r.IcebergQty).GreaterThanOrEqual(ToDecimal(r.Quantity)) {
+			return fmt.Errorf("%w: 冰山数量 %s 必须小于下单数量 %s", ErrInvalidOrder, r.IcebergQty, r.Quantity)
+		}
+	case OrderTypeMarket:
+		if r.Price != "" && r.Price != "0" {
+			return fmt.Errorf("%w: 市价单不能指定价格", ErrInvalidOrder)
+		}
+		if r.TimeInForce != "" || r.PostOnly || r.IcebergQty != "" {
+			return fmt.Errorf("%w: 市价单不能指定时间类型、只做挂单或冰山数量", ErrInvalidOrder)
+		}
+	default:
+		return fmt.Errorf("%w: 不支持的订单类型 %s", ErrInvalidOrder, r.Type)
+	}
+
+	if r.Market == MarketSpot && (r.ReduceOnly || r.PositionSide != "") {
+		return fmt.Errorf("%w: 现货不支持只减仓与持仓方向", ErrInvalidOrder)
+	}
+	switch r.PositionSide {
+	case "", PositionSideLong, PositionSideShort, PositionSideBoth:
+	default:
+		return fmt.Errorf("%w: 不支持的持仓方向 %s", ErrInvalidOrder, r.PositionSide)
+	}
+	return nil
+}
+
+// ValidateAmend 校验改单参数，价格与数量至少修改一项，修改的值必须为正数。校验失败返回包装 ErrInvalidOrder 的错误
+func ValidateAmend(newPrice, newQty string) error {
+	if newPrice == "" && newQty == "" {
+		return fmt.Errorf("%w: 价格与数量至少修改一项", ErrInvalidOrder)
+	}
+	if newPrice != "" && !isPositive(newPrice) {
+		return fmt.Errorf("%w: 无效的价格 %s", ErrInvalidOrder, newPrice)
+	}
+	if newQty != "" && !isPositive(newQty) {
+		return fmt.Errorf("%w: 无效的数量 %s", ErrInvalidOrder, newQty)
+	}
+	return nil
+}
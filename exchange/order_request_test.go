@@ -0,0 +1,85 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestNewOrderRequest 价格为空或 0 时构造市价单，其他构造 GTC 限价单
+// go test -v ./exchange -run "^TestNewOrderRequest$"
+func TestNewOrderRequest(t *testing.T) {
+	for _, price := range []string{"", "0"} {
+		req := NewOrderRequest(MarketSpot, "BTCUSDT", OrderSideBuy, price, "1")
+		if req.Type != OrderTypeMarket || req.Price != "" || req.TimeInForce != "" {
+			t.Errorf("价格 %q 应构造市价单: %+v", price, req)
+		}
+	}
+	req := NewOrderRequest(MarketFutures, "BTCUSDT", OrderSideSell, "100", "1")
+	if req.Type != OrderTypeLimit || req.Price != "100" || req.TimeInForce != OrderTimeInForceGTC {
+		t.Errorf("应构造 GTC 限价单: %+v", req)
+	}
+}
+
+// TestOrderRequestValidate 校验下单参数并补全默认值
+// go test -v ./exchange -run "^TestOrderRequestValidate$"
+func TestOrderRequestValidate(t *testing.T) {
+	limit := OrderRequest{Market: MarketFutures, Symbol: "BTCUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, Price: "100", Quantity: "1"}
+
+	// 限价单默认 GTC，只做挂单补全为 GTX
+	req := limit
+	if err := req.Validate(); err != nil || req.TimeInForce != OrderTimeInForceGTC {
+		t.Errorf("限价单应默认 GTC: %+v, %v", req, err)
+	}
+	req = limit
+	req.PostOnly = true
+	if err := req.Validate(); err != nil || req.TimeInForce != OrderTimeInForceGTX {
+		t.Errorf("只做挂单应补全为 GTX: %+v, %v", req, err)
+	}
+	req = limit
+	req.TimeInForce = OrderTimeInForceGTX
+	if err := req.Validate(); err != nil || !req.PostOnly {
+		t.Errorf("GTX 应补全只做挂单: %+v, %v", req, err)
+	}
+
+	market := OrderRequest{Market: MarketSpot, Symbol: "BTCUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, QuoteQty: "10"}
+	if err := market.Validate(); err != nil {
+		t.Errorf("按金额市价买入应通过校验: %v", err)
+	}
+
+	invalid := map[string]func(r *OrderRequest){
+		"缺少交易对":       func(r *OrderRequest) { r.Symbol = "" },
+		"不支持的方向":      func(r *OrderRequest) { r.Side = "HOLD" },
+		"同时指定数量与金额":   func(r *OrderRequest) { r.QuoteQty = "10" },
+		"数量为 0":       func(r *OrderRequest) { r.Quantity = "0" },
+		"限价单缺少价格":     func(r *OrderRequest) { r.Price = "" },
+		"市价单指定价格":     func(r *OrderRequest) { r.Type = OrderTypeMarket },
+		"只做挂单与 IOC":   func(r *OrderRequest) { r.PostOnly, r.TimeInForce = true, OrderTimeInForceIOC },
+		"现货只减仓":       func(r *OrderRequest) { r.Market, r.ReduceOnly = MarketSpot, true },
+		"现货持仓方向":      func(r *OrderRequest) { r.Market, r.PositionSide = MarketSpot, PositionSideLong },
+		"冰山数量不小于下单数量": func(r *OrderRequest) { r.IcebergQty = "1" },
+		"不支持的订单类型":    func(r *OrderRequest) { r.Type = "STOP" },
+	}
+	for name, modify := range invalid {
+		req := limit
+		modify(&req)
+		if err := req.Validate(); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("%s: 应返回 ErrInvalidOrder, got %v", name, err)
+		}
+	}
+}
+
+// TestValidateAmend 改单参数校验
+// go test -v ./exchange -run "^TestValidateAmend$"
+func TestValidateAmend(t *testing.T) {
+	if err := ValidateAmend("100", ""); err != nil {
+		t.Errorf("只修改价格应通过: %v", err)
+	}
+	if err := ValidateAmend("", "1"); err != nil {
+		t.Errorf("只修改数量应通过: %v", err)
+	}
+	for _, args := range [][2]string{{"", ""}, {"0", ""}, {"", "-1"}, {"abc", "1"}} {
+		if err := ValidateAmend(args[0], args[1]); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("%v: 应返回 ErrInvalidOrder, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package exchange
+
+import (
+	"sort"
+)
+
+// OrderBook 深度快照
+type OrderBook struct {
+	Symbol       string           `json:"symbol"`       // 交易对
+	Bids         []OrderBookLevel `json:"bids"`         // 买盘，价格从高到低
+	Asks         []OrderBookLevel `json:"asks"`         // 卖盘，价格从低到高
+	LastUpdateID int64            `json:"lastUpdateId"` // 最后更新ID/序列号
+	Time         int64            `json:"time"`         // 快照时间，毫秒
+}
+
+// OrderBookLevel 深度档位
+type OrderBookLevel struct {
+	Price    string `json:"price"`    // 价格
+	Quantity string `json:"quantity"` // 数量，单位：基础资产
+}
+
+// BestBid 获取买一档位
+func (o *OrderBook) BestBid() *OrderBookLevel {
+	if len(o.Bids) == 0 {
+		return nil
+	}
+	return &o.Bids[0]
+}
+
+// BestAsk 获取卖一档位
+func (o *OrderBook) BestAsk() *OrderBookLevel {
+	if len(o.Asks) == 0 {
+		return nil
+	}
+	return &o.Asks[0]
+}
+
+// Sort 买盘按价格从高到低、卖盘按价格从低到高排序
+func (o *OrderBook) Sort() {
+	sort.SliceStable(o.Bids, func(i, j int) bool {
+		return comparePrice(o.Bids[i].Price, o.Bids[j].Price) > 0
+	})
+	sort.SliceStable(o.Asks, func(i, j int) bool {
+		return comparePrice(o.Asks[i].Price, o.Asks[j].Price) < 0
+	})
+}
+
+// comparePrice 比较价格大小，无法解析的价格视为 0
+func comparePrice(a, b string) int {
+	return ToDecimal(a).Cmp(ToDecimal(b))
+}
@@ -0,0 +1,23 @@
+package exchange
+
+import (
+	"context"
+	"net/http"
+)
+
+// RateLimitPolicy 超出限频时的处理策略
+type RateLimitPolicy string
+
+const (
+	RateLimitPolicyWait     RateLimitPolicy = "WAIT"      // 等待额度恢复后发送，直到 ctx 结束
+	RateLimitPolicyFailFast RateLimitPolicy = "FAIL_FAST" // 立即返回包装 ErrRateLimited 的错误
+)
+
+// RateLimiter 客户端限频器，在请求发送前扣减额度，并根据响应同步交易所统计的已用额度。
+// 同一 API Key 的多个实例应共用一个限频器，实现需并发安全
+type RateLimiter interface {
+	// Acquire 请求发送前获取额度，超限时按策略等待或返回包装 ErrRateLimited 的错误
+	Acquire(ctx context.Context, req *http.Request) error
+	// Update 收到响应后根据响应头与状态码同步额度
+	Update(req *http.Request, resp *http.Response)
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 交易对类型
+type SymbolKind string
+
+const (
+	SymbolKindSpot      SymbolKind = "SPOT"      // 现货
+	SymbolKindPerpetual SymbolKind = "PERPETUAL" // 永续合约
+	SymbolKindFutures   SymbolKind = "FUTURES"   // 交割合约
+)
+
+// Symbol 统一交易对，字符串格式：现货 BTC/USDT，永续合约 BTC/USDT:USDT，交割合约 BTC/USDT:USDT-251226
+// 公开方法的交易对参数与返回值均使用该格式，交易所原生格式（如 BTCUSDT、BTC_USDT、BTC-USDT-SWAP）仍可作为参数传入
+type Symbol struct {
+	Base   string     `json:"base"`   // 基础资产
+	Quote  string     `json:"quote"`  // 计价资产
+	Settle string     `json:"settle"` // 结算资产，现货为空
+	Kind   SymbolKind `json:"kind"`   // 交易对类型
+	Expiry string     `json:"expiry"` // 交割日期 YYMMDD，仅交割合约
+}
+
+// NewSpotSymbol 创建现货交易对
+func NewSpotSymbol(base, quote string) Symbol {
+	return Symbol{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote), Kind: SymbolKindSpot}
+}
+
+// NewPerpetualSymbol 创建永续合约交易对
+func NewPerpetualSymbol(base, quote, settle string) Symbol {
+	return Symbol{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote), Settle: strings.ToUpper(settle), Kind: SymbolKindPerpetual}
+}
+
+// NewFuturesSymbol 创建交割合约交易对，expiry 格式为 YYMMDD
+func NewFuturesSymbol(base, quote, settle, expiry string) Symbol {
+	return Symbol{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote), Settle: strings.ToUpper(settle), Kind: SymbolKindFutures, Expiry: expiry}
+}
+
+// String 统一格式字符串
+func (s Symbol) String() string {
+	switch s.Kind {
+	case SymbolKindPerpetual:
+		return s.Base + "/" + s.Quote + ":" + s.Settle
+	case SymbolKindFutures:
+		return s.Base + "/" + s.Quote + ":" + s.Settle + "-" + s.Expiry
+	default:
+		return s.Base + "/" + s.Quote
+	}
+}
+
+// Market 交易对所属市场
+func (s Symbol) Market() Market {
+	if s.Kind == SymbolKindSpot {
+		return MarketSpot
+	}
+	return MarketFutures
+}
+
+// IsCanonicalSymbol 是否为统一格式交易对
+func IsCanonicalSymbol(value string) bool {
+	return strings.Contains(value, "/")
+}
+
+// ParseSymbol 解析统一格式交易对
+func ParseSymbol(value string) (Symbol, error) {
+	pair, settle, isContract := strings.Cut(strings.ToUpper(strings.TrimSpace(value)), ":")
+	base, quote, ok := strings.Cut(pair, "/")
+	if !ok || base == "" || quote == "" || strings.Contains(quote, "/") {
+		return Symbol{}, fmt.Errorf("无效的交易对: %s", value)
+	}
+	if !isContract {
+		return NewSpotSymbol(base, quote), nil
+	}
+
+	settle, expiry, isFutures := strings.Cut(settle, "-")
+	if settle == "" || (isFutures && expiry == "") {
+		return Symbol{}, fmt.Errorf("无效的交易对: %s", value)
+	}
+	if isFutures {
+		return NewFuturesSymbol(base, quote, settle, expiry), nil
+	}
+	return NewPerpetualSymbol(base, quote, settle), nil
+}
@@ -0,0 +1,41 @@
+package exchange
+
+import "testing"
+
+// TestParseSymbol 解析统一格式交易对，转换为字符串后与输入一致
+// go test -v ./exchange -run "^TestParseSymbol$"
+func TestParseSymbol(t *testing.T) {
+	cases := []struct {
+		value string
+		want  Symbol
+	}{
+		{"BTC/USDT", Symbol{Base: "BTC", Quote: "USDT", Kind: SymbolKindSpot}},
+		{"BTC/USDT:USDT", Symbol{Base: "BTC", Quote: "USDT", Settle: "USDT", Kind: SymbolKindPerpetual}},
+		{"BTC/USD:BTC", Symbol{Base: "BTC", Quote: "USD", Settle: "BTC", Kind: SymbolKindPerpetual}},
+		{"BTC/USDT:USDT-251226", Symbol{Base: "BTC", Quote: "USDT", Settle: "USDT", Kind: SymbolKindFutures, Expiry: "251226"}},
+	}
+	for _, c := range cases {
+		got, err := ParseSymbol(c.value)
+		if err != nil {
+			t.Errorf("ParseSymbol(%s) error: %v", c.value, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseSymbol(%s) = %+v, want %+v", c.value, got, c.want)
+		}
+		if got.String() != c.value {
+			t.Errorf("String() = %s, want %s", got.String(), c.value)
+		}
+	}
+
+	// 小写输入统一转换为大写
+	if got, _ := ParseSymbol(" eth/usdt:usdt "); got.String() != "ETH/USDT:USDT" || got.Market() != MarketFutures {
+		t.Errorf("ParseSymbol() = %+v", got)
+	}
+
+	for _, value := range []string{"", "BTCUSDT", "BTC/", "/USDT", "BTC/USDT/ETH", "BTC/USDT:", "BTC/USDT:USDT-"} {
+		if _, err := ParseSymbol(value); err == nil {
+			t.Errorf("ParseSymbol(%q) 应返回错误", value)
+		}
+	}
+}
@@ -30,3 +30,43 @@ type Ticker struct {
 	QuoteVolume        string `json:"quoteVolume"` // 成交额，单位：USDT
 	Count              int64  `json:"count"`       // 成交笔数
 }
+
+// PriceChangeDecimal 价格变动，无法解析时返回 0
+func (t *Ticker) PriceChangeDecimal() Decimal {
+	return ToDecimal(t.PriceChange)
+}
+
+// PriceChangePercentDecimal 24h 涨跌幅，无法解析时返回 0
+func (t *Ticker) PriceChangePercentDecimal() Decimal {
+	return ToDecimal(t.PriceChangePercent)
+}
+
+// LastPriceDecimal 最新价，无法解析时返回 0
+func (t *Ticker) LastPriceDecimal() Decimal {
+	return ToDecimal(t.LastPrice)
+}
+
+// OpenPriceDecimal 开盘价，无法解析时返回 0
+func (t *Ticker) OpenPriceDecimal() Decimal {
+	return ToDecimal(t.OpenPrice)
+}
+
+// HighPriceDecimal 最高价，无法解析时返回 0
+func (t *Ticker) HighPriceDecimal() Decimal {
+	return ToDecimal(t.HighPrice)
+}
+
+// LowPriceDecimal 最低价，无法解析时返回 0
+func (t *Ticker) LowPriceDecimal() Decimal {
+	return ToDecimal(t.LowPrice)
+}
+
+// VolumeDecimal 成交量，无法解析时返回 0
+func (t *Ticker) VolumeDecimal() Decimal {
+	return ToDecimal(t.Volume)
+}
+
+// QuoteVolumeDecimal 成交额，无法解析时返回 0
+func (t *Ticker) QuoteVolumeDecimal() Decimal {
+	return ToDecimal(t.QuoteVolume)
+}
@@ -0,0 +1,11 @@
+package exchange
+
+// Trade 公开成交
+type Trade struct {
+	Symbol   string    `json:"symbol"`   // 交易对
+	ID       string    `json:"id"`       // 成交ID，归集成交为归集ID
+	Price    string    `json:"price"`    // 成交价格
+	Quantity string    `json:"quantity"` // 成交数量，单位：基础资产
+	Side     OrderSide `json:"side"`     // 主动成交方（Taker）方向
+	Time     int64     `json:"time"`     // 成交时间，毫秒
+}
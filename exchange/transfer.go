@@ -0,0 +1,76 @@
+package exchange
+
+import (
+	"fmt"
+	"time"
+)
+
+// 账户类型
+type AccountType string
+
+// 划转状态
+type TransferStatus string
+
+const (
+	AccountTypeSpot    AccountType = "SPOT"    // 现货账户
+	AccountTypeFutures AccountType = "FUTURES" // U 本位合约账户
+	AccountTypeFunding AccountType = "FUNDING" // 资金账户
+
+	TransferStatusPending TransferStatus = "PENDING" // 处理中
+	TransferStatusSuccess TransferStatus = "SUCCESS" // 成功
+	TransferStatusFailed  TransferStatus = "FAILED"  // 失败
+)
+
+// Transfer 账户间资金划转记录
+type Transfer struct {
+	ID     string         `json:"id"`     // 划转ID，交易所未提供时为流水ID
+	Asset  string         `json:"asset"`  // 币种
+	Amount string         `json:"amount"` // 数量
+	From   AccountType    `json:"from"`   // 转出账户
+	To     AccountType    `json:"to"`     // 转入账户
+	Status TransferStatus `json:"status"` // 状态
+	Time   int64          `json:"time"`   // 时间，毫秒
+}
+
+// AmountDecimal 数量，无法解析时返回 0
+func (t *Transfer) AmountDecimal() Decimal {
+	return ToDecimal(t.Amount)
+}
+
+// TransferQuery 划转记录查询条件
+type TransferQuery struct {
+	Asset     string      `json:"asset"`     // 币种，为空表示不限制
+	From      AccountType `json:"from"`      // 转出账户，为空表示不限制
+	To        AccountType `json:"to"`        // 转入账户，为空表示不限制
+	StartTime time.Time   `json:"startTime"` // 开始时间，零值表示不限制
+	EndTime   time.Time   `json:"endTime"`   // 结束时间，零值表示不限制
+	Limit     int         `json:"limit"`     // 每页数量，0 使用交易所默认值
+	Cursor    string      `json:"cursor"`    // 分页游标，为空查询第一页，之后传入上一页的 NextCursor
+}
+
+// TransferPage 划转记录分页结果
+type TransferPage struct {
+	Transfers  []*Transfer `json:"transfers"`  // 划转记录
+	NextCursor string      `json:"nextCursor"` // 下一页游标，为空表示没有更多数据
+}
+
+// ValidateTransfer 校验划转参数，数量必须为正数，转出与转入账户必须为不同的已知账户类型。校验失败返回包装 ErrInvalidTransfer 的错误
+func ValidateTransfer(asset, amount string, from, to AccountType) error {
+	if asset == "" {
+		return fmt.Errorf("%w: 币种不能为空", ErrInvalidTransfer)
+	}
+	if !isPositive(amount) {
+		return fmt.Errorf("%w: 无效的数量 %s", ErrInvalidTransfer, amount)
+	}
+	for _, account := range []AccountType{from, to} {
+		switch account {
+		case AccountTypeSpot, AccountTypeFutures, AccountTypeFunding:
+		default:
+			return fmt.Errorf("%w: 不支持的账户类型 %s", ErrInvalidTransfer, account)
+		}
+	}
+	if from == to {
+		return fmt.Errorf("%w: 转出与转入账户相同", ErrInvalidTransfer)
+	}
+	return nil
+}
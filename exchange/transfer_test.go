@@ -0,0 +1,29 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestValidateTransfer 划转参数校验
+// go test -v ./exchange -run "^TestValidateTransfer$"
+func TestValidateTransfer(t *testing.T) {
+	if err := ValidateTransfer("USDT", "100", AccountTypeSpot, AccountTypeFutures); err != nil {
+		t.Errorf("现货划转到合约应通过: %v", err)
+	}
+	tests := []struct {
+		asset, amount string
+		from, to      AccountType
+	}{
+		{"", "100", AccountTypeSpot, AccountTypeFutures},
+		{"USDT", "0", AccountTypeSpot, AccountTypeFutures},
+		{"USDT", "abc", AccountTypeFunding, AccountTypeFutures},
+		{"USDT", "100", AccountTypeSpot, AccountTypeSpot},
+		{"USDT", "100", AccountTypeSpot, "MARGIN"},
+	}
+	for _, tt := range tests {
+		if err := ValidateTransfer(tt.asset, tt.amount, tt.from, tt.to); !errors.Is(err, ErrInvalidTransfer) {
+			t.Errorf("%+v: 应返回 ErrInvalidTransfer, got %v", tt, err)
+		}
+	}
+}
@@ -23,8 +23,15 @@ type PositionSide string
 // 订单状态
 type OrderStatus string
 
+// 交易环境
+type Environment string
+
+// 市场类型
+type Market string
+
 const (
-	CtxKeyTestnet ctxKey = "testnet" // 测试网
+	CtxKeyTestnet       ctxKey = "testnet"       // 测试网
+	CtxKeyClientOrderID ctxKey = "clientOrderId" // 客户端订单ID
 
 	OrderTimeInForceGTC OrderTimeInForce = "GTC" // 一直有效，直到手动取消或完全成交
 	OrderTimeInForceIOC OrderTimeInForce = "IOC" // 立即成交，否则取消
@@ -42,6 +49,7 @@ const (
 
 	PositionSideLong  PositionSide = "LONG"  // 多头
 	PositionSideShort PositionSide = "SHORT" // 空头
+	PositionSideBoth  PositionSide = "BOTH"  // 单向持仓
 
 	OrderStatusNew             OrderStatus = "NEW"              // 新订单
 	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED" // 部分成交
@@ -50,9 +58,43 @@ const (
 	OrderStatusPendingCancel   OrderStatus = "PENDING_CANCEL"   // 待取消
 	OrderStatusRejected        OrderStatus = "REJECTED"         // 已拒绝
 	OrderStatusExpired         OrderStatus = "EXPIRED"          // 已过期
+
+	EnvironmentProduction Environment = "PRODUCTION" // 正式网
+	EnvironmentTestnet    Environment = "TESTNET"    // 测试网
+
+	MarketSpot    Market = "SPOT"    // 现货
+	MarketFutures Market = "FUTURES" // 合约
 )
 
 // WithTestnet 设置测试网
 func WithTestnet(parent context.Context) context.Context {
 	return context.WithValue(parent, CtxKeyTestnet, true)
 }
+
+// WithoutTestnet 清除上下文的测试网设置，请求使用实例配置的环境
+func WithoutTestnet(parent context.Context) context.Context {
+	return context.WithValue(parent, CtxKeyTestnet, false)
+}
+
+// IsTestnet 上下文是否设置了测试网
+func IsTestnet(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	testnet, _ := ctx.Value(CtxKeyTestnet).(bool)
+	return testnet
+}
+
+// WithClientOrderID 设置下单使用的客户端订单ID，未设置时下单自动生成
+func WithClientOrderID(parent context.Context, clientOrderID string) context.Context {
+	return context.WithValue(parent, CtxKeyClientOrderID, clientOrderID)
+}
+
+// GetClientOrderID 上下文设置的客户端订单ID，未设置时返回空字符串
+func GetClientOrderID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	clientOrderID, _ := ctx.Value(CtxKeyClientOrderID).(string)
+	return clientOrderID
+}
@@ -0,0 +1,55 @@
+package exchange
+
+import "context"
+
+// OrderUpdate 订单更新事件
+type OrderUpdate struct {
+	Market Market `json:"market"` // 市场类型
+	Order  *Order `json:"order"`  // 订单最新状态
+	Time   int64  `json:"time"`   // 事件时间，毫秒
+}
+
+// Fill 成交明细
+type Fill struct {
+	Market      Market    `json:"market"`      // 市场类型
+	Symbol      string    `json:"symbol"`      // 交易对
+	OrderID     string    `json:"orderId"`     // 订单ID
+	TradeID     string    `json:"tradeId"`     // 成交ID
+	Side        OrderSide `json:"side"`        // 订单方向
+	Price       string    `json:"price"`       // 成交价格
+	Quantity    string    `json:"quantity"`    // 成交数量，单位：基础资产
+	Fee         string    `json:"fee"`         // 手续费，正数表示支出，负数表示返佣
+	FeeAsset    string    `json:"feeAsset"`    // 手续费币种
+	IsMaker     bool      `json:"isMaker"`     // 是否为挂单方（Maker）
+	RealizedPnl string    `json:"realizedPnl"` // 已实现盈亏，仅合约，交易所未提供时为空
+	Time        int64     `json:"time"`        // 成交时间，毫秒
+}
+
+// BalanceUpdate 余额更新事件，仅包含发生变化的币种
+type BalanceUpdate struct {
+	Market   Market    `json:"market"`   // 市场类型
+	Balances []Balance `json:"balances"` // 变化后的余额
+	Time     int64     `json:"time"`     // 事件时间，毫秒
+}
+
+// PositionUpdate 持仓更新事件，仅包含发生变化的持仓
+type PositionUpdate struct {
+	Market    Market          `json:"market"`    // 市场类型
+	Positions []*PositionRisk `json:"positions"` // 变化后的持仓，数量为 0 表示已平仓
+	Time      int64           `json:"time"`      // 事件时间，毫秒
+}
+
+// UserDataHandler 私有数据流回调，未设置的回调忽略对应事件
+type UserDataHandler struct {
+	OnOrder    func(update *OrderUpdate)    // 订单更新
+	OnFill     func(fill *Fill)             // 成交明细
+	OnBalance  func(update *BalanceUpdate)  // 余额更新
+	OnPosition func(update *PositionUpdate) // 持仓更新，仅合约
+}
+
+// UserDataStream 私有数据流接口，断线重连与重新认证由实现处理
+type UserDataStream interface {
+	Lifecycle
+	// StartListenUserData 开始监听账户私有数据，每个市场使用独立连接，ctx 结束时停止该市场的数据流
+	StartListenUserData(ctx context.Context, market Market, handler UserDataHandler) error
+}
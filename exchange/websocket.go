@@ -1,13 +1,94 @@
 package exchange
 
+import "context"
+
+// ConnectionState 连接状态
+type ConnectionState string
+
+const (
+	ConnectionStateConnected    ConnectionState = "CONNECTED"    // 已连接
+	ConnectionStateReconnecting ConnectionState = "RECONNECTING" // 连接断开，正在重连
+	ConnectionStateDisconnected ConnectionState = "DISCONNECTED" // 已关闭或重试次数用尽
+)
+
+// ConnectionStateHandler 连接状态回调，url 为连接地址，err 为断开或重连失败的原因
+type ConnectionStateHandler func(url string, state ConnectionState, err error)
+
+// Channel 可按交易对订阅的频道
+type Channel string
+
+const (
+	ChannelSpotTicker    Channel = "SPOT_TICKER"    // 现货行情
+	ChannelFuturesTicker Channel = "FUTURES_TICKER" // 合约行情
+)
+
+// WebsocketTickerHandler 行情回调
+type WebsocketTickerHandler func(ticker *Ticker)
+
 type WebsocketSpotTickerHandler func(ticker *Ticker)
 
 type WebsocketFuturesTickerHandler func(ticker *Ticker)
 
+// WebsocketOrderBookHandler 本地深度变化回调，参数为前 N 档深度快照
+type WebsocketOrderBookHandler func(orderBook *OrderBook)
+
+// WebsocketKlineHandler K线回调，Closed 标记该K线是否已收盘
+type WebsocketKlineHandler func(kline *Kline)
+
+// WebsocketTradeHandler 公开成交回调
+type WebsocketTradeHandler func(trade *Trade)
+
+// WebsocketMarkPriceHandler 合约标记价格回调
+type WebsocketMarkPriceHandler func(markPrice *MarkPrice)
+
+// LocalOrderBook 通过 Websocket 增量数据在本地维护的深度
+type LocalOrderBook interface {
+	// Symbol 交易对
+	Symbol() string
+	// Synced 是否已与交易所同步，重新同步期间返回 false
+	Synced() bool
+	// Snapshot 获取前 depth 档深度，depth <= 0 表示全部档位
+	Snapshot(depth int) *OrderBook
+	// BestBid 获取买一档位
+	BestBid() (OrderBookLevel, bool)
+	// BestAsk 获取卖一档位
+	BestAsk() (OrderBookLevel, bool)
+}
+
+// Lifecycle 长连接的生命周期管理
+type Lifecycle interface {
+	// SetStateHandler 设置连接状态回调，需在开始监听前设置，传入 nil 清除回调
+	SetStateHandler(handler ConnectionStateHandler)
+	// Errors 异步错误通道（连接断开、重连失败、续期失败等），缓冲满时丢弃，通道不会关闭
+	Errors() <-chan error
+	// Done 关闭完成后关闭的通道
+	Done() <-chan struct{}
+	// Close 关闭全部连接并停止后台任务，等待后台任务退出后返回，可重复调用，不能在回调中调用
+	Close() error
+}
+
 // Websocket 接口
+// 开始监听方法的 ctx 用于本次启动的 REST 请求，ctx 结束时停止本次启动的数据流
 type Websocket interface {
-	// StartListenSpotTickers 开始监听现货交易对行情
-	StartListenSpotTickers(handler WebsocketSpotTickerHandler) error
-	// StartListenFuturesTickers 开始监听合约交易对行情
-	StartListenFuturesTickers(handler WebsocketFuturesTickerHandler) error
+	Lifecycle
+	// SetTickerHandler 设置行情频道的回调，需在 Subscribe 前设置
+	SetTickerHandler(channel Channel, handler WebsocketTickerHandler)
+	// Subscribe 订阅频道，symbols 为空表示订阅全部交易对；连接中调用立即生效，重连后自动恢复订阅，
+	// 交易所限制单个连接的订阅数量时自动拆分到多个连接
+	Subscribe(channel Channel, symbols ...string) error
+	// Unsubscribe 取消订阅频道，symbols 为空表示取消该频道的全部订阅
+	Unsubscribe(channel Channel, symbols ...string) error
+	// StartListenSpotTickers 开始监听现货全部交易对行情，等同于 SetTickerHandler 后 Subscribe 全部交易对
+	StartListenSpotTickers(ctx context.Context, handler WebsocketSpotTickerHandler) error
+	// StartListenFuturesTickers 开始监听合约全部交易对行情，等同于 SetTickerHandler 后 Subscribe 全部交易对
+	StartListenFuturesTickers(ctx context.Context, handler WebsocketFuturesTickerHandler) error
+	// StartListenOrderBook 开始维护本地深度，深度变化时回调前 depth 档快照，handler 可为空
+	StartListenOrderBook(ctx context.Context, market Market, symbol string, depth int, handler WebsocketOrderBookHandler) (LocalOrderBook, error)
+	// StartListenKlines 开始监听K线
+	StartListenKlines(ctx context.Context, market Market, symbol string, interval KlineInterval, handler WebsocketKlineHandler) error
+	// StartListenTrades 开始监听公开成交，多个交易对共用一个连接
+	StartListenTrades(ctx context.Context, market Market, symbols []string, handler WebsocketTradeHandler) error
+	// StartListenMarkPrice 开始监听合约标记价格、指数价格与资金费率，多个交易对共用一个连接，
+	// 交易所未推送的字段为空
+	StartListenMarkPrice(ctx context.Context, symbols []string, handler WebsocketMarkPriceHandler) error
 }
@@ -1 +1,134 @@
 package factory
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/impl/binance"
+	"github.com/so68/exchange-lib/impl/gate"
+	"github.com/so68/exchange-lib/impl/okx"
+)
+
+const (
+	Binance = "binance" // 币安
+	Gate    = "gate"    // 芝麻
+	OKX     = "okx"     // 欧易
+)
+
+// Config 交易所配置
+type Config struct {
+	Exchange            string        `json:"exchange"`            // 交易所ID: binance gate okx
+	APIKey              string        `json:"apiKey"`              // API Key
+	SecretKey           string        `json:"secretKey"`           // Secret Key
+	Passphrase          string        `json:"passphrase"`          // API 密码（OKX）
+	Testnet             bool          `json:"testnet"`             // 是否使用测试网
+	SpotBaseURL         string        `json:"spotBaseURL"`         // 现货接口地址
+	FuturesBaseURL      string        `json:"futuresBaseURL"`      // 合约接口地址
+	SpotWebsocketURL    string        `json:"spotWebsocketURL"`    // 现货 Websocket 地址
+	FuturesWebsocketURL string        `json:"futuresWebsocketURL"` // 合约 Websocket 地址
+	Timeout             time.Duration `json:"timeout"`             // HTTP 请求超时时间
+	HTTPClient          *http.Client  `json:"-"`                   // 自定义 HTTP 客户端
+}
+
+// Options 转换为交易所实例配置项
+func (c Config) Options() []exchange.Option {
+	environment := exchange.EnvironmentProduction
+	if c.Testnet {
+		environment = exchange.EnvironmentTestnet
+	}
+	return []exchange.Option{
+		exchange.WithEnvironment(environment),
+		exchange.WithSpotBaseURL(c.SpotBaseURL),
+		exchange.WithFuturesBaseURL(c.FuturesBaseURL),
+		exchange.WithSpotWebsocketURL(c.SpotWebsocketURL),
+		exchange.WithFuturesWebsocketURL(c.FuturesWebsocketURL),
+		exchange.WithHTTPClient(c.HTTPClient),
+		exchange.WithTimeout(c.Timeout),
+	}
+}
+
+func init() {
+	MustRegister(Binance, Registration{
+		NewExchange: func(cfg Config) (exchange.Exchange, error) {
+			return binance.NewBinance(cfg.APIKey, cfg.SecretKey, cfg.Options()...), nil
+		},
+		NewWebsocket: func(cfg Config) (exchange.Websocket, error) {
+			return binance.NewBinanceWebsocket(cfg.Options()...), nil
+		},
+		NewUserDataStream: func(cfg Config) (exchange.UserDataStream, error) {
+			return binance.NewBinanceUserDataStream(cfg.APIKey, cfg.SecretKey, cfg.Options()...), nil
+		},
+		Features: Features{Spot: true, Futures: true, Testnet: true},
+	})
+	MustRegister(Gate, Registration{
+		NewExchange: func(cfg Config) (exchange.Exchange, error) {
+			return gate.NewGateExchange(cfg.APIKey, cfg.SecretKey, cfg.Options()...), nil
+		},
+		NewWebsocket: func(cfg Config) (exchange.Websocket, error) {
+			return gate.NewGateWebsocket(cfg.Options()...), nil
+		},
+		NewUserDataStream: func(cfg Config) (exchange.UserDataStream, error) {
+			return gate.NewGateUserDataStream(cfg.APIKey, cfg.SecretKey, cfg.Options()...), nil
+		},
+		Features: Features{Spot: true, Futures: true, Testnet: true},
+	})
+	MustRegister(OKX, Registration{
+		NewExchange: func(cfg Config) (exchange.Exchange, error) {
+			return okx.NewOKX(cfg.APIKey, cfg.SecretKey, cfg.Passphrase, cfg.Options()...), nil
+		},
+		NewWebsocket: func(cfg Config) (exchange.Websocket, error) {
+			return okx.NewOKXWebsocket(cfg.Options()...), nil
+		},
+		NewUserDataStream: func(cfg Config) (exchange.UserDataStream, error) {
+			return okx.NewOKXUserDataStream(cfg.APIKey, cfg.SecretKey, cfg.Passphrase, cfg.Options()...), nil
+		},
+		Features: Features{Spot: true, Futures: true, Testnet: true, RequiresPassphrase: true},
+	})
+}
+
+// NewExchange 根据配置创建交易所实例
+func NewExchange(cfg Config) (exchange.Exchange, error) {
+	registration, err := lookupWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return registration.NewExchange(cfg)
+}
+
+// NewWebsocket 根据配置创建 Websocket 实例
+func NewWebsocket(cfg Config) (exchange.Websocket, error) {
+	registration, err := lookupWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if registration.NewWebsocket == nil {
+		return nil, fmt.Errorf("交易所 %s 不支持 Websocket", cfg.Exchange)
+	}
+	return registration.NewWebsocket(cfg)
+}
+
+// NewUserDataStream 根据配置创建私有数据流实例
+func NewUserDataStream(cfg Config) (exchange.UserDataStream, error) {
+	registration, err := lookupWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if registration.NewUserDataStream == nil {
+		return nil, fmt.Errorf("交易所 %s 不支持私有数据流", cfg.Exchange)
+	}
+	return registration.NewUserDataStream(cfg)
+}
+
+// lookupWithConfig 获取交易所注册信息并验证配置
+func lookupWithConfig(cfg Config) (Registration, error) {
+	registration, ok := Lookup(cfg.Exchange)
+	if !ok {
+		return Registration{}, fmt.Errorf("不支持的交易所: %s", cfg.Exchange)
+	}
+	if cfg.Testnet && !registration.Features.Testnet {
+		return Registration{}, fmt.Errorf("交易所 %s 不支持测试网", cfg.Exchange)
+	}
+	return registration, nil
+}
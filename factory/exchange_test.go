@@ -0,0 +1,138 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+func TestExchanges(t *testing.T) {
+	infos := Exchanges()
+	ids := make([]string, 0, len(infos))
+	for _, info := range infos {
+		ids = append(ids, info.ID)
+	}
+	if got := strings.Join(ids, ","); got != "binance,gate,okx" {
+		t.Fatalf("Exchanges() = %s", got)
+	}
+	for _, info := range infos {
+		if !info.Features.Spot || !info.Features.Futures || !info.Features.Testnet {
+			t.Errorf("%s 应支持现货、合约和测试网", info.ID)
+		}
+		if !info.Features.Websocket {
+			t.Errorf("%s 应支持 Websocket", info.ID)
+		}
+		if !info.Features.UserData {
+			t.Errorf("%s 应支持私有数据流", info.ID)
+		}
+		if info.ID == OKX && !info.Features.RequiresPassphrase {
+			t.Errorf("okx 功能不正确: %+v", info.Features)
+		}
+	}
+}
+
+func TestNewExchange(t *testing.T) {
+	for _, id := range []string{Binance, Gate, OKX, " OKX "} {
+		ex, err := NewExchange(Config{Exchange: id, APIKey: "key", SecretKey: "secret", Passphrase: "pass"})
+		if err != nil {
+			t.Fatalf("NewExchange(%q) error: %v", id, err)
+		}
+		if ex == nil {
+			t.Fatalf("NewExchange(%q) 返回 nil", id)
+		}
+	}
+
+	if _, err := NewExchange(Config{Exchange: Binance, Testnet: true}); err != nil {
+		t.Fatalf("NewExchange(binance testnet) error: %v", err)
+	}
+	if _, err := NewExchange(Config{Exchange: "unknown"}); err == nil {
+		t.Fatal("未注册的交易所应返回错误")
+	}
+}
+
+func TestNewWebsocket(t *testing.T) {
+	if _, err := NewWebsocket(Config{Exchange: Binance}); err != nil {
+		t.Fatalf("NewWebsocket(binance) error: %v", err)
+	}
+	if _, err := NewWebsocket(Config{Exchange: OKX}); err != nil {
+		t.Fatalf("NewWebsocket(okx) error: %v", err)
+	}
+
+	MustRegister("rest-only", Registration{
+		NewExchange: func(cfg Config) (exchange.Exchange, error) { return nil, nil },
+	})
+	defer Unregister("rest-only")
+	if _, err := NewWebsocket(Config{Exchange: "rest-only"}); err == nil {
+		t.Fatal("不支持 Websocket 的交易所应返回错误")
+	}
+}
+
+func TestNewUserDataStream(t *testing.T) {
+	for _, id := range []string{Binance, Gate, OKX} {
+		stream, err := NewUserDataStream(Config{Exchange: id, APIKey: "key", SecretKey: "secret", Passphrase: "pass"})
+		if err != nil {
+			t.Fatalf("NewUserDataStream(%q) error: %v", id, err)
+		}
+		if stream == nil {
+			t.Fatalf("NewUserDataStream(%q) 返回 nil", id)
+		}
+	}
+
+	MustRegister("rest-only", Registration{
+		NewExchange: func(cfg Config) (exchange.Exchange, error) { return nil, nil },
+	})
+	defer Unregister("rest-only")
+	if _, err := NewUserDataStream(Config{Exchange: "rest-only"}); err == nil {
+		t.Fatal("不支持私有数据流的交易所应返回错误")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	var got Config
+	registration := Registration{
+		NewExchange: func(cfg Config) (exchange.Exchange, error) {
+			got = cfg
+			return nil, nil
+		},
+		Features: Features{Spot: true, Testnet: true},
+	}
+	if err := Register("Custom", registration); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	defer Unregister("custom")
+
+	if err := Register("custom", registration); err == nil {
+		t.Fatal("重复注册应返回错误")
+	}
+	if err := Register("empty", Registration{}); err == nil {
+		t.Fatal("缺少构造函数应返回错误")
+	}
+
+	cfg := Config{Exchange: "custom", APIKey: "key", Testnet: true, SpotBaseURL: "http://localhost"}
+	if _, err := NewExchange(cfg); err != nil {
+		t.Fatalf("NewExchange(custom) error: %v", err)
+	}
+	if got.APIKey != "key" || got.SpotBaseURL != "http://localhost" {
+		t.Fatalf("配置未传递: %+v", got)
+	}
+
+	registration, ok := Lookup("custom")
+	if !ok || registration.Features.Websocket {
+		t.Fatalf("Lookup(custom) = %+v, %v", registration.Features, ok)
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	cfg := Config{SpotBaseURL: "http://spot", FuturesWebsocketURL: "ws://futures", Testnet: true}
+	options := exchange.NewOptions(cfg.Options()...)
+	if !options.IsTestnet() {
+		t.Fatal("Testnet 未转换为测试网环境")
+	}
+	if options.SpotBaseURL != "http://spot" || options.FuturesWebsocketURL != "ws://futures" {
+		t.Fatalf("Options() = %+v", options)
+	}
+	if options.NewHTTPClient() != nil {
+		t.Fatal("未配置 HTTP 客户端时应返回 nil")
+	}
+}
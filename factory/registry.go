@@ -0,0 +1,111 @@
+package factory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// ExchangeConstructor 交易所实例构造函数
+type ExchangeConstructor func(cfg Config) (exchange.Exchange, error)
+
+// WebsocketConstructor Websocket 实例构造函数
+type WebsocketConstructor func(cfg Config) (exchange.Websocket, error)
+
+// UserDataStreamConstructor 私有数据流实例构造函数
+type UserDataStreamConstructor func(cfg Config) (exchange.UserDataStream, error)
+
+// Features 交易所支持的功能
+type Features struct {
+	Spot               bool `json:"spot"`               // 支持现货
+	Futures            bool `json:"futures"`            // 支持合约
+	Websocket          bool `json:"websocket"`          // 支持 Websocket 行情
+	UserData           bool `json:"userData"`           // 支持私有数据流
+	Testnet            bool `json:"testnet"`            // 支持测试网
+	RequiresPassphrase bool `json:"requiresPassphrase"` // 需要 API 密码
+}
+
+// Registration 交易所注册信息
+type Registration struct {
+	NewExchange       ExchangeConstructor       // 交易所实例构造函数，必填
+	NewWebsocket      WebsocketConstructor      // Websocket 实例构造函数，为空表示不支持
+	NewUserDataStream UserDataStreamConstructor // 私有数据流实例构造函数，为空表示不支持
+	Features          Features                  // 支持的功能
+}
+
+// Info 已注册的交易所信息
+type Info struct {
+	ID       string   `json:"id"`       // 交易所ID
+	Features Features `json:"features"` // 支持的功能
+}
+
+var (
+	registry    = make(map[string]Registration)
+	registryMux sync.RWMutex
+)
+
+// Register 注册交易所，第三方适配器可在 init 中调用
+func Register(id string, registration Registration) error {
+	id = normalizeID(id)
+	if id == "" {
+		return fmt.Errorf("交易所ID不能为空")
+	}
+	if registration.NewExchange == nil {
+		return fmt.Errorf("交易所 %s 缺少实例构造函数", id)
+	}
+	registration.Features.Websocket = registration.NewWebsocket != nil
+	registration.Features.UserData = registration.NewUserDataStream != nil
+
+	registryMux.Lock()
+	defer registryMux.Unlock()
+	if _, ok := registry[id]; ok {
+		return fmt.Errorf("交易所 %s 已注册", id)
+	}
+	registry[id] = registration
+	return nil
+}
+
+// MustRegister 注册交易所，失败时 panic
+func MustRegister(id string, registration Registration) {
+	if err := Register(id, registration); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister 注销交易所
+func Unregister(id string) {
+	registryMux.Lock()
+	defer registryMux.Unlock()
+	delete(registry, normalizeID(id))
+}
+
+// Exchanges 获取已注册的交易所列表，按交易所ID排序
+func Exchanges() []Info {
+	registryMux.RLock()
+	defer registryMux.RUnlock()
+
+	infos := make([]Info, 0, len(registry))
+	for id, registration := range registry {
+		infos = append(infos, Info{ID: id, Features: registration.Features})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// Lookup 获取交易所注册信息
+func Lookup(id string) (Registration, bool) {
+	registryMux.RLock()
+	defer registryMux.RUnlock()
+	registration, ok := registry[normalizeID(id)]
+	return registration, ok
+}
+
+// normalizeID 格式化交易所ID
+func normalizeID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
@@ -3,13 +3,16 @@ module github.com/so68/exchange-lib
 go 1.24.2
 
 require (
-	github.com/adshao/go-binance/v2 v2.8.7 // indirect
-	github.com/antihax/optional v1.0.0 // indirect
+	github.com/adshao/go-binance/v2 v2.8.7
+	github.com/antihax/optional v1.0.0
+	github.com/gateio/gateapi-go/v6 v6.104.3
+	github.com/gorilla/websocket v1.5.3
+	github.com/shopspring/decimal v1.4.0
+)
+
+require (
 	github.com/bitly/go-simplejson v0.5.0 // indirect
-	github.com/gateio/gateapi-go/v6 v6.104.3 // indirect
 	github.com/google/uuid v1.6.0 // indirect
-	github.com/gorilla/websocket v1.5.3 // indirect
 	github.com/jpillora/backoff v1.0.0 // indirect
 	github.com/okx/go-wallet-sdk v0.0.1 // indirect
-	github.com/shopspring/decimal v1.4.0 // indirect
 )
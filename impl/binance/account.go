@@ -2,31 +2,30 @@ package binance
 
 import (
 	"context"
-	"math/big"
 
 	"github.com/so68/exchange-lib/exchange"
 )
 
 // SpotBalance 获取现货余额
 func (b *binanceExchange) GetSpotBalance(ctx context.Context) ([]exchange.Balance, error) {
-	acc, err := b.client.NewGetAccountService().Do(ctx)
+	acc, err := b.getClient(ctx).NewGetAccountService().Do(ctx)
 	if err != nil {
-		return nil, err
+		return nil, toAPIError(err)
 	}
 	var res []exchange.Balance
 	for _, bal := range acc.Balances {
 		if bal.Free == "0.00000000" && bal.Locked == "0.00000000" {
 			continue
 		}
-		freeFloat := new(big.Float).SetPrec(64)
-		lockedFloat := new(big.Float).SetPrec(64)
-		if _, ok := freeFloat.SetString(bal.Free); !ok {
+		free, err := exchange.ParseDecimal(bal.Free)
+		if err != nil {
 			continue
 		}
-		if _, ok := lockedFloat.SetString(bal.Locked); !ok {
+		locked, err := exchange.ParseDecimal(bal.Locked)
+		if err != nil {
 			continue
 		}
-		total := new(big.Float).Add(freeFloat, lockedFloat).Text('f', -1)
+		total := free.Add(locked).String()
 		res = append(res, exchange.Balance{
 			Symbol: bal.Asset,
 			Free:   bal.Free,
@@ -39,26 +38,25 @@ func (b *binanceExchange) GetSpotBalance(ctx context.Context) ([]exchange.Balanc
 
 // FuturesBalance 获取合约余额
 func (b *binanceExchange) GetFuturesBalance(ctx context.Context) ([]exchange.Balance, error) {
-	acc, err := b.futuresClient.NewGetAccountService().Do(ctx)
+	acc, err := b.getFuturesClient(ctx).NewGetAccountService().Do(ctx)
 	if err != nil {
-		return nil, err
+		return nil, toAPIError(err)
 	}
 
 	var res []exchange.Balance
 	for _, asset := range acc.Assets {
 		// 解析余额
-		availableFloat := new(big.Float).SetPrec(64)
-		orderMarginFloat := new(big.Float).SetPrec(64)
-
-		if _, ok := availableFloat.SetString(asset.AvailableBalance); !ok {
+		available, err := exchange.ParseDecimal(asset.AvailableBalance)
+		if err != nil {
 			continue
 		}
-		if _, ok := orderMarginFloat.SetString(asset.OpenOrderInitialMargin); !ok {
+		orderMargin, err := exchange.ParseDecimal(asset.OpenOrderInitialMargin)
+		if err != nil {
 			continue
 		}
 
 		// 跳过余额为 0 的资产
-		if availableFloat.Cmp(big.NewFloat(0)) == 0 && orderMarginFloat.Cmp(big.NewFloat(0)) == 0 {
+		if available.IsZero() && orderMargin.IsZero() {
 			continue
 		}
 
@@ -0,0 +1,126 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/batch"
+)
+
+const (
+	FuturesBatchOrderLimit  = 5  // 合约批量下单每次最多订单数
+	FuturesBatchCancelLimit = 10 // 合约批量撤单每次最多订单数
+)
+
+// PlaceOrders 批量下单，现货没有批量接口，逐个下单
+func (b *binanceExchange) PlaceOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	return batch.SplitMarket(reqs, func(market exchange.Market, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+		if market == exchange.MarketSpot {
+			return b.placeSpotOrders(ctx, reqs)
+		}
+		return b.placeFuturesOrders(ctx, reqs)
+	})
+}
+
+// placeSpotOrders 现货逐个下单
+func (b *binanceExchange) placeSpotOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	prepare := func(req exchange.OrderRequest) (exchange.OrderRequest, error) {
+		return req, req.Validate()
+	}
+	return batch.Do(ctx, reqs, 1, prepare, func(reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+		order, err := b.placeSpotOrder(ctx, reqs[0])
+		return []exchange.OrderResult{{Order: order, Err: err}}, nil
+	})
+}
+
+// placeFuturesOrders 合约批量下单
+func (b *binanceExchange) placeFuturesOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	prepare := func(req exchange.OrderRequest) (*futures.CreateOrderService, error) {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+		service, _, err := b.newFuturesOrderService(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewOrderResponseType(futures.NewOrderRespTypeRESULT), nil
+	}
+	return batch.Do(ctx, reqs, FuturesBatchOrderLimit, prepare, func(services []*futures.CreateOrderService) ([]exchange.OrderResult, error) {
+		resp, err := b.getFuturesClient(ctx).NewCreateBatchOrdersService().OrderList(services).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("合约批量下单失败: %w", toAPIError(err))
+		}
+
+		return toFuturesBatchResults(resp), nil
+	})
+}
+
+// toFuturesBatchResults 合约批量下单结果按请求顺序转换，成功的订单按顺序紧凑排列，失败的位置记录在 Errors 中
+func toFuturesBatchResults(resp *futures.CreateBatchOrdersResponse) []exchange.OrderResult {
+	results := make([]exchange.OrderResult, len(resp.Errors))
+	next := 0
+	for i, err := range resp.Errors {
+		if err != nil {
+			results[i].Err = fmt.Errorf("合约下单失败: %w", toAPIError(err))
+			continue
+		}
+		if next >= len(resp.Orders) {
+			results[i].Err = fmt.Errorf("合约下单失败: 缺少订单结果")
+			continue
+		}
+		results[i].Order = toFuturesOrder(resp.Orders[next])
+		next++
+	}
+	return results
+}
+
+// CancelOrders 批量撤单，现货没有批量接口，逐个撤单
+func (b *binanceExchange) CancelOrders(ctx context.Context, market exchange.Market, symbol string, orderIDs []string) ([]exchange.OrderResult, error) {
+	switch market {
+	case exchange.MarketSpot:
+		prepare := func(orderID string) (string, error) {
+			return orderID, nil
+		}
+		return batch.Do(ctx, orderIDs, 1, prepare, func(orderIDs []string) ([]exchange.OrderResult, error) {
+			order, err := b.CancelSpotOrder(ctx, symbol, orderIDs[0])
+			return []exchange.OrderResult{{Order: order, Err: err}}, nil
+		})
+	case exchange.MarketFutures:
+		return b.cancelFuturesOrders(ctx, symbol, orderIDs)
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+}
+
+// cancelFuturesOrders 合约批量撤单，接口不返回单个订单的错误信息，失败的订单逐个撤单获取错误
+func (b *binanceExchange) cancelFuturesOrders(ctx context.Context, symbol string, orderIDs []string) ([]exchange.OrderResult, error) {
+	prepare := func(orderID string) (int64, error) {
+		orderIDInt, err := strconv.ParseInt(orderID, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("无效的订单ID: %w", err)
+		}
+		return orderIDInt, nil
+	}
+	return batch.Do(ctx, orderIDs, FuturesBatchCancelLimit, prepare, func(ids []int64) ([]exchange.OrderResult, error) {
+		resp, err := b.getFuturesClient(ctx).NewCancelMultipleOrdersService().Symbol(symbol).OrderIDList(ids).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("合约批量撤单失败: %w", toAPIError(err))
+		}
+		if len(resp) != len(ids) {
+			return nil, fmt.Errorf("合约批量撤单结果数量 %d 与请求数量 %d 不一致", len(resp), len(ids))
+		}
+
+		results := make([]exchange.OrderResult, len(ids))
+		for i, item := range resp {
+			if item.OrderID == 0 {
+				results[i].Order, results[i].Err = b.CancelFuturesOrder(ctx, symbol, strconv.FormatInt(ids[i], 10))
+				continue
+			}
+			results[i].Order = toFuturesCancelOrder(item)
+		}
+		return results, nil
+	})
+}
@@ -0,0 +1,75 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestToFuturesBatchResults 合约批量下单成功的订单紧凑排列，按 Errors 的位置还原为请求顺序
+// go test -v ./impl/binance -run "^TestToFuturesBatchResults$"
+func TestToFuturesBatchResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		resp      *futures.CreateBatchOrdersResponse
+		wantIDs   []int64 // 0 表示失败
+		wantKinds []error
+	}{
+		{
+			name: "全部成功",
+			resp: &futures.CreateBatchOrdersResponse{
+				N:      2,
+				Orders: []*futures.Order{{OrderID: 1}, {OrderID: 2}},
+				Errors: []error{nil, nil},
+			},
+			wantIDs:   []int64{1, 2},
+			wantKinds: []error{nil, nil},
+		},
+		{
+			name: "中间失败",
+			resp: &futures.CreateBatchOrdersResponse{
+				N:      3,
+				Orders: []*futures.Order{{OrderID: 1}, {OrderID: 3}},
+				Errors: []error{nil, &common.APIError{Code: -2019, Message: "Margin is insufficient."}, nil},
+			},
+			wantIDs:   []int64{1, 0, 3},
+			wantKinds: []error{nil, exchange.ErrInsufficientBalance, nil},
+		},
+		{
+			name: "缺少订单结果",
+			resp: &futures.CreateBatchOrdersResponse{
+				N:      2,
+				Orders: []*futures.Order{{OrderID: 1}},
+				Errors: []error{nil, nil},
+			},
+			wantIDs:   []int64{1, 0},
+			wantKinds: []error{nil, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := toFuturesBatchResults(tt.resp)
+			if len(results) != len(tt.wantIDs) {
+				t.Fatalf("结果数量错误: %d", len(results))
+			}
+			for i, result := range results {
+				if tt.wantIDs[i] == 0 {
+					if result.Err == nil || result.Order != nil {
+						t.Errorf("第 %d 个结果应失败: %+v", i+1, result)
+					}
+					if tt.wantKinds[i] != nil && !errors.Is(result.Err, tt.wantKinds[i]) {
+						t.Errorf("第 %d 个结果错误分类错误: %v", i+1, result.Err)
+					}
+					continue
+				}
+				if result.Err != nil || result.Order == nil || result.Order.OrderID != fmt.Sprint(tt.wantIDs[i]) {
+					t.Errorf("第 %d 个结果错误: %+v", i+1, result)
+				}
+			}
+		})
+	}
+}
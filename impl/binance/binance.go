@@ -1,21 +1,92 @@
 package binance
 
 import (
+	"context"
+
 	"github.com/adshao/go-binance/v2"
 	"github.com/adshao/go-binance/v2/futures"
 	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/ratelimit"
+	"github.com/so68/exchange-lib/internal/symbolmap"
+)
+
+const (
+	SpotTestnetBaseURL    = "https://testnet.binance.vision"    // 现货测试网接口地址
+	FuturesTestnetBaseURL = "https://testnet.binancefuture.com" // 合约测试网接口地址
 )
 
 // 现货实例
 type binanceExchange struct {
-	client        *binance.Client
-	futuresClient *futures.Client
+	testnet              bool            // 实例是否为测试网
+	client               *binance.Client // 实例环境现货客户端
+	futuresClient        *futures.Client // 实例环境合约客户端
+	testnetClient        *binance.Client // 测试网现货客户端，用于上下文设置了测试网的请求
+	testnetFuturesClient *futures.Client // 测试网合约客户端，用于上下文设置了测试网的请求
+	maxRetries           int             // 下单遇到临时错误时的最大重试次数
+}
+
+// 创建现货实例，交易对参数支持统一格式（如 BTC/USDT:USDT）与原生格式，返回值使用统一格式
+func NewBinance(apiKey, secretKey string, opts ...exchange.Option) exchange.Exchange {
+	return symbolmap.WrapExchange(newBinance(apiKey, secretKey, opts...), symbolFormatter{})
 }
 
 // 创建现货实例
-func NewBinance(apiKey, secretKey string) exchange.Exchange {
+func newBinance(apiKey, secretKey string, opts ...exchange.Option) *binanceExchange {
+	options := exchange.NewOptions(opts...)
+	limiter := options.RateLimiter
+	if limiter == nil {
+		limiter = NewRateLimiter(options.RateLimitPolicy)
+	}
+	httpClient := ratelimit.WrapClient(options.NewHTTPClient(), limiter)
+
+	testnetClient := binance.NewClient(apiKey, secretKey)
+	testnetClient.BaseURL = SpotTestnetBaseURL
+	testnetFuturesClient := futures.NewClient(apiKey, secretKey)
+	testnetFuturesClient.BaseURL = FuturesTestnetBaseURL
+
+	client := binance.NewClient(apiKey, secretKey)
+	futuresClient := futures.NewClient(apiKey, secretKey)
+	if options.IsTestnet() {
+		client, futuresClient = testnetClient, testnetFuturesClient
+	}
+	if options.SpotBaseURL != "" {
+		client.BaseURL = options.SpotBaseURL
+	}
+	if options.FuturesBaseURL != "" {
+		futuresClient.BaseURL = options.FuturesBaseURL
+	}
+	client.HTTPClient = httpClient
+	futuresClient.HTTPClient = httpClient
+	testnetClient.HTTPClient = httpClient
+	testnetFuturesClient.HTTPClient = httpClient
+
 	return &binanceExchange{
-		client:        binance.NewClient(apiKey, secretKey),
-		futuresClient: futures.NewClient(apiKey, secretKey),
+		testnet:              options.IsTestnet(),
+		client:               client,
+		futuresClient:        futuresClient,
+		testnetClient:        testnetClient,
+		testnetFuturesClient: testnetFuturesClient,
+		maxRetries:           options.Retries(),
+	}
+}
+
+// isTestnet 请求是否使用测试网
+func (b *binanceExchange) isTestnet(ctx context.Context) bool {
+	return b.testnet || exchange.IsTestnet(ctx)
+}
+
+// getClient 获取请求对应环境的现货客户端
+func (b *binanceExchange) getClient(ctx context.Context) *binance.Client {
+	if !b.testnet && exchange.IsTestnet(ctx) {
+		return b.testnetClient
+	}
+	return b.client
+}
+
+// getFuturesClient 获取请求对应环境的合约客户端
+func (b *binanceExchange) getFuturesClient(ctx context.Context) *futures.Client {
+	if !b.testnet && exchange.IsTestnet(ctx) {
+		return b.testnetFuturesClient
 	}
+	return b.futuresClient
 }
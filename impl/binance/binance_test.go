@@ -1,7 +1,17 @@
 package binance
 
 import (
+	"context"
 	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
 )
 
 const (
@@ -17,3 +27,102 @@ var (
 	leverage  = flag.Int("leverage", 1, "杠杆")
 	orderID   = flag.String("orderID", "", "订单ID")
 )
+
+// testRequest 测试服务器收到的请求
+type testRequest struct {
+	Method string
+	Path   string
+	Params map[string]string // 查询参数与表单参数
+}
+
+// testServer 模拟 Binance 接口的测试服务器
+type testServer struct {
+	t        *testing.T
+	routes   map[string]string // "METHOD /path" -> 响应 JSON，{"code": 开头的响应按错误返回 400
+	requests []testRequest
+	mux      sync.Mutex
+}
+
+// newTestBinance 创建现货与合约均连接到测试服务器的 Binance 实例
+func newTestBinance(t *testing.T, routes map[string]string) (*binanceExchange, *testServer) {
+	t.Helper()
+
+	// 清空交易对规格缓存，避免测试之间互相影响
+	binanceSpotSpec.DeleteSymbolsSpec()
+	binanceFuturesSpec.DeleteSymbolsSpec()
+
+	ts := &testServer{t: t, routes: routes}
+	server := httptest.NewServer(http.HandlerFunc(ts.handle))
+	t.Cleanup(server.Close)
+
+	b := newBinance(apiKey, secretKey, exchange.WithSpotBaseURL(server.URL), exchange.WithFuturesBaseURL(server.URL))
+	return b, ts
+}
+
+// handle 记录请求参数并返回预设数据
+func (ts *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	params := map[string]string{}
+	for k := range r.URL.Query() {
+		params[k] = r.URL.Query().Get(k)
+	}
+	if form, err := url.ParseQuery(string(body)); err == nil {
+		for k := range form {
+			params[k] = form.Get(k)
+		}
+	}
+	ts.mux.Lock()
+	ts.requests = append(ts.requests, testRequest{Method: r.Method, Path: r.URL.Path, Params: params})
+	ts.mux.Unlock()
+
+	data, ok := ts.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		ts.t.Errorf("未预设的请求: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":-1,"msg":"not found"}`))
+		return
+	}
+	if strings.HasPrefix(data, `{"code":`) {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	w.Write([]byte(data))
+}
+
+// findRequest 查找指定路径的请求
+func (ts *testServer) findRequest(method, path string) *testRequest {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+	for i := range ts.requests {
+		if ts.requests[i].Method == method && ts.requests[i].Path == path {
+			return &ts.requests[i]
+		}
+	}
+	return nil
+}
+
+// TestTestnet 测试网客户端选择
+// go test -v ./impl/binance -run "^TestTestnet$"
+func TestTestnet(t *testing.T) {
+	b := newBinance(apiKey, secretKey)
+	ctx := exchange.WithTestnet(context.Background())
+	if b.getClient(context.Background()).BaseURL == SpotTestnetBaseURL {
+		t.Errorf("正式网请求使用了测试网现货地址")
+	}
+	if b.getClient(ctx).BaseURL != SpotTestnetBaseURL {
+		t.Errorf("测试网现货地址错误: %s", b.getClient(ctx).BaseURL)
+	}
+	if b.getFuturesClient(ctx).BaseURL != FuturesTestnetBaseURL {
+		t.Errorf("测试网合约地址错误: %s", b.getFuturesClient(ctx).BaseURL)
+	}
+	if b.getSpotSpec(ctx) != binanceSpotTestnetSpec || b.getFuturesSpec(context.Background()) != binanceFuturesSpec {
+		t.Errorf("交易对规格缓存环境错误")
+	}
+
+	b = newBinance(apiKey, secretKey, exchange.WithEnvironment(exchange.EnvironmentTestnet))
+	if b.getClient(context.Background()).BaseURL != SpotTestnetBaseURL {
+		t.Errorf("测试网实例现货地址错误: %s", b.getClient(context.Background()).BaseURL)
+	}
+	if b.getFuturesClient(context.Background()).BaseURL != FuturesTestnetBaseURL {
+		t.Errorf("测试网实例合约地址错误: %s", b.getFuturesClient(context.Background()).BaseURL)
+	}
+}
@@ -0,0 +1,398 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+const (
+	OCOIDPrefix = "oco-" // 现货 OCO 条件单ID前缀，后接 orderListId
+)
+
+// PlaceConditionalOrder 下条件单。现货跟踪止损使用 trailingDelta，合约不支持 OCO
+func (b *binanceExchange) PlaceConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Market == exchange.MarketSpot {
+		return b.placeSpotConditionalOrder(ctx, req)
+	}
+	return b.placeFuturesConditionalOrder(ctx, req)
+}
+
+// ListConditionalOrders 获取交易对未触发的条件单
+func (b *binanceExchange) ListConditionalOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.ConditionalOrder, error) {
+	if market == exchange.MarketSpot {
+		return b.listSpotConditionalOrders(ctx, symbol)
+	}
+	return b.listFuturesConditionalOrders(ctx, symbol)
+}
+
+// CancelConditionalOrder 撤销未触发的条件单，现货 OCO 撤销整个订单列表
+func (b *binanceExchange) CancelConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) error {
+	if market == exchange.MarketFutures {
+		orderID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return fmt.Errorf("无效的条件单ID: %w", err)
+		}
+		if _, err := b.getFuturesClient(ctx).NewCancelOrderService().Symbol(symbol).OrderID(orderID).Do(ctx); err != nil {
+			return fmt.Errorf("撤销条件单失败: %w", toAPIError(err))
+		}
+		return nil
+	}
+
+	if listID, ok := strings.CutPrefix(id, OCOIDPrefix); ok {
+		orderListID, err := strconv.ParseInt(listID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("无效的条件单ID: %w", err)
+		}
+		if _, err := b.getClient(ctx).NewCancelOCOService().Symbol(symbol).OrderListID(orderListID).Do(ctx); err != nil {
+			return fmt.Errorf("撤销条件单失败: %w", toAPIError(err))
+		}
+		return nil
+	}
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("无效的条件单ID: %w", err)
+	}
+	if _, err := b.getClient(ctx).NewCancelOrderService().Symbol(symbol).OrderID(orderID).Do(ctx); err != nil {
+		return fmt.Errorf("撤销条件单失败: %w", toAPIError(err))
+	}
+	return nil
+}
+
+// placeSpotConditionalOrder 现货条件单
+func (b *binanceExchange) placeSpotConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	spec, err := b.getSpotSymbolSpec(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := b.filtersQuantity(spec, req.Price, req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+
+	if req.Type == exchange.ConditionalOrderTypeOCO {
+		service := b.getClient(ctx).NewCreateOCOService().
+			Symbol(req.Symbol).
+			Side(binance.SideType(string(req.Side))).
+			Quantity(quantity).
+			Price(req.Price).
+			StopPrice(req.TriggerPrice).
+			ListClientOrderID(clientOrderID)
+		if req.StopLimitPrice != "" {
+			service.StopLimitPrice(req.StopLimitPrice).StopLimitTimeInForce(binance.TimeInForceTypeGTC)
+		}
+		resp, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("下条件单失败: %w", toAPIError(err))
+		}
+		return newConditionalOrder(req, OCOIDPrefix+strconv.FormatInt(resp.OrderListID, 10), resp.ListClientOrderID, quantity, resp.TransactionTime), nil
+	}
+
+	service := b.getClient(ctx).NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(binance.SideType(string(req.Side))).
+		Quantity(quantity).
+		NewClientOrderID(clientOrderID)
+	switch req.Type {
+	case exchange.ConditionalOrderTypeStopMarket:
+		service.Type(binance.OrderTypeStopLoss).StopPrice(req.TriggerPrice)
+	case exchange.ConditionalOrderTypeTakeProfitMarket:
+		service.Type(binance.OrderTypeTakeProfit).StopPrice(req.TriggerPrice)
+	case exchange.ConditionalOrderTypeStopLimit:
+		service.Type(binance.OrderTypeStopLossLimit).StopPrice(req.TriggerPrice).Price(req.Price).TimeInForce(binance.TimeInForceTypeGTC)
+	case exchange.ConditionalOrderTypeTakeProfitLimit:
+		service.Type(binance.OrderTypeTakeProfitLimit).StopPrice(req.TriggerPrice).Price(req.Price).TimeInForce(binance.TimeInForceTypeGTC)
+	case exchange.ConditionalOrderTypeTrailingStop:
+		// 回调幅度转换为基点，1% = 100
+		service.Type(binance.OrderTypeStopLoss).TrailingDelta(toTrailingDelta(req.CallbackRate))
+		if req.TriggerPrice != "" {
+			service.StopPrice(req.TriggerPrice)
+		}
+	}
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("下条件单失败: %w", toAPIError(err))
+	}
+	return newConditionalOrder(req, strconv.FormatInt(resp.OrderID, 10), resp.ClientOrderID, quantity, resp.TransactTime), nil
+}
+
+// placeFuturesConditionalOrder 合约条件单，双向持仓模式下不发送只减仓参数
+func (b *binanceExchange) placeFuturesConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	spec, err := b.getFuturesSymbolSpec(ctx, req.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	quantity, err := b.filtersQuantity(spec, req.Price, req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+
+	service := b.getFuturesClient(ctx).NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(futures.SideType(string(req.Side))).
+		Quantity(quantity).
+		NewClientOrderID(idempotent.ClientOrderID(ctx, req.ClientOrderID))
+	if req.PositionSide != "" {
+		service.PositionSide(futures.PositionSideType(req.PositionSide))
+	}
+	if req.ReduceOnly && (req.PositionSide == "" || req.PositionSide == exchange.PositionSideBoth) {
+		service.ReduceOnly(true)
+	}
+	switch req.Type {
+	case exchange.ConditionalOrderTypeStopMarket:
+		service.Type(futures.OrderTypeStopMarket).StopPrice(req.TriggerPrice)
+	case exchange.ConditionalOrderTypeTakeProfitMarket:
+		service.Type(futures.OrderTypeTakeProfitMarket).StopPrice(req.TriggerPrice)
+	case exchange.ConditionalOrderTypeStopLimit:
+		service.Type(futures.OrderTypeStop).StopPrice(req.TriggerPrice).Price(req.Price).TimeInForce(futures.TimeInForceTypeGTC)
+	case exchange.ConditionalOrderTypeTakeProfitLimit:
+		service.Type(futures.OrderTypeTakeProfit).StopPrice(req.TriggerPrice).Price(req.Price).TimeInForce(futures.TimeInForceTypeGTC)
+	case exchange.ConditionalOrderTypeTrailingStop:
+		service.Type(futures.OrderTypeTrailingStopMarket).CallbackRate(req.CallbackRate)
+		if req.TriggerPrice != "" {
+			service.ActivationPrice(req.TriggerPrice)
+		}
+	default:
+		return nil, fmt.Errorf("%w: 合约不支持 %s", exchange.ErrInvalidOrder, req.Type)
+	}
+
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("下条件单失败: %w", toAPIError(err))
+	}
+	return newConditionalOrder(req, strconv.FormatInt(resp.OrderID, 10), resp.ClientOrderID, quantity, resp.UpdateTime), nil
+}
+
+// listSpotConditionalOrders 现货未触发的条件单，OCO 的两个订单合并为一个条件单。
+// 现货订单不返回 trailingDelta，跟踪止损返回为 STOP_MARKET
+func (b *binanceExchange) listSpotConditionalOrders(ctx context.Context, symbol string) ([]*exchange.ConditionalOrder, error) {
+	orders, err := b.getClient(ctx).NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取现货挂单失败: %w", toAPIError(err))
+	}
+
+	result := make([]*exchange.ConditionalOrder, 0)
+	ocoOrders := make(map[int64]*exchange.ConditionalOrder)
+	for _, o := range orders {
+		if o.OrderListId != -1 {
+			oco, ok := ocoOrders[o.OrderListId]
+			if !ok {
+				oco = &exchange.ConditionalOrder{
+					ID:         OCOIDPrefix + strconv.FormatInt(o.OrderListId, 10),
+					Market:     exchange.MarketSpot,
+					Symbol:     o.Symbol,
+					Side:       exchange.OrderSide(o.Side),
+					Type:       exchange.ConditionalOrderTypeOCO,
+					Status:     exchange.ConditionalOrderStatusUntriggered,
+					Quantity:   o.OrigQuantity,
+					CreateTime: o.Time,
+					UpdateTime: o.UpdateTime,
+				}
+				ocoOrders[o.OrderListId] = oco
+				result = append(result, oco)
+			}
+			switch o.Type {
+			case binance.OrderTypeLimitMaker:
+				oco.Price = o.Price
+			case binance.OrderTypeStopLossLimit:
+				oco.TriggerPrice, oco.StopLimitPrice = o.StopPrice, o.Price
+			default:
+				oco.TriggerPrice = o.StopPrice
+			}
+			continue
+		}
+
+		if _, ok := spotConditionalTypes[o.Type]; !ok || o.IsWorking {
+			continue
+		}
+		result = append(result, toSpotConditionalOrder(o))
+	}
+	sortConditionalOrders(result)
+	return result, nil
+}
+
+// listFuturesConditionalOrders 合约未触发的条件单
+func (b *binanceExchange) listFuturesConditionalOrders(ctx context.Context, symbol string) ([]*exchange.ConditionalOrder, error) {
+	orders, err := b.getFuturesClient(ctx).NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约挂单失败: %w", toAPIError(err))
+	}
+
+	result := make([]*exchange.ConditionalOrder, 0)
+	for _, o := range orders {
+		if _, ok := futuresConditionalTypes[o.Type]; !ok || o.Status != futures.OrderStatusTypeNew {
+			continue
+		}
+		result = append(result, toFuturesConditionalOrder(o))
+	}
+	sortConditionalOrders(result)
+	return result, nil
+}
+
+// GetConditionalOrder 获取条件单，条件单触发后订单ID不变，现货 OCO 不支持按ID查询
+func (b *binanceExchange) GetConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) (*exchange.ConditionalOrder, error) {
+	if strings.HasPrefix(id, OCOIDPrefix) {
+		return nil, fmt.Errorf("%w: 不支持查询 OCO 条件单", exchange.ErrInvalidOrder)
+	}
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("无效的条件单ID: %w", err)
+	}
+
+	if market == exchange.MarketFutures {
+		order, err := b.getFuturesClient(ctx).NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取条件单失败: %w", toAPIError(err))
+		}
+		if _, ok := futuresConditionalTypes[order.OrigType]; !ok {
+			return nil, fmt.Errorf("%w: 订单 %s 不是条件单", exchange.ErrOrderNotFound, id)
+		}
+		return toFuturesConditionalOrder(order), nil
+	}
+
+	order, err := b.getClient(ctx).NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取条件单失败: %w", toAPIError(err))
+	}
+	if _, ok := spotConditionalTypes[order.Type]; !ok {
+		return nil, fmt.Errorf("%w: 订单 %s 不是条件单", exchange.ErrOrderNotFound, id)
+	}
+	return toSpotConditionalOrder(order), nil
+}
+
+// toSpotConditionalOrder 转换现货条件单，订单开始生效表示已触发
+func toSpotConditionalOrder(o *binance.Order) *exchange.ConditionalOrder {
+	conditionalType := spotConditionalTypes[o.Type]
+	conditional := &exchange.ConditionalOrder{
+		ID:            strconv.FormatInt(o.OrderID, 10),
+		ClientOrderID: o.ClientOrderID,
+		Market:        exchange.MarketSpot,
+		Symbol:        o.Symbol,
+		Side:          exchange.OrderSide(o.Side),
+		Type:          conditionalType,
+		Status:        toConditionalStatus(string(o.Status), o.IsWorking),
+		Quantity:      o.OrigQuantity,
+		TriggerPrice:  o.StopPrice,
+		CreateTime:    o.Time,
+		UpdateTime:    o.UpdateTime,
+	}
+	if conditionalType.IsLimit() {
+		conditional.Price = o.Price
+	}
+	if conditional.Status == exchange.ConditionalOrderStatusTriggered {
+		conditional.OrderID = conditional.ID
+	}
+	return conditional
+}
+
+// toFuturesConditionalOrder 转换合约条件单，触发后订单类型变为普通订单，原类型为 origType
+func toFuturesConditionalOrder(o *futures.Order) *exchange.ConditionalOrder {
+	origType := o.OrigType
+	if origType == "" {
+		origType = o.Type
+	}
+	conditionalType := futuresConditionalTypes[origType]
+	conditional := &exchange.ConditionalOrder{
+		ID:            strconv.FormatInt(o.OrderID, 10),
+		ClientOrderID: o.ClientOrderID,
+		Market:        exchange.MarketFutures,
+		Symbol:        o.Symbol,
+		Side:          exchange.OrderSide(o.Side),
+		Type:          conditionalType,
+		Status:        toConditionalStatus(string(o.Status), o.Type != origType),
+		Quantity:      o.OrigQuantity,
+		TriggerPrice:  o.StopPrice,
+		CreateTime:    o.Time,
+		UpdateTime:    o.UpdateTime,
+	}
+	switch {
+	case conditionalType == exchange.ConditionalOrderTypeTrailingStop:
+		conditional.TriggerPrice, conditional.CallbackRate = o.ActivatePrice, o.PriceRate
+	case conditionalType.IsLimit():
+		conditional.Price = o.Price
+	}
+	if conditional.Status == exchange.ConditionalOrderStatusTriggered {
+		conditional.OrderID = conditional.ID
+	}
+	return conditional
+}
+
+// toConditionalStatus 按订单状态与是否已触发转换条件单状态，触发后下单被拒绝或过期为失败
+func toConditionalStatus(status string, triggered bool) exchange.ConditionalOrderStatus {
+	switch status {
+	case "NEW", "PENDING_NEW":
+		if triggered {
+			return exchange.ConditionalOrderStatusTriggered
+		}
+		return exchange.ConditionalOrderStatusUntriggered
+	case "PARTIALLY_FILLED", "FILLED":
+		return exchange.ConditionalOrderStatusTriggered
+	case "CANCELED", "PENDING_CANCEL":
+		if triggered {
+			return exchange.ConditionalOrderStatusTriggered
+		}
+		return exchange.ConditionalOrderStatusCanceled
+	default:
+		return exchange.ConditionalOrderStatusFailed
+	}
+}
+
+// 现货订单类型对应的条件单类型
+var spotConditionalTypes = map[binance.OrderType]exchange.ConditionalOrderType{
+	binance.OrderTypeStopLoss:        exchange.ConditionalOrderTypeStopMarket,
+	binance.OrderTypeStopLossLimit:   exchange.ConditionalOrderTypeStopLimit,
+	binance.OrderTypeTakeProfit:      exchange.ConditionalOrderTypeTakeProfitMarket,
+	binance.OrderTypeTakeProfitLimit: exchange.ConditionalOrderTypeTakeProfitLimit,
+}
+
+// 合约订单类型对应的条件单类型
+var futuresConditionalTypes = map[futures.OrderType]exchange.ConditionalOrderType{
+	futures.OrderTypeStopMarket:         exchange.ConditionalOrderTypeStopMarket,
+	futures.OrderTypeStop:               exchange.ConditionalOrderTypeStopLimit,
+	futures.OrderTypeTakeProfitMarket:   exchange.ConditionalOrderTypeTakeProfitMarket,
+	futures.OrderTypeTakeProfit:         exchange.ConditionalOrderTypeTakeProfitLimit,
+	futures.OrderTypeTrailingStopMarket: exchange.ConditionalOrderTypeTrailingStop,
+}
+
+// toTrailingDelta 回调幅度百分比转换为基点
+func toTrailingDelta(callbackRate string) string {
+	return exchange.ToDecimal(callbackRate).Mul(exchange.NewDecimalFromInt(100)).Truncate(0).String()
+}
+
+// newConditionalOrder 按请求构造新建的条件单
+func newConditionalOrder(req exchange.ConditionalOrderRequest, id, clientOrderID, quantity string, createTime int64) *exchange.ConditionalOrder {
+	return &exchange.ConditionalOrder{
+		ID:             id,
+		ClientOrderID:  clientOrderID,
+		Market:         req.Market,
+		Symbol:         req.Symbol,
+		Side:           req.Side,
+		Type:           req.Type,
+		Status:         exchange.ConditionalOrderStatusUntriggered,
+		Quantity:       quantity,
+		TriggerPrice:   req.TriggerPrice,
+		Price:          req.Price,
+		StopLimitPrice: req.StopLimitPrice,
+		CallbackRate:   req.CallbackRate,
+		CreateTime:     createTime,
+		UpdateTime:     createTime,
+	}
+}
+
+// sortConditionalOrders 按创建时间升序排列条件单
+func sortConditionalOrders(orders []*exchange.ConditionalOrder) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].CreateTime < orders[j].CreateTime
+	})
+}
@@ -0,0 +1,121 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestToTrailingDelta 跟踪止损回调幅度百分比转换为基点
+// go test -v ./impl/binance -run "^TestToTrailingDelta$"
+func TestToTrailingDelta(t *testing.T) {
+	cases := map[string]string{"1": "100", "0.5": "50", "2.345": "234"}
+	for rate, want := range cases {
+		if delta := toTrailingDelta(rate); delta != want {
+			t.Errorf("toTrailingDelta(%s) = %s, want %s", rate, delta, want)
+		}
+	}
+}
+
+// TestPlaceConditionalOrderTypes 条件单类型转换为交易所订单类型，跟踪止损分别使用 trailingDelta 与 callbackRate
+// go test -v ./impl/binance -run "^TestPlaceConditionalOrderTypes$"
+func TestPlaceConditionalOrderTypes(t *testing.T) {
+	tests := []struct {
+		req    exchange.ConditionalOrderRequest
+		path   string
+		params map[string]string
+	}{
+		{exchange.ConditionalOrderRequest{Market: exchange.MarketSpot, Side: exchange.OrderSideSell, Type: exchange.ConditionalOrderTypeStopMarket, TriggerPrice: "90"},
+			"/api/v3/order", map[string]string{"type": "STOP_LOSS", "stopPrice": "90", "price": ""}},
+		{exchange.ConditionalOrderRequest{Market: exchange.MarketSpot, Side: exchange.OrderSideSell, Type: exchange.ConditionalOrderTypeTakeProfitLimit, TriggerPrice: "110", Price: "109"},
+			"/api/v3/order", map[string]string{"type": "TAKE_PROFIT_LIMIT", "stopPrice": "110", "price": "109", "timeInForce": "GTC"}},
+		{exchange.ConditionalOrderRequest{Market: exchange.MarketSpot, Side: exchange.OrderSideSell, Type: exchange.ConditionalOrderTypeTrailingStop, CallbackRate: "1.5"},
+			"/api/v3/order", map[string]string{"type": "STOP_LOSS", "trailingDelta": "150", "stopPrice": ""}},
+		{exchange.ConditionalOrderRequest{Market: exchange.MarketFutures, Side: exchange.OrderSideBuy, Type: exchange.ConditionalOrderTypeStopLimit, TriggerPrice: "110", Price: "111", ReduceOnly: true},
+			"/fapi/v1/order", map[string]string{"type": "STOP", "stopPrice": "110", "price": "111", "reduceOnly": "true"}},
+		{exchange.ConditionalOrderRequest{Market: exchange.MarketFutures, Side: exchange.OrderSideSell, Type: exchange.ConditionalOrderTypeTakeProfitMarket, TriggerPrice: "120", PositionSide: exchange.PositionSideLong, ReduceOnly: true},
+			"/fapi/v1/order", map[string]string{"type": "TAKE_PROFIT_MARKET", "stopPrice": "120", "positionSide": "LONG", "reduceOnly": ""}},
+		{exchange.ConditionalOrderRequest{Market: exchange.MarketFutures, Side: exchange.OrderSideSell, Type: exchange.ConditionalOrderTypeTrailingStop, CallbackRate: "1", TriggerPrice: "105"},
+			"/fapi/v1/order", map[string]string{"type": "TRAILING_STOP_MARKET", "callbackRate": "1", "activationPrice": "105"}},
+	}
+	for i, tt := range tests {
+		b, ts := newTestBinance(t, map[string]string{
+			"POST " + tt.path: `{"symbol":"BTCUSDT","orderId":5,"clientOrderId":"c5","transactTime":1700000000000,"updateTime":1700000000000}`,
+		})
+		b.getSpotSpec(context.Background()).SetSymbolSpec("BTCUSDT", testAmendSpec)
+		b.getFuturesSpec(context.Background()).SetSymbolSpec("BTCUSDT", testAmendSpec)
+		tt.req.Symbol, tt.req.Quantity = "BTCUSDT", "1"
+		order, err := b.PlaceConditionalOrder(context.Background(), tt.req)
+		if err != nil {
+			t.Fatalf("第 %d 组下条件单失败: %v", i, err)
+		}
+		if order.ID != "5" || order.Type != tt.req.Type || order.Status != exchange.ConditionalOrderStatusUntriggered {
+			t.Errorf("第 %d 组条件单数据错误: %+v", i, order)
+		}
+		req := ts.findRequest("POST", tt.path)
+		for k, v := range tt.params {
+			if req.Params[k] != v {
+				t.Errorf("第 %d 组参数 %s 应为 %q: %q", i, k, v, req.Params[k])
+			}
+		}
+	}
+}
+
+// TestToConditionalStatus 订单状态与是否触发转换为条件单状态
+// go test -v ./impl/binance -run "^TestToConditionalStatus$"
+func TestToConditionalStatus(t *testing.T) {
+	tests := []struct {
+		status    string
+		triggered bool
+		want      exchange.ConditionalOrderStatus
+	}{
+		{"NEW", false, exchange.ConditionalOrderStatusUntriggered},
+		{"NEW", true, exchange.ConditionalOrderStatusTriggered},
+		{"PARTIALLY_FILLED", true, exchange.ConditionalOrderStatusTriggered},
+		{"FILLED", false, exchange.ConditionalOrderStatusTriggered},
+		{"CANCELED", false, exchange.ConditionalOrderStatusCanceled},
+		{"CANCELED", true, exchange.ConditionalOrderStatusTriggered},
+		{"EXPIRED", true, exchange.ConditionalOrderStatusFailed},
+		{"REJECTED", false, exchange.ConditionalOrderStatusFailed},
+	}
+	for _, tt := range tests {
+		if status := toConditionalStatus(tt.status, tt.triggered); status != tt.want {
+			t.Errorf("toConditionalStatus(%s, %v) = %s, want %s", tt.status, tt.triggered, status, tt.want)
+		}
+	}
+}
+
+// TestGetConditionalOrder 合约条件单触发后按原类型还原，现货按是否生效判断触发，OCO 不支持查询
+// go test -v ./impl/binance -run "^TestGetConditionalOrder$"
+func TestGetConditionalOrder(t *testing.T) {
+	b, _ := newTestBinance(t, map[string]string{
+		"GET /fapi/v1/order": `{"symbol":"BTCUSDT","orderId":7,"clientOrderId":"sl1","price":"0","origQty":"1.000","stopPrice":"90",
+			"status":"FILLED","type":"MARKET","origType":"STOP_MARKET","side":"SELL","time":1700000000000,"updateTime":1700000001000}`,
+		"GET /api/v3/order": `{"symbol":"BTCUSDT","orderId":8,"clientOrderId":"tp1","price":"109","origQty":"1.000","stopPrice":"110",
+			"status":"CANCELED","type":"TAKE_PROFIT_LIMIT","side":"SELL","isWorking":false,"time":1700000000000,"updateTime":1700000001000}`,
+	})
+
+	order, err := b.GetConditionalOrder(context.Background(), exchange.MarketFutures, "BTCUSDT", "7")
+	if err != nil {
+		t.Fatalf("获取条件单失败: %v", err)
+	}
+	if order.Type != exchange.ConditionalOrderTypeStopMarket || order.Status != exchange.ConditionalOrderStatusTriggered ||
+		order.OrderID != "7" || order.TriggerPrice != "90" || order.ClientOrderID != "sl1" {
+		t.Errorf("合约条件单转换错误: %+v", order)
+	}
+
+	order, err = b.GetConditionalOrder(context.Background(), exchange.MarketSpot, "BTCUSDT", "8")
+	if err != nil {
+		t.Fatalf("获取条件单失败: %v", err)
+	}
+	if order.Type != exchange.ConditionalOrderTypeTakeProfitLimit || order.Status != exchange.ConditionalOrderStatusCanceled ||
+		order.OrderID != "" || order.Price != "109" {
+		t.Errorf("现货条件单转换错误: %+v", order)
+	}
+
+	if _, err := b.GetConditionalOrder(context.Background(), exchange.MarketSpot, "BTCUSDT", OCOIDPrefix+"1"); !errors.Is(err, exchange.ErrInvalidOrder) {
+		t.Errorf("查询 OCO 应返回 ErrInvalidOrder: %v", err)
+	}
+}
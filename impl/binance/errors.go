@@ -0,0 +1,72 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// errorKinds Binance 错误码对应的错误分类
+var errorKinds = map[int64]error{
+	-1000: exchange.ErrTemporary,           // UNKNOWN
+	-1001: exchange.ErrTemporary,           // DISCONNECTED
+	-1006: exchange.ErrTemporary,           // UNEXPECTED_RESP，执行状态未知
+	-1007: exchange.ErrTemporary,           // TIMEOUT，执行状态未知
+	-1008: exchange.ErrTemporary,           // SERVER_BUSY
+	-1003: exchange.ErrRateLimited,         // TOO_MANY_REQUESTS
+	-1015: exchange.ErrRateLimited,         // TOO_MANY_ORDERS
+	-1016: exchange.ErrMaintenance,         // SERVICE_SHUTTING_DOWN
+	-1021: exchange.ErrTimestampSkew,       // INVALID_TIMESTAMP
+	-1022: exchange.ErrAuth,                // INVALID_SIGNATURE
+	-1111: exchange.ErrInvalidPrecision,    // BAD_PRECISION
+	-2008: exchange.ErrAuth,                // BAD_API_ID
+	-2011: exchange.ErrOrderNotFound,       // CANCEL_REJECTED，订单不存在
+	-2013: exchange.ErrOrderNotFound,       // NO_SUCH_ORDER
+	-2014: exchange.ErrAuth,                // BAD_API_KEY_FMT
+	-2015: exchange.ErrAuth,                // REJECTED_MBX_KEY
+	-2018: exchange.ErrInsufficientBalance, // BALANCE_NOT_SUFFICIENT
+	-2019: exchange.ErrInsufficientBalance, // MARGIN_NOT_SUFFICIEN
+	-4014: exchange.ErrInvalidPrecision,    // PRICE_NOT_INCREASED_BY_TICK_SIZE
+	-4023: exchange.ErrInvalidPrecision,    // QTY_NOT_INCREASED_BY_STEP_SIZE
+	-4116: exchange.ErrDuplicateOrder,      // DUPLICATED_CLIENT_ORDER_ID
+	-4164: exchange.ErrMinNotional,         // MIN_NOTIONAL
+}
+
+// toAPIError Binance 接口错误转换为 *exchange.APIError，网关返回的 5xx 等非 JSON 响应与无法解析的响应体按临时错误返回，其他错误原样返回
+func toAPIError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return exchange.NewAPIError(exchange.ErrTemporary, "", syntaxErr.Error(), err)
+	}
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	// 错误响应体不是 JSON 时没有错误码，请求结果未知
+	if !apiErr.IsValid() {
+		return exchange.NewAPIError(exchange.ErrTemporary, "", string(apiErr.Response), err)
+	}
+	kind := errorKinds[apiErr.Code]
+	message := strings.ToLower(apiErr.Message)
+	switch apiErr.Code {
+	case -1013: // 过滤器校验失败，如 Filter failure: LOT_SIZE
+		switch {
+		case strings.Contains(message, "notional"):
+			kind = exchange.ErrMinNotional
+		case strings.Contains(message, "lot_size"), strings.Contains(message, "price_filter"):
+			kind = exchange.ErrInvalidPrecision
+		}
+	case -2010: // NEW_ORDER_REJECTED，具体原因在错误信息中
+		switch {
+		case strings.Contains(message, "insufficient balance"):
+			kind = exchange.ErrInsufficientBalance
+		case strings.Contains(message, "duplicate order"):
+			kind = exchange.ErrDuplicateOrder
+		}
+	}
+	return exchange.NewAPIError(kind, strconv.FormatInt(apiErr.Code, 10), apiErr.Message, err)
+}
@@ -0,0 +1,83 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+// TestToAPIError Binance 错误码映射为错误分类
+// go test -v ./impl/binance -run "^TestToAPIError$"
+func TestToAPIError(t *testing.T) {
+	cases := []struct {
+		code    int64
+		message string
+		want    error
+	}{
+		{-1003, "Too many requests.", exchange.ErrRateLimited},
+		{-1021, "Timestamp for this request is outside of the recvWindow.", exchange.ErrTimestampSkew},
+		{-2015, "Invalid API-key, IP, or permissions for action.", exchange.ErrAuth},
+		{-2013, "Order does not exist.", exchange.ErrOrderNotFound},
+		{-2010, "Account has insufficient balance for requested action.", exchange.ErrInsufficientBalance},
+		{-2019, "Margin is insufficient.", exchange.ErrInsufficientBalance},
+		{-1013, "Filter failure: NOTIONAL", exchange.ErrMinNotional},
+		{-1013, "Filter failure: LOT_SIZE", exchange.ErrInvalidPrecision},
+		{-1111, "Precision is over the maximum defined for this asset.", exchange.ErrInvalidPrecision},
+		{-1007, "Timeout waiting for response from backend server. Send status unknown; execution status unknown.", exchange.ErrTemporary},
+		{-2010, "Duplicate order sent.", exchange.ErrDuplicateOrder},
+		{-4116, "ClientOrderId is duplicated.", exchange.ErrDuplicateOrder},
+	}
+	for _, c := range cases {
+		err := fmt.Errorf("下单失败: %w", toAPIError(&common.APIError{Code: c.code, Message: c.message}))
+		if !errors.Is(err, c.want) {
+			t.Errorf("toAPIError(%d, %s) = %v, want %v", c.code, c.message, err, c.want)
+		}
+		var apiErr *exchange.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != fmt.Sprint(c.code) || apiErr.Message != c.message {
+			t.Errorf("原生错误码错误: %+v", apiErr)
+		}
+	}
+
+	// 非接口错误原样返回
+	cause := errors.New("network error")
+	if err := toAPIError(cause); err != cause {
+		t.Errorf("toAPIError() = %v", err)
+	}
+	if err := toAPIError(&common.APIError{Code: -2010, Message: "Order would immediately match and take."}); errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("未知的拒绝原因不应分类: %v", err)
+	}
+}
+
+// TestGatewayError 网关返回的 5xx 非 JSON 响应与无法解析的响应体为临时错误，下单时按客户端订单ID对账
+// go test -v ./impl/binance -run "^TestGatewayError$"
+func TestGatewayError(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusBadGateway, "<html><body>502 Bad Gateway</body></html>"},
+		{http.StatusServiceUnavailable, ""},
+		{http.StatusOK, "<html></html>"},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		b := newBinance(apiKey, secretKey, exchange.WithSpotBaseURL(server.URL), exchange.WithFuturesBaseURL(server.URL))
+		for _, market := range []exchange.Market{exchange.MarketSpot, exchange.MarketFutures} {
+			_, err := b.ListOpenOrders(context.Background(), market, "BTCUSDT")
+			if !errors.Is(err, exchange.ErrTemporary) || !idempotent.IsTemporary(err) {
+				t.Errorf("%s %d %q 应为临时错误: %v", market, tt.status, tt.body, err)
+			}
+		}
+		server.Close()
+	}
+}
@@ -0,0 +1,152 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	FillListLimit = 500 // 成交明细默认每页数量
+)
+
+// ListMyTrades 按成交ID升序分页查询成交明细，游标为下一页的起始成交ID。
+// 交易所不支持按游标或订单ID查询时同时指定时间范围，此时开始时间被忽略，超过结束时间的成交在本地过滤；
+// 未指定开始时间与游标时返回最近的成交；合约按订单ID查询翻页时在本地过滤订单，单页数量可能少于 Limit
+func (b *binanceExchange) ListMyTrades(ctx context.Context, market exchange.Market, query exchange.FillQuery) (*exchange.FillPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = FillListLimit
+	}
+	var fromID, orderID int64
+	if query.Cursor != "" {
+		id, err := strconv.ParseInt(query.Cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的分页游标: %w", err)
+		}
+		fromID = id
+	}
+	if query.OrderID != "" {
+		id, err := strconv.ParseInt(query.OrderID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的订单ID: %w", err)
+		}
+		orderID = id
+	}
+	// 按时间查询不能与游标、订单ID同时使用
+	byTime := fromID == 0 && orderID == 0
+
+	fills := make([]*exchange.Fill, 0)
+	var lastID int64
+	var count int
+	switch market {
+	case exchange.MarketSpot:
+		service := b.getClient(ctx).NewListTradesService().Symbol(query.Symbol).Limit(limit)
+		if orderID > 0 {
+			service.OrderId(orderID)
+		}
+		if fromID > 0 {
+			service.FromID(fromID)
+		}
+		if byTime && !query.StartTime.IsZero() {
+			service.StartTime(query.StartTime.UnixMilli())
+		}
+		if byTime && !query.EndTime.IsZero() {
+			service.EndTime(query.EndTime.UnixMilli())
+		}
+		trades, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取现货成交明细失败: %w", toAPIError(err))
+		}
+		for _, t := range trades {
+			lastID = t.ID
+			fills = append(fills, toSpotFill(t))
+		}
+		count = len(trades)
+	case exchange.MarketFutures:
+		// 合约订单ID不能与 fromId 同时使用，翻页时只按 fromId 查询并在本地过滤订单
+		service := b.getFuturesClient(ctx).NewListAccountTradeService().Symbol(query.Symbol).Limit(limit)
+		if fromID > 0 {
+			service.FromID(fromID)
+		} else if orderID > 0 {
+			service.OrderID(orderID)
+		}
+		if byTime && !query.StartTime.IsZero() {
+			service.StartTime(query.StartTime.UnixMilli())
+		}
+		if byTime && !query.EndTime.IsZero() {
+			service.EndTime(query.EndTime.UnixMilli())
+		}
+		trades, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取合约成交明细失败: %w", toAPIError(err))
+		}
+		for _, t := range trades {
+			lastID = t.ID
+			if orderID > 0 && t.OrderID != orderID {
+				continue
+			}
+			fills = append(fills, toFuturesFill(t))
+		}
+		count = len(trades)
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	page := &exchange.FillPage{Fills: make([]*exchange.Fill, 0, len(fills))}
+	ended := false
+	for _, fill := range fills {
+		if !query.EndTime.IsZero() && fill.Time > query.EndTime.UnixMilli() {
+			ended = true
+			break
+		}
+		page.Fills = append(page.Fills, fill)
+	}
+	if count >= limit && !ended {
+		page.NextCursor = strconv.FormatInt(lastID+1, 10)
+	}
+	return page, nil
+}
+
+// toSpotFill 转换现货成交明细
+func toSpotFill(t *binance.TradeV3) *exchange.Fill {
+	side := exchange.OrderSideSell
+	if t.IsBuyer {
+		side = exchange.OrderSideBuy
+	}
+	return &exchange.Fill{
+		Market:   exchange.MarketSpot,
+		Symbol:   t.Symbol,
+		OrderID:  strconv.FormatInt(t.OrderID, 10),
+		TradeID:  strconv.FormatInt(t.ID, 10),
+		Side:     side,
+		Price:    t.Price,
+		Quantity: t.Quantity,
+		Fee:      t.Commission,
+		FeeAsset: t.CommissionAsset,
+		IsMaker:  t.IsMaker,
+		Time:     t.Time,
+	}
+}
+
+// toFuturesFill 转换合约成交明细
+func toFuturesFill(t *futures.AccountTrade) *exchange.Fill {
+	return &exchange.Fill{
+		Market:      exchange.MarketFutures,
+		Symbol:      t.Symbol,
+		OrderID:     strconv.FormatInt(t.OrderID, 10),
+		TradeID:     strconv.FormatInt(t.ID, 10),
+		Side:        exchange.OrderSide(t.Side),
+		Price:       t.Price,
+		Quantity:    t.Quantity,
+		Fee:         t.Commission,
+		FeeAsset:    t.CommissionAsset,
+		IsMaker:     t.Maker,
+		RealizedPnl: t.RealizedPnl,
+		Time:        t.Time,
+	}
+}
@@ -0,0 +1,72 @@
+package binance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestToFills 成交明细转换方向、手续费币种与已实现盈亏
+// go test -v ./impl/binance -run "^TestToFills$"
+func TestToFills(t *testing.T) {
+	spot := toSpotFill(&binance.TradeV3{
+		ID: 11, Symbol: "BTCUSDT", OrderID: 1, Price: "100", Quantity: "0.5", Commission: "0.0001",
+		CommissionAsset: "BNB", Time: 1700000000000, IsBuyer: false, IsMaker: true,
+	})
+	want := exchange.Fill{
+		Market: exchange.MarketSpot, Symbol: "BTCUSDT", OrderID: "1", TradeID: "11", Side: exchange.OrderSideSell,
+		Price: "100", Quantity: "0.5", Fee: "0.0001", FeeAsset: "BNB", IsMaker: true, Time: 1700000000000,
+	}
+	if *spot != want {
+		t.Errorf("现货成交转换错误: %+v", spot)
+	}
+
+	fill := toFuturesFill(&futures.AccountTrade{
+		ID: 21, Symbol: "BTCUSDT", OrderID: 2, Side: futures.SideTypeBuy, Price: "101", Quantity: "2", Commission: "0.08",
+		CommissionAsset: "USDT", RealizedPnl: "-1.5", Time: 1700000001000,
+	})
+	want = exchange.Fill{
+		Market: exchange.MarketFutures, Symbol: "BTCUSDT", OrderID: "2", TradeID: "21", Side: exchange.OrderSideBuy,
+		Price: "101", Quantity: "2", Fee: "0.08", FeeAsset: "USDT", RealizedPnl: "-1.5", Time: 1700000001000,
+	}
+	if *fill != want {
+		t.Errorf("合约成交转换错误: %+v", fill)
+	}
+}
+
+// TestListMyTradesFuturesOrderID 合约按订单ID查询不同时发送起始成交ID，翻页时只发送起始成交ID并在本地过滤订单
+// go test -v ./impl/binance -run "^TestListMyTradesFuturesOrderID$"
+func TestListMyTradesFuturesOrderID(t *testing.T) {
+	const trades = `[{"id":30,"symbol":"BTCUSDT","orderId":2,"side":"BUY","price":"100","qty":"1","commission":"0.04","commissionAsset":"USDT","realizedPnl":"0","time":1700000000000},
+		{"id":31,"symbol":"BTCUSDT","orderId":3,"side":"SELL","price":"101","qty":"1","commission":"0.04","commissionAsset":"USDT","realizedPnl":"1","time":1700000001000}]`
+
+	tests := []struct {
+		cursor     string
+		orderID    string
+		fromID     string
+		fills      int
+		nextCursor string
+	}{
+		{"", "2", "", 1, "32"},
+		{"32", "", "32", 1, "32"},
+	}
+	for _, tt := range tests {
+		b, ts := newTestBinance(t, map[string]string{"GET /fapi/v1/userTrades": trades})
+		page, err := b.ListMyTrades(context.Background(), exchange.MarketFutures, exchange.FillQuery{
+			Symbol: "BTCUSDT", OrderID: "2", Limit: 2, Cursor: tt.cursor,
+		})
+		if err != nil {
+			t.Fatalf("获取成交明细失败: %v", err)
+		}
+		req := ts.findRequest("GET", "/fapi/v1/userTrades")
+		if req.Params["orderId"] != tt.orderID || req.Params["fromID"] != tt.fromID {
+			t.Errorf("游标 %q 请求参数错误: %+v", tt.cursor, req.Params)
+		}
+		if len(page.Fills) != tt.fills || page.Fills[0].OrderID != "2" || page.NextCursor != tt.nextCursor {
+			t.Errorf("游标 %q 分页结果错误: %+v", tt.cursor, page)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// GetFundingRate 获取合约当前资金费率，premiumIndex 接口的 lastFundingRate 为本期预测费率
+func (b *binanceExchange) GetFundingRate(ctx context.Context, symbol string) (*exchange.FundingRate, error) {
+	indexes, err := b.getFuturesClient(ctx).NewPremiumIndexService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取资金费率失败: %w", toAPIError(err))
+	}
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("资金费率为空: %s", symbol)
+	}
+	return &exchange.FundingRate{
+		Symbol:      indexes[0].Symbol,
+		FundingRate: indexes[0].LastFundingRate,
+		FundingTime: indexes[0].NextFundingTime,
+	}, nil
+}
+
+// GetFundingRateHistory 获取合约历史资金费率，默认返回最近 100 条，limit 最大 1000
+func (b *binanceExchange) GetFundingRateHistory(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.FundingRate, error) {
+	service := b.getFuturesClient(ctx).NewFundingRateService().Symbol(symbol)
+	if !start.IsZero() {
+		service.StartTime(start.UnixMilli())
+	}
+	if !end.IsZero() {
+		service.EndTime(end.UnixMilli())
+	}
+	if limit > 0 {
+		service.Limit(limit)
+	}
+	rates, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取历史资金费率失败: %w", toAPIError(err))
+	}
+
+	result := make([]*exchange.FundingRate, 0, len(rates))
+	for _, rate := range rates {
+		result = append(result, &exchange.FundingRate{
+			Symbol:      rate.Symbol,
+			FundingRate: rate.FundingRate,
+			FundingTime: rate.FundingTime,
+		})
+	}
+	return result, nil
+}
+
+// GetMarkPrice 获取合约标记价格，单个交易对按交易对查询，多个交易对查询全部后过滤
+func (b *binanceExchange) GetMarkPrice(ctx context.Context, symbols ...string) ([]*exchange.MarkPrice, error) {
+	service := b.getFuturesClient(ctx).NewPremiumIndexService()
+	if len(symbols) == 1 {
+		service.Symbol(symbols[0])
+	}
+	indexes, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取标记价格失败: %w", toAPIError(err))
+	}
+
+	wanted := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		wanted[symbol] = struct{}{}
+	}
+	result := make([]*exchange.MarkPrice, 0, len(indexes))
+	for _, index := range indexes {
+		if _, ok := wanted[index.Symbol]; len(wanted) > 0 && !ok {
+			continue
+		}
+		result = append(result, toMarkPrice(index))
+	}
+	return result, nil
+}
+
+// toMarkPrice 转换标记价格
+func toMarkPrice(index *futures.PremiumIndex) *exchange.MarkPrice {
+	return &exchange.MarkPrice{
+		Symbol:          index.Symbol,
+		MarkPrice:       index.MarkPrice,
+		IndexPrice:      index.IndexPrice,
+		FundingRate:     index.LastFundingRate,
+		NextFundingTime: index.NextFundingTime,
+		Time:            index.Time,
+	}
+}
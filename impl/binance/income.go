@@ -0,0 +1,149 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	IncomeListLimit = 1000 // 资金流水每页最大数量
+)
+
+// incomeTypes 统一流水类型对应的交易所流水类型，一对多的类型不按类型查询，在本地过滤
+var incomeTypes = map[exchange.IncomeType]string{
+	exchange.IncomeTypeFundingFee:     "FUNDING_FEE",
+	exchange.IncomeTypeCommission:     "COMMISSION",
+	exchange.IncomeTypeRealizedPnl:    "REALIZED_PNL",
+	exchange.IncomeTypeLiquidationFee: "INSURANCE_CLEAR",
+}
+
+// GetIncomeHistory 按时间升序分页查询合约资金流水，游标为下一页的起始时间与该时间已返回的流水数量。
+// 未指定开始时间与游标时从七天前开始查询
+func (b *binanceExchange) GetIncomeHistory(ctx context.Context, query exchange.IncomeQuery) (*exchange.IncomePage, error) {
+	fetch := func(startTime int64, limit int) ([]*futures.IncomeHistory, error) {
+		service := b.getFuturesClient(ctx).NewGetIncomeHistoryService().Symbol(query.Symbol).StartTime(startTime).Limit(int64(limit))
+		if incomeType, ok := incomeTypes[query.Type]; ok {
+			service.IncomeType(incomeType)
+		}
+		if !query.EndTime.IsZero() {
+			service.EndTime(query.EndTime.UnixMilli())
+		}
+		incomes, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取资金流水失败: %w", toAPIError(err))
+		}
+		return incomes, nil
+	}
+	return getIncomePage(fetch, query)
+}
+
+// getIncomePage 按游标查询一页资金流水，同一毫秒的流水可能跨页，按游标记录的数量跳过已返回的流水
+func getIncomePage(fetch func(startTime int64, limit int) ([]*futures.IncomeHistory, error), query exchange.IncomeQuery) (*exchange.IncomePage, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > IncomeListLimit {
+		limit = IncomeListLimit
+	}
+	startTime, skip, err := parseIncomeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if query.Cursor == "" {
+		startTime = time.Now().Add(-7 * 24 * time.Hour).UnixMilli()
+		if !query.StartTime.IsZero() {
+			startTime = query.StartTime.UnixMilli()
+		}
+	}
+
+	// 多查询已返回的流水数量，跳过后仍能返回一整页
+	requestLimit := min(limit+skip, IncomeListLimit)
+	incomes, err := fetch(startTime, requestLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(incomes)
+	i := 0
+	for i < len(incomes) && i < skip && incomes[i].Time == startTime {
+		i++
+	}
+	incomes = incomes[i:]
+	if len(incomes) > limit {
+		incomes = incomes[:limit]
+	}
+
+	page := &exchange.IncomePage{Incomes: make([]*exchange.Income, 0, len(incomes))}
+	for _, income := range incomes {
+		if item := toIncome(income); query.Type == "" || item.Type == query.Type {
+			page.Incomes = append(page.Incomes, item)
+		}
+	}
+	if total >= requestLimit && len(incomes) > 0 {
+		lastTime := incomes[len(incomes)-1].Time
+		count := 0
+		for j := len(incomes) - 1; j >= 0 && incomes[j].Time == lastTime; j-- {
+			count++
+		}
+		if lastTime == startTime {
+			count += i
+		}
+		page.NextCursor = fmt.Sprintf("%d:%d", lastTime, count)
+	}
+	return page, nil
+}
+
+// parseIncomeCursor 解析资金流水游标，格式为 起始时间:已返回数量
+func parseIncomeCursor(cursor string) (int64, int, error) {
+	if cursor == "" {
+		return 0, 0, nil
+	}
+	timeText, skipText, ok := strings.Cut(cursor, ":")
+	startTime, err := strconv.ParseInt(timeText, 10, 64)
+	if err != nil || !ok {
+		return 0, 0, fmt.Errorf("无效的分页游标: %s", cursor)
+	}
+	skip, err := strconv.Atoi(skipText)
+	if err != nil || skip < 0 {
+		return 0, 0, fmt.Errorf("无效的分页游标: %s", cursor)
+	}
+	return startTime, skip, nil
+}
+
+// toIncome 转换合约资金流水
+func toIncome(income *futures.IncomeHistory) *exchange.Income {
+	return &exchange.Income{
+		ID:      strconv.FormatInt(income.TranID, 10),
+		Symbol:  income.Symbol,
+		Type:    toIncomeType(income.IncomeType),
+		RawType: income.IncomeType,
+		Amount:  income.Income,
+		Asset:   income.Asset,
+		TradeID: income.TradeID,
+		Time:    income.Time,
+	}
+}
+
+// toIncomeType 转换流水类型
+func toIncomeType(incomeType string) exchange.IncomeType {
+	switch incomeType {
+	case "FUNDING_FEE":
+		return exchange.IncomeTypeFundingFee
+	case "COMMISSION":
+		return exchange.IncomeTypeCommission
+	case "REALIZED_PNL":
+		return exchange.IncomeTypeRealizedPnl
+	case "TRANSFER", "INTERNAL_TRANSFER", "CROSS_COLLATERAL_TRANSFER", "STRATEGY_UMFUTURES_TRANSFER":
+		return exchange.IncomeTypeTransfer
+	case "INSURANCE_CLEAR":
+		return exchange.IncomeTypeLiquidationFee
+	case "COMMISSION_REBATE", "REFERRAL_KICKBACK", "API_REBATE", "FEE_RETURN":
+		return exchange.IncomeTypeRebate
+	default:
+		return exchange.IncomeTypeOther
+	}
+}
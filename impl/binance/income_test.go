@@ -0,0 +1,73 @@
+package binance
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetIncomePage 按时间游标分页，同一毫秒的流水跨页时不重复也不遗漏
+// go test -v ./impl/binance -run "^TestGetIncomePage$"
+func TestGetIncomePage(t *testing.T) {
+	// 每 3 条流水同一毫秒，依次为已实现盈亏、手续费与资金费
+	types := []string{"REALIZED_PNL", "COMMISSION", "FUNDING_FEE"}
+	var all []*futures.IncomeHistory
+	for id := int64(1); id <= 10; id++ {
+		all = append(all, &futures.IncomeHistory{
+			TranID:     id,
+			IncomeType: types[(id-1)%3],
+			Income:     "-0.1",
+			Asset:      "USDT",
+			Time:       1000 + (id-1)/3,
+		})
+	}
+	fetch := func(startTime int64, limit int) ([]*futures.IncomeHistory, error) {
+		var incomes []*futures.IncomeHistory
+		for _, income := range all {
+			if income.Time >= startTime && len(incomes) < limit {
+				incomes = append(incomes, income)
+			}
+		}
+		return incomes, nil
+	}
+
+	var ids []string
+	var cursors []string
+	query := exchange.IncomeQuery{StartTime: time.UnixMilli(1000), Limit: 4}
+	for {
+		page, err := getIncomePage(fetch, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, income := range page.Incomes {
+			ids = append(ids, income.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursors = append(cursors, page.NextCursor)
+		query.Cursor = page.NextCursor
+	}
+	if got := fmt.Sprint(ids); got != "[1 2 3 4 5 6 7 8 9 10]" {
+		t.Errorf("流水ID错误: %s", got)
+	}
+	if got := fmt.Sprint(cursors); got != "[1001:1 1002:2]" {
+		t.Errorf("游标错误: %s", got)
+	}
+
+	page, err := getIncomePage(fetch, exchange.IncomeQuery{StartTime: time.UnixMilli(1000), Type: exchange.IncomeTypeFundingFee})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Incomes) != 3 || page.Incomes[0].ID != strconv.Itoa(3) || page.Incomes[0].Type != exchange.IncomeTypeFundingFee {
+		t.Errorf("按类型过滤错误: %+v", page.Incomes)
+	}
+
+	if _, err := getIncomePage(fetch, exchange.IncomeQuery{Cursor: "abc"}); err == nil {
+		t.Error("无效游标应返回错误")
+	}
+}
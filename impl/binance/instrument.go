@@ -0,0 +1,173 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	PerpetualDeliveryDate = 4133404800000 // 永续合约交割时间 2100-12-25，表示未计划下架
+)
+
+// ListInstruments 获取市场全部交易对元数据，Binance 交易规则不包含手续费率与最大杠杆
+func (b *binanceExchange) ListInstruments(ctx context.Context, market exchange.Market) ([]*exchange.Instrument, error) {
+	var instruments []*exchange.Instrument
+	switch market {
+	case exchange.MarketSpot:
+		info, err := b.getClient(ctx).NewExchangeInfoService().Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取交易规则失败: %w", toAPIError(err))
+		}
+		for i := range info.Symbols {
+			spec := toSpotSymbolSpec(&info.Symbols[i])
+			b.getSpotSpec(ctx).SetSymbolSpec(spec.Symbol, spec)
+			instruments = append(instruments, toInstrument(market, spec))
+		}
+	case exchange.MarketFutures:
+		info, err := b.getFuturesClient(ctx).NewExchangeInfoService().Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取合约交易规则失败: %w", toAPIError(err))
+		}
+		for i := range info.Symbols {
+			spec := toFuturesSymbolSpec(&info.Symbols[i])
+			if spec.Status == "TRADING" {
+				b.getFuturesSpec(ctx).SetSymbolSpec(spec.Symbol, spec)
+			}
+			instruments = append(instruments, toInstrument(market, spec))
+		}
+		b.getFuturesSpec(ctx).SetLoaded()
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+	return instruments, nil
+}
+
+// GetInstrument 获取交易对元数据
+func (b *binanceExchange) GetInstrument(ctx context.Context, market exchange.Market, symbol string) (*exchange.Instrument, error) {
+	var (
+		spec *symbolSpec
+		err  error
+	)
+	switch market {
+	case exchange.MarketSpot:
+		spec, err = b.getSpotSymbolSpec(ctx, symbol)
+	case exchange.MarketFutures:
+		spec, err = b.getFuturesSymbolSpec(ctx, symbol)
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toInstrument(market, spec), nil
+}
+
+// toSpotSymbolSpec 转换现货交易规则
+func toSpotSymbolSpec(s *binance.Symbol) *symbolSpec {
+	spec := &symbolSpec{
+		Symbol:         s.Symbol,
+		BaseAsset:      s.BaseAsset,
+		QuoteAsset:     s.QuoteAsset,
+		BasePrecision:  s.BaseAssetPrecision,
+		QuotePrecision: s.QuoteAssetPrecision,
+		Status:         s.Status,
+	}
+	for _, f := range s.Filters {
+		switch filterValue(f, "filterType") {
+		case "PRICE_FILTER":
+			spec.MinPrice = filterValue(f, "minPrice")
+			spec.MaxPrice = filterValue(f, "maxPrice")
+			spec.TickSize = filterValue(f, "tickSize")
+		case "LOT_SIZE":
+			spec.MinQty = filterValue(f, "minQty")
+			spec.MaxQty = filterValue(f, "maxQty")
+			spec.StepSize = filterValue(f, "stepSize")
+		case "MIN_NOTIONAL", "NOTIONAL":
+			spec.MinNotional = filterValue(f, "minNotional")
+		}
+	}
+	return spec
+}
+
+// toFuturesSymbolSpec 转换合约交易规则
+func toFuturesSymbolSpec(s *futures.Symbol) *symbolSpec {
+	spec := &symbolSpec{
+		Symbol:         s.Symbol,
+		BaseAsset:      s.BaseAsset,
+		QuoteAsset:     s.QuoteAsset,
+		BasePrecision:  s.BaseAssetPrecision,
+		QuotePrecision: s.QuantityPrecision,
+		Status:         s.Status,
+		MarginAsset:    s.MarginAsset,
+		DeliveryDate:   s.DeliveryDate,
+	}
+	for _, f := range s.Filters {
+		switch filterValue(f, "filterType") {
+		case "PRICE_FILTER":
+			spec.MinPrice = filterValue(f, "minPrice")
+			spec.MaxPrice = filterValue(f, "maxPrice")
+			spec.TickSize = filterValue(f, "tickSize")
+		case "LOT_SIZE":
+			spec.MinQty = filterValue(f, "minQty")
+			spec.MaxQty = filterValue(f, "maxQty")
+			spec.StepSize = filterValue(f, "stepSize")
+		case "MIN_NOTIONAL":
+			spec.MinNotional = filterValue(f, "notional")
+		}
+	}
+	return spec
+}
+
+// filterValue 获取交易规则过滤器的字符串值，不存在时返回空
+func filterValue(filter map[string]interface{}, key string) string {
+	value, _ := filter[key].(string)
+	return value
+}
+
+// toInstrument 交易规则转换为交易对元数据，交割合约的原生交易对带有交割日期后缀，如 BTCUSDT_251226
+func toInstrument(market exchange.Market, spec *symbolSpec) *exchange.Instrument {
+	instrument := &exchange.Instrument{
+		Market:      market,
+		Symbol:      exchange.NewSpotSymbol(spec.BaseAsset, spec.QuoteAsset).String(),
+		ID:          spec.Symbol,
+		Base:        spec.BaseAsset,
+		Quote:       spec.QuoteAsset,
+		TickSize:    spec.TickSize,
+		StepSize:    spec.StepSize,
+		MinQty:      spec.MinQty,
+		MaxQty:      spec.MaxQty,
+		MinNotional: spec.MinNotional,
+		Status:      toInstrumentStatus(spec.Status),
+	}
+	if market == exchange.MarketFutures {
+		_, expiry, isFutures := strings.Cut(spec.Symbol, "_")
+		instrument.Symbol = toSymbol(market, spec.BaseAsset, spec.QuoteAsset, spec.MarginAsset, expiry, isFutures).String()
+		instrument.Settle = spec.MarginAsset
+		instrument.ContractSize = "1"
+		if spec.DeliveryDate > 0 && spec.DeliveryDate < PerpetualDeliveryDate {
+			instrument.DelistTime = spec.DeliveryDate
+		}
+	}
+	return instrument
+}
+
+// toInstrumentStatus 转换交易对状态
+func toInstrumentStatus(status string) exchange.InstrumentStatus {
+	switch status {
+	case "TRADING":
+		return exchange.InstrumentStatusTrading
+	case "PRE_TRADING", "PENDING_TRADING":
+		return exchange.InstrumentStatusPreTrading
+	case "PRE_DELIVERING", "DELIVERING", "PRE_SETTLE", "SETTLING":
+		return exchange.InstrumentStatusDelisting
+	case "DELIVERED", "CLOSE":
+		return exchange.InstrumentStatusDelisted
+	default:
+		return exchange.InstrumentStatusSuspended
+	}
+}
@@ -0,0 +1,75 @@
+package binance
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetInstrument 获取合约交易对元数据
+// go test -v ./impl/binance -run "^TestGetInstrument$" -args --symbol=BTCUSDT
+func TestGetInstrument(t *testing.T) {
+	flag.Parse()
+
+	binanceExchange := NewBinance("", "")
+	instrument, err := binanceExchange.GetInstrument(context.Background(), exchange.MarketFutures, *symbol)
+	if err != nil {
+		t.Fatalf("获取交易对元数据失败: %v", err)
+	}
+	fmt.Printf("instrument %+v\n", instrument)
+}
+
+// TestToFuturesInstrument 合约交易规则转换为交易对元数据
+// go test -v ./impl/binance -run "^TestToFuturesInstrument$"
+func TestToFuturesInstrument(t *testing.T) {
+	spec := toFuturesSymbolSpec(&futures.Symbol{
+		Symbol:       "BTCUSDT",
+		BaseAsset:    "BTC",
+		QuoteAsset:   "USDT",
+		MarginAsset:  "USDT",
+		Status:       "SETTLING",
+		DeliveryDate: 1767225600000,
+		Filters: []map[string]interface{}{
+			{"filterType": "PRICE_FILTER", "minPrice": "0.10", "maxPrice": "1000000", "tickSize": "0.10"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "1000", "stepSize": "0.001"},
+			{"filterType": "MIN_NOTIONAL", "notional": "100"},
+			{"filterType": "PERCENT_PRICE", "multiplierUp": "1.05"},
+		},
+	})
+	got := toInstrument(exchange.MarketFutures, spec)
+	want := exchange.Instrument{
+		Market:       exchange.MarketFutures,
+		Symbol:       "BTC/USDT:USDT",
+		ID:           "BTCUSDT",
+		Base:         "BTC",
+		Quote:        "USDT",
+		Settle:       "USDT",
+		TickSize:     "0.10",
+		StepSize:     "0.001",
+		MinQty:       "0.001",
+		MaxQty:       "1000",
+		MinNotional:  "100",
+		ContractSize: "1",
+		Status:       exchange.InstrumentStatusDelisting,
+		DelistTime:   1767225600000,
+	}
+	if *got != want {
+		t.Errorf("交易对元数据错误: %+v", got)
+	}
+
+	// 永续合约交割时间表示未计划下架
+	spec.DeliveryDate = PerpetualDeliveryDate
+	if got := toInstrument(exchange.MarketFutures, spec); got.DelistTime != 0 {
+		t.Errorf("下架时间错误: %d", got.DelistTime)
+	}
+
+	// 交割合约带有交割日期后缀
+	spec.Symbol = "BTCUSDT_260327"
+	if got := toInstrument(exchange.MarketFutures, spec); got.Symbol != "BTC/USDT:USDT-260327" || got.ID != "BTCUSDT_260327" {
+		t.Errorf("交割合约交易对错误: %s %s", got.Symbol, got.ID)
+	}
+}
@@ -0,0 +1,77 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/kline"
+)
+
+const (
+	SpotKlinePageSize    = 1000 // 现货K线单页最大数量
+	FuturesKlinePageSize = 1500 // 合约K线单页最大数量
+)
+
+// GetSpotKlines 获取现货K线，超过单页上限时自动分页
+func (b *binanceExchange) GetSpotKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	fetch := func(start, end time.Time, limit int) ([]*exchange.Kline, error) {
+		resp, err := b.getClient(ctx).NewKlinesService().
+			Symbol(symbol).
+			Interval(string(interval)).
+			StartTime(start.UnixMilli()).
+			EndTime(end.UnixMilli()).
+			Limit(limit).
+			Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("binance get klines: %w", toAPIError(err))
+		}
+		klines := make([]*exchange.Kline, 0, len(resp))
+		for _, k := range resp {
+			klines = append(klines, toKline(symbol, interval, k.OpenTime, k.CloseTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.QuoteAssetVolume, k.TradeNum))
+		}
+		return klines, nil
+	}
+	return kline.Paginate(fetch, interval, start, end, limit, SpotKlinePageSize, kline.Forward)
+}
+
+// GetFuturesKlines 获取合约K线，超过单页上限时自动分页
+func (b *binanceExchange) GetFuturesKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	fetch := func(start, end time.Time, limit int) ([]*exchange.Kline, error) {
+		resp, err := b.getFuturesClient(ctx).NewKlinesService().
+			Symbol(symbol).
+			Interval(string(interval)).
+			StartTime(start.UnixMilli()).
+			EndTime(end.UnixMilli()).
+			Limit(limit).
+			Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("binance futures get klines: %w", toAPIError(err))
+		}
+		klines := make([]*exchange.Kline, 0, len(resp))
+		for _, k := range resp {
+			klines = append(klines, toKline(symbol, interval, k.OpenTime, k.CloseTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.QuoteAssetVolume, k.TradeNum))
+		}
+		return klines, nil
+	}
+	return kline.Paginate(fetch, interval, start, end, limit, FuturesKlinePageSize, kline.Forward)
+}
+
+// toKline 转换K线，REST 接口不返回收盘标记，收盘时间早于当前时间即视为已收盘
+func toKline(symbol string, interval exchange.KlineInterval, openTime, closeTime int64, open, high, low, close, volume, quoteVolume string, tradeCount int64) *exchange.Kline {
+	return &exchange.Kline{
+		Symbol:      symbol,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   closeTime,
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       close,
+		Volume:      volume,
+		QuoteVolume: quoteVolume,
+		TradeCount:  tradeCount,
+		Closed:      closeTime < time.Now().UnixMilli(),
+	}
+}
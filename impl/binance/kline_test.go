@@ -0,0 +1,38 @@
+package binance
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetSpotKlines 获取现货K线，超过单页上限时分页
+// go test -v ./impl/binance -run "^TestGetSpotKlines$" -args --symbol=BTCUSDT
+func TestGetSpotKlines(t *testing.T) {
+	flag.Parse()
+
+	binanceExchange := NewBinance("", "")
+	klines, err := binanceExchange.GetSpotKlines(context.Background(), *symbol, exchange.KlineInterval1m, time.Time{}, time.Time{}, 1500)
+	if err != nil {
+		t.Fatalf("获取现货K线失败: %v", err)
+	}
+	fmt.Println("klines", len(klines), klines[0], klines[len(klines)-1])
+}
+
+// TestGetFuturesKlines 获取合约K线
+// go test -v ./impl/binance -run "^TestGetFuturesKlines$" -args --symbol=BTCUSDT
+func TestGetFuturesKlines(t *testing.T) {
+	flag.Parse()
+
+	binanceExchange := NewBinance("", "")
+	start := time.Now().Add(-24 * time.Hour)
+	klines, err := binanceExchange.GetFuturesKlines(context.Background(), *symbol, exchange.KlineInterval1h, start, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("获取合约K线失败: %v", err)
+	}
+	fmt.Println("klines", len(klines), klines[0], klines[len(klines)-1])
+}
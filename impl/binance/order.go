@@ -1,72 +1,84 @@
 package binance
 
 import (
+	"context"
 	"fmt"
-	"math/big"
 
+	"github.com/adshao/go-binance/v2"
+	"github.com/so68/exchange-lib/exchange"
 	"github.com/so68/exchange-lib/internal/utils"
 )
 
-// filtersQuantity 获取交易对数量精度
-func (b *binanceExchange) filtersQuantity(spec *symbolSpec, price, quantity string) (string, error) {
-	// 使用 big.Float 进行精确比较
-	quantityFloat := new(big.Float).SetPrec(64)
-	minQtyFloat := new(big.Float).SetPrec(64)
-	maxQtyFloat := new(big.Float).SetPrec(64)
-	priceFloat := new(big.Float).SetPrec(64)
-	minPriceFloat := new(big.Float).SetPrec(64)
-	maxPriceFloat := new(big.Float).SetPrec(64)
+// PlaceOrder 下单
+func (b *binanceExchange) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Market == exchange.MarketSpot {
+		return b.placeSpotOrder(ctx, req)
+	}
+	return b.placeFuturesOrder(ctx, req)
+}
+
+// toOrderType 转换订单类型，LIMIT_MAKER 视为限价单
+func toOrderType(orderType string) exchange.OrderType {
+	if orderType == string(binance.OrderTypeLimitMaker) {
+		return exchange.OrderTypeLimit
+	}
+	return exchange.OrderType(orderType)
+}
 
-	if _, ok := quantityFloat.SetString(quantity); !ok {
+// filtersQuantity 获取交易对数量精度，价格为空或 0 的市价单不校验价格
+func (b *binanceExchange) filtersQuantity(spec *symbolSpec, price, quantity string) (string, error) {
+	quantityDec, err := exchange.ParseDecimal(quantity)
+	if err != nil {
 		return "", fmt.Errorf("无效的数量: %s", quantity)
 	}
-	if _, ok := minQtyFloat.SetString(spec.MinQty); !ok {
+	minQty, err := exchange.ParseDecimal(spec.MinQty)
+	if err != nil {
 		return "", fmt.Errorf("无效的最小数量: %s", spec.MinQty)
 	}
-	if _, ok := maxQtyFloat.SetString(spec.MaxQty); !ok {
+	maxQty, err := exchange.ParseDecimal(spec.MaxQty)
+	if err != nil {
 		return "", fmt.Errorf("无效的最大数量: %s", spec.MaxQty)
 	}
-	if _, ok := priceFloat.SetString(price); !ok {
+	priceDec, err := exchange.ParseDecimal(price)
+	if err != nil {
 		return "", fmt.Errorf("无效的价格: %s", price)
 	}
-	if _, ok := minPriceFloat.SetString(spec.MinPrice); !ok {
+	minPrice, err := exchange.ParseDecimal(spec.MinPrice)
+	if err != nil {
 		return "", fmt.Errorf("无效的最小价格: %s", spec.MinPrice)
 	}
-	if _, ok := maxPriceFloat.SetString(spec.MaxPrice); !ok {
+	maxPrice, err := exchange.ParseDecimal(spec.MaxPrice)
+	if err != nil {
 		return "", fmt.Errorf("无效的最大价格: %s", spec.MaxPrice)
 	}
 	// 如果价格小于最小价格，或大于最大价格，返回错误
-	if priceFloat.Cmp(minPriceFloat) < 0 || priceFloat.Cmp(maxPriceFloat) > 0 {
+	if priceDec.IsPositive() && (priceDec.LessThan(minPrice) || priceDec.GreaterThan(maxPrice)) {
 		return "", fmt.Errorf("价格 %s 小于最小价格 %s 或大于最大价格 %s", price, spec.MinPrice, spec.MaxPrice)
 	}
 	// 如果 quantity 小于 minQty，或大于 maxQty，返回错误
-	if quantityFloat.Cmp(minQtyFloat) < 0 || quantityFloat.Cmp(maxQtyFloat) > 0 {
-		return "", fmt.Errorf("数量 %s 小于最小数量 %s 或大于最大数量 %s", quantity, spec.MinQty, spec.MaxQty)
+	if quantityDec.LessThan(minQty) {
+		return "", fmt.Errorf("%w: 数量 %s 小于最小数量 %s", exchange.ErrMinNotional, quantity, spec.MinQty)
+	}
+	if quantityDec.GreaterThan(maxQty) {
+		return "", fmt.Errorf("数量 %s 大于最大数量 %s", quantity, spec.MaxQty)
 	}
 
 	// 按照 stepSize 的倍数向下取整 quantity
 	// 逻辑：floor(quantity / stepSize) * stepSize
-	stepSizeFloat := new(big.Float).SetPrec(64)
-	if _, ok := stepSizeFloat.SetString(spec.StepSize); !ok {
+	stepSize, err := exchange.ParseDecimal(spec.StepSize)
+	if err != nil {
 		return "", fmt.Errorf("无效的步长: %s", spec.StepSize)
 	}
-
-	// quantity / stepSize
-	ratio := new(big.Float).Quo(quantityFloat, stepSizeFloat)
-
-	// 向下取整
-	ratioInt, _ := ratio.Int(nil)
-
-	// 乘以 stepSize: floor(quantity / stepSize) * stepSize
-	quantityFloat = new(big.Float).SetInt(ratioInt)
-	quantityFloat = quantityFloat.Mul(quantityFloat, stepSizeFloat)
+	quantityDec = exchange.FloorToStep(quantityDec, stepSize)
 
 	// 再次检查处理后的 quantity 是否大于等于 minQty
-	if quantityFloat.Cmp(minQtyFloat) < 0 {
-		precision := utils.GetNumberPrecision(spec.StepSize)
-		return "", fmt.Errorf("处理后的数量 %s 小于最小数量 %s", quantityFloat.Text('f', precision), spec.MinQty)
+	precision := int32(utils.GetNumberPrecision(spec.StepSize))
+	if quantityDec.LessThan(minQty) {
+		return "", fmt.Errorf("%w: 处理后的数量 %s 小于最小数量 %s", exchange.ErrMinNotional, quantityDec.StringFixed(precision), spec.MinQty)
 	}
 
-	precision := utils.GetNumberPrecision(spec.StepSize)
-	return quantityFloat.Text('f', precision), nil
+	return quantityDec.StringFixed(precision), nil
 }
@@ -0,0 +1,161 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+// AmendOrder 修改未完成的限价单，未修改的价格或数量沿用原订单
+func (b *binanceExchange) AmendOrder(ctx context.Context, market exchange.Market, symbol, orderID, newPrice, newQty string) (*exchange.Order, error) {
+	if err := exchange.ValidateAmend(newPrice, newQty); err != nil {
+		return nil, err
+	}
+	orderIDInt, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("无效的订单ID: %w", err)
+	}
+
+	switch market {
+	case exchange.MarketSpot:
+		return b.amendSpotOrder(ctx, symbol, orderIDInt, newPrice, newQty)
+	case exchange.MarketFutures:
+		return b.amendFuturesOrder(ctx, symbol, orderIDInt, newPrice, newQty)
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+}
+
+// amendSpotOrder 现货撤单重下，撤单失败时不下新单，新订单沿用原订单的方向、类型、时间类型与冰山数量；
+// 新订单下单失败时原订单可能已撤销，返回的错误包装 ErrAmendOrderCanceled 或 ErrAmendOrderKept
+func (b *binanceExchange) amendSpotOrder(ctx context.Context, symbol string, orderID int64, newPrice, newQty string) (*exchange.Order, error) {
+	order, err := b.getClient(ctx).NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取现货订单失败: %w", toAPIError(err))
+	}
+	if order.Type != binance.OrderTypeLimit && order.Type != binance.OrderTypeLimitMaker {
+		return nil, fmt.Errorf("%w: 仅支持修改限价单", exchange.ErrInvalidOrder)
+	}
+
+	spec, err := b.getSpotSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	price, quantity := amendValues(order.Price, order.OrigQuantity, newPrice, newQty)
+	quantity, err = b.filtersQuantity(spec, price, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+
+	service := b.getClient(ctx).NewCancelReplaceOrderService().
+		Symbol(symbol).
+		Side(order.Side).
+		Type(order.Type).
+		CancelReplaceMode(binance.CancelReplaceModeStopOnFailure).
+		CancelOrderID(orderID).
+		Quantity(quantity).
+		Price(price).
+		NewClientOrderID(idempotent.ClientOrderID(ctx, ""))
+	if order.Type == binance.OrderTypeLimit {
+		service.TimeInForce(order.TimeInForce)
+	}
+	if exchange.ToDecimal(order.IcebergQuantity).IsPositive() {
+		service.IcebergQuantity(order.IcebergQuantity)
+	}
+
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("现货撤单重下失败: %w", toCancelReplaceError(err))
+	}
+	if resp.NewOrderResponse == nil {
+		kind := exchange.ErrAmendOrderKept
+		if resp.CancelResult == "SUCCESS" {
+			kind = exchange.ErrAmendOrderCanceled
+		}
+		return nil, fmt.Errorf("现货撤单重下失败: %w: %w: 撤单结果 %s，下单结果 %s", exchange.ErrInvalidOrder, kind, resp.CancelResult, resp.NewOrderResult)
+	}
+	return toSpotCreateOrder(resp.NewOrderResponse), nil
+}
+
+// toCancelReplaceError 转换撤单重下错误，STOP_ON_FAILURE 模式下 -2021 表示撤单成功、新订单下单失败，
+// -2022 表示撤单失败、未下新单，分别包装 ErrAmendOrderCanceled 与 ErrAmendOrderKept
+func toCancelReplaceError(err error) error {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return toAPIError(err)
+	}
+	switch apiErr.Code {
+	case -2021:
+		return fmt.Errorf("%w: %w: %w", exchange.ErrInvalidOrder, exchange.ErrAmendOrderCanceled, toAPIError(err))
+	case -2022:
+		return fmt.Errorf("%w: %w: %w", exchange.ErrInvalidOrder, exchange.ErrAmendOrderKept, toAPIError(err))
+	default:
+		return toAPIError(err)
+	}
+}
+
+// amendFuturesOrder 合约改单，接口需要同时传入方向、价格与数量
+func (b *binanceExchange) amendFuturesOrder(ctx context.Context, symbol string, orderID int64, newPrice, newQty string) (*exchange.Order, error) {
+	order, err := b.getFuturesClient(ctx).NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约订单失败: %w", toAPIError(err))
+	}
+
+	spec, err := b.getFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	price, quantity := amendValues(order.Price, order.OrigQuantity, newPrice, newQty)
+	quantity, err = b.filtersQuantity(spec, price, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+
+	resp, err := b.getFuturesClient(ctx).NewModifyOrderService().
+		Symbol(symbol).
+		OrderID(orderID).
+		Side(order.Side).
+		Quantity(quantity).
+		Price(price).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("合约改单失败: %w", toAPIError(err))
+	}
+	return toFuturesModifyOrder(resp), nil
+}
+
+// toFuturesModifyOrder 转换合约改单结果
+func toFuturesModifyOrder(resp *futures.ModifyOrderResponse) *exchange.Order {
+	return &exchange.Order{
+		OrderID:       strconv.FormatInt(resp.OrderID, 10),
+		ClientOrderID: resp.ClientOrderID,
+		Symbol:        resp.Symbol,
+		Side:          exchange.OrderSide(resp.Side),
+		Type:          exchange.OrderType(resp.Type),
+		Status:        exchange.OrderStatus(string(resp.Status)),
+		Price:         resp.Price,
+		Quantity:      resp.OriginalQuantity,
+		ExecutedQty:   resp.ExecutedQuantity,
+		ActualQty:     resp.CumulativeQuantity,
+		TimeInForce:   exchange.OrderTimeInForce(resp.TimeInForce),
+		UpdateTime:    resp.UpdateTime,
+	}
+}
+
+// amendValues 改单后的价格与数量，为空时沿用原订单
+func amendValues(price, quantity, newPrice, newQty string) (string, string) {
+	if newPrice != "" {
+		price = newPrice
+	}
+	if newQty != "" {
+		quantity = newQty
+	}
+	return price, quantity
+}
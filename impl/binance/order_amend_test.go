@@ -0,0 +1,140 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// testAmendSpec 改单测试使用的交易对规格
+var testAmendSpec = &symbolSpec{
+	Symbol:   "BTCUSDT",
+	MinQty:   "0.001",
+	MaxQty:   "1000",
+	StepSize: "0.001",
+	MinPrice: "0.01",
+	MaxPrice: "1000000",
+	TickSize: "0.01",
+}
+
+// TestAmendSpotOrder 现货撤单重下沿用原订单参数，失败时按错误码区分原订单是否已撤销
+// go test -v ./impl/binance -run "^TestAmendSpotOrder$"
+func TestAmendSpotOrder(t *testing.T) {
+	const openOrder = `{"symbol":"BTCUSDT","orderId":1,"price":"100.00","origQty":"1.000","type":"LIMIT","side":"BUY","timeInForce":"GTC","icebergQty":"0.000"}`
+
+	b, ts := newTestBinance(t, map[string]string{
+		"GET /api/v3/order": openOrder,
+		"POST /api/v3/order/cancelReplace": `{"cancelResult":"SUCCESS","newOrderResult":"SUCCESS",
+			"newOrderResponse":{"symbol":"BTCUSDT","orderId":2,"clientOrderId":"amend-1","transactTime":1700000000000,
+			"price":"101.00","origQty":"1.000","executedQty":"0.000","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY"}}`,
+	})
+	b.getSpotSpec(context.Background()).SetSymbolSpec("BTCUSDT", testAmendSpec)
+	order, err := b.AmendOrder(context.Background(), exchange.MarketSpot, "BTCUSDT", "1", "101", "")
+	if err != nil {
+		t.Fatalf("改单失败: %v", err)
+	}
+	if order.OrderID != "2" || order.Price != "101.00" || order.Status != exchange.OrderStatusNew {
+		t.Errorf("改单结果错误: %+v", order)
+	}
+	req := ts.findRequest("POST", "/api/v3/order/cancelReplace")
+	if req == nil {
+		t.Fatalf("未发送撤单重下请求")
+	}
+	want := map[string]string{
+		"cancelReplaceMode": "STOP_ON_FAILURE",
+		"cancelOrderId":     "1",
+		"side":              "BUY",
+		"type":              "LIMIT",
+		"timeInForce":       "GTC",
+		"price":             "101",
+		"quantity":          "1.000",
+	}
+	for k, v := range want {
+		if req.Params[k] != v {
+			t.Errorf("参数 %s 应为 %s: %s", k, v, req.Params[k])
+		}
+	}
+	if _, ok := req.Params["icebergQty"]; ok {
+		t.Errorf("冰山数量为 0 时不应发送 icebergQty")
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		kind     error
+	}{
+		{"撤单成功下单失败", `{"code":-2021,"msg":"Order cancel-replace partially failed."}`, exchange.ErrAmendOrderCanceled},
+		{"撤单失败", `{"code":-2022,"msg":"Order cancel-replace failed."}`, exchange.ErrAmendOrderKept},
+	}
+	for _, tt := range tests {
+		b, _ := newTestBinance(t, map[string]string{
+			"GET /api/v3/order":                openOrder,
+			"POST /api/v3/order/cancelReplace": tt.response,
+		})
+		b.getSpotSpec(context.Background()).SetSymbolSpec("BTCUSDT", testAmendSpec)
+		_, err := b.AmendOrder(context.Background(), exchange.MarketSpot, "BTCUSDT", "1", "101", "")
+		if !errors.Is(err, exchange.ErrInvalidOrder) || !errors.Is(err, tt.kind) {
+			t.Errorf("%s: 错误应包装 ErrInvalidOrder 与 %v: %v", tt.name, tt.kind, err)
+		}
+		var apiErr *exchange.APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("%s: 错误应包含交易所错误码: %v", tt.name, err)
+		}
+	}
+}
+
+// TestToCancelReplaceError 撤单重下错误码转换，其他错误按普通接口错误转换
+// go test -v ./impl/binance -run "^TestToCancelReplaceError$"
+func TestToCancelReplaceError(t *testing.T) {
+	tests := []struct {
+		err      error
+		kinds    []error
+		notKinds []error
+	}{
+		{&common.APIError{Code: -2021, Message: "Order cancel-replace partially failed."},
+			[]error{exchange.ErrInvalidOrder, exchange.ErrAmendOrderCanceled}, []error{exchange.ErrAmendOrderKept}},
+		{&common.APIError{Code: -2022, Message: "Order cancel-replace failed."},
+			[]error{exchange.ErrInvalidOrder, exchange.ErrAmendOrderKept}, []error{exchange.ErrAmendOrderCanceled}},
+		{&common.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."},
+			[]error{exchange.ErrInsufficientBalance}, []error{exchange.ErrAmendOrderCanceled, exchange.ErrAmendOrderKept}},
+		{errors.New("network error"), nil, []error{exchange.ErrInvalidOrder}},
+	}
+	for _, tt := range tests {
+		err := toCancelReplaceError(tt.err)
+		for _, kind := range tt.kinds {
+			if !errors.Is(err, kind) {
+				t.Errorf("%v 应包装 %v: %v", tt.err, kind, err)
+			}
+		}
+		for _, kind := range tt.notKinds {
+			if errors.Is(err, kind) {
+				t.Errorf("%v 不应包装 %v: %v", tt.err, kind, err)
+			}
+		}
+	}
+}
+
+// TestAmendFuturesOrder 合约改单沿用原订单方向与未修改的价格
+// go test -v ./impl/binance -run "^TestAmendFuturesOrder$"
+func TestAmendFuturesOrder(t *testing.T) {
+	b, ts := newTestBinance(t, map[string]string{
+		"GET /fapi/v1/order": `{"symbol":"BTCUSDT","orderId":1,"price":"100.00","origQty":"1.000","type":"LIMIT","side":"SELL","timeInForce":"GTC"}`,
+		"PUT /fapi/v1/order": `{"symbol":"BTCUSDT","orderId":1,"clientOrderId":"c1","price":"100.00","origQty":"2.000","executedQty":"0",
+			"cumQty":"0","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"SELL","updateTime":1700000000000}`,
+	})
+	b.getFuturesSpec(context.Background()).SetSymbolSpec("BTCUSDT", testAmendSpec)
+	order, err := b.AmendOrder(context.Background(), exchange.MarketFutures, "BTCUSDT", "1", "", "2")
+	if err != nil {
+		t.Fatalf("改单失败: %v", err)
+	}
+	if order.OrderID != "1" || order.Quantity != "2.000" || order.Side != exchange.OrderSideSell {
+		t.Errorf("改单结果错误: %+v", order)
+	}
+	req := ts.findRequest("PUT", "/fapi/v1/order")
+	if req == nil || req.Params["side"] != "SELL" || req.Params["price"] != "100.00" || req.Params["quantity"] != "2.000" {
+		t.Errorf("改单参数错误: %+v", req)
+	}
+}
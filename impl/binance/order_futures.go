@@ -3,63 +3,95 @@ package binance
 import (
 	"context"
 	"fmt"
-	"math"
 	"strconv"
 
 	"github.com/adshao/go-binance/v2/futures"
 	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
 )
 
 // CreateFuturesOrder 合约下单
 func (b *binanceExchange) CreateFuturesOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
+	req := exchange.NewOrderRequest(exchange.MarketFutures, symbol, side, limitPrice, quantity)
+	req.PositionSide = exchange.PositionSideShort
+	if side == exchange.OrderSideBuy {
+		req.PositionSide = exchange.PositionSideLong
+	}
+	return b.PlaceOrder(ctx, req)
+}
+
+// placeFuturesOrder 合约下单
+func (b *binanceExchange) placeFuturesOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	service, clientOrderID, err := b.newFuturesOrderService(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 执行订单，结果未知时按客户端订单ID对账
+	return idempotent.PlaceOrder(ctx, b.maxRetries, func() (*exchange.Order, error) {
+		resp, err := service.Do(ctx)
+		if err != nil {
+			return nil, toAPIError(err)
+		}
+
+		return &exchange.Order{
+			OrderID:       strconv.FormatInt(resp.OrderID, 10),
+			ClientOrderID: resp.ClientOrderID,
+			Symbol:        resp.Symbol,
+			Side:          exchange.OrderSide(resp.Side),
+			Type:          exchange.OrderType(resp.Type),
+			Status:        exchange.OrderStatus(string(resp.Status)),
+			Price:         resp.Price,
+			Quantity:      resp.OrigQuantity,
+			ExecutedQty:   resp.ExecutedQuantity,
+			QuoteQuantity: resp.CumQuote,
+			TimeInForce:   exchange.OrderTimeInForce(resp.TimeInForce),
+			CreateTime:    resp.UpdateTime,
+			UpdateTime:    resp.UpdateTime,
+		}, nil
+	}, func() (*exchange.Order, error) {
+		return b.GetFuturesOrderByClientID(ctx, req.Symbol, clientOrderID)
+	})
+}
+
+// newFuturesOrderService 按下单请求创建合约下单服务，只做挂单使用 GTX 时间类型，双向持仓模式下不发送只减仓参数
+func (b *binanceExchange) newFuturesOrderService(ctx context.Context, req exchange.OrderRequest) (*futures.CreateOrderService, string, error) {
+	if req.QuoteQty != "" || req.IcebergQty != "" {
+		return nil, "", fmt.Errorf("%w: 合约不支持按金额下单与冰山单", exchange.ErrInvalidOrder)
+	}
+
 	// 获取交易规则
-	spec, err := b.getFuturesSymbolSpec(ctx, symbol)
+	spec, err := b.getFuturesSymbolSpec(ctx, req.Symbol)
 	if err != nil {
-		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+		return nil, "", fmt.Errorf("获取交易规则失败: %w", err)
 	}
 
 	// 验证交易规则
-	quantity, err = b.filtersQuantity(spec, limitPrice, quantity)
+	quantity, err := b.filtersQuantity(spec, req.Price, req.Quantity)
 	if err != nil {
-		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		return nil, "", fmt.Errorf("验证交易规则失败: %w", err)
 	}
 
-	service := b.futuresClient.NewCreateOrderService().
-		Symbol(symbol).
-		Side(futures.SideType(string(side))).
-		Quantity(quantity)
-	if side == exchange.OrderSideBuy {
-		service.PositionSide(futures.PositionSideTypeLong)
-	} else {
-		service.PositionSide(futures.PositionSideTypeShort)
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	service := b.getFuturesClient(ctx).NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(futures.SideType(string(req.Side))).
+		Quantity(quantity).
+		NewClientOrderID(clientOrderID)
+	if req.PositionSide != "" {
+		service.PositionSide(futures.PositionSideType(req.PositionSide))
+	}
+	if req.ReduceOnly && (req.PositionSide == "" || req.PositionSide == exchange.PositionSideBoth) {
+		service.ReduceOnly(true)
 	}
 
 	// 市价单
-	if limitPrice == "" || limitPrice == "0" {
+	if req.Type == exchange.OrderTypeMarket {
 		service.Type(futures.OrderTypeMarket)
 	} else {
-		service.Type(futures.OrderTypeLimit).Price(limitPrice).TimeInForce(futures.TimeInForceTypeGTC)
+		service.Type(futures.OrderTypeLimit).Price(req.Price).TimeInForce(futures.TimeInForceType(req.TimeInForce))
 	}
-
-	resp, err := service.Do(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	return &exchange.Order{
-		OrderID:       strconv.FormatInt(resp.OrderID, 10),
-		Symbol:        resp.Symbol,
-		Side:          exchange.OrderSide(resp.Side),
-		Type:          exchange.OrderType(resp.Type),
-		Status:        exchange.OrderStatus(string(resp.Status)),
-		Price:         resp.Price,
-		Quantity:      resp.OrigQuantity,
-		ExecutedQty:   resp.ExecutedQuantity,
-		QuoteQuantity: resp.CumQuote,
-		TimeInForce:   exchange.OrderTimeInForce(resp.TimeInForce),
-		CreateTime:    resp.UpdateTime,
-		UpdateTime:    resp.UpdateTime,
-	}, nil
+	return service, clientOrderID, nil
 }
 
 // GetFuturesOrder 获取合约订单
@@ -68,13 +100,28 @@ func (b *binanceExchange) GetFuturesOrder(ctx context.Context, symbol string, or
 	if err != nil {
 		return nil, fmt.Errorf("无效的订单ID: %w", err)
 	}
-	resp, err := b.futuresClient.NewGetOrderService().Symbol(symbol).OrderID(orderIDInt).Do(ctx)
+	return getFuturesOrder(ctx, b.getFuturesClient(ctx).NewGetOrderService().Symbol(symbol).OrderID(orderIDInt))
+}
+
+// GetFuturesOrderByClientID 按客户端订单ID获取合约订单
+func (b *binanceExchange) GetFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return getFuturesOrder(ctx, b.getFuturesClient(ctx).NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID))
+}
+
+// getFuturesOrder 查询合约订单
+func getFuturesOrder(ctx context.Context, service *futures.GetOrderService) (*exchange.Order, error) {
+	resp, err := service.Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("binance futures get order: %w", err)
+		return nil, fmt.Errorf("binance futures get order: %w", toAPIError(err))
 	}
+	return toFuturesOrder(resp), nil
+}
 
+// toFuturesOrder 转换合约订单
+func toFuturesOrder(resp *futures.Order) *exchange.Order {
 	return &exchange.Order{
-		OrderID:       orderID,
+		OrderID:       strconv.FormatInt(resp.OrderID, 10),
+		ClientOrderID: resp.ClientOrderID,
 		Symbol:        resp.Symbol,
 		Side:          exchange.OrderSide(resp.Side),
 		Type:          exchange.OrderType(resp.Type),
@@ -87,14 +134,14 @@ func (b *binanceExchange) GetFuturesOrder(ctx context.Context, symbol string, or
 		TimeInForce:   exchange.OrderTimeInForce(resp.TimeInForce),
 		CreateTime:    resp.UpdateTime,
 		UpdateTime:    resp.UpdateTime,
-	}, nil
+	}
 }
 
 // GetFuturesPositionRisk 获取合约持仓风险
 func (b *binanceExchange) GetFuturesPositionRisk(ctx context.Context, symbol string) (*exchange.SymbolPositionRisk, error) {
-	positions, err := b.futuresClient.NewGetPositionRiskService().Symbol(symbol).Do(ctx)
+	positions, err := b.getFuturesClient(ctx).NewGetPositionRiskService().Symbol(symbol).Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("获取持仓风险失败: %w", err)
+		return nil, fmt.Errorf("获取持仓风险失败: %w", toAPIError(err))
 	}
 
 	data := &exchange.SymbolPositionRisk{}
@@ -130,8 +177,7 @@ func (b *binanceExchange) SetFuturesSLTP(ctx context.Context, symbol string, pos
 		return fmt.Errorf("获取指定方向 %s 持仓风险失败: 未找到该方向的持仓", positionSide)
 	}
 
-	qtyFloat, _ := strconv.ParseFloat(sidePositionRisk.PositionAmt, 64)
-	qtyAbs := fmt.Sprintf("%.8f", math.Abs(qtyFloat))
+	qtyAbs := sidePositionRisk.PositionAmtDecimal().Abs().String()
 
 	// 设置止损(STOP_MARKET: 市价止损)
 	if stopPrice != "" {
@@ -151,22 +197,22 @@ func (b *binanceExchange) SetFuturesSLTP(ctx context.Context, symbol string, pos
 
 // SetFuturesLeverage 设置合约杠杆
 func (b *binanceExchange) SetFuturesLeverage(ctx context.Context, symbol string, leverage int) error {
-	if _, err := b.futuresClient.NewChangeLeverageService().
+	if _, err := b.getFuturesClient(ctx).NewChangeLeverageService().
 		Symbol(symbol).
 		Leverage(leverage).
 		Do(ctx); err != nil {
-		return fmt.Errorf("设置杠杆失败: %w", err)
+		return fmt.Errorf("设置杠杆失败: %w", toAPIError(err))
 	}
 	return nil
 }
 
 // SetFuturesMarginMode 设置合约保证金模式
 func (b *binanceExchange) SetFuturesMarginMode(ctx context.Context, symbol string, marginMode exchange.MarginMode) error {
-	if err := b.futuresClient.NewChangeMarginTypeService().
+	if err := b.getFuturesClient(ctx).NewChangeMarginTypeService().
 		Symbol(symbol).
 		MarginType(futures.MarginType(string(marginMode))).
 		Do(ctx); err != nil {
-		return fmt.Errorf("设置保证金模式失败: %w", err)
+		return fmt.Errorf("设置保证金模式失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -174,9 +220,9 @@ func (b *binanceExchange) SetFuturesMarginMode(ctx context.Context, symbol strin
 // CancelFuturesSLTP 撤销合约止损止盈
 func (b *binanceExchange) CancelFuturesSLTP(ctx context.Context, symbol string) error {
 	// 获取该 symbol 的所有开放订单
-	openOrders, err := b.futuresClient.NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+	openOrders, err := b.getFuturesClient(ctx).NewListOpenOrdersService().Symbol(symbol).Do(ctx)
 	if err != nil {
-		return fmt.Errorf("获取 %s 开放订单失败: %w", symbol, err)
+		return fmt.Errorf("获取 %s 开放订单失败: %w", symbol, toAPIError(err))
 	}
 
 	// 3. 遍历并取消除已成交止损之外的 TP/SL
@@ -189,12 +235,12 @@ func (b *binanceExchange) CancelFuturesSLTP(ctx context.Context, symbol string)
 			}
 
 			// 取消订单
-			_, err = b.futuresClient.NewCancelOrderService().
+			_, err = b.getFuturesClient(ctx).NewCancelOrderService().
 				Symbol(symbol).
 				OrderID(o.OrderID).
 				Do(ctx)
 			if err != nil {
-				return fmt.Errorf("取消订单 %d 失败: %w", o.OrderID, err)
+				return fmt.Errorf("取消订单 %d 失败: %w", o.OrderID, toAPIError(err))
 			}
 		}
 	}
@@ -216,29 +262,29 @@ func (b *binanceExchange) CloseFuturesPositionRisk(ctx context.Context, symbol s
 	}
 
 	// 如果持仓风险为0，则不进行平仓
-	if sidePositionRisk.PositionAmt == "0" {
+	if sidePositionRisk.PositionAmtDecimal().IsZero() {
 		return nil
 	}
 
 	// 确定平仓方向：LONG 持仓用 SELL 平仓，SHORT 持仓用 BUY 平仓
-	amt, _ := strconv.ParseFloat(sidePositionRisk.PositionAmt, 64)
+	amt := sidePositionRisk.PositionAmtDecimal()
 	side := futures.SideTypeSell
 	if positionSide == exchange.PositionSideShort {
 		side = futures.SideTypeBuy
-		amt = -amt
+		amt = amt.Neg()
 	}
 
 	// 市价平仓
-	_, err = b.futuresClient.NewCreateOrderService().
+	_, err = b.getFuturesClient(ctx).NewCreateOrderService().
 		Symbol(symbol).
 		Side(side).
 		Type(futures.OrderTypeMarket).
-		Quantity(fmt.Sprintf("%.8f", amt)).                           // 必须传持仓数量
+		Quantity(amt.String()).                                       // 必须传持仓数量
 		PositionSide(futures.PositionSideType(string(positionSide))). // 持仓方向
 		Do(ctx)
 
 	if err != nil {
-		return fmt.Errorf("平仓失败: %w", err)
+		return fmt.Errorf("平仓失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -249,12 +295,28 @@ func (b *binanceExchange) CancelFuturesOrder(ctx context.Context, symbol string,
 	if err != nil {
 		return nil, fmt.Errorf("无效的订单ID: %w", err)
 	}
-	resp, err := b.futuresClient.NewCancelOrderService().Symbol(symbol).OrderID(orderIDInt).Do(ctx)
+	return cancelFuturesOrder(ctx, b.getFuturesClient(ctx).NewCancelOrderService().Symbol(symbol).OrderID(orderIDInt))
+}
+
+// CancelFuturesOrderByClientID 按客户端订单ID撤销合约订单
+func (b *binanceExchange) CancelFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return cancelFuturesOrder(ctx, b.getFuturesClient(ctx).NewCancelOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID))
+}
+
+// cancelFuturesOrder 执行合约撤单
+func cancelFuturesOrder(ctx context.Context, service *futures.CancelOrderService) (*exchange.Order, error) {
+	resp, err := service.Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("binance futures cancel order: %w", err)
+		return nil, fmt.Errorf("binance futures cancel order: %w", toAPIError(err))
 	}
+	return toFuturesCancelOrder(resp), nil
+}
+
+// toFuturesCancelOrder 转换合约撤单结果
+func toFuturesCancelOrder(resp *futures.CancelOrderResponse) *exchange.Order {
 	return &exchange.Order{
-		OrderID:       orderID,
+		OrderID:       strconv.FormatInt(resp.OrderID, 10),
+		ClientOrderID: resp.ClientOrderID,
 		Symbol:        resp.Symbol,
 		Side:          exchange.OrderSide(resp.Side),
 		Type:          exchange.OrderType(resp.Type),
@@ -267,15 +329,15 @@ func (b *binanceExchange) CancelFuturesOrder(ctx context.Context, symbol string,
 		TimeInForce:   exchange.OrderTimeInForce(resp.TimeInForce),
 		CreateTime:    resp.UpdateTime,
 		UpdateTime:    resp.UpdateTime,
-	}, nil
+	}
 }
 
 // SetFuturesDualMode 设置持仓模式
 func (b *binanceExchange) SetFuturesDualMode(ctx context.Context, dualMode bool) error {
-	if err := b.futuresClient.NewChangePositionModeService().
+	if err := b.getFuturesClient(ctx).NewChangePositionModeService().
 		DualSide(dualMode).
 		Do(ctx); err != nil {
-		return fmt.Errorf("设置持仓模式失败: %w", err)
+		return fmt.Errorf("设置持仓模式失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -288,7 +350,7 @@ func (b *binanceExchange) setFuturesStopLoss(ctx context.Context, symbol string,
 		side = futures.SideTypeBuy
 	}
 	// 设置止损 (STOP_MARKET: 市价止损)
-	_, err := b.futuresClient.NewCreateOrderService().
+	_, err := b.getFuturesClient(ctx).NewCreateOrderService().
 		Symbol(symbol).
 		Side(side).                        // 平仓方向
 		Type(futures.OrderTypeStopMarket). // 市价止损类型
@@ -300,7 +362,7 @@ func (b *binanceExchange) setFuturesStopLoss(ctx context.Context, symbol string,
 		Do(ctx)
 
 	if err != nil {
-		return fmt.Errorf("设置止损失败: %w", err)
+		return fmt.Errorf("设置止损失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -313,7 +375,7 @@ func (b *binanceExchange) setFuturesTakeProfit(ctx context.Context, symbol strin
 		side = futures.SideTypeBuy
 	}
 
-	_, err := b.futuresClient.NewCreateOrderService().
+	_, err := b.getFuturesClient(ctx).NewCreateOrderService().
 		Symbol(symbol).
 		Side(side).                              // 平仓方向
 		Type(futures.OrderTypeTakeProfitMarket). // 市价止盈类型
@@ -325,7 +387,7 @@ func (b *binanceExchange) setFuturesTakeProfit(ctx context.Context, symbol strin
 		Do(ctx)
 
 	if err != nil {
-		return fmt.Errorf("设置止盈失败: %w", err)
+		return fmt.Errorf("设置止盈失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -335,13 +397,19 @@ func (b *binanceExchange) getFuturesSymbolSpec(ctx context.Context, symbol strin
 	var spec *symbolSpec
 
 	// 从缓存中获取交易对规格
-	spec, _ = binanceFuturesSpec.GetSymbolSpec(symbol)
+	specs := b.getFuturesSpec(ctx)
+	spec, _ = specs.GetSymbolSpec(symbol)
 
-	// 如果缓存中没有，则获取最新交易对规格
+	// 如果缓存中没有，则获取最新交易对规格，并发未命中只拉取一次，且近期全量加载过时不再重复拉取
 	if spec == nil {
-		info, err := b.futuresClient.NewExchangeInfoService().Do(ctx)
+		specs.loadMux.Lock()
+		defer specs.loadMux.Unlock()
+		spec, _ = specs.GetSymbolSpec(symbol)
+	}
+	if spec == nil && !specs.LoadedWithin(SpecRetryInterval) {
+		info, err := b.getFuturesClient(ctx).NewExchangeInfoService().Do(ctx)
 		if err != nil {
-			return nil, err
+			return nil, toAPIError(err)
 		}
 
 		for _, s := range info.Symbols {
@@ -349,38 +417,16 @@ func (b *binanceExchange) getFuturesSymbolSpec(ctx context.Context, symbol strin
 				continue
 			}
 
-			specTmp := &symbolSpec{
-				Symbol:         s.Symbol,
-				BaseAsset:      s.BaseAsset,
-				QuoteAsset:     s.QuoteAsset,
-				BasePrecision:  s.BaseAssetPrecision,
-				QuotePrecision: s.QuantityPrecision,
-				Status:         s.Status,
-			}
-
-			for _, f := range s.Filters {
-				if f["filterType"].(string) == "PRICE_FILTER" {
-					specTmp.MinPrice = f["minPrice"].(string)
-					specTmp.MaxPrice = f["maxPrice"].(string)
-					specTmp.TickSize = f["tickSize"].(string)
-				}
-				if f["filterType"].(string) == "LOT_SIZE" {
-					specTmp.MinQty = f["minQty"].(string)
-					specTmp.MaxQty = f["maxQty"].(string)
-					specTmp.StepSize = f["stepSize"].(string)
-				}
-				if f["filterType"].(string) == "MIN_NOTIONAL" {
-					specTmp.MinNotional = f["notional"].(string)
-				}
-			}
+			specTmp := toFuturesSymbolSpec(&s)
 
 			// 找到对应的交易对规格
 			if s.Symbol == symbol {
 				spec = specTmp
 			}
 
-			binanceFuturesSpec.SetSymbolSpec(s.Symbol, specTmp)
+			specs.SetSymbolSpec(s.Symbol, specTmp)
 		}
+		specs.SetLoaded()
 	}
 
 	if spec == nil {
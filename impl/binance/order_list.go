@@ -0,0 +1,143 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	OrderHistoryLimit = 500 // 历史订单默认每页数量
+)
+
+// ListOpenOrders 获取交易对未完成的普通订单，现货排除 OCO 与未触发的条件单，合约排除条件单
+func (b *binanceExchange) ListOpenOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.Order, error) {
+	result := make([]*exchange.Order, 0)
+	switch market {
+	case exchange.MarketSpot:
+		orders, err := b.getClient(ctx).NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取现货挂单失败: %w", toAPIError(err))
+		}
+		for _, o := range orders {
+			if _, ok := spotConditionalTypes[o.Type]; (ok && !o.IsWorking) || o.OrderListId != -1 {
+				continue
+			}
+			result = append(result, toSpotOrder(o))
+		}
+	case exchange.MarketFutures:
+		orders, err := b.getFuturesClient(ctx).NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取合约挂单失败: %w", toAPIError(err))
+		}
+		for _, o := range orders {
+			if _, ok := futuresConditionalTypes[o.Type]; ok {
+				continue
+			}
+			result = append(result, toFuturesOrder(o))
+		}
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+	return result, nil
+}
+
+// CancelAllOrders 撤销交易对全部挂单，包括未触发的条件单
+func (b *binanceExchange) CancelAllOrders(ctx context.Context, market exchange.Market, symbol string) error {
+	switch market {
+	case exchange.MarketSpot:
+		// 没有挂单时返回订单不存在
+		_, err := b.getClient(ctx).NewCancelOpenOrdersService().Symbol(symbol).Do(ctx)
+		if err != nil && !errors.Is(toAPIError(err), exchange.ErrOrderNotFound) {
+			return fmt.Errorf("撤销现货全部挂单失败: %w", toAPIError(err))
+		}
+	case exchange.MarketFutures:
+		if err := b.getFuturesClient(ctx).NewCancelAllOpenOrdersService().Symbol(symbol).Do(ctx); err != nil {
+			return fmt.Errorf("撤销合约全部挂单失败: %w", toAPIError(err))
+		}
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+	return nil
+}
+
+// ListOrderHistory 按订单ID升序分页查询历史订单，游标为下一页的起始订单ID，未指定开始时间时从最早的订单开始；
+// 接口同时返回未完成的订单，过滤后单页数量可能少于 Limit
+func (b *binanceExchange) ListOrderHistory(ctx context.Context, query exchange.OrderQuery) (*exchange.OrderPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = OrderHistoryLimit
+	}
+	var fromID int64
+	if query.Cursor != "" {
+		id, err := strconv.ParseInt(query.Cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的分页游标: %w", err)
+		}
+		fromID = id
+	}
+
+	page := &exchange.OrderPage{Orders: make([]*exchange.Order, 0)}
+	var lastID int64
+	var count int
+	switch query.Market {
+	case exchange.MarketSpot:
+		service := b.getClient(ctx).NewListOrdersService().Symbol(query.Symbol).Limit(limit)
+		// 未指定开始时间时从订单ID 0 开始查询，不传订单ID时接口只返回最近的订单，无法向后翻页
+		if fromID > 0 || query.StartTime.IsZero() {
+			service.OrderID(fromID)
+		} else {
+			service.StartTime(query.StartTime.UnixMilli())
+		}
+		if !query.EndTime.IsZero() {
+			service.EndTime(query.EndTime.UnixMilli())
+		}
+		orders, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取现货历史订单失败: %w", toAPIError(err))
+		}
+		for _, o := range orders {
+			lastID = o.OrderID
+			if order := toSpotOrder(o); !isOpenOrder(order.Status) {
+				page.Orders = append(page.Orders, order)
+			}
+		}
+		count = len(orders)
+	case exchange.MarketFutures:
+		service := b.getFuturesClient(ctx).NewListOrdersService().Symbol(query.Symbol).Limit(limit)
+		if fromID > 0 || query.StartTime.IsZero() {
+			service.OrderID(fromID)
+		} else {
+			service.StartTime(query.StartTime.UnixMilli())
+		}
+		if !query.EndTime.IsZero() {
+			service.EndTime(query.EndTime.UnixMilli())
+		}
+		orders, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取合约历史订单失败: %w", toAPIError(err))
+		}
+		for _, o := range orders {
+			lastID = o.OrderID
+			if order := toFuturesOrder(o); !isOpenOrder(order.Status) {
+				page.Orders = append(page.Orders, order)
+			}
+		}
+		count = len(orders)
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", query.Market)
+	}
+
+	if count >= limit {
+		page.NextCursor = strconv.FormatInt(lastID+1, 10)
+	}
+	return page, nil
+}
+
+// isOpenOrder 订单是否未完成
+func isOpenOrder(status exchange.OrderStatus) bool {
+	return status == exchange.OrderStatusNew || status == exchange.OrderStatusPartiallyFilled || status == "PENDING_NEW"
+}
@@ -0,0 +1,48 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestListOrderHistoryCursor 未指定开始时间时从订单ID 0 开始，游标为最后一个订单ID加一，未完成的订单不返回
+// go test -v ./impl/binance -run "^TestListOrderHistoryCursor$"
+func TestListOrderHistoryCursor(t *testing.T) {
+	const orders = `[{"symbol":"BTCUSDT","orderId":10,"price":"100","origQty":"1","executedQty":"1","status":"FILLED","type":"LIMIT","side":"BUY"},
+		{"symbol":"BTCUSDT","orderId":12,"price":"101","origQty":"1","executedQty":"0","status":"NEW","type":"LIMIT","side":"BUY"}]`
+
+	tests := []struct {
+		market     exchange.Market
+		path       string
+		query      exchange.OrderQuery
+		orderID    string
+		startTime  string
+		nextCursor string
+	}{
+		{exchange.MarketSpot, "/api/v3/allOrders", exchange.OrderQuery{Limit: 2}, "0", "", "13"},
+		{exchange.MarketSpot, "/api/v3/allOrders", exchange.OrderQuery{Limit: 2, Cursor: "13"}, "13", "", "13"},
+		{exchange.MarketSpot, "/api/v3/allOrders", exchange.OrderQuery{Limit: 3, StartTime: time.UnixMilli(1700000000000)}, "", "1700000000000", ""},
+		{exchange.MarketFutures, "/fapi/v1/allOrders", exchange.OrderQuery{Limit: 2}, "0", "", "13"},
+		{exchange.MarketFutures, "/fapi/v1/allOrders", exchange.OrderQuery{Limit: 2, Cursor: "13", StartTime: time.UnixMilli(1700000000000)}, "13", "", "13"},
+	}
+	for i, tt := range tests {
+		b, ts := newTestBinance(t, map[string]string{"GET " + tt.path: orders})
+		tt.query.Market = tt.market
+		tt.query.Symbol = "BTCUSDT"
+		page, err := b.ListOrderHistory(context.Background(), tt.query)
+		if err != nil {
+			t.Fatalf("第 %d 组获取历史订单失败: %v", i, err)
+		}
+		if len(page.Orders) != 1 || page.Orders[0].OrderID != "10" || page.NextCursor != tt.nextCursor {
+			t.Errorf("第 %d 组分页结果错误: orders=%d next=%q", i, len(page.Orders), page.NextCursor)
+		}
+		req := ts.findRequest("GET", tt.path)
+		if req.Params["orderId"] != tt.orderID || req.Params["startTime"] != tt.startTime || req.Params["limit"] != strconv.Itoa(tt.query.Limit) {
+			t.Errorf("第 %d 组请求参数错误: %+v", i, req.Params)
+		}
+	}
+}
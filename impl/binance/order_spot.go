@@ -3,49 +3,75 @@ package binance
 import (
 	"context"
 	"fmt"
-	"math/big"
 	"strconv"
 
 	"github.com/adshao/go-binance/v2"
 	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
 )
 
 // CreateSpotOrder 创建现货订单
 func (b *binanceExchange) CreateSpotOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
-	spec, err := b.getSpotSymbolSpec(ctx, symbol)
-	if err != nil {
-		return nil, err
-	}
+	return b.PlaceOrder(ctx, exchange.NewOrderRequest(exchange.MarketSpot, symbol, side, limitPrice, quantity))
+}
 
-	quantity, err = b.filtersQuantity(spec, limitPrice, quantity)
+// placeSpotOrder 现货下单，只做挂单使用 LIMIT_MAKER 类型
+func (b *binanceExchange) placeSpotOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	spec, err := b.getSpotSymbolSpec(ctx, req.Symbol)
 	if err != nil {
-		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		return nil, err
 	}
 
 	// 创建订单服务
-	service := b.client.NewCreateOrderService().
-		Symbol(symbol).
-		Side(binance.SideType(string(side))).
-		Quantity(quantity)
-
-	// 市价单
-	if limitPrice == "" || limitPrice == "0" {
-		service.Type(binance.OrderTypeMarket)
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	service := b.getClient(ctx).NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(binance.SideType(string(req.Side))).
+		NewClientOrderID(clientOrderID)
+
+	// 按金额下单不校验数量
+	if req.QuoteQty != "" {
+		service.QuoteOrderQty(req.QuoteQty)
 	} else {
-		service.Type(binance.OrderTypeLimit).Price(limitPrice).TimeInForce(binance.TimeInForceTypeGTC)
+		quantity, err := b.filtersQuantity(spec, req.Price, req.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		}
+		service.Quantity(quantity)
 	}
 
-	// 执行订单
-	orderResp, err := service.Do(ctx)
-	if err != nil {
-		return nil, err
+	switch {
+	case req.Type == exchange.OrderTypeMarket:
+		service.Type(binance.OrderTypeMarket)
+	case req.PostOnly:
+		service.Type(binance.OrderTypeLimitMaker).Price(req.Price)
+	default:
+		service.Type(binance.OrderTypeLimit).Price(req.Price).TimeInForce(binance.TimeInForceType(req.TimeInForce))
+	}
+	if req.IcebergQty != "" {
+		service.IcebergQuantity(req.IcebergQty)
 	}
 
+	// 执行订单，结果未知时按客户端订单ID对账
+	return idempotent.PlaceOrder(ctx, b.maxRetries, func() (*exchange.Order, error) {
+		orderResp, err := service.Do(ctx)
+		if err != nil {
+			return nil, toAPIError(err)
+		}
+		return toSpotCreateOrder(orderResp), nil
+	}, func() (*exchange.Order, error) {
+		return b.GetSpotOrderByClientID(ctx, req.Symbol, clientOrderID)
+	})
+}
+
+// toSpotCreateOrder 转换现货下单结果
+func toSpotCreateOrder(orderResp *binance.CreateOrderResponse) *exchange.Order {
 	return &exchange.Order{
 		OrderID:       strconv.FormatInt(orderResp.OrderID, 10),
+		ClientOrderID: orderResp.ClientOrderID,
 		Symbol:        orderResp.Symbol,
 		Side:          exchange.OrderSide(orderResp.Side),
-		Type:          exchange.OrderType(orderResp.Type),
+		Type:          toOrderType(string(orderResp.Type)),
 		Status:        exchange.OrderStatus(string(orderResp.Status)),
 		Price:         orderResp.Price,
 		Quantity:      orderResp.OrigQuantity,
@@ -54,7 +80,7 @@ func (b *binanceExchange) CreateSpotOrder(ctx context.Context, symbol string, si
 		TimeInForce:   exchange.OrderTimeInForce(orderResp.TimeInForce),
 		CreateTime:    orderResp.TransactTime,
 		UpdateTime:    orderResp.TransactTime,
-	}, nil
+	}
 }
 
 // GetSpotOrder 获取现货订单
@@ -63,21 +89,28 @@ func (b *binanceExchange) GetSpotOrder(ctx context.Context, symbol string, order
 	if err != nil {
 		return nil, fmt.Errorf("无效的订单ID: %w", err)
 	}
-	resp, err := b.client.NewGetOrderService().
-		Symbol(symbol).
-		OrderID(orderIDInt).
-		Do(ctx)
+	return b.getSpotOrder(ctx, symbol, b.getClient(ctx).NewGetOrderService().Symbol(symbol).OrderID(orderIDInt))
+}
+
+// GetSpotOrderByClientID 按客户端订单ID获取现货订单
+func (b *binanceExchange) GetSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return b.getSpotOrder(ctx, symbol, b.getClient(ctx).NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID))
+}
+
+// getSpotOrder 查询现货订单，并根据成交记录计算扣除手续费后的实际数量
+func (b *binanceExchange) getSpotOrder(ctx context.Context, symbol string, service *binance.GetOrderService) (*exchange.Order, error) {
+	resp, err := service.Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("binance spot get order failed: %w", err)
+		return nil, fmt.Errorf("binance spot get order failed: %w", toAPIError(err))
 	}
 
 	// 获取订单的成交记录（包含手续费信息）
-	trades, err := b.client.NewListTradesService().
+	trades, err := b.getClient(ctx).NewListTradesService().
 		Symbol(symbol).
-		OrderId(orderIDInt).
+		OrderId(resp.OrderID).
 		Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("binance spot get order trades failed: %w", err)
+		return nil, fmt.Errorf("binance spot get order trades failed: %w", toAPIError(err))
 	}
 
 	// 获取交易对规格，用于计算实际数量
@@ -88,54 +121,61 @@ func (b *binanceExchange) GetSpotOrder(ctx context.Context, symbol string, order
 
 	var actualQty string
 	side := exchange.OrderSide(resp.Side)
-	totalCommission := new(big.Float).SetPrec(64)
+	totalCommission := exchange.Decimal{}
 
 	// 将成交记录转换为 Fill
 	for _, trade := range trades {
 		// 累计手续费
-		commission := new(big.Float).SetPrec(64)
-		if _, ok := commission.SetString(trade.Commission); !ok {
+		commission, err := exchange.ParseDecimal(trade.Commission)
+		if err != nil {
 			return nil, fmt.Errorf("无效的手续费: %s", trade.Commission)
 		}
 
 		if side == exchange.OrderSideBuy && trade.CommissionAsset == spec.BaseAsset {
-			totalCommission = totalCommission.Add(totalCommission, commission)
+			totalCommission = totalCommission.Add(commission)
 		}
 
 		if side == exchange.OrderSideSell && trade.CommissionAsset == spec.QuoteAsset {
-			totalCommission = totalCommission.Add(totalCommission, commission)
+			totalCommission = totalCommission.Add(commission)
 		}
 	}
 
 	if side == exchange.OrderSideBuy {
-		bigActualQty := new(big.Float).SetPrec(64)
-		if _, ok := bigActualQty.SetString(resp.ExecutedQuantity); !ok {
+		executedQty, err := exchange.ParseDecimal(resp.ExecutedQuantity)
+		if err != nil {
 			return nil, fmt.Errorf("无效的数量: %s", resp.ExecutedQuantity)
 		}
-		actualQty = bigActualQty.Sub(bigActualQty, totalCommission).Text('f', spec.BasePrecision)
+		actualQty = executedQty.Sub(totalCommission).StringFixed(int32(spec.BasePrecision))
 	} else {
-		bigActualQty := new(big.Float).SetPrec(64)
-		if _, ok := bigActualQty.SetString(resp.CummulativeQuoteQuantity); !ok {
+		quoteQty, err := exchange.ParseDecimal(resp.CummulativeQuoteQuantity)
+		if err != nil {
 			return nil, fmt.Errorf("无效的成交金额: %s", resp.CummulativeQuoteQuantity)
 		}
-		actualQty = bigActualQty.Sub(bigActualQty, totalCommission).Text('f', spec.QuotePrecision)
+		actualQty = quoteQty.Sub(totalCommission).StringFixed(int32(spec.QuotePrecision))
 	}
 
+	order := toSpotOrder(resp)
+	order.ActualQty = actualQty
+	return order, nil
+}
+
+// toSpotOrder 转换现货订单，不计算实际数量
+func toSpotOrder(resp *binance.Order) *exchange.Order {
 	return &exchange.Order{
 		OrderID:       strconv.FormatInt(resp.OrderID, 10),
+		ClientOrderID: resp.ClientOrderID,
 		Symbol:        resp.Symbol,
 		Side:          exchange.OrderSide(resp.Side),
-		Type:          exchange.OrderType(resp.Type),
+		Type:          toOrderType(string(resp.Type)),
 		Status:        exchange.OrderStatus(string(resp.Status)),
 		Price:         resp.Price,
 		Quantity:      resp.OrigQuantity,
 		ExecutedQty:   resp.ExecutedQuantity,
-		ActualQty:     actualQty,
 		QuoteQuantity: resp.CummulativeQuoteQuantity,
 		TimeInForce:   exchange.OrderTimeInForce(resp.TimeInForce),
 		CreateTime:    resp.UpdateTime,
 		UpdateTime:    resp.UpdateTime,
-	}, nil
+	}
 }
 
 // CancelSpotOrder 撤销现货订单
@@ -144,19 +184,27 @@ func (b *binanceExchange) CancelSpotOrder(ctx context.Context, symbol string, or
 	if err != nil {
 		return nil, fmt.Errorf("无效的订单ID: %w", err)
 	}
-	resp, err := b.client.NewCancelOrderService().
-		Symbol(symbol).
-		OrderID(orderIDInt).
-		Do(ctx)
+	return cancelSpotOrder(ctx, b.getClient(ctx).NewCancelOrderService().Symbol(symbol).OrderID(orderIDInt))
+}
+
+// CancelSpotOrderByClientID 按客户端订单ID撤销现货订单
+func (b *binanceExchange) CancelSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return cancelSpotOrder(ctx, b.getClient(ctx).NewCancelOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID))
+}
+
+// cancelSpotOrder 执行现货撤单
+func cancelSpotOrder(ctx context.Context, service *binance.CancelOrderService) (*exchange.Order, error) {
+	resp, err := service.Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("binance spot cancel order failed: %w", err)
+		return nil, fmt.Errorf("binance spot cancel order failed: %w", toAPIError(err))
 	}
 
 	return &exchange.Order{
-		OrderID:       orderID,
+		OrderID:       strconv.FormatInt(resp.OrderID, 10),
+		ClientOrderID: resp.OrigClientOrderID,
 		Symbol:        resp.Symbol,
 		Side:          exchange.OrderSide(resp.Side),
-		Type:          exchange.OrderType(resp.Type),
+		Type:          toOrderType(string(resp.Type)),
 		Status:        exchange.OrderStatus(string(resp.Status)),
 		Price:         resp.Price,
 		Quantity:      resp.OrigQuantity,
@@ -173,41 +221,20 @@ func (b *binanceExchange) getSpotSymbolSpec(ctx context.Context, symbol string)
 	var spec *symbolSpec
 
 	// 从缓存中获取交易对规格
-	spec, _ = binanceSpotSpec.GetSymbolSpec(symbol)
+	spec, _ = b.getSpotSpec(ctx).GetSymbolSpec(symbol)
 
 	// 如果缓存中没有，则获取最新交易对规格
 	if spec == nil {
-		info, err := b.client.NewExchangeInfoService().Symbol(symbol).Do(ctx)
+		info, err := b.getClient(ctx).NewExchangeInfoService().Symbol(symbol).Do(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("获取交易规则失败: %w", err)
+			return nil, fmt.Errorf("获取交易规则失败: %w", toAPIError(err))
 		}
 
-		symbolInfo := info.Symbols[0]
-		spec = &symbolSpec{
-			Symbol:         symbol,
-			BaseAsset:      symbolInfo.BaseAsset,
-			QuoteAsset:     symbolInfo.QuoteAsset,
-			BasePrecision:  symbolInfo.BaseAssetPrecision,
-			QuotePrecision: symbolInfo.QuoteAssetPrecision,
-			Status:         symbolInfo.Status,
+		if len(info.Symbols) == 0 {
+			return nil, fmt.Errorf("交易对规格不存在: %s", symbol)
 		}
-		for _, f := range symbolInfo.Filters {
-			if f["filterType"].(string) == "PRICE_FILTER" {
-				spec.MinPrice = f["minPrice"].(string)
-				spec.MaxPrice = f["maxPrice"].(string)
-				spec.TickSize = f["tickSize"].(string)
-			}
-			if f["filterType"].(string) == "LOT_SIZE" {
-				spec.MinQty = f["minQty"].(string)
-				spec.MaxQty = f["maxQty"].(string)
-				spec.StepSize = f["stepSize"].(string)
-			}
-			if f["filterType"].(string) == "MIN_NOTIONAL" {
-				spec.MinNotional = f["minNotional"].(string)
-			}
-		}
-
-		binanceSpotSpec.SetSymbolSpec(symbol, spec)
+		spec = toSpotSymbolSpec(&info.Symbols[0])
+		b.getSpotSpec(ctx).SetSymbolSpec(symbol, spec)
 	}
 
 	if spec == nil {
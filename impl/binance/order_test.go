@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -30,7 +31,7 @@ func TestSpotCreateOrder(t *testing.T) {
 		}
 		*lastPrice = tickers.GetTicker(*symbol).LastPrice
 	}
-	quantity := utils.AmountWithPriceToQuantity(*amount, *lastPrice, 8)
+	quantity := utils.AmountWithPriceToQuantity(strconv.FormatFloat(*amount, 'f', -1, 64), *lastPrice, 8)
 
 	// 如果方向不存在, 则默认买入
 	*side = strings.ToUpper(*side)
@@ -89,7 +90,7 @@ func TestFuturesCreateOrder(t *testing.T) {
 	}
 
 	*amount = *amount * float64(*leverage) // 数量乘以杠杆
-	quantity := utils.AmountWithPriceToQuantity(*amount, *lastPrice, 8)
+	quantity := utils.AmountWithPriceToQuantity(strconv.FormatFloat(*amount, 'f', -1, 64), *lastPrice, 8)
 
 	// 设置杠杆倍数
 	err := binanceExchange.SetFuturesLeverage(ctx, *symbol, *leverage)
@@ -0,0 +1,67 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// GetSpotOrderBook 获取现货深度快照
+func (b *binanceExchange) GetSpotOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	service := b.getClient(ctx).NewDepthService().Symbol(symbol)
+	if limit > 0 {
+		service = service.Limit(limit)
+	}
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance get order book: %w", toAPIError(err))
+	}
+
+	// 现货深度接口不返回时间，使用本地接收时间
+	orderBook := &exchange.OrderBook{
+		Symbol:       symbol,
+		Bids:         toOrderBookLevels(resp.Bids),
+		Asks:         toOrderBookLevels(resp.Asks),
+		LastUpdateID: resp.LastUpdateID,
+		Time:         time.Now().UnixMilli(),
+	}
+	orderBook.Sort()
+	return orderBook, nil
+}
+
+// GetFuturesOrderBook 获取合约深度快照
+func (b *binanceExchange) GetFuturesOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	service := b.getFuturesClient(ctx).NewDepthService().Symbol(symbol)
+	if limit > 0 {
+		service = service.Limit(limit)
+	}
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance futures get order book: %w", toAPIError(err))
+	}
+
+	orderBook := &exchange.OrderBook{
+		Symbol:       symbol,
+		Bids:         toOrderBookLevels(resp.Bids),
+		Asks:         toOrderBookLevels(resp.Asks),
+		LastUpdateID: resp.LastUpdateID,
+		Time:         resp.Time,
+	}
+	orderBook.Sort()
+	return orderBook, nil
+}
+
+// toOrderBookLevels 转换深度档位
+func toOrderBookLevels(levels []common.PriceLevel) []exchange.OrderBookLevel {
+	res := make([]exchange.OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		res = append(res, exchange.OrderBookLevel{
+			Price:    level.Price,
+			Quantity: level.Quantity,
+		})
+	}
+	return res
+}
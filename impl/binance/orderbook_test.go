@@ -0,0 +1,34 @@
+package binance
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+)
+
+// TestGetSpotOrderBook 获取现货深度快照
+// go test -v ./impl/binance -run "^TestGetSpotOrderBook$" -args --symbol=BTCUSDT
+func TestGetSpotOrderBook(t *testing.T) {
+	flag.Parse()
+
+	binanceExchange := NewBinance("", "")
+	orderBook, err := binanceExchange.GetSpotOrderBook(context.Background(), *symbol, 10)
+	if err != nil {
+		t.Fatalf("获取现货深度失败: %v", err)
+	}
+	fmt.Println("orderBook", orderBook.LastUpdateID, orderBook.BestBid(), orderBook.BestAsk())
+}
+
+// TestGetFuturesOrderBook 获取合约深度快照
+// go test -v ./impl/binance -run "^TestGetFuturesOrderBook$" -args --symbol=BTCUSDT
+func TestGetFuturesOrderBook(t *testing.T) {
+	flag.Parse()
+
+	binanceExchange := NewBinance("", "")
+	orderBook, err := binanceExchange.GetFuturesOrderBook(context.Background(), *symbol, 10)
+	if err != nil {
+		t.Fatalf("获取合约深度失败: %v", err)
+	}
+	fmt.Println("orderBook", orderBook.LastUpdateID, orderBook.BestBid(), orderBook.BestAsk())
+}
@@ -0,0 +1,226 @@
+package binance
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/ratelimit"
+)
+
+const (
+	SpotRequestWeightLimit    = 6000   // 现货每分钟请求权重上限
+	SpotOrderLimit10s         = 100    // 现货每 10 秒下单数上限
+	SpotOrderLimitDay         = 200000 // 现货每日下单数上限
+	FuturesRequestWeightLimit = 2400   // 合约每分钟请求权重上限
+	FuturesOrderLimit10s      = 300    // 合约每 10 秒下单数上限
+	FuturesOrderLimitMinute   = 1200   // 合约每分钟下单数上限
+)
+
+// spotWeights 现货接口固定权重，未列出的接口权重为 1
+var spotWeights = map[string]int{
+	"/api/v3/exchangeInfo":     20,
+	"/api/v3/klines":           2,
+	"/api/v3/trades":           25,
+	"/api/v3/historicalTrades": 25,
+	"/api/v3/aggTrades":        4,
+	"/api/v3/account":          20,
+	"/api/v3/myTrades":         20,
+	"/api/v3/allOrders":        20,
+	"/api/v3/userDataStream":   2,
+}
+
+// futuresWeights 合约接口固定权重，未列出的接口权重为 1
+var futuresWeights = map[string]int{
+	"/fapi/v1/trades":           5,
+	"/fapi/v1/historicalTrades": 20,
+	"/fapi/v1/aggTrades":        20,
+	"/fapi/v2/account":          5,
+	"/fapi/v3/account":          5,
+	"/fapi/v2/balance":          5,
+	"/fapi/v2/positionRisk":     5,
+	"/fapi/v3/positionRisk":     5,
+	"/fapi/v1/allOrders":        5,
+	"/fapi/v1/userTrades":       5,
+	"/fapi/v1/income":           30,
+	"/fapi/v1/batchOrders":      5,
+}
+
+// NewRateLimiter 创建 Binance 限频器，按接口权重与下单数计数，并根据 X-MBX-USED-WEIGHT-* 与 X-MBX-ORDER-COUNT-* 响应头校准。
+// 计数按接口域名区分现货、合约与测试网，同一 API Key 的多个实例应共用同一个限频器
+func NewRateLimiter(policy exchange.RateLimitPolicy) exchange.RateLimiter {
+	return ratelimit.New(policy, rateLimitModel{})
+}
+
+// rateLimitModel Binance 限频模型
+type rateLimitModel struct{}
+
+// Costs 请求消耗的权重与下单数
+func (rateLimitModel) Costs(req *http.Request) []ratelimit.Cost {
+	rules := rateLimitRules(req.URL)
+	costs := []ratelimit.Cost{{Rule: rules.weight, Weight: requestWeight(req.Method, req.URL)}}
+	if isOrderRequest(req.Method, req.URL.Path) {
+		for _, rule := range rules.orders {
+			costs = append(costs, ratelimit.Cost{Rule: rule, Weight: 1})
+		}
+	}
+	return costs
+}
+
+// Sync 根据响应头同步已用权重与下单数
+func (rateLimitModel) Sync(limiter *ratelimit.Limiter, req *http.Request, resp *http.Response) {
+	rules := rateLimitRules(req.URL)
+	if used, err := strconv.Atoi(resp.Header.Get("X-MBX-USED-WEIGHT-1M")); err == nil {
+		limiter.Sync(rules.weight, used)
+	}
+	for header, rule := range rules.orders {
+		if used, err := strconv.Atoi(resp.Header.Get(header)); err == nil {
+			limiter.Sync(rule, used)
+		}
+	}
+}
+
+// limitRules 接口所属的限频规则
+type limitRules struct {
+	weight ratelimit.Rule
+	orders map[string]ratelimit.Rule // 按对应的下单数响应头索引
+}
+
+// rateLimitRules 按接口域名与路径获取限频规则
+func rateLimitRules(u *url.URL) limitRules {
+	key := u.Host
+	if isFuturesPath(u.Path) {
+		return limitRules{
+			weight: ratelimit.Rule{Key: key + ":futures:weight", Limit: FuturesRequestWeightLimit, Interval: time.Minute},
+			orders: map[string]ratelimit.Rule{
+				"X-MBX-ORDER-COUNT-10S": {Key: key + ":futures:orders:10s", Limit: FuturesOrderLimit10s, Interval: 10 * time.Second},
+				"X-MBX-ORDER-COUNT-1M":  {Key: key + ":futures:orders:1m", Limit: FuturesOrderLimitMinute, Interval: time.Minute},
+			},
+		}
+	}
+	return limitRules{
+		weight: ratelimit.Rule{Key: key + ":spot:weight", Limit: SpotRequestWeightLimit, Interval: time.Minute},
+		orders: map[string]ratelimit.Rule{
+			"X-MBX-ORDER-COUNT-10S": {Key: key + ":spot:orders:10s", Limit: SpotOrderLimit10s, Interval: 10 * time.Second},
+			"X-MBX-ORDER-COUNT-1D":  {Key: key + ":spot:orders:1d", Limit: SpotOrderLimitDay, Interval: 24 * time.Hour},
+		},
+	}
+}
+
+// isFuturesPath 是否合约接口
+func isFuturesPath(path string) bool {
+	return strings.HasPrefix(path, "/fapi/")
+}
+
+// isOrderRequest 是否计入下单数的请求
+func isOrderRequest(method, path string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+	switch path {
+	case "/api/v3/order", "/api/v3/order/oco", "/api/v3/orderList/oco", "/api/v3/order/cancelReplace",
+		"/fapi/v1/order", "/fapi/v1/batchOrders":
+		return true
+	}
+	return false
+}
+
+// requestWeight 请求权重，部分接口权重随参数变化
+func requestWeight(method string, u *url.URL) int {
+	query := u.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	hasSymbol := query.Get("symbol") != "" || query.Get("symbols") != ""
+
+	if isFuturesPath(u.Path) {
+		switch u.Path {
+		case "/fapi/v1/depth":
+			return futuresDepthWeight(limit)
+		case "/fapi/v1/klines":
+			return futuresKlinesWeight(limit)
+		case "/fapi/v1/ticker/24hr":
+			return selectWeight(hasSymbol, 1, 40)
+		case "/fapi/v1/openOrders":
+			return selectWeight(hasSymbol, 1, 40)
+		case "/fapi/v1/premiumIndex":
+			return selectWeight(hasSymbol, 1, 10)
+		}
+		if weight, ok := futuresWeights[u.Path]; ok {
+			return weight
+		}
+		return 1
+	}
+
+	switch u.Path {
+	case "/api/v3/depth":
+		return spotDepthWeight(limit)
+	case "/api/v3/ticker/24hr":
+		return selectWeight(hasSymbol, 2, 80)
+	case "/api/v3/openOrders":
+		return selectWeight(hasSymbol, 6, 80)
+	case "/api/v3/order":
+		if method == http.MethodGet {
+			return 4
+		}
+	}
+	if weight, ok := spotWeights[u.Path]; ok {
+		return weight
+	}
+	return 1
+}
+
+// selectWeight 按是否指定交易对选择权重
+func selectWeight(hasSymbol bool, symbolWeight, allWeight int) int {
+	if hasSymbol {
+		return symbolWeight
+	}
+	return allWeight
+}
+
+// spotDepthWeight 现货深度权重，limit 默认 100
+func spotDepthWeight(limit int) int {
+	switch {
+	case limit <= 100:
+		return 5
+	case limit <= 500:
+		return 25
+	case limit <= 1000:
+		return 50
+	default:
+		return 250
+	}
+}
+
+// futuresDepthWeight 合约深度权重，limit 默认 500
+func futuresDepthWeight(limit int) int {
+	switch {
+	case limit == 0:
+		return 10
+	case limit <= 50:
+		return 2
+	case limit <= 100:
+		return 5
+	case limit <= 500:
+		return 10
+	default:
+		return 20
+	}
+}
+
+// futuresKlinesWeight 合约K线权重，limit 默认 500
+func futuresKlinesWeight(limit int) int {
+	switch {
+	case limit == 0:
+		return 5
+	case limit < 100:
+		return 1
+	case limit < 500:
+		return 2
+	case limit <= 1000:
+		return 5
+	default:
+		return 10
+	}
+}
@@ -0,0 +1,87 @@
+package binance
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/ratelimit"
+)
+
+// TestRequestWeight 按接口与参数计算请求权重
+// go test -v ./impl/binance -run "^TestRequestWeight$"
+func TestRequestWeight(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		weight int
+	}{
+		{http.MethodGet, "/api/v3/depth?symbol=BTCUSDT", 5},
+		{http.MethodGet, "/api/v3/depth?symbol=BTCUSDT&limit=1000", 50},
+		{http.MethodGet, "/api/v3/depth?symbol=BTCUSDT&limit=5000", 250},
+		{http.MethodGet, "/api/v3/ticker/24hr?symbol=BTCUSDT", 2},
+		{http.MethodGet, "/api/v3/ticker/24hr", 80},
+		{http.MethodGet, "/api/v3/exchangeInfo", 20},
+		{http.MethodGet, "/api/v3/order?symbol=BTCUSDT&orderId=1", 4},
+		{http.MethodPost, "/api/v3/order", 1},
+		{http.MethodGet, "/api/v3/openOrders", 80},
+		{http.MethodGet, "/api/v3/ping", 1},
+		{http.MethodGet, "/fapi/v1/depth?symbol=BTCUSDT", 10},
+		{http.MethodGet, "/fapi/v1/depth?symbol=BTCUSDT&limit=20", 2},
+		{http.MethodGet, "/fapi/v1/klines?symbol=BTCUSDT&limit=1500", 10},
+		{http.MethodGet, "/fapi/v1/ticker/24hr", 40},
+		{http.MethodGet, "/fapi/v1/exchangeInfo", 1},
+		{http.MethodGet, "/fapi/v2/positionRisk", 5},
+		{http.MethodGet, "/fapi/v1/premiumIndex", 10},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse("https://api.binance.com" + tt.path)
+		if weight := requestWeight(tt.method, u); weight != tt.weight {
+			t.Errorf("%s %s 权重应为 %d: %d", tt.method, tt.path, tt.weight, weight)
+		}
+	}
+}
+
+// TestRateLimiterSync 下单计入下单数，按 X-MBX-USED-WEIGHT-1M 响应头校准已用权重，现货与合约分开计数
+// go test -v ./impl/binance -run "^TestRateLimiterSync$"
+func TestRateLimiterSync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/exchangeInfo" {
+			w.Header().Set("X-MBX-USED-WEIGHT-1M", "6000")
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(exchange.RateLimitPolicyFailFast)
+	client := ratelimit.WrapClient(nil, limiter)
+	get := func(method, path string) error {
+		req, _ := http.NewRequest(method, server.URL+path, nil)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(http.MethodPost, "/api/v3/order"); err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	u, _ := url.Parse(server.URL + "/api/v3/order")
+	if used := limiter.(*ratelimit.Limiter).Used(rateLimitRules(u).orders["X-MBX-ORDER-COUNT-10S"]); used != 1 {
+		t.Errorf("下单应计入下单数: %d", used)
+	}
+
+	if err := get(http.MethodGet, "/api/v3/exchangeInfo"); err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	if err := get(http.MethodGet, "/api/v3/ping"); !errors.Is(err, exchange.ErrRateLimited) {
+		t.Fatalf("权重用尽后应返回 ErrRateLimited: %v", err)
+	}
+	if err := get(http.MethodGet, "/fapi/v1/ping"); err != nil {
+		t.Fatalf("合约权重应单独计数: %v", err)
+	}
+}
@@ -5,11 +5,17 @@ import (
 	"time"
 )
 
+const (
+	SpecRetryInterval = time.Minute // 缓存未命中时全量刷新交易对规格的最小间隔
+)
+
 // exchangeSpec 交易所规格
 type exchangeSpec struct {
 	Symbols []*symbolSpec `json:"symbols"`
 	sync.RWMutex
-	UpdateTime time.Time // 更新时间
+	UpdateTime time.Time  // 更新时间
+	LoadTime   time.Time  // 最近一次全量加载时间
+	loadMux    sync.Mutex // 全量加载锁，避免并发重复拉取
 }
 
 // SymbolSpec 交易对规格
@@ -27,21 +33,23 @@ type symbolSpec struct {
 	TickSize       string // 最小价格变动
 	MinNotional    string // 最小交易金额
 	Status         string // 状态
+	MarginAsset    string // 保证金资产，仅合约
+	DeliveryDate   int64  // 交割时间，毫秒，仅合约
 }
 
 // SetSymbolSpec 设置交易对规格
 func (e *exchangeSpec) SetSymbolSpec(symbol string, spec *symbolSpec) error {
-	symbolSpec, index := e.GetSymbolSpec(symbol)
-	if symbolSpec == nil {
-		e.Symbols = append(e.Symbols, spec)
-		return nil
-	}
-
 	e.Lock()
 	defer e.Unlock()
 
 	// 更新符号规格
-	e.Symbols[index] = spec
+	for i, s := range e.Symbols {
+		if s.Symbol == symbol {
+			e.Symbols[i] = spec
+			return nil
+		}
+	}
+	e.Symbols = append(e.Symbols, spec)
 	return nil
 }
 
@@ -59,9 +67,24 @@ func (e *exchangeSpec) GetSymbolSpec(symbol string) (*symbolSpec, int) {
 
 // DeleteSymbolsSpec 删除所有交易对规格
 func (e *exchangeSpec) DeleteSymbolsSpec() {
-	e.RLock()
-	defer e.RUnlock()
+	e.Lock()
+	defer e.Unlock()
 
 	e.Symbols = make([]*symbolSpec, 0)
 	e.UpdateTime = time.Now()
+	e.LoadTime = time.Time{}
+}
+
+// SetLoaded 记录全量加载时间
+func (e *exchangeSpec) SetLoaded() {
+	e.Lock()
+	defer e.Unlock()
+	e.LoadTime = time.Now()
+}
+
+// LoadedWithin 是否在 interval 内全量加载过
+func (e *exchangeSpec) LoadedWithin(interval time.Duration) bool {
+	e.RLock()
+	defer e.RUnlock()
+	return !e.LoadTime.IsZero() && time.Since(e.LoadTime) < interval
 }
@@ -0,0 +1,46 @@
+package binance
+
+import (
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// quoteAssets 常见计价资产，用于拆分不在交易对列表中的原生交易对，长的优先匹配
+var quoteAssets = []string{"FDUSD", "USDT", "USDC", "BUSD", "TUSD", "BTC", "ETH", "BNB", "EUR", "TRY", "BRL", "JPY"}
+
+// symbolFormatter Binance 交易对命名规则，现货与永续合约为 BTCUSDT，交割合约为 BTCUSDT_251226
+type symbolFormatter struct{}
+
+// Native 统一交易对转换为原生交易对
+func (symbolFormatter) Native(market exchange.Market, symbol exchange.Symbol) string {
+	if symbol.Kind == exchange.SymbolKindFutures {
+		return symbol.Base + symbol.Quote + "_" + symbol.Expiry
+	}
+	return symbol.Base + symbol.Quote
+}
+
+// Canonical 原生交易对转换为统一交易对，按常见计价资产拆分，合约结算资产与计价资产相同
+func (symbolFormatter) Canonical(market exchange.Market, native string) (exchange.Symbol, bool) {
+	pair, expiry, isFutures := strings.Cut(strings.ToUpper(native), "_")
+	for _, quote := range quoteAssets {
+		base, ok := strings.CutSuffix(pair, quote)
+		if !ok || base == "" {
+			continue
+		}
+		return toSymbol(market, base, quote, quote, expiry, isFutures), true
+	}
+	return exchange.Symbol{}, false
+}
+
+// toSymbol 创建统一交易对，合约交易对带有交割日期后缀时为交割合约
+func toSymbol(market exchange.Market, base, quote, settle, expiry string, isFutures bool) exchange.Symbol {
+	switch {
+	case market == exchange.MarketSpot:
+		return exchange.NewSpotSymbol(base, quote)
+	case isFutures && expiry != "":
+		return exchange.NewFuturesSymbol(base, quote, settle, expiry)
+	default:
+		return exchange.NewPerpetualSymbol(base, quote, settle)
+	}
+}
@@ -9,9 +9,9 @@ import (
 
 // GetSymbolTickers 获取交易对行情
 func (b *binanceExchange) GetSpotSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
-	resp, err := b.client.NewListPriceChangeStatsService().Symbols(symbols).Do(ctx)
+	resp, err := b.getClient(ctx).NewListPriceChangeStatsService().Symbols(symbols).Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("binance get ticker: %w", err)
+		return nil, fmt.Errorf("binance get ticker: %w", toAPIError(err))
 	}
 	var res []*exchange.Ticker
 	for _, ticker := range resp {
@@ -39,9 +39,9 @@ func (b *binanceExchange) GetSpotSymbolTickers(ctx context.Context, symbols ...s
 func (b *binanceExchange) GetFuturesSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
 	var res []*exchange.Ticker
 	for _, symbol := range symbols {
-		resp, err := b.futuresClient.NewListPriceChangeStatsService().Symbol(symbol).Do(ctx)
+		resp, err := b.getFuturesClient(ctx).NewListPriceChangeStatsService().Symbol(symbol).Do(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("binance futures get ticker: %w", err)
+			return nil, fmt.Errorf("binance futures get ticker: %w", toAPIError(err))
 		}
 
 		for _, t := range resp {
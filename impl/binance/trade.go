@@ -0,0 +1,161 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/utils"
+)
+
+const (
+	AggTradesPageSize = 1000           // 归集成交单页最大数量
+	AggTradesWindow   = 60 * 60 * 1000 // 按时间查询归集成交的最大区间，毫秒
+)
+
+// aggTradesFetcher 查询归集成交，fromID 大于 0 时按ID分页，否则按 [startTime, endTime] 查询
+type aggTradesFetcher func(fromID, startTime, endTime int64) ([]*exchange.Trade, error)
+
+// GetSpotRecentTrades 获取现货最近成交
+func (b *binanceExchange) GetSpotRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	service := b.getClient(ctx).NewRecentTradesService().Symbol(symbol)
+	if limit > 0 {
+		service = service.Limit(limit)
+	}
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance get recent trades: %w", toAPIError(err))
+	}
+	trades := make([]*exchange.Trade, 0, len(resp))
+	for _, t := range resp {
+		trades = append(trades, toTrade(symbol, t.ID, t.Price, t.Quantity, t.IsBuyerMaker, t.Time))
+	}
+	return trades, nil
+}
+
+// GetFuturesRecentTrades 获取合约最近成交
+func (b *binanceExchange) GetFuturesRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	service := b.getFuturesClient(ctx).NewRecentTradesService().Symbol(symbol)
+	if limit > 0 {
+		service = service.Limit(limit)
+	}
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance futures get recent trades: %w", toAPIError(err))
+	}
+	trades := make([]*exchange.Trade, 0, len(resp))
+	for _, t := range resp {
+		trades = append(trades, toTrade(symbol, t.ID, t.Price, t.Quantity, t.IsBuyerMaker, t.Time))
+	}
+	return trades, nil
+}
+
+// GetSpotAggTrades 获取现货归集成交
+func (b *binanceExchange) GetSpotAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	return getAggTrades(func(fromID, startTime, endTime int64) ([]*exchange.Trade, error) {
+		service := b.getClient(ctx).NewAggTradesService().Symbol(symbol).Limit(AggTradesPageSize)
+		if fromID > 0 {
+			service = service.FromID(fromID)
+		} else {
+			service = service.StartTime(startTime).EndTime(endTime)
+		}
+		resp, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("binance get agg trades: %w", toAPIError(err))
+		}
+		trades := make([]*exchange.Trade, 0, len(resp))
+		for _, t := range resp {
+			trades = append(trades, toTrade(symbol, t.AggTradeID, t.Price, t.Quantity, t.IsBuyerMaker, t.Timestamp))
+		}
+		return trades, nil
+	}, start, end, limit)
+}
+
+// GetFuturesAggTrades 获取合约归集成交
+func (b *binanceExchange) GetFuturesAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	return getAggTrades(func(fromID, startTime, endTime int64) ([]*exchange.Trade, error) {
+		service := b.getFuturesClient(ctx).NewAggTradesService().Symbol(symbol).Limit(AggTradesPageSize)
+		if fromID > 0 {
+			service = service.FromID(fromID)
+		} else {
+			service = service.StartTime(startTime).EndTime(endTime)
+		}
+		resp, err := service.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("binance futures get agg trades: %w", toAPIError(err))
+		}
+		trades := make([]*exchange.Trade, 0, len(resp))
+		for _, t := range resp {
+			trades = append(trades, toTrade(symbol, t.AggTradeID, t.Price, t.Quantity, t.IsBuyerMaker, t.Timestamp))
+		}
+		return trades, nil
+	}, start, end, limit)
+}
+
+// getAggTrades 分页查询归集成交
+// 按时间查询的区间不能超过 1 小时，先按小时窗口找到 start 之后的第一页，再按归集ID向后分页直到超过 end
+func getAggTrades(fetch aggTradesFetcher, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	if start.IsZero() {
+		return nil, fmt.Errorf("开始时间不能为空")
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	startMs, endMs := start.UnixMilli(), end.UnixMilli()
+	if endMs < startMs {
+		return nil, fmt.Errorf("结束时间不能早于开始时间")
+	}
+
+	var (
+		page []*exchange.Trade
+		err  error
+	)
+	for windowStart, i := startMs, 0; windowStart <= endMs && i < utils.MaxPages; windowStart, i = windowStart+AggTradesWindow, i+1 {
+		page, err = fetch(0, windowStart, min(windowStart+AggTradesWindow-1, endMs))
+		if err != nil {
+			return nil, err
+		}
+		if len(page) > 0 {
+			break
+		}
+	}
+
+	var trades []*exchange.Trade
+	for i := 0; len(page) > 0 && i < utils.MaxPages; i++ {
+		for _, t := range page {
+			if t.Time > endMs || (limit > 0 && len(trades) >= limit) {
+				return trades, nil
+			}
+			trades = append(trades, t)
+		}
+		if len(page) < AggTradesPageSize {
+			break
+		}
+		lastID, err := strconv.ParseInt(page[len(page)-1].ID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的归集成交ID: %s", page[len(page)-1].ID)
+		}
+		if page, err = fetch(lastID+1, 0, 0); err != nil {
+			return nil, err
+		}
+	}
+	return trades, nil
+}
+
+// toTrade 转换成交，买方为挂单方时主动成交方向为卖出
+func toTrade(symbol string, id int64, price, quantity string, isBuyerMaker bool, timestamp int64) *exchange.Trade {
+	side := exchange.OrderSideBuy
+	if isBuyerMaker {
+		side = exchange.OrderSideSell
+	}
+	return &exchange.Trade{
+		Symbol:   symbol,
+		ID:       strconv.FormatInt(id, 10),
+		Price:    price,
+		Quantity: quantity,
+		Side:     side,
+		Time:     timestamp,
+	}
+}
@@ -0,0 +1,97 @@
+package binance
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetSpotRecentTrades 获取现货最近成交
+// go test -v ./impl/binance -run "^TestGetSpotRecentTrades$" -args --symbol=BTCUSDT
+func TestGetSpotRecentTrades(t *testing.T) {
+	flag.Parse()
+
+	binanceExchange := NewBinance("", "")
+	trades, err := binanceExchange.GetSpotRecentTrades(context.Background(), *symbol, 10)
+	if err != nil {
+		t.Fatalf("获取现货最近成交失败: %v", err)
+	}
+	for _, trade := range trades {
+		fmt.Println("trade", trade)
+	}
+}
+
+// TestGetFuturesAggTrades 获取合约归集成交
+// go test -v ./impl/binance -run "^TestGetFuturesAggTrades$" -args --symbol=BTCUSDT
+func TestGetFuturesAggTrades(t *testing.T) {
+	flag.Parse()
+
+	binanceExchange := NewBinance("", "")
+	trades, err := binanceExchange.GetFuturesAggTrades(context.Background(), *symbol, time.Now().Add(-time.Hour), time.Time{}, 2500)
+	if err != nil {
+		t.Fatalf("获取合约归集成交失败: %v", err)
+	}
+	fmt.Println("trades", len(trades), trades[0], trades[len(trades)-1])
+}
+
+// TestGetAggTradesPagination 归集成交先按小时窗口定位，再按ID分页
+// go test -v ./impl/binance -run "^TestGetAggTradesPagination$"
+func TestGetAggTradesPagination(t *testing.T) {
+	// 从第 2 小时开始每秒一笔成交，ID 从 1 开始
+	const hour = int64(AggTradesWindow)
+	const count = 2500
+	var calls []string
+	fetch := func(fromID, startTime, endTime int64) ([]*exchange.Trade, error) {
+		calls = append(calls, fmt.Sprint(fromID, startTime, endTime))
+		var trades []*exchange.Trade
+		for id := int64(1); id <= count && len(trades) < AggTradesPageSize; id++ {
+			timestamp := 2*hour + id*1000
+			if (fromID > 0 && id >= fromID) || (fromID == 0 && timestamp >= startTime && timestamp <= endTime) {
+				trades = append(trades, &exchange.Trade{ID: strconv.FormatInt(id, 10), Time: timestamp})
+			}
+		}
+		return trades, nil
+	}
+
+	trades, err := getAggTrades(fetch, time.UnixMilli(1), time.UnixMilli(2*hour+2200*1000), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 2200 || trades[0].ID != "1" || trades[2199].ID != "2200" {
+		t.Fatalf("成交错误: %d", len(trades))
+	}
+	// 两个空窗口 + 一个有数据的窗口 + 两次ID分页
+	if len(calls) != 5 {
+		t.Fatalf("请求次数错误: %v", calls)
+	}
+
+	trades, err = getAggTrades(fetch, time.UnixMilli(2*hour), time.Time{}, 1500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1500 || trades[1499].ID != "1500" {
+		t.Fatalf("限制数量错误: %d", len(trades))
+	}
+
+	if _, err := getAggTrades(fetch, time.Time{}, time.Time{}, 0); err == nil {
+		t.Fatal("开始时间为空应返回错误")
+	}
+}
+
+// TestParseAggTradeEvent 解析归集成交事件
+// go test -v ./impl/binance -run "^TestParseAggTradeEvent$"
+func TestParseAggTradeEvent(t *testing.T) {
+	message := `{"e":"aggTrade","E":1672515782136,"s":"BNBBTC","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":1672515782136,"m":true,"M":true}`
+	trade, err := parseAggTradeEvent([]byte(message))
+	if err != nil {
+		t.Fatalf("解析归集成交失败: %v", err)
+	}
+	if trade.Symbol != "BNBBTC" || trade.ID != "12345" || trade.Price != "0.001" || trade.Quantity != "100" || trade.Side != exchange.OrderSideSell || trade.Time != 1672515782136 {
+		t.Errorf("成交错误: %+v", trade)
+	}
+}
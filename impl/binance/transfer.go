@@ -0,0 +1,109 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	TransferListLimit = 100 // 划转记录每页最大数量
+)
+
+// transferTypes 转出与转入账户对应的万向划转类型
+var transferTypes = map[[2]exchange.AccountType]binance.UserUniversalTransferType{
+	{exchange.AccountTypeSpot, exchange.AccountTypeFutures}:    binance.UserUniversalTransferTypeMainToUmFutures,
+	{exchange.AccountTypeFutures, exchange.AccountTypeSpot}:    binance.UserUniversalTransferTypeUmFuturesToMain,
+	{exchange.AccountTypeSpot, exchange.AccountTypeFunding}:    binance.UserUniversalTransferTypeMainToFunding,
+	{exchange.AccountTypeFunding, exchange.AccountTypeSpot}:    binance.UserUniversalTransferTypeFundingToMain,
+	{exchange.AccountTypeFunding, exchange.AccountTypeFutures}: binance.UserUniversalTransferTypeFundingToUmFutures,
+	{exchange.AccountTypeFutures, exchange.AccountTypeFunding}: binance.UserUniversalTransferTypeUmFuturesToFunding,
+}
+
+// Transfer 使用万向划转在现货、U 本位合约与资金账户之间划转
+func (b *binanceExchange) Transfer(ctx context.Context, asset, amount string, from, to exchange.AccountType) (string, error) {
+	if err := exchange.ValidateTransfer(asset, amount, from, to); err != nil {
+		return "", err
+	}
+	transferType, ok := transferTypes[[2]exchange.AccountType{from, to}]
+	if !ok {
+		return "", fmt.Errorf("%w: 不支持从 %s 划转到 %s", exchange.ErrInvalidTransfer, from, to)
+	}
+	resp, err := b.getClient(ctx).NewUserUniversalTransferService().
+		Type(transferType).
+		Asset(asset).
+		Amount(amount).
+		Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("资金划转失败: %w", toAPIError(err))
+	}
+	return strconv.FormatInt(resp.ID, 10), nil
+}
+
+// ListTransfers 按时间倒序分页查询万向划转记录，游标为页码。
+// 交易所按划转类型查询，必须同时指定转出与转入账户，币种在本地过滤
+func (b *binanceExchange) ListTransfers(ctx context.Context, query exchange.TransferQuery) (*exchange.TransferPage, error) {
+	transferType, ok := transferTypes[[2]exchange.AccountType{query.From, query.To}]
+	if !ok {
+		return nil, fmt.Errorf("%w: 查询划转记录必须指定不同的转出与转入账户", exchange.ErrInvalidTransfer)
+	}
+	limit := query.Limit
+	if limit <= 0 || limit > TransferListLimit {
+		limit = TransferListLimit
+	}
+	current := 1
+	if query.Cursor != "" {
+		n, err := strconv.Atoi(query.Cursor)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("无效的分页游标: %s", query.Cursor)
+		}
+		current = n
+	}
+
+	service := b.getClient(ctx).NewListUserUniversalTransferService().Type(transferType).Current(current).Size(limit)
+	if !query.StartTime.IsZero() {
+		service.StartTime(query.StartTime.UnixMilli())
+	}
+	if !query.EndTime.IsZero() {
+		service.EndTime(query.EndTime.UnixMilli())
+	}
+	resp, err := service.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取划转记录失败: %w", toAPIError(err))
+	}
+
+	page := &exchange.TransferPage{Transfers: make([]*exchange.Transfer, 0, len(resp.Results))}
+	for _, row := range resp.Results {
+		if query.Asset != "" && row.Asset != query.Asset {
+			continue
+		}
+		page.Transfers = append(page.Transfers, &exchange.Transfer{
+			ID:     strconv.FormatInt(row.TranId, 10),
+			Asset:  row.Asset,
+			Amount: row.Amount,
+			From:   query.From,
+			To:     query.To,
+			Status: toTransferStatus(row.Status),
+			Time:   row.Timestamp,
+		})
+	}
+	if len(resp.Results) >= limit {
+		page.NextCursor = strconv.Itoa(current + 1)
+	}
+	return page, nil
+}
+
+// toTransferStatus 转换划转状态
+func toTransferStatus(status binance.UserUniversalTransferStatusType) exchange.TransferStatus {
+	switch status {
+	case binance.UserUniversalTransferStatusTypeConfirmed:
+		return exchange.TransferStatusSuccess
+	case binance.UserUniversalTransferStatusTypeFailed:
+		return exchange.TransferStatusFailed
+	default:
+		return exchange.TransferStatusPending
+	}
+}
@@ -0,0 +1,79 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestTransfer 账户组合转换为万向划转类型，不支持的组合返回 ErrInvalidTransfer
+// go test -v ./impl/binance -run "^TestTransfer$"
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		from, to exchange.AccountType
+		want     string
+	}{
+		{exchange.AccountTypeSpot, exchange.AccountTypeFutures, "MAIN_UMFUTURE"},
+		{exchange.AccountTypeFutures, exchange.AccountTypeSpot, "UMFUTURE_MAIN"},
+		{exchange.AccountTypeSpot, exchange.AccountTypeFunding, "MAIN_FUNDING"},
+		{exchange.AccountTypeFunding, exchange.AccountTypeSpot, "FUNDING_MAIN"},
+		{exchange.AccountTypeFunding, exchange.AccountTypeFutures, "FUNDING_UMFUTURE"},
+		{exchange.AccountTypeFutures, exchange.AccountTypeFunding, "UMFUTURE_FUNDING"},
+	}
+	for _, tt := range tests {
+		b, ts := newTestBinance(t, map[string]string{"POST /sapi/v1/asset/transfer": `{"tranId":13526853623}`})
+		id, err := b.Transfer(context.Background(), "USDT", "10", tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("%s -> %s 划转失败: %v", tt.from, tt.to, err)
+		}
+		req := ts.findRequest("POST", "/sapi/v1/asset/transfer")
+		if id != "13526853623" || req.Params["type"] != tt.want || req.Params["asset"] != "USDT" || req.Params["amount"] != "10" {
+			t.Errorf("%s -> %s 划转参数错误: id=%s %+v", tt.from, tt.to, id, req.Params)
+		}
+	}
+
+	b, ts := newTestBinance(t, nil)
+	invalid := [][2]exchange.AccountType{
+		{exchange.AccountTypeSpot, exchange.AccountTypeSpot},
+		{exchange.AccountTypeSpot, "MARGIN"},
+	}
+	for _, pair := range invalid {
+		if _, err := b.Transfer(context.Background(), "USDT", "10", pair[0], pair[1]); !errors.Is(err, exchange.ErrInvalidTransfer) {
+			t.Errorf("%s -> %s 应返回 ErrInvalidTransfer: %v", pair[0], pair[1], err)
+		}
+	}
+	if _, err := b.ListTransfers(context.Background(), exchange.TransferQuery{From: exchange.AccountTypeSpot}); !errors.Is(err, exchange.ErrInvalidTransfer) {
+		t.Errorf("未指定转入账户应返回 ErrInvalidTransfer: %v", err)
+	}
+	if len(ts.requests) != 0 {
+		t.Errorf("参数无效时不应发送请求: %+v", ts.requests)
+	}
+}
+
+// TestListTransfers 按划转类型查询，币种在本地过滤，满页时游标为下一页页码
+// go test -v ./impl/binance -run "^TestListTransfers$"
+func TestListTransfers(t *testing.T) {
+	b, ts := newTestBinance(t, map[string]string{
+		"GET /sapi/v1/asset/transfer": `{"total":2,"rows":[{"asset":"USDT","amount":"10","type":"FUNDING_MAIN","status":"CONFIRMED","tranId":1,"timestamp":1700000000000},
+			{"asset":"BTC","amount":"0.1","type":"FUNDING_MAIN","status":"PENDING","tranId":2,"timestamp":1700000001000}]}`,
+	})
+	page, err := b.ListTransfers(context.Background(), exchange.TransferQuery{
+		From: exchange.AccountTypeFunding, To: exchange.AccountTypeSpot, Asset: "USDT", Limit: 2, Cursor: "3",
+	})
+	if err != nil {
+		t.Fatalf("获取划转记录失败: %v", err)
+	}
+	req := ts.findRequest("GET", "/sapi/v1/asset/transfer")
+	if req.Params["type"] != "FUNDING_MAIN" || req.Params["current"] != "3" || req.Params["size"] != "2" {
+		t.Errorf("查询参数错误: %+v", req.Params)
+	}
+	if len(page.Transfers) != 1 || page.NextCursor != "4" {
+		t.Fatalf("分页结果错误: %+v", page)
+	}
+	if tr := page.Transfers[0]; tr.ID != "1" || tr.From != exchange.AccountTypeFunding || tr.To != exchange.AccountTypeSpot ||
+		tr.Status != exchange.TransferStatusSuccess || tr.Time != 1700000000000 {
+		t.Errorf("划转记录错误: %+v", tr)
+	}
+}
@@ -1,9 +1,14 @@
 package binance
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 var binanceSpotSpec *exchangeSpec
 var binanceFuturesSpec *exchangeSpec
+var binanceSpotTestnetSpec *exchangeSpec
+var binanceFuturesTestnetSpec *exchangeSpec
 
 func init() {
 	binanceSpotSpec = &exchangeSpec{
@@ -14,6 +19,14 @@ func init() {
 		Symbols:    make([]*symbolSpec, 0),
 		UpdateTime: time.Now(),
 	}
+	binanceSpotTestnetSpec = &exchangeSpec{
+		Symbols:    make([]*symbolSpec, 0),
+		UpdateTime: time.Now(),
+	}
+	binanceFuturesTestnetSpec = &exchangeSpec{
+		Symbols:    make([]*symbolSpec, 0),
+		UpdateTime: time.Now(),
+	}
 
 	// 定时更新交易对规格
 	initSymbolsSpec()
@@ -23,6 +36,24 @@ func init() {
 func initSymbolsSpec() {
 	binanceSpotSpec.DeleteSymbolsSpec()
 	binanceFuturesSpec.DeleteSymbolsSpec()
+	binanceSpotTestnetSpec.DeleteSymbolsSpec()
+	binanceFuturesTestnetSpec.DeleteSymbolsSpec()
 
 	time.AfterFunc(time.Hour, initSymbolsSpec)
 }
+
+// getSpotSpec 获取请求对应环境的现货交易对规格缓存
+func (b *binanceExchange) getSpotSpec(ctx context.Context) *exchangeSpec {
+	if b.isTestnet(ctx) {
+		return binanceSpotTestnetSpec
+	}
+	return binanceSpotSpec
+}
+
+// getFuturesSpec 获取请求对应环境的合约交易对规格缓存
+func (b *binanceExchange) getFuturesSpec(ctx context.Context) *exchangeSpec {
+	if b.isTestnet(ctx) {
+		return binanceFuturesTestnetSpec
+	}
+	return binanceFuturesSpec
+}
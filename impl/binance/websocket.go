@@ -1,21 +1,35 @@
 package binance
 
 import (
-	"encoding/json"
+	"sync"
 
 	"github.com/so68/exchange-lib/exchange"
 	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/subscription"
+	"github.com/so68/exchange-lib/internal/symbolmap"
 )
 
 const (
 	SpotWebsocketURL    = "wss://stream.binance.com:9443/ws"
-	FuturesWebsocketURL = "wss://fstream.binance.com/ws/!ticker@arr"
+	FuturesWebsocketURL = "wss://fstream.binance.com/ws"
+
+	SpotTestnetWebsocketURL    = "wss://stream.testnet.binance.vision/ws" // 现货测试网 Websocket 地址
+	FuturesTestnetWebsocketURL = "wss://stream.binancefuture.com/ws"      // 合约测试网 Websocket 地址
+
+	SpotMaxStreams    = 1024 // 现货单个连接最大订阅数量
+	FuturesMaxStreams = 200  // 合约单个连接最大订阅数量
 )
 
 // binanceWebsocket Binance Websocket实例
 type binanceWebsocket struct {
-	spotWs    *client.Websocket
-	futuresWs *client.Websocket
+	spotURL    string
+	futuresURL string
+	opts       []exchange.Option
+	group      *client.Group // 全部连接的生命周期
+	mux        sync.Mutex
+
+	tickerPools    map[exchange.Channel]*subscription.Pool              // 行情订阅连接池
+	tickerHandlers map[exchange.Channel]exchange.WebsocketTickerHandler // 行情回调
 }
 
 // SubscribeParams 订阅参数
@@ -25,67 +39,60 @@ type SubscribeParams struct {
 	ID     int      `json:"id"`
 }
 
-// NewBinanceWebsocket 创建Binance Websocket实例
-func NewBinanceWebsocket() exchange.Websocket {
-	return &binanceWebsocket{}
+// NewBinanceWebsocket 创建Binance Websocket实例，交易对映射由公开接口的交易对列表驱动
+func NewBinanceWebsocket(opts ...exchange.Option) exchange.Websocket {
+	return symbolmap.WrapWebsocket(newBinanceWebsocket(opts...), newBinance("", "", opts...).ListInstruments, symbolFormatter{})
 }
 
-// StartListenSpotTickers 开始监听现货交易对行情
-func (b *binanceWebsocket) StartListenSpotTickers(handler exchange.WebsocketSpotTickerHandler) error {
-	b.spotWs = client.NewWebsocket(SpotWebsocketURL+"/!ticker@arr", func(message []byte) {
-		var event []*WsAllTickerEvent
-		err := json.Unmarshal(message, &event)
-		if err != nil {
-			return
-		}
-
-		for _, event := range event {
-			spotTicker := &exchange.Ticker{
-				Symbol:             event.Symbol,
-				PriceChange:        event.PriceChange,
-				PriceChangePercent: event.PriceChangePercent,
-				WeightedAvgPrice:   event.WeightedAvgPrice,
-				LastPrice:          event.LastPrice,
-				LastQty:            event.LastQty,
-				OpenPrice:          event.OpenPrice,
-				HighPrice:          event.HighPrice,
-				LowPrice:           event.LowPrice,
-				Volume:             event.TotalVolume,
-				QuoteVolume:        event.TotalQuoteVolume,
-				Count:              event.TradeCount,
-			}
-			handler(spotTicker)
-		}
-	})
-	return b.spotWs.Start()
+// newBinanceWebsocket 创建Binance Websocket实例
+func newBinanceWebsocket(opts ...exchange.Option) *binanceWebsocket {
+	options := exchange.NewOptions(opts...)
+
+	b := &binanceWebsocket{
+		spotURL:    SpotWebsocketURL,
+		futuresURL: FuturesWebsocketURL,
+		opts:       opts,
+		group:      client.NewGroup(),
+
+		tickerPools:    make(map[exchange.Channel]*subscription.Pool),
+		tickerHandlers: make(map[exchange.Channel]exchange.WebsocketTickerHandler),
+	}
+	if options.IsTestnet() {
+		b.spotURL = SpotTestnetWebsocketURL
+		b.futuresURL = FuturesTestnetWebsocketURL
+	}
+	if options.SpotWebsocketURL != "" {
+		b.spotURL = options.SpotWebsocketURL
+	}
+	if options.FuturesWebsocketURL != "" {
+		b.futuresURL = options.FuturesWebsocketURL
+	}
+	return b
 }
 
-// StartListenFuturesTickers 开始监听合约交易对行情
-func (b *binanceWebsocket) StartListenFuturesTickers(handler exchange.WebsocketFuturesTickerHandler) error {
-	b.futuresWs = client.NewWebsocket(FuturesWebsocketURL, func(message []byte) {
-		var event []*WsAllTickerEvent
-		err := json.Unmarshal(message, &event)
-		if err != nil {
-			return
-		}
-
-		for _, event := range event {
-			futuresTicker := &exchange.Ticker{
-				Symbol:             event.Symbol,
-				PriceChange:        event.PriceChange,
-				PriceChangePercent: event.PriceChangePercent,
-				WeightedAvgPrice:   event.WeightedAvgPrice,
-				LastPrice:          event.LastPrice,
-				LastQty:            event.LastQty,
-				OpenPrice:          event.OpenPrice,
-				HighPrice:          event.HighPrice,
-				LowPrice:           event.LowPrice,
-				Volume:             event.TotalVolume,
-				QuoteVolume:        event.TotalQuoteVolume,
-				Count:              event.TradeCount,
-			}
-			handler(futuresTicker)
-		}
+// SetStateHandler 设置连接状态回调，为 nil 时清除回调
+func (b *binanceWebsocket) SetStateHandler(handler exchange.ConnectionStateHandler) {
+	if handler == nil {
+		b.group.SetStateHandler(nil)
+		return
+	}
+	b.group.SetStateHandler(func(url string, state client.State, err error) {
+		handler(url, exchange.ConnectionState(state), err)
 	})
-	return b.futuresWs.Start()
+}
+
+// Errors 异步错误通道
+func (b *binanceWebsocket) Errors() <-chan error {
+	return b.group.Errors()
+}
+
+// Done 关闭完成后关闭的通道
+func (b *binanceWebsocket) Done() <-chan struct{} {
+	return b.group.Done()
+}
+
+// Close 关闭全部连接并停止后台任务
+func (b *binanceWebsocket) Close() error {
+	b.group.Close()
+	return nil
 }
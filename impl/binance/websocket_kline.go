@@ -0,0 +1,63 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenKlines 开始监听K线，订阅 <symbol>@kline_<interval>
+func (b *binanceWebsocket) StartListenKlines(ctx context.Context, market exchange.Market, symbol string, interval exchange.KlineInterval, handler exchange.WebsocketKlineHandler) error {
+	if err := interval.Validate(); err != nil {
+		return err
+	}
+	dialURL := b.spotURL
+	switch market {
+	case exchange.MarketSpot:
+	case exchange.MarketFutures:
+		dialURL = b.futuresURL
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	ws := client.NewWebsocket(dialURL+"/"+strings.ToLower(symbol)+"@kline_"+string(interval), func(message []byte) {
+		k, err := parseKlineEvent(message)
+		if err != nil {
+			return
+		}
+		if handler != nil {
+			handler(k)
+		}
+	})
+	return b.group.Start(ctx, ws)
+}
+
+// parseKlineEvent 解析K线事件
+func parseKlineEvent(message []byte) (*exchange.Kline, error) {
+	event := &WsKlineEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		return nil, err
+	}
+	if event.EventType != "kline" {
+		return nil, fmt.Errorf("非K线事件: %s", event.EventType)
+	}
+	k := event.Kline
+	return &exchange.Kline{
+		Symbol:      event.Symbol,
+		Interval:    exchange.KlineInterval(k.Interval),
+		OpenTime:    k.StartTime,
+		CloseTime:   k.EndTime,
+		Open:        k.Open,
+		High:        k.High,
+		Low:         k.Low,
+		Close:       k.Close,
+		Volume:      k.Volume,
+		QuoteVolume: k.QuoteVolume,
+		TradeCount:  k.TradeNum,
+		Closed:      k.IsFinal,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package binance
+
+import (
+	"testing"
+)
+
+// TestParseKlineEvent 解析K线事件
+// go test -v ./impl/binance -run "^TestParseKlineEvent$"
+func TestParseKlineEvent(t *testing.T) {
+	message := `{"e":"kline","E":1700000061000,"s":"BTCUSDT","k":{"t":1700000000000,"T":1700000059999,"s":"BTCUSDT","i":"1m","f":100,"L":200,"o":"100","c":"101","h":"102","l":"99","v":"10","n":101,"x":true,"q":"1005","V":"5","Q":"502","B":"0"}}`
+	k, err := parseKlineEvent([]byte(message))
+	if err != nil {
+		t.Fatalf("解析K线事件失败: %v", err)
+	}
+	if k.Symbol != "BTCUSDT" || k.Interval != "1m" || k.OpenTime != 1700000000000 || k.CloseTime != 1700000059999 {
+		t.Errorf("K线时间错误: %+v", k)
+	}
+	if k.Open != "100" || k.Close != "101" || k.High != "102" || k.Low != "99" || k.Volume != "10" || k.QuoteVolume != "1005" || k.TradeCount != 101 || !k.Closed {
+		t.Errorf("K线数据错误: %+v", k)
+	}
+
+	if _, err := parseKlineEvent([]byte(`{"e":"depthUpdate"}`)); err == nil {
+		t.Error("非K线事件应返回错误")
+	}
+}
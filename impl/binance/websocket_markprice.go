@@ -0,0 +1,61 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenMarkPrice 开始监听合约标记价格，连接成功后订阅 <symbol>@markPrice@1s
+func (b *binanceWebsocket) StartListenMarkPrice(ctx context.Context, symbols []string, handler exchange.WebsocketMarkPriceHandler) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("交易对不能为空")
+	}
+
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@markPrice@1s")
+	}
+	var ws *client.Websocket
+	ws = client.NewWebsocket(b.futuresURL, func(message []byte) {
+		markPrice, err := parseMarkPriceEvent(message)
+		if err != nil {
+			return
+		}
+		if handler != nil {
+			handler(markPrice)
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		subscribeBytes, err := json.Marshal(SubscribeParams{Method: "SUBSCRIBE", Params: streams, ID: 1})
+		if err != nil {
+			return err
+		}
+		return ws.WriteMessage(subscribeBytes)
+	})
+	return b.group.Start(ctx, ws)
+}
+
+// parseMarkPriceEvent 解析合约标记价格事件
+func parseMarkPriceEvent(message []byte) (*exchange.MarkPrice, error) {
+	event := &WsMarkPriceEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		return nil, err
+	}
+	if event.EventType != "markPriceUpdate" {
+		return nil, fmt.Errorf("非标记价格事件: %s", event.EventType)
+	}
+	return &exchange.MarkPrice{
+		Symbol:          event.Symbol,
+		MarkPrice:       event.MarkPrice,
+		IndexPrice:      event.IndexPrice,
+		FundingRate:     event.FundingRate,
+		NextFundingTime: event.NextFundingTime,
+		Time:            event.EventTime,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package binance
+
+import "testing"
+
+// TestParseMarkPriceEvent 解析合约标记价格事件
+// go test -v ./impl/binance -run "^TestParseMarkPriceEvent$"
+func TestParseMarkPriceEvent(t *testing.T) {
+	message := `{"e":"markPriceUpdate","E":1562305380000,"s":"BTCUSDT","p":"11794.15000000","i":"11784.62659091","P":"11784.25641265","r":"0.00038167","T":1562306400000}`
+	markPrice, err := parseMarkPriceEvent([]byte(message))
+	if err != nil {
+		t.Fatalf("解析标记价格失败: %v", err)
+	}
+	if markPrice.Symbol != "BTCUSDT" || markPrice.MarkPrice != "11794.15000000" || markPrice.IndexPrice != "11784.62659091" {
+		t.Errorf("标记价格错误: %+v", markPrice)
+	}
+	if markPrice.FundingRate != "0.00038167" || markPrice.NextFundingTime != 1562306400000 || markPrice.Time != 1562305380000 {
+		t.Errorf("资金费率错误: %+v", markPrice)
+	}
+
+	if _, err := parseMarkPriceEvent([]byte(`{"e":"aggTrade"}`)); err == nil {
+		t.Errorf("非标记价格事件应返回错误")
+	}
+}
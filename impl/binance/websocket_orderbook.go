@@ -0,0 +1,89 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/orderbook"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+const (
+	OrderBookSnapshotLimit = 1000 // 本地深度快照档位数量
+)
+
+// StartListenOrderBook 开始维护本地深度
+// 同步流程：订阅 <symbol>@depth@100ms 并缓存事件，获取 REST 快照，校验 U/u（合约为 pu）连续性，出现缺口时重新同步
+func (b *binanceWebsocket) StartListenOrderBook(ctx context.Context, market exchange.Market, symbol string, depth int, handler exchange.WebsocketOrderBookHandler) (exchange.LocalOrderBook, error) {
+	rest := newBinance("", "", b.opts...)
+	dialURL := b.spotURL
+	snapshot := func(ctx context.Context) (*exchange.OrderBook, error) {
+		return rest.GetSpotOrderBook(ctx, symbol, OrderBookSnapshotLimit)
+	}
+	switch market {
+	case exchange.MarketSpot:
+	case exchange.MarketFutures:
+		dialURL = b.futuresURL
+		snapshot = func(ctx context.Context) (*exchange.OrderBook, error) {
+			return rest.GetFuturesOrderBook(ctx, symbol, OrderBookSnapshotLimit)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	syncer := orderbook.NewSyncer(orderbook.NewBook(symbol, ""), snapshot, func(book *orderbook.Book) {
+		if handler != nil {
+			handler(book.Snapshot(depth))
+		}
+	})
+	ws := client.NewWebsocket(dialURL+"/"+strings.ToLower(symbol)+"@depth@100ms", func(message []byte) {
+		update, err := parseDepthEvent(message)
+		if err != nil {
+			return
+		}
+		syncer.Push(update)
+	})
+	// 连接重建后重新同步
+	ws.SetAfterConnectionHandler(func() error {
+		syncer.Reset()
+		return nil
+	})
+	if err := b.group.Start(ctx, ws, syncer.Close); err != nil {
+		return nil, err
+	}
+	return syncer.Book(), nil
+}
+
+// parseDepthEvent 解析增量深度事件
+func parseDepthEvent(message []byte) (*orderbook.Update, error) {
+	event := &WsDepthEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		return nil, err
+	}
+	if event.EventType != "depthUpdate" {
+		return nil, fmt.Errorf("非深度事件: %s", event.EventType)
+	}
+	return &orderbook.Update{
+		FirstUpdateID: event.FirstUpdateID,
+		LastUpdateID:  event.LastUpdateID,
+		PrevUpdateID:  event.PrevUpdateID,
+		Bids:          toWsOrderBookLevels(event.Bids),
+		Asks:          toWsOrderBookLevels(event.Asks),
+		Time:          event.EventTime,
+	}, nil
+}
+
+// toWsOrderBookLevels 转换增量档位 [价格, 数量]
+func toWsOrderBookLevels(levels [][]string) []exchange.OrderBookLevel {
+	res := make([]exchange.OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		res = append(res, exchange.OrderBookLevel{Price: level[0], Quantity: level[1]})
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package binance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/orderbook"
+)
+
+// 录制的合约增量深度消息，第 1 条已包含在快照 1003 中，第 2 条跨越快照，第 4 条与上一条的 pu 不连续
+var testFuturesDepthMessages = []string{
+	`{"e":"depthUpdate","E":1700000000100,"T":1700000000099,"s":"BTCUSDT","U":990,"u":1000,"pu":989,"b":[["37000.10","1.000"]],"a":[]}`,
+	`{"e":"depthUpdate","E":1700000000200,"T":1700000000199,"s":"BTCUSDT","U":1001,"u":1005,"pu":1000,"b":[["37000.10","0.000"],["36999.90","2.500"]],"a":[["37000.20","0.800"]]}`,
+	`{"e":"depthUpdate","E":1700000000300,"T":1700000000299,"s":"BTCUSDT","U":1006,"u":1010,"pu":1005,"b":[],"a":[["37000.30","1.200"]]}`,
+	`{"e":"depthUpdate","E":1700000000500,"T":1700000000499,"s":"BTCUSDT","U":1020,"u":1025,"pu":1015,"b":[["36999.90","3.000"]],"a":[]}`,
+	`{"e":"depthUpdate","E":1700000000600,"T":1700000000599,"s":"BTCUSDT","U":1026,"u":1030,"pu":1025,"b":[],"a":[["37000.20","0.500"]]}`,
+}
+
+// TestOrderBookReplay 回放录制的增量深度消息，缺口后重新获取快照
+// go test -v ./impl/binance -run "^TestOrderBookReplay$"
+func TestOrderBookReplay(t *testing.T) {
+	snapshots := []*exchange.OrderBook{
+		{
+			Bids:         []exchange.OrderBookLevel{{Price: "37000.10", Quantity: "1.000"}, {Price: "36999.00", Quantity: "4.000"}},
+			Asks:         []exchange.OrderBookLevel{{Price: "37000.20", Quantity: "1.000"}},
+			LastUpdateID: 1003,
+		},
+		{
+			Bids:         []exchange.OrderBookLevel{{Price: "36999.90", Quantity: "3.000"}},
+			Asks:         []exchange.OrderBookLevel{{Price: "37000.20", Quantity: "0.900"}, {Price: "37000.30", Quantity: "1.200"}},
+			LastUpdateID: 1027,
+		},
+	}
+	calls := 0
+	var changes []*exchange.OrderBook
+	syncer := orderbook.NewSyncer(orderbook.NewBook("BTCUSDT", ""), func(ctx context.Context) (*exchange.OrderBook, error) {
+		snapshot := snapshots[calls]
+		calls++
+		return snapshot, nil
+	}, func(book *orderbook.Book) {
+		changes = append(changes, book.Snapshot(1))
+	}).SetRetryDelay(0).SetRunner(func(f func()) { f() })
+
+	for i, message := range testFuturesDepthMessages {
+		update, err := parseDepthEvent([]byte(message))
+		if err != nil {
+			t.Fatalf("解析第 %d 条消息失败: %v", i, err)
+		}
+		syncer.Push(update)
+	}
+
+	book := syncer.Book()
+	if calls != 2 || !book.Synced() || book.LastUpdateID() != 1030 {
+		t.Fatalf("同步错误: calls=%d synced=%v lastUpdateId=%d", calls, book.Synced(), book.LastUpdateID())
+	}
+	if bid, _ := book.BestBid(); bid.Price != "36999.90" || bid.Quantity != "3.000" {
+		t.Errorf("买一错误: %+v", bid)
+	}
+	if ask, _ := book.BestAsk(); ask.Price != "37000.20" || ask.Quantity != "0.500" {
+		t.Errorf("卖一错误: %+v", ask)
+	}
+	if len(changes) == 0 || changes[len(changes)-1].Asks[0].Quantity != "0.500" {
+		t.Errorf("变化回调错误: %+v", changes)
+	}
+}
+
+// TestParseDepthEvent 解析现货增量深度消息
+// go test -v ./impl/binance -run "^TestParseDepthEvent$"
+func TestParseDepthEvent(t *testing.T) {
+	update, err := parseDepthEvent([]byte(`{"e":"depthUpdate","E":1700000000000,"s":"BNBBTC","U":157,"u":160,"b":[["0.0024","10"]],"a":[["0.0026","100"]]}`))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if update.FirstUpdateID != 157 || update.LastUpdateID != 160 || update.PrevUpdateID != 0 {
+		t.Errorf("更新ID错误: %+v", update)
+	}
+	if len(update.Bids) != 1 || update.Bids[0].Price != "0.0024" || update.Asks[0].Quantity != "100" {
+		t.Errorf("档位错误: %+v", update)
+	}
+	if _, err := parseDepthEvent([]byte(`{"e":"24hrTicker"}`)); err == nil {
+		t.Error("非深度事件应返回错误")
+	}
+}
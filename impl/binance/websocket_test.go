@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -11,7 +12,7 @@ import (
 // go test -v ./impl/binance -run "^TestListenSpotTickers$"
 func TestListenSpotTickers(t *testing.T) {
 	binanceWebsocket := NewBinanceWebsocket()
-	err := binanceWebsocket.StartListenSpotTickers(func(ticker *exchange.Ticker) {
+	err := binanceWebsocket.StartListenSpotTickers(context.Background(), func(ticker *exchange.Ticker) {
 		fmt.Println("===>", ticker)
 	})
 	if err != nil {
@@ -26,7 +27,7 @@ func TestListenSpotTickers(t *testing.T) {
 // go test -v ./impl/binance -run "^TestListenFuturesTickers$"
 func TestListenFuturesTickers(t *testing.T) {
 	binanceWebsocket := NewBinanceWebsocket()
-	err := binanceWebsocket.StartListenFuturesTickers(func(ticker *exchange.Ticker) {
+	err := binanceWebsocket.StartListenFuturesTickers(context.Background(), func(ticker *exchange.Ticker) {
 		fmt.Println("===>", ticker)
 	})
 	if err != nil {
@@ -0,0 +1,197 @@
+package binance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/subscription"
+)
+
+const (
+	AllTickerStream = "!ticker@arr" // 全部交易对行情数据流
+)
+
+// StartListenSpotTickers 开始监听现货全部交易对行情，ctx 结束时取消该频道的全部订阅
+func (b *binanceWebsocket) StartListenSpotTickers(ctx context.Context, handler exchange.WebsocketSpotTickerHandler) error {
+	b.SetTickerHandler(exchange.ChannelSpotTicker, exchange.WebsocketTickerHandler(handler))
+	if err := b.Subscribe(exchange.ChannelSpotTicker); err != nil {
+		return err
+	}
+	b.group.AfterFunc(ctx, func() {
+		_ = b.Unsubscribe(exchange.ChannelSpotTicker)
+	})
+	return nil
+}
+
+// StartListenFuturesTickers 开始监听合约全部交易对行情，ctx 结束时取消该频道的全部订阅
+func (b *binanceWebsocket) StartListenFuturesTickers(ctx context.Context, handler exchange.WebsocketFuturesTickerHandler) error {
+	b.SetTickerHandler(exchange.ChannelFuturesTicker, exchange.WebsocketTickerHandler(handler))
+	if err := b.Subscribe(exchange.ChannelFuturesTicker); err != nil {
+		return err
+	}
+	b.group.AfterFunc(ctx, func() {
+		_ = b.Unsubscribe(exchange.ChannelFuturesTicker)
+	})
+	return nil
+}
+
+// SetTickerHandler 设置行情频道的回调
+func (b *binanceWebsocket) SetTickerHandler(channel exchange.Channel, handler exchange.WebsocketTickerHandler) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.tickerHandlers[channel] = handler
+}
+
+// Subscribe 订阅行情，单个交易对订阅 <symbol>@ticker，symbols 为空时订阅 !ticker@arr
+func (b *binanceWebsocket) Subscribe(channel exchange.Channel, symbols ...string) error {
+	pool, err := b.tickerPool(channel)
+	if err != nil {
+		return err
+	}
+	return pool.Subscribe(tickerStreams(symbols)...)
+}
+
+// Unsubscribe 取消订阅行情，symbols 为空时取消该频道的全部订阅
+func (b *binanceWebsocket) Unsubscribe(channel exchange.Channel, symbols ...string) error {
+	pool, err := b.tickerPool(channel)
+	if err != nil {
+		return err
+	}
+	if len(symbols) == 0 {
+		return pool.Unsubscribe(pool.Streams()...)
+	}
+	return pool.Unsubscribe(tickerStreams(symbols)...)
+}
+
+// tickerPool 获取行情订阅连接池，不存在时创建
+func (b *binanceWebsocket) tickerPool(channel exchange.Channel) (*subscription.Pool, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if pool, ok := b.tickerPools[channel]; ok {
+		return pool, nil
+	}
+	if b.group.Context().Err() != nil {
+		return nil, exchange.ErrClosed
+	}
+
+	var (
+		dialURL    string
+		maxStreams int
+	)
+	switch channel {
+	case exchange.ChannelSpotTicker:
+		dialURL, maxStreams = b.spotURL, SpotMaxStreams
+	case exchange.ChannelFuturesTicker:
+		dialURL, maxStreams = b.futuresURL, FuturesMaxStreams
+	default:
+		return nil, fmt.Errorf("不支持的订阅频道: %s", channel)
+	}
+
+	pool := subscription.NewPool(subscription.Config{
+		MaxStreams: maxStreams,
+		NewConn: func(onConnect func() error, onDisconnect func()) subscription.Conn {
+			ws := client.NewWebsocket(dialURL, func(message []byte) {
+				handler := b.tickerHandler(channel)
+				if handler == nil {
+					return
+				}
+				for _, event := range parseTickerEvents(message) {
+					handler(toTicker(event))
+				}
+			})
+			ws.SetBeforeConnectionHandler(func() error {
+				onDisconnect()
+				return nil
+			})
+			ws.SetAfterConnectionHandler(onConnect)
+			// 已关闭时关闭连接，启动将失败
+			if err := b.group.Add(ws); err != nil {
+				ws.Close()
+			}
+			return ws
+		},
+		Subscribe: func(conn subscription.Conn, streams []string) error {
+			return writeSubscribe(conn, "SUBSCRIBE", streams)
+		},
+		Unsubscribe: func(conn subscription.Conn, streams []string) error {
+			return writeSubscribe(conn, "UNSUBSCRIBE", streams)
+		},
+	})
+	b.tickerPools[channel] = pool
+	return pool, nil
+}
+
+// tickerHandler 获取行情频道的回调
+func (b *binanceWebsocket) tickerHandler(channel exchange.Channel) exchange.WebsocketTickerHandler {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.tickerHandlers[channel]
+}
+
+// tickerStreams 交易对转换为行情数据流名称，symbols 为空时返回全部交易对数据流
+func tickerStreams(symbols []string) []string {
+	if len(symbols) == 0 {
+		return []string{AllTickerStream}
+	}
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@ticker")
+	}
+	return streams
+}
+
+// writeSubscribe 发送订阅或取消订阅请求
+func writeSubscribe(conn subscription.Conn, method string, streams []string) error {
+	message, err := json.Marshal(SubscribeParams{Method: method, Params: streams, ID: 1})
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(message)
+}
+
+// parseTickerEvents 解析行情推送，!ticker@arr 推送数组，<symbol>@ticker 推送单个对象，订阅响应等其他消息忽略
+func parseTickerEvents(message []byte) []*WsAllTickerEvent {
+	var events []*WsAllTickerEvent
+	if message = bytes.TrimSpace(message); len(message) > 0 && message[0] == '[' {
+		if err := json.Unmarshal(message, &events); err != nil {
+			return nil
+		}
+	} else {
+		event := &WsAllTickerEvent{}
+		if err := json.Unmarshal(message, event); err != nil {
+			return nil
+		}
+		events = append(events, event)
+	}
+
+	tickers := events[:0]
+	for _, event := range events {
+		if event != nil && event.EventType == "24hrTicker" {
+			tickers = append(tickers, event)
+		}
+	}
+	return tickers
+}
+
+// toTicker 转换行情推送
+func toTicker(event *WsAllTickerEvent) *exchange.Ticker {
+	return &exchange.Ticker{
+		Symbol:             event.Symbol,
+		PriceChange:        event.PriceChange,
+		PriceChangePercent: event.PriceChangePercent,
+		WeightedAvgPrice:   event.WeightedAvgPrice,
+		LastPrice:          event.LastPrice,
+		LastQty:            event.LastQty,
+		OpenPrice:          event.OpenPrice,
+		HighPrice:          event.HighPrice,
+		LowPrice:           event.LowPrice,
+		Volume:             event.TotalVolume,
+		QuoteVolume:        event.TotalQuoteVolume,
+		Count:              event.TradeCount,
+	}
+}
@@ -0,0 +1,38 @@
+package binance
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTickerStreams 交易对转换为行情数据流
+// go test -v ./impl/binance -run "^TestTickerStreams$"
+func TestTickerStreams(t *testing.T) {
+	if got := tickerStreams(nil); !reflect.DeepEqual(got, []string{AllTickerStream}) {
+		t.Errorf("tickerStreams(nil) = %v", got)
+	}
+	if got := tickerStreams([]string{"BTCUSDT", "ethusdt"}); !reflect.DeepEqual(got, []string{"btcusdt@ticker", "ethusdt@ticker"}) {
+		t.Errorf("tickerStreams() = %v", got)
+	}
+}
+
+// TestParseTickerEvents 解析全部交易对数组推送与单个交易对推送，忽略订阅响应
+// go test -v ./impl/binance -run "^TestParseTickerEvents$"
+func TestParseTickerEvents(t *testing.T) {
+	single := `{"e":"24hrTicker","E":1700000000000,"s":"BTCUSDT","p":"100","P":"0.3","w":"30000","x":"29900","c":"30000","Q":"0.1","b":"29999","B":"1","a":"30001","A":"1","o":"29900","h":"30100","l":"29800","v":"1000","q":"30000000","O":1699913600000,"C":1700000000000,"F":1,"L":100,"n":100}`
+	events := parseTickerEvents([]byte(single))
+	if len(events) != 1 {
+		t.Fatalf("解析单个推送数量错误: %d", len(events))
+	}
+	ticker := toTicker(events[0])
+	if ticker.Symbol != "BTCUSDT" || ticker.LastPrice != "30000" || ticker.LowPrice != "29800" || ticker.QuoteVolume != "30000000" || ticker.Count != 100 {
+		t.Errorf("行情数据错误: %+v", ticker)
+	}
+
+	if events := parseTickerEvents([]byte("[" + single + "," + single + "]")); len(events) != 2 {
+		t.Errorf("解析数组推送数量错误: %d", len(events))
+	}
+	if events := parseTickerEvents([]byte(`{"result":null,"id":1}`)); len(events) != 0 {
+		t.Errorf("订阅响应应忽略: %d", len(events))
+	}
+}
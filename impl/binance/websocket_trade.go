@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenTrades 开始监听归集成交，连接成功后订阅 <symbol>@aggTrade
+func (b *binanceWebsocket) StartListenTrades(ctx context.Context, market exchange.Market, symbols []string, handler exchange.WebsocketTradeHandler) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("交易对不能为空")
+	}
+	dialURL := b.spotURL
+	switch market {
+	case exchange.MarketSpot:
+	case exchange.MarketFutures:
+		dialURL = b.futuresURL
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@aggTrade")
+	}
+	var ws *client.Websocket
+	ws = client.NewWebsocket(dialURL, func(message []byte) {
+		trade, err := parseAggTradeEvent(message)
+		if err != nil {
+			return
+		}
+		if handler != nil {
+			handler(trade)
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		subscribeBytes, err := json.Marshal(SubscribeParams{Method: "SUBSCRIBE", Params: streams, ID: 1})
+		if err != nil {
+			return err
+		}
+		return ws.WriteMessage(subscribeBytes)
+	})
+	return b.group.Start(ctx, ws)
+}
+
+// parseAggTradeEvent 解析归集成交事件
+func parseAggTradeEvent(message []byte) (*exchange.Trade, error) {
+	event := &WsAggTradeEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		return nil, err
+	}
+	if event.EventType != "aggTrade" {
+		return nil, fmt.Errorf("非归集成交事件: %s", event.EventType)
+	}
+	return toTrade(event.Symbol, event.AggTradeID, event.Price, event.Quantity, event.IsBuyerMaker, event.TradeTime), nil
+}
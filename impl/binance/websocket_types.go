@@ -21,3 +21,221 @@ type WsAllTickerEvent struct {
 	LastTradeID        int64  `json:"L"` // 最后一笔成交ID
 	TradeCount         int64  `json:"n"` // 成交笔数
 }
+
+// WsDepthEvent 增量深度事件
+type WsDepthEvent struct {
+	EventType       string     `json:"e"`  // "depthUpdate"
+	EventTime       int64      `json:"E"`  // 事件时间
+	TransactionTime int64      `json:"T"`  // 撮合时间，仅合约
+	Symbol          string     `json:"s"`  // 交易对
+	FirstUpdateID   int64      `json:"U"`  // 第一个更新ID
+	LastUpdateID    int64      `json:"u"`  // 最后一个更新ID
+	PrevUpdateID    int64      `json:"pu"` // 上一个事件的最后更新ID，仅合约
+	Bids            [][]string `json:"b"`  // 买盘变化 [价格, 数量]
+	Asks            [][]string `json:"a"`  // 卖盘变化 [价格, 数量]
+}
+
+// WsKlineEvent K线事件
+type WsKlineEvent struct {
+	EventType string  `json:"e"` // "kline"
+	EventTime int64   `json:"E"` // 事件时间
+	Symbol    string  `json:"s"` // 交易对
+	Kline     WsKline `json:"k"` // K线
+}
+
+// WsKline K线数据，字段名大小写不同的字段需全部声明，避免 JSON 解析时大小写不敏感匹配
+type WsKline struct {
+	StartTime           int64  `json:"t"` // 开盘时间
+	EndTime             int64  `json:"T"` // 收盘时间
+	Symbol              string `json:"s"` // 交易对
+	Interval            string `json:"i"` // 周期
+	FirstTradeID        int64  `json:"f"` // 第一笔成交ID
+	LastTradeID         int64  `json:"L"` // 最后一笔成交ID
+	Open                string `json:"o"` // 开盘价
+	Close               string `json:"c"` // 收盘价
+	High                string `json:"h"` // 最高价
+	Low                 string `json:"l"` // 最低价
+	Volume              string `json:"v"` // 成交量
+	TradeNum            int64  `json:"n"` // 成交笔数
+	IsFinal             bool   `json:"x"` // 是否已收盘
+	QuoteVolume         string `json:"q"` // 成交额
+	TakerBuyVolume      string `json:"V"` // 主动买入成交量
+	TakerBuyQuoteVolume string `json:"Q"` // 主动买入成交额
+}
+
+// WsAggTradeEvent 归集成交事件
+type WsAggTradeEvent struct {
+	EventType    string `json:"e"` // "aggTrade"
+	EventTime    int64  `json:"E"` // 事件时间
+	Symbol       string `json:"s"` // 交易对
+	AggTradeID   int64  `json:"a"` // 归集成交ID
+	Price        string `json:"p"` // 成交价格
+	Quantity     string `json:"q"` // 成交数量
+	FirstTradeID int64  `json:"f"` // 第一笔成交ID
+	LastTradeID  int64  `json:"l"` // 最后一笔成交ID
+	TradeTime    int64  `json:"T"` // 成交时间
+	IsBuyerMaker bool   `json:"m"` // 买方是否为挂单方
+	IsBestMatch  bool   `json:"M"` // 已废弃，仅现货
+}
+
+// WsMarkPriceEvent 合约标记价格事件
+type WsMarkPriceEvent struct {
+	EventType            string `json:"e"` // "markPriceUpdate"
+	EventTime            int64  `json:"E"` // 事件时间
+	Symbol               string `json:"s"` // 交易对
+	MarkPrice            string `json:"p"` // 标记价格
+	IndexPrice           string `json:"i"` // 现货指数价格
+	EstimatedSettlePrice string `json:"P"` // 预估结算价，仅在结算前最后一小时有参考价值
+	FundingRate          string `json:"r"` // 资金费率
+	NextFundingTime      int64  `json:"T"` // 下次资金费时间
+}
+
+// WsUserDataEvent 私有数据流事件，用于判断事件类型
+type WsUserDataEvent struct {
+	EventType string `json:"e"` // 事件类型
+	EventTime int64  `json:"E"` // 事件时间
+}
+
+// WsExecutionReport 现货订单更新事件 executionReport，字段名大小写不同的字段需全部声明
+type WsExecutionReport struct {
+	EventType               string `json:"e"` // "executionReport"
+	EventTime               int64  `json:"E"` // 事件时间
+	Symbol                  string `json:"s"` // 交易对
+	ClientOrderID           string `json:"c"` // 客户自定义订单ID
+	Side                    string `json:"S"` // 订单方向
+	Type                    string `json:"o"` // 订单类型
+	TimeInForce             string `json:"f"` // 有效方式
+	Quantity                string `json:"q"` // 订单原始数量
+	Price                   string `json:"p"` // 订单原始价格
+	StopPrice               string `json:"P"` // 止盈止损单触发价格
+	IcebergQuantity         string `json:"F"` // 冰山单数量
+	OrderListID             int64  `json:"g"` // 订单列表ID
+	OrigClientOrderID       string `json:"C"` // 原始客户自定义订单ID，撤单时有效
+	ExecutionType           string `json:"x"` // 本次事件的执行类型 NEW CANCELED REPLACED REJECTED TRADE EXPIRED
+	Status                  string `json:"X"` // 订单当前状态
+	RejectReason            string `json:"r"` // 拒绝原因
+	OrderID                 int64  `json:"i"` // 订单ID
+	Ignore                  int64  `json:"I"` // 忽略
+	LastExecutedQuantity    string `json:"l"` // 最后一次成交数量
+	CumulativeQuantity      string `json:"z"` // 累计成交数量
+	LastExecutedPrice       string `json:"L"` // 最后一次成交价格
+	Commission              string `json:"n"` // 手续费数量
+	CommissionAsset         string `json:"N"` // 手续费资产
+	TransactionTime         int64  `json:"T"` // 成交时间
+	TradeID                 int64  `json:"t"` // 成交ID
+	IsWorking               bool   `json:"w"` // 订单是否在订单簿上
+	IsMaker                 bool   `json:"m"` // 该成交是否为挂单方
+	IgnoreM                 bool   `json:"M"` // 忽略
+	CreateTime              int64  `json:"O"` // 订单创建时间
+	CumulativeQuoteQuantity string `json:"Z"` // 累计成交金额
+	LastQuoteQuantity       string `json:"Y"` // 最后一次成交金额
+	QuoteOrderQuantity      string `json:"Q"` // 报价资产委托数量
+	WorkingTime             int64  `json:"W"` // 订单进入订单簿的时间
+	SelfTradePreventionMode string `json:"V"` // 自成交防护模式
+	PreventedMatchID        int64  `json:"v"` // 自成交防护ID
+	TrailingDelta           int64  `json:"d"` // 跟踪止损偏移
+	TrailingTime            int64  `json:"D"` // 跟踪止损激活时间
+	StrategyID              int64  `json:"j"` // 策略ID
+	StrategyType            int64  `json:"J"` // 策略类型
+	PreventedQuantity       string `json:"A"` // 自成交防护数量
+	LastPreventedQuantity   string `json:"B"` // 最后一次自成交防护数量
+	TradeGroupID            int64  `json:"u"` // 成交组ID
+	CounterOrderID          int64  `json:"U"` // 自成交防护对手订单ID
+}
+
+// WsOutboundAccountPosition 现货账户余额更新事件 outboundAccountPosition
+type WsOutboundAccountPosition struct {
+	EventType      string             `json:"e"` // "outboundAccountPosition"
+	EventTime      int64              `json:"E"` // 事件时间
+	LastUpdateTime int64              `json:"u"` // 账户最后更新时间
+	Balances       []WsAccountBalance `json:"B"` // 发生变化的余额
+}
+
+// WsAccountBalance 现货账户余额
+type WsAccountBalance struct {
+	Asset  string `json:"a"` // 资产
+	Free   string `json:"f"` // 可用余额
+	Locked string `json:"l"` // 冻结余额
+}
+
+// WsFuturesOrderTradeUpdate 合约订单更新事件 ORDER_TRADE_UPDATE
+type WsFuturesOrderTradeUpdate struct {
+	EventType       string         `json:"e"` // "ORDER_TRADE_UPDATE"
+	EventTime       int64          `json:"E"` // 事件时间
+	TransactionTime int64          `json:"T"` // 撮合时间
+	Order           WsFuturesOrder `json:"o"` // 订单
+}
+
+// WsFuturesOrder 合约订单，字段名大小写不同的字段需全部声明
+type WsFuturesOrder struct {
+	Symbol              string `json:"s"`   // 交易对
+	ClientOrderID       string `json:"c"`   // 客户自定义订单ID
+	Side                string `json:"S"`   // 订单方向
+	Type                string `json:"o"`   // 订单类型
+	TimeInForce         string `json:"f"`   // 有效方式
+	Quantity            string `json:"q"`   // 订单原始数量
+	Price               string `json:"p"`   // 订单原始价格
+	AveragePrice        string `json:"ap"`  // 订单平均价格
+	StopPrice           string `json:"sp"`  // 条件订单触发价格
+	ExecutionType       string `json:"x"`   // 本次事件的执行类型 NEW CANCELED CALCULATED EXPIRED TRADE AMENDMENT
+	Status              string `json:"X"`   // 订单当前状态
+	OrderID             int64  `json:"i"`   // 订单ID
+	LastFilledQuantity  string `json:"l"`   // 最后一次成交数量
+	CumulativeQuantity  string `json:"z"`   // 累计成交数量
+	LastFilledPrice     string `json:"L"`   // 最后一次成交价格
+	CommissionAsset     string `json:"N"`   // 手续费资产
+	Commission          string `json:"n"`   // 手续费数量
+	TradeTime           int64  `json:"T"`   // 成交时间
+	TradeID             int64  `json:"t"`   // 成交ID
+	BidsNotional        string `json:"b"`   // 买单净值
+	AsksNotional        string `json:"a"`   // 卖单净值
+	IsMaker             bool   `json:"m"`   // 该成交是否为挂单方
+	IsReduceOnly        bool   `json:"R"`   // 是否只减仓
+	WorkingType         string `json:"wt"`  // 触发价类型
+	OriginalType        string `json:"ot"`  // 原始订单类型
+	PositionSide        string `json:"ps"`  // 持仓方向
+	IsClosePosition     bool   `json:"cp"`  // 是否为触发平仓单
+	ActivationPrice     string `json:"AP"`  // 跟踪止损激活价格
+	CallbackRate        string `json:"cr"`  // 跟踪止损回调比例
+	PriceProtect        bool   `json:"pP"`  // 是否开启条件单触发保护
+	RealizedPnL         string `json:"rp"`  // 该成交的已实现盈亏
+	SelfTradePrevention string `json:"V"`   // 自成交防护模式
+	PriceMatch          string `json:"pm"`  // 价格匹配模式
+	GoodTillDate        int64  `json:"gtd"` // GTD 订单自动取消时间
+}
+
+// WsFuturesAccountUpdate 合约账户更新事件 ACCOUNT_UPDATE
+type WsFuturesAccountUpdate struct {
+	EventType       string           `json:"e"` // "ACCOUNT_UPDATE"
+	EventTime       int64            `json:"E"` // 事件时间
+	TransactionTime int64            `json:"T"` // 撮合时间
+	Account         WsFuturesAccount `json:"a"` // 账户更新
+}
+
+// WsFuturesAccount 合约账户更新内容
+type WsFuturesAccount struct {
+	Reason    string              `json:"m"` // 事件原因
+	Balances  []WsFuturesBalance  `json:"B"` // 发生变化的余额
+	Positions []WsFuturesPosition `json:"P"` // 发生变化的持仓
+}
+
+// WsFuturesBalance 合约账户余额
+type WsFuturesBalance struct {
+	Asset              string `json:"a"`  // 资产
+	WalletBalance      string `json:"wb"` // 钱包余额
+	CrossWalletBalance string `json:"cw"` // 除去逐仓保证金的钱包余额
+	BalanceChange      string `json:"bc"` // 除去盈亏与手续费以外的钱包余额改变量
+}
+
+// WsFuturesPosition 合约持仓
+type WsFuturesPosition struct {
+	Symbol              string `json:"s"`   // 交易对
+	PositionAmt         string `json:"pa"`  // 持仓数量
+	EntryPrice          string `json:"ep"`  // 开仓均价
+	BreakEvenPrice      string `json:"bep"` // 盈亏平衡价
+	AccumulatedRealized string `json:"cr"`  // 累计已实现盈亏
+	UnrealizedPnL       string `json:"up"`  // 未实现盈亏
+	MarginType          string `json:"mt"`  // 保证金模式 cross isolated
+	IsolatedWallet      string `json:"iw"`  // 逐仓保证金
+	PositionSide        string `json:"ps"`  // 持仓方向
+}
@@ -0,0 +1,373 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/symbolmap"
+)
+
+const (
+	ListenKeyKeepaliveInterval = 30 * time.Minute // listenKey 延长有效期间隔，有效期为 60 分钟
+)
+
+// binanceUserDataStream Binance 私有数据流实例
+type binanceUserDataStream struct {
+	ws   *binanceWebsocket
+	rest *binanceExchange
+}
+
+// NewBinanceUserDataStream 创建 Binance 私有数据流实例，使用 listenKey 连接用户数据流
+func NewBinanceUserDataStream(apiKey, secretKey string, opts ...exchange.Option) exchange.UserDataStream {
+	rest := newBinance(apiKey, secretKey, opts...)
+	stream := &binanceUserDataStream{
+		ws:   newBinanceWebsocket(opts...),
+		rest: rest,
+	}
+	return symbolmap.WrapUserDataStream(stream, rest.ListInstruments, symbolFormatter{})
+}
+
+// StartListenUserData 开始监听账户私有数据
+// 每次连接前获取 listenKey（有效的 listenKey 会被复用），连接期间定时延长有效期，
+// 延长失败或收到 listenKeyExpired 事件时断开连接，重连时使用新的 listenKey，连接关闭时停止延长；
+// listenKey 的获取与延长使用订阅时上下文对应环境的客户端，上下文设置测试网时连接测试网数据流
+func (b *binanceUserDataStream) StartListenUserData(ctx context.Context, market exchange.Market, handler exchange.UserDataHandler) error {
+	testnet := !b.rest.testnet && exchange.IsTestnet(ctx)
+	var (
+		baseURL   string
+		listenKey func(ctx context.Context) (string, error)
+		keepalive func(ctx context.Context, listenKey string) error
+		parse     func(message []byte, handler exchange.UserDataHandler) (string, error)
+	)
+	switch market {
+	case exchange.MarketSpot:
+		baseURL = b.ws.spotURL
+		if testnet {
+			baseURL = SpotTestnetWebsocketURL
+		}
+		restClient := b.rest.getClient(ctx)
+		listenKey = func(ctx context.Context) (string, error) {
+			return restClient.NewStartUserStreamService().Do(ctx)
+		}
+		keepalive = func(ctx context.Context, listenKey string) error {
+			return restClient.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+		}
+		parse = handleSpotUserDataEvent
+	case exchange.MarketFutures:
+		baseURL = b.ws.futuresURL
+		if testnet {
+			baseURL = FuturesTestnetWebsocketURL
+		}
+		restClient := b.rest.getFuturesClient(ctx)
+		listenKey = func(ctx context.Context) (string, error) {
+			return restClient.NewStartUserStreamService().Do(ctx)
+		}
+		keepalive = func(ctx context.Context, listenKey string) error {
+			return restClient.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+		}
+		parse = handleFuturesUserDataEvent
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	var (
+		ws         *client.Websocket
+		currentKey string
+		keyMux     sync.Mutex
+	)
+	ws = client.NewWebsocket(baseURL, func(message []byte) {
+		eventType, err := parse(message, handler)
+		if err != nil {
+			return
+		}
+		if eventType == "listenKeyExpired" {
+			slog.Warn("Binance listenKey expired, reconnect", "market", market)
+			ws.Reconnect()
+		}
+	})
+	// 连接前获取 listenKey，重连时自动续期或更换
+	ws.SetBeforeConnectionHandler(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		key, err := listenKey(ctx)
+		if err != nil {
+			return fmt.Errorf("获取 listenKey 失败: %w", toAPIError(err))
+		}
+		keyMux.Lock()
+		currentKey = key
+		keyMux.Unlock()
+		ws.SetDialURL(baseURL + "/" + key)
+		return nil
+	})
+	stop := make(chan struct{})
+	if err := b.ws.group.Start(ctx, ws, func() { close(stop) }); err != nil {
+		return err
+	}
+
+	// 定时延长 listenKey 有效期
+	go func() {
+		ticker := time.NewTicker(ListenKeyKeepaliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			keyMux.Lock()
+			key := currentKey
+			keyMux.Unlock()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err := keepalive(ctx, key)
+			cancel()
+			if err != nil {
+				slog.Warn("Binance listenKey keepalive failed, reconnect", "market", market, "error", err.Error())
+				b.ws.group.ReportError(fmt.Errorf("延长 listenKey 有效期失败: %w", toAPIError(err)))
+				ws.Reconnect()
+			}
+		}
+	}()
+	return nil
+}
+
+// SetStateHandler 设置连接状态回调
+func (b *binanceUserDataStream) SetStateHandler(handler exchange.ConnectionStateHandler) {
+	b.ws.SetStateHandler(handler)
+}
+
+// Errors 异步错误通道
+func (b *binanceUserDataStream) Errors() <-chan error {
+	return b.ws.Errors()
+}
+
+// Done 关闭完成后关闭的通道
+func (b *binanceUserDataStream) Done() <-chan struct{} {
+	return b.ws.Done()
+}
+
+// Close 关闭全部连接并停止 listenKey 延长
+func (b *binanceUserDataStream) Close() error {
+	return b.ws.Close()
+}
+
+// handleSpotUserDataEvent 解析现货私有数据事件并回调，返回事件类型
+func handleSpotUserDataEvent(message []byte, handler exchange.UserDataHandler) (string, error) {
+	event := &WsUserDataEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		return "", err
+	}
+
+	switch event.EventType {
+	case "executionReport":
+		report := &WsExecutionReport{}
+		if err := json.Unmarshal(message, report); err != nil {
+			return "", err
+		}
+		if handler.OnOrder != nil {
+			handler.OnOrder(&exchange.OrderUpdate{
+				Market: exchange.MarketSpot,
+				Order:  toSpotOrderFromReport(report),
+				Time:   report.EventTime,
+			})
+		}
+		if report.ExecutionType == "TRADE" && handler.OnFill != nil {
+			handler.OnFill(&exchange.Fill{
+				Market:   exchange.MarketSpot,
+				Symbol:   report.Symbol,
+				OrderID:  strconv.FormatInt(report.OrderID, 10),
+				TradeID:  strconv.FormatInt(report.TradeID, 10),
+				Side:     exchange.OrderSide(report.Side),
+				Price:    report.LastExecutedPrice,
+				Quantity: report.LastExecutedQuantity,
+				Fee:      report.Commission,
+				FeeAsset: report.CommissionAsset,
+				IsMaker:  report.IsMaker,
+				Time:     report.TransactionTime,
+			})
+		}
+	case "outboundAccountPosition":
+		account := &WsOutboundAccountPosition{}
+		if err := json.Unmarshal(message, account); err != nil {
+			return "", err
+		}
+		if handler.OnBalance != nil {
+			balances := make([]exchange.Balance, 0, len(account.Balances))
+			for _, balance := range account.Balances {
+				balances = append(balances, exchange.Balance{
+					Symbol: balance.Asset,
+					Free:   balance.Free,
+					Locked: balance.Locked,
+					Total:  addDecimal(balance.Free, balance.Locked),
+				})
+			}
+			handler.OnBalance(&exchange.BalanceUpdate{
+				Market:   exchange.MarketSpot,
+				Balances: balances,
+				Time:     account.EventTime,
+			})
+		}
+	}
+	return event.EventType, nil
+}
+
+// handleFuturesUserDataEvent 解析合约私有数据事件并回调，返回事件类型
+func handleFuturesUserDataEvent(message []byte, handler exchange.UserDataHandler) (string, error) {
+	event := &WsUserDataEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		return "", err
+	}
+
+	switch event.EventType {
+	case "ORDER_TRADE_UPDATE":
+		update := &WsFuturesOrderTradeUpdate{}
+		if err := json.Unmarshal(message, update); err != nil {
+			return "", err
+		}
+		o := update.Order
+		if handler.OnOrder != nil {
+			handler.OnOrder(&exchange.OrderUpdate{
+				Market: exchange.MarketFutures,
+				Order:  toFuturesOrderFromUpdate(&o),
+				Time:   update.EventTime,
+			})
+		}
+		if o.ExecutionType == "TRADE" && handler.OnFill != nil {
+			handler.OnFill(&exchange.Fill{
+				Market:      exchange.MarketFutures,
+				Symbol:      o.Symbol,
+				OrderID:     strconv.FormatInt(o.OrderID, 10),
+				TradeID:     strconv.FormatInt(o.TradeID, 10),
+				Side:        exchange.OrderSide(o.Side),
+				Price:       o.LastFilledPrice,
+				Quantity:    o.LastFilledQuantity,
+				Fee:         o.Commission,
+				FeeAsset:    o.CommissionAsset,
+				IsMaker:     o.IsMaker,
+				RealizedPnl: o.RealizedPnL,
+				Time:        o.TradeTime,
+			})
+		}
+	case "ACCOUNT_UPDATE":
+		update := &WsFuturesAccountUpdate{}
+		if err := json.Unmarshal(message, update); err != nil {
+			return "", err
+		}
+		if handler.OnBalance != nil && len(update.Account.Balances) > 0 {
+			balances := make([]exchange.Balance, 0, len(update.Account.Balances))
+			for _, balance := range update.Account.Balances {
+				// 推送中不包含可用余额与冻结保证金，Free 使用全仓钱包余额
+				balances = append(balances, exchange.Balance{
+					Symbol: balance.Asset,
+					Free:   balance.CrossWalletBalance,
+					Total:  balance.WalletBalance,
+				})
+			}
+			handler.OnBalance(&exchange.BalanceUpdate{
+				Market:   exchange.MarketFutures,
+				Balances: balances,
+				Time:     update.EventTime,
+			})
+		}
+		if handler.OnPosition != nil && len(update.Account.Positions) > 0 {
+			positions := make([]*exchange.PositionRisk, 0, len(update.Account.Positions))
+			for _, p := range update.Account.Positions {
+				positions = append(positions, &exchange.PositionRisk{
+					Symbol:           p.Symbol,
+					PositionSide:     exchange.PositionSide(p.PositionSide),
+					PositionAmt:      p.PositionAmt,
+					EntryPrice:       p.EntryPrice,
+					UnRealizedProfit: p.UnrealizedPnL,
+					MarginType:       p.MarginType,
+					IsolatedMargin:   p.IsolatedWallet,
+				})
+			}
+			handler.OnPosition(&exchange.PositionUpdate{
+				Market:    exchange.MarketFutures,
+				Positions: positions,
+				Time:      update.EventTime,
+			})
+		}
+	}
+	return event.EventType, nil
+}
+
+// toSpotOrderFromReport 转换现货订单更新，推送中不包含累计手续费，实际数量为已成交数量
+func toSpotOrderFromReport(report *WsExecutionReport) *exchange.Order {
+	// 撤单推送中 c 为撤单请求的ID，原订单的客户端订单ID在 C 中
+	clientOrderID := report.ClientOrderID
+	if report.OrigClientOrderID != "" {
+		clientOrderID = report.OrigClientOrderID
+	}
+	return &exchange.Order{
+		OrderID:       strconv.FormatInt(report.OrderID, 10),
+		ClientOrderID: clientOrderID,
+		Symbol:        report.Symbol,
+		Side:          exchange.OrderSide(report.Side),
+		Type:          toOrderType(string(report.Type)),
+		Status:        exchange.OrderStatus(report.Status),
+		Price:         report.Price,
+		Quantity:      report.Quantity,
+		ExecutedQty:   report.CumulativeQuantity,
+		ActualQty:     report.CumulativeQuantity,
+		QuoteQuantity: report.CumulativeQuoteQuantity,
+		TimeInForce:   exchange.OrderTimeInForce(report.TimeInForce),
+		CreateTime:    report.CreateTime,
+		UpdateTime:    report.TransactionTime,
+	}
+}
+
+// toFuturesOrderFromUpdate 转换合约订单更新
+func toFuturesOrderFromUpdate(o *WsFuturesOrder) *exchange.Order {
+	return &exchange.Order{
+		OrderID:       strconv.FormatInt(o.OrderID, 10),
+		ClientOrderID: o.ClientOrderID,
+		Symbol:        o.Symbol,
+		Side:          exchange.OrderSide(o.Side),
+		Type:          exchange.OrderType(o.Type),
+		Status:        exchange.OrderStatus(o.Status),
+		Price:         o.Price,
+		Quantity:      o.Quantity,
+		ExecutedQty:   o.CumulativeQuantity,
+		ActualQty:     o.CumulativeQuantity,
+		QuoteQuantity: mulDecimal(o.CumulativeQuantity, o.AveragePrice),
+		TimeInForce:   exchange.OrderTimeInForce(o.TimeInForce),
+		CreateTime:    o.TradeTime,
+		UpdateTime:    o.TradeTime,
+	}
+}
+
+// addDecimal 两个十进制字符串相加
+func addDecimal(a, b string) string {
+	aDecimal, err := decimal.NewFromString(a)
+	if err != nil {
+		return b
+	}
+	bDecimal, err := decimal.NewFromString(b)
+	if err != nil {
+		return a
+	}
+	return aDecimal.Add(bDecimal).String()
+}
+
+// mulDecimal 两个十进制字符串相乘
+func mulDecimal(a, b string) string {
+	aDecimal, err := decimal.NewFromString(a)
+	if err != nil {
+		return "0"
+	}
+	bDecimal, err := decimal.NewFromString(b)
+	if err != nil {
+		return "0"
+	}
+	return aDecimal.Mul(bDecimal).String()
+}
@@ -0,0 +1,132 @@
+package binance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestHandleSpotUserDataEvent 解析现货私有数据事件
+// go test -v ./impl/binance -run "^TestHandleSpotUserDataEvent$"
+func TestHandleSpotUserDataEvent(t *testing.T) {
+	var (
+		orders   []*exchange.OrderUpdate
+		fills    []*exchange.Fill
+		balances []*exchange.BalanceUpdate
+	)
+	handler := exchange.UserDataHandler{
+		OnOrder:   func(update *exchange.OrderUpdate) { orders = append(orders, update) },
+		OnFill:    func(fill *exchange.Fill) { fills = append(fills, fill) },
+		OnBalance: func(update *exchange.BalanceUpdate) { balances = append(balances, update) },
+	}
+
+	report := `{"e":"executionReport","E":1700000000100,"s":"BTCUSDT","c":"myOrder","S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"30000.00","P":"0.00","F":"0.00","g":-1,"C":"","x":"TRADE","X":"PARTIALLY_FILLED","r":"NONE","i":12345,"l":"0.40000000","z":"0.40000000","L":"29999.00","n":"0.00040000","N":"BTC","T":1700000000099,"t":678,"I":999,"w":false,"m":true,"M":true,"O":1700000000000,"Z":"11999.60","Y":"11999.60","Q":"0.00","W":1700000000000,"V":"NONE"}`
+	eventType, err := handleSpotUserDataEvent([]byte(report), handler)
+	if err != nil || eventType != "executionReport" {
+		t.Fatalf("解析订单事件失败: %s, %v", eventType, err)
+	}
+	if len(orders) != 1 || len(fills) != 1 {
+		t.Fatalf("回调次数错误: orders=%d fills=%d", len(orders), len(fills))
+	}
+	order := orders[0].Order
+	if order.OrderID != "12345" || order.Side != exchange.OrderSideBuy || order.Status != exchange.OrderStatusPartiallyFilled ||
+		order.Price != "30000.00" || order.Quantity != "1.00000000" || order.ExecutedQty != "0.40000000" ||
+		order.QuoteQuantity != "11999.60" || order.CreateTime != 1700000000000 || order.UpdateTime != 1700000000099 {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+	fill := fills[0]
+	if fill.TradeID != "678" || fill.Price != "29999.00" || fill.Quantity != "0.40000000" || fill.Fee != "0.00040000" ||
+		fill.FeeAsset != "BTC" || !fill.IsMaker || fill.Time != 1700000000099 || fill.Market != exchange.MarketSpot {
+		t.Errorf("成交数据错误: %+v", fill)
+	}
+
+	// 非成交事件不回调成交
+	canceled := `{"e":"executionReport","E":1,"s":"BTCUSDT","S":"SELL","o":"LIMIT","x":"CANCELED","X":"CANCELED","i":1,"l":"0","z":"0","L":"0","n":"0","N":null,"T":1,"t":-1}`
+	if _, err := handleSpotUserDataEvent([]byte(canceled), handler); err != nil {
+		t.Fatalf("解析撤单事件失败: %v", err)
+	}
+	if len(orders) != 2 || len(fills) != 1 || orders[1].Order.Status != exchange.OrderStatusCanceled {
+		t.Errorf("撤单事件回调错误: orders=%d fills=%d", len(orders), len(fills))
+	}
+
+	account := `{"e":"outboundAccountPosition","E":1700000000200,"u":1700000000199,"B":[{"a":"BTC","f":"1.5","l":"0.5"},{"a":"USDT","f":"100","l":"0"}]}`
+	if _, err := handleSpotUserDataEvent([]byte(account), handler); err != nil {
+		t.Fatalf("解析余额事件失败: %v", err)
+	}
+	if len(balances) != 1 || len(balances[0].Balances) != 2 {
+		t.Fatalf("余额回调错误: %+v", balances)
+	}
+	if b := balances[0].Balances[0]; b.Symbol != "BTC" || b.Free != "1.5" || b.Locked != "0.5" || b.Total != "2" {
+		t.Errorf("余额数据错误: %+v", b)
+	}
+
+	if eventType, _ := handleSpotUserDataEvent([]byte(`{"e":"listenKeyExpired","E":1,"listenKey":"abc"}`), handler); eventType != "listenKeyExpired" {
+		t.Errorf("事件类型错误: %s", eventType)
+	}
+}
+
+// TestHandleFuturesUserDataEvent 解析合约私有数据事件
+// go test -v ./impl/binance -run "^TestHandleFuturesUserDataEvent$"
+func TestHandleFuturesUserDataEvent(t *testing.T) {
+	var (
+		orders    []*exchange.OrderUpdate
+		fills     []*exchange.Fill
+		balances  []*exchange.BalanceUpdate
+		positions []*exchange.PositionUpdate
+	)
+	handler := exchange.UserDataHandler{
+		OnOrder:    func(update *exchange.OrderUpdate) { orders = append(orders, update) },
+		OnFill:     func(fill *exchange.Fill) { fills = append(fills, fill) },
+		OnBalance:  func(update *exchange.BalanceUpdate) { balances = append(balances, update) },
+		OnPosition: func(update *exchange.PositionUpdate) { positions = append(positions, update) },
+	}
+
+	orderUpdate := `{"e":"ORDER_TRADE_UPDATE","E":1700000000100,"T":1700000000099,"o":{"s":"BTCUSDT","c":"myOrder","S":"SELL","o":"LIMIT","f":"GTC","q":"0.002","p":"30000","ap":"30000.5","sp":"0","x":"TRADE","X":"FILLED","i":8886774,"l":"0.002","z":"0.002","L":"30000.5","N":"USDT","n":"0.024","T":1700000000099,"t":12345,"b":"0","a":"0","m":false,"R":false,"wt":"CONTRACT_PRICE","ot":"LIMIT","ps":"BOTH","cp":false,"AP":"0","cr":"0","pP":false,"si":0,"ss":0,"rp":"1.5","V":"NONE","pm":"NONE","gtd":0}}`
+	if _, err := handleFuturesUserDataEvent([]byte(orderUpdate), handler); err != nil {
+		t.Fatalf("解析订单事件失败: %v", err)
+	}
+	if len(orders) != 1 || len(fills) != 1 {
+		t.Fatalf("回调次数错误: orders=%d fills=%d", len(orders), len(fills))
+	}
+	order := orders[0].Order
+	if order.OrderID != "8886774" || order.Side != exchange.OrderSideSell || order.Status != exchange.OrderStatusFilled ||
+		order.ExecutedQty != "0.002" || order.QuoteQuantity != "60.001" {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+	if fill := fills[0]; fill.TradeID != "12345" || fill.Price != "30000.5" || fill.Fee != "0.024" || fill.FeeAsset != "USDT" || fill.IsMaker || fill.Market != exchange.MarketFutures {
+		t.Errorf("成交数据错误: %+v", fill)
+	}
+
+	accountUpdate := `{"e":"ACCOUNT_UPDATE","E":1700000000200,"T":1700000000199,"a":{"m":"ORDER","B":[{"a":"USDT","wb":"122.6","cw":"100.1","bc":"0"}],"P":[{"s":"BTCUSDT","pa":"-0.002","ep":"30000.5","bep":"30010","cr":"200","up":"0.1","mt":"isolated","iw":"22.5","ps":"BOTH"}]}}`
+	if _, err := handleFuturesUserDataEvent([]byte(accountUpdate), handler); err != nil {
+		t.Fatalf("解析账户事件失败: %v", err)
+	}
+	if len(balances) != 1 || balances[0].Balances[0].Total != "122.6" || balances[0].Balances[0].Free != "100.1" {
+		t.Errorf("余额数据错误: %+v", balances)
+	}
+	if len(positions) != 1 || len(positions[0].Positions) != 1 {
+		t.Fatalf("持仓回调错误: %+v", positions)
+	}
+	if p := positions[0].Positions[0]; p.Symbol != "BTCUSDT" || p.PositionAmt != "-0.002" || p.EntryPrice != "30000.5" ||
+		p.UnRealizedProfit != "0.1" || p.MarginType != "isolated" || p.IsolatedMargin != "22.5" || p.PositionSide != "BOTH" {
+		t.Errorf("持仓数据错误: %+v", p)
+	}
+}
+
+// TestUserDataListenKeyTestnet 上下文设置测试网时 listenKey 使用测试网客户端获取
+// go test -v ./impl/binance -run "^TestUserDataListenKeyTestnet$"
+func TestUserDataListenKeyTestnet(t *testing.T) {
+	b, ts := newTestBinance(t, map[string]string{"POST /api/v3/userDataStream": `{"listenKey":"testnet-key"}`})
+	b.testnetClient.BaseURL, b.client.BaseURL = b.client.BaseURL, "http://127.0.0.1:0"
+	stream := &binanceUserDataStream{ws: newBinanceWebsocket(), rest: b}
+	defer stream.Close()
+
+	// 数据流地址不可用时连接失败，只检查 listenKey 请求
+	ctx, cancel := context.WithCancel(exchange.WithTestnet(context.Background()))
+	defer cancel()
+	_ = stream.StartListenUserData(ctx, exchange.MarketSpot, exchange.UserDataHandler{})
+	if ts.findRequest("POST", "/api/v3/userDataStream") == nil {
+		t.Errorf("测试网订阅应使用测试网客户端获取 listenKey")
+	}
+}
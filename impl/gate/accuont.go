@@ -2,31 +2,30 @@ package gate
 
 import (
 	"context"
-	"math/big"
 
 	"github.com/so68/exchange-lib/exchange"
 )
 
 // GetSpotBalance 获取现货余额
 func (g *gateExchange) GetSpotBalance(ctx context.Context) ([]exchange.Balance, error) {
-	bal, _, err := g.client.SpotApi.ListSpotAccounts(ctx, nil)
+	bal, _, err := g.getClient(ctx).SpotApi.ListSpotAccounts(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, toAPIError(err)
 	}
 	var res []exchange.Balance
 	for _, account := range bal {
 		if account.Available == "0" && account.Locked == "0" {
 			continue
 		}
-		availableFloat := new(big.Float).SetPrec(64)
-		lockedFloat := new(big.Float).SetPrec(64)
-		if _, ok := availableFloat.SetString(account.Available); !ok {
+		available, err := exchange.ParseDecimal(account.Available)
+		if err != nil {
 			continue
 		}
-		if _, ok := lockedFloat.SetString(account.Locked); !ok {
+		locked, err := exchange.ParseDecimal(account.Locked)
+		if err != nil {
 			continue
 		}
-		total := new(big.Float).Add(availableFloat, lockedFloat).Text('f', -1)
+		total := available.Add(locked).String()
 		res = append(res, exchange.Balance{
 			Symbol: account.Currency,
 			Free:   account.Available,
@@ -39,28 +38,28 @@ func (g *gateExchange) GetSpotBalance(ctx context.Context) ([]exchange.Balance,
 
 // GetFuturesBalance 获取合约余额
 func (g *gateExchange) GetFuturesBalance(ctx context.Context) ([]exchange.Balance, error) {
-	account, _, err := g.client.FuturesApi.ListFuturesAccounts(ctx, Settle)
+	account, _, err := g.getClient(ctx).FuturesApi.ListFuturesAccounts(ctx, Settle)
 	if err != nil {
-		return nil, err
+		return nil, toAPIError(err)
 	}
 
 	var res []exchange.Balance
 	// 跳过余额为 0 的账户
-	availableFloat := new(big.Float).SetPrec(64)
-	orderMarginFloat := new(big.Float).SetPrec(64)
-	if _, ok := availableFloat.SetString(account.Available); !ok {
+	available, err := exchange.ParseDecimal(account.Available)
+	if err != nil {
 		return res, nil
 	}
-	if _, ok := orderMarginFloat.SetString(account.OrderMargin); !ok {
+	orderMargin, err := exchange.ParseDecimal(account.OrderMargin)
+	if err != nil {
 		return res, nil
 	}
 
-	if availableFloat.Cmp(big.NewFloat(0)) == 0 && orderMarginFloat.Cmp(big.NewFloat(0)) == 0 {
+	if available.IsZero() && orderMargin.IsZero() {
 		return res, nil
 	}
 
 	// 计算总余额：可用余额 + 订单保证金
-	total := new(big.Float).Add(availableFloat, orderMarginFloat).Text('f', -1)
+	total := available.Add(orderMargin).String()
 
 	res = append(res, exchange.Balance{
 		Symbol: account.Currency,
@@ -0,0 +1,236 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/batch"
+)
+
+const (
+	BatchOrderLimit  = 10 // 批量下单每次最多订单数
+	BatchCancelLimit = 20 // 批量撤单每次最多订单数
+)
+
+// spotBatchOrder 现货批量下单参数与交易对规格
+type spotBatchOrder struct {
+	spec   *symbolSpec
+	params gateapi.Order
+}
+
+// futuresBatchOrder 合约批量下单参数与合约规格
+type futuresBatchOrder struct {
+	spec   *futuresSpec
+	params gateapi.FuturesOrder
+}
+
+// PlaceOrders 批量下单
+func (g *gateExchange) PlaceOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	return batch.SplitMarket(reqs, func(market exchange.Market, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+		if market == exchange.MarketSpot {
+			return g.placeSpotOrders(ctx, reqs)
+		}
+		return g.placeFuturesOrders(ctx, reqs)
+	})
+}
+
+// placeSpotOrders 现货批量下单
+func (g *gateExchange) placeSpotOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	prepare := func(req exchange.OrderRequest) (spotBatchOrder, error) {
+		if err := req.Validate(); err != nil {
+			return spotBatchOrder{}, err
+		}
+		spec, params, err := g.newSpotOrderParams(ctx, req)
+		return spotBatchOrder{spec: spec, params: params}, err
+	}
+	return batch.Do(ctx, reqs, BatchOrderLimit, prepare, func(orders []spotBatchOrder) ([]exchange.OrderResult, error) {
+		params := make([]gateapi.Order, len(orders))
+		for i, order := range orders {
+			params[i] = order.params
+		}
+		resp, _, err := g.getClient(ctx).SpotApi.CreateBatchOrders(ctx, params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("批量下单失败: %w", toAPIError(err))
+		}
+		return toSpotBatchResults(orders, resp)
+	})
+}
+
+// toSpotBatchResults 现货批量下单结果转换，结果数量与请求数量不一致时返回错误
+func toSpotBatchResults(orders []spotBatchOrder, resp []gateapi.BatchOrder) ([]exchange.OrderResult, error) {
+	if len(resp) != len(orders) {
+		return nil, fmt.Errorf("批量下单结果数量 %d 与请求数量 %d 不一致", len(resp), len(orders))
+	}
+	results := make([]exchange.OrderResult, len(resp))
+	for i, item := range resp {
+		if !item.Succeeded {
+			results[i].Err = fmt.Errorf("下单失败: %w", toBatchError(item.Label, item.Message))
+			continue
+		}
+		results[i].Order, results[i].Err = toSpotOrder(orders[i].spec, toBatchSpotOrder(item))
+	}
+	return results, nil
+}
+
+// placeFuturesOrders 合约批量下单
+func (g *gateExchange) placeFuturesOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	prepare := func(req exchange.OrderRequest) (futuresBatchOrder, error) {
+		if err := req.Validate(); err != nil {
+			return futuresBatchOrder{}, err
+		}
+		spec, params, err := g.newFuturesOrderParams(ctx, req)
+		return futuresBatchOrder{spec: spec, params: params}, err
+	}
+	return batch.Do(ctx, reqs, BatchOrderLimit, prepare, func(orders []futuresBatchOrder) ([]exchange.OrderResult, error) {
+		params := make([]gateapi.FuturesOrder, len(orders))
+		for i, order := range orders {
+			params[i] = order.params
+		}
+		resp, _, err := g.getClient(ctx).FuturesApi.CreateBatchFuturesOrder(ctx, strings.ToLower(Settle), params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("合约批量下单失败: %w", toAPIError(err))
+		}
+		return toFuturesBatchResults(orders, resp)
+	})
+}
+
+// toFuturesBatchResults 合约批量下单结果转换，结果数量与请求数量不一致时返回错误
+func toFuturesBatchResults(orders []futuresBatchOrder, resp []gateapi.BatchFuturesOrder) ([]exchange.OrderResult, error) {
+	if len(resp) != len(orders) {
+		return nil, fmt.Errorf("合约批量下单结果数量 %d 与请求数量 %d 不一致", len(resp), len(orders))
+	}
+	results := make([]exchange.OrderResult, len(resp))
+	for i, item := range resp {
+		if !item.Succeeded {
+			results[i].Err = fmt.Errorf("合约下单失败: %w", toBatchError(item.Label, item.Detail))
+			continue
+		}
+		results[i].Order = toFuturesOrder(orders[i].spec, toBatchFuturesOrder(item))
+	}
+	return results, nil
+}
+
+// CancelOrders 批量撤单，批量接口只返回撤单是否成功，撤销成功的订单状态记为已撤销
+func (g *gateExchange) CancelOrders(ctx context.Context, market exchange.Market, symbol string, orderIDs []string) ([]exchange.OrderResult, error) {
+	prepare := func(orderID string) (string, error) {
+		return orderID, nil
+	}
+	switch market {
+	case exchange.MarketSpot:
+		return batch.Do(ctx, orderIDs, BatchCancelLimit, prepare, func(orderIDs []string) ([]exchange.OrderResult, error) {
+			params := make([]gateapi.CancelBatchOrder, len(orderIDs))
+			for i, orderID := range orderIDs {
+				params[i] = gateapi.CancelBatchOrder{CurrencyPair: symbol, Id: orderID}
+			}
+			resp, _, err := g.getClient(ctx).SpotApi.CancelBatchOrders(ctx, params, nil)
+			if err != nil {
+				return nil, fmt.Errorf("批量撤单失败: %w", toAPIError(err))
+			}
+			return toSpotCancelResults(symbol, len(orderIDs), resp)
+		})
+	case exchange.MarketFutures:
+		return batch.Do(ctx, orderIDs, BatchCancelLimit, prepare, func(orderIDs []string) ([]exchange.OrderResult, error) {
+			resp, _, err := g.getClient(ctx).FuturesApi.CancelBatchFutureOrders(ctx, strings.ToLower(Settle), orderIDs, nil)
+			if err != nil {
+				return nil, fmt.Errorf("合约批量撤单失败: %w", toAPIError(err))
+			}
+			return toFuturesCancelResults(symbol, len(orderIDs), resp)
+		})
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+}
+
+// toSpotCancelResults 现货批量撤单结果转换，结果数量与请求数量不一致时返回错误
+func toSpotCancelResults(symbol string, count int, resp []gateapi.CancelOrderResult) ([]exchange.OrderResult, error) {
+	if len(resp) != count {
+		return nil, fmt.Errorf("批量撤单结果数量 %d 与请求数量 %d 不一致", len(resp), count)
+	}
+	results := make([]exchange.OrderResult, len(resp))
+	for i, item := range resp {
+		if !item.Succeeded {
+			results[i].Err = fmt.Errorf("取消单个订单失败: %w", toBatchError(item.Label, item.Message))
+			continue
+		}
+		results[i].Order = toCanceledOrder(symbol, item.Id, item.Text)
+	}
+	return results, nil
+}
+
+// toFuturesCancelResults 合约批量撤单结果转换，失败时 message 为错误标签，结果数量与请求数量不一致时返回错误
+func toFuturesCancelResults(symbol string, count int, resp []gateapi.FutureCancelOrderResult) ([]exchange.OrderResult, error) {
+	if len(resp) != count {
+		return nil, fmt.Errorf("合约批量撤单结果数量 %d 与请求数量 %d 不一致", len(resp), count)
+	}
+	results := make([]exchange.OrderResult, len(resp))
+	for i, item := range resp {
+		if !item.Succeeded {
+			results[i].Err = fmt.Errorf("取消合约订单失败: %w", toBatchError(item.Message, item.Message))
+			continue
+		}
+		results[i].Order = toCanceledOrder(symbol, item.Id, "")
+	}
+	return results, nil
+}
+
+// toBatchError 批量接口单个订单的错误标签转换为 *exchange.APIError
+func toBatchError(label, message string) error {
+	return exchange.NewAPIError(errorKinds[label], label, message, nil)
+}
+
+// toCanceledOrder 批量撤单成功的订单
+func toCanceledOrder(symbol, orderID, text string) *exchange.Order {
+	return &exchange.Order{
+		OrderID:       orderID,
+		ClientOrderID: toClientOrderID(text),
+		Symbol:        symbol,
+		Status:        exchange.OrderStatusCanceled,
+	}
+}
+
+// toBatchSpotOrder 现货批量下单结果转换为订单
+func toBatchSpotOrder(order gateapi.BatchOrder) gateapi.Order {
+	return gateapi.Order{
+		Id:           order.Id,
+		Text:         order.Text,
+		CreateTimeMs: order.CreateTimeMs,
+		UpdateTimeMs: order.UpdateTimeMs,
+		Status:       order.Status,
+		CurrencyPair: order.CurrencyPair,
+		Type:         order.Type,
+		Side:         order.Side,
+		Amount:       order.Amount,
+		Price:        order.Price,
+		TimeInForce:  order.TimeInForce,
+		Iceberg:      order.Iceberg,
+		Left:         order.Left,
+		FilledAmount: order.FilledAmount,
+		FilledTotal:  order.FilledTotal,
+		AvgDealPrice: order.AvgDealPrice,
+		Fee:          order.Fee,
+		FeeCurrency:  order.FeeCurrency,
+		FinishAs:     order.FinishAs,
+	}
+}
+
+// toBatchFuturesOrder 合约批量下单结果转换为合约订单
+func toBatchFuturesOrder(order gateapi.BatchFuturesOrder) gateapi.FuturesOrder {
+	return gateapi.FuturesOrder{
+		Id:         order.Id,
+		CreateTime: order.CreateTime,
+		FinishTime: order.FinishTime,
+		FinishAs:   order.FinishAs,
+		Status:     order.Status,
+		Contract:   order.Contract,
+		Size:       order.Size,
+		Price:      order.Price,
+		ReduceOnly: order.ReduceOnly,
+		Tif:        order.Tif,
+		Left:       order.Left,
+		FillPrice:  order.FillPrice,
+		Text:       order.Text,
+	}
+}
@@ -0,0 +1,150 @@
+package gate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestBatchResults 批量下单与撤单结果按请求顺序转换，失败的错误标签映射为错误分类，结果数量不一致时返回错误
+// go test -v ./impl/gate -run "^TestBatchResults$"
+func TestBatchResults(t *testing.T) {
+	spec := &symbolSpec{AmountPrecision: 4}
+	spotOrders := []spotBatchOrder{{spec: spec}, {spec: spec}}
+	futuresSpec := &futuresSpec{QuantoMultiplier: "0.01"}
+	futuresOrders := []futuresBatchOrder{{spec: futuresSpec}, {spec: futuresSpec}}
+
+	tests := []struct {
+		name      string
+		results   func() ([]exchange.OrderResult, error)
+		wantIDs   []string // 空字符串表示失败
+		wantKinds []error
+		wantErr   bool
+	}{
+		{
+			name: "现货下单",
+			results: func() ([]exchange.OrderResult, error) {
+				return toSpotBatchResults(spotOrders, []gateapi.BatchOrder{
+					{Succeeded: true, Id: "1", CurrencyPair: "BTC_USDT", Amount: "1", FilledAmount: "0", Fee: "0"},
+					{Succeeded: false, Label: "BALANCE_NOT_ENOUGH", Message: "Not enough balance"},
+				})
+			},
+			wantIDs:   []string{"1", ""},
+			wantKinds: []error{nil, exchange.ErrInsufficientBalance},
+		},
+		{
+			name: "现货下单结果数量不一致",
+			results: func() ([]exchange.OrderResult, error) {
+				return toSpotBatchResults(spotOrders, []gateapi.BatchOrder{{Succeeded: true, Id: "1", FilledAmount: "0", Fee: "0"}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "合约下单",
+			results: func() ([]exchange.OrderResult, error) {
+				return toFuturesBatchResults(futuresOrders, []gateapi.BatchFuturesOrder{
+					{Succeeded: false, Label: "MARGIN_BALANCE_NOT_ENOUGH", Detail: "margin not enough"},
+					{Succeeded: true, Id: 2, Contract: "BTC_USDT", Size: 1},
+				})
+			},
+			wantIDs:   []string{"", "2"},
+			wantKinds: []error{exchange.ErrInsufficientBalance, nil},
+		},
+		{
+			name: "合约下单结果数量不一致",
+			results: func() ([]exchange.OrderResult, error) {
+				return toFuturesBatchResults(futuresOrders[:1], nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "现货撤单",
+			results: func() ([]exchange.OrderResult, error) {
+				return toSpotCancelResults("BTC_USDT", 2, []gateapi.CancelOrderResult{
+					{Succeeded: true, Id: "1", Text: "t-abc"},
+					{Succeeded: false, Id: "2", Label: "ORDER_NOT_FOUND", Message: "Order not found"},
+				})
+			},
+			wantIDs:   []string{"1", ""},
+			wantKinds: []error{nil, exchange.ErrOrderNotFound},
+		},
+		{
+			name: "合约撤单",
+			results: func() ([]exchange.OrderResult, error) {
+				return toFuturesCancelResults("BTC_USDT", 2, []gateapi.FutureCancelOrderResult{
+					{Succeeded: false, Id: "1", Message: "ORDER_NOT_FOUND"},
+					{Succeeded: true, Id: "2"},
+				})
+			},
+			wantIDs:   []string{"", "2"},
+			wantKinds: []error{exchange.ErrOrderNotFound, nil},
+		},
+		{
+			name: "合约撤单结果数量不一致",
+			results: func() ([]exchange.OrderResult, error) {
+				return toFuturesCancelResults("BTC_USDT", 3, []gateapi.FutureCancelOrderResult{{Succeeded: true, Id: "1"}})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := tt.results()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("结果数量不一致应返回错误")
+				}
+				return
+			}
+			if err != nil || len(results) != len(tt.wantIDs) {
+				t.Fatalf("结果错误: %v, %+v", err, results)
+			}
+			for i, result := range results {
+				if tt.wantIDs[i] == "" {
+					if result.Order != nil || !errors.Is(result.Err, tt.wantKinds[i]) {
+						t.Errorf("第 %d 个结果应返回 %v: %+v", i+1, tt.wantKinds[i], result)
+					}
+					continue
+				}
+				if result.Err != nil || result.Order == nil || result.Order.OrderID != tt.wantIDs[i] {
+					t.Errorf("第 %d 个结果错误: %+v", i+1, result)
+				}
+			}
+		})
+	}
+}
+
+// TestToFuturesBatchResults 合约批量下单结果按各订单的合约乘数转换为基础资产数量，价格为委托价格，已成交数量为委托张数减去剩余张数
+// go test -v ./impl/gate -run "^TestToFuturesBatchResults$"
+func TestToFuturesBatchResults(t *testing.T) {
+	orders := []futuresBatchOrder{
+		{spec: &futuresSpec{QuantoMultiplier: "0.01"}},
+		{spec: &futuresSpec{QuantoMultiplier: "10"}},
+	}
+	results, err := toFuturesBatchResults(orders, []gateapi.BatchFuturesOrder{
+		{Succeeded: true, Id: 1, Contract: "BTC_USDT", Size: 30, Left: 10, Price: "100", FillPrice: "99.5", Status: "open", Tif: "gtc"},
+		{Succeeded: true, Id: 2, Contract: "DOGE_USDT", Size: -5, Left: -5, Price: "0.1", FillPrice: "0", Status: "open", Tif: "gtc"},
+	})
+	if err != nil {
+		t.Fatalf("结果转换失败: %v", err)
+	}
+	tests := []struct {
+		side        exchange.OrderSide
+		status      exchange.OrderStatus
+		price       string
+		quantity    string
+		executedQty string
+	}{
+		{exchange.OrderSideBuy, exchange.OrderStatusPartiallyFilled, "100", "0.3", "0.2"},
+		{exchange.OrderSideSell, exchange.OrderStatusNew, "0.1", "50", "0"},
+	}
+	for i, tt := range tests {
+		order := results[i].Order
+		if order == nil || order.Side != tt.side || order.Status != tt.status || order.Price != tt.price ||
+			order.Quantity != tt.quantity || order.ExecutedQty != tt.executedQty {
+			t.Errorf("第 %d 个订单转换错误: %+v", i+1, order)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+const (
+	TriggerOrderExpiration = 7 * 24 * 3600 // 现货条件单等待触发的最长时间，秒
+)
+
+// PlaceConditionalOrder 下条件单，使用价格触发订单，不支持跟踪止损与 OCO
+func (g *gateExchange) PlaceConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Type == exchange.ConditionalOrderTypeTrailingStop || req.Type == exchange.ConditionalOrderTypeOCO {
+		return nil, fmt.Errorf("%w: Gate 不支持 %s", exchange.ErrInvalidOrder, req.Type)
+	}
+	if req.Market == exchange.MarketSpot {
+		return g.placeSpotConditionalOrder(ctx, req)
+	}
+	return g.placeFuturesConditionalOrder(ctx, req)
+}
+
+// ListConditionalOrders 获取交易对未触发的条件单
+func (g *gateExchange) ListConditionalOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.ConditionalOrder, error) {
+	if market == exchange.MarketSpot {
+		orders, _, err := g.getClient(ctx).SpotApi.ListSpotPriceTriggeredOrders(ctx, "open", &gateapi.ListSpotPriceTriggeredOrdersOpts{
+			Market: optional.NewString(symbol),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取现货条件单失败: %w", toAPIError(err))
+		}
+		result := make([]*exchange.ConditionalOrder, 0, len(orders))
+		for _, order := range orders {
+			result = append(result, toSpotConditionalOrder(order))
+		}
+		return result, nil
+	}
+
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	orders, _, err := g.getClient(ctx).FuturesApi.ListPriceTriggeredOrders(ctx, strings.ToLower(Settle), "open", &gateapi.ListPriceTriggeredOrdersOpts{
+		Contract: optional.NewString(symbol),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取合约条件单失败: %w", toAPIError(err))
+	}
+	result := make([]*exchange.ConditionalOrder, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, toFuturesConditionalOrder(spec, order))
+	}
+	return result, nil
+}
+
+// GetConditionalOrder 获取条件单
+func (g *gateExchange) GetConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) (*exchange.ConditionalOrder, error) {
+	if market == exchange.MarketSpot {
+		order, _, err := g.getClient(ctx).SpotApi.GetSpotPriceTriggeredOrder(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("获取现货条件单失败: %w", toAPIError(err))
+		}
+		return toSpotConditionalOrder(order), nil
+	}
+
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	order, _, err := g.getClient(ctx).FuturesApi.GetPriceTriggeredOrder(ctx, strings.ToLower(Settle), id)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约条件单失败: %w", toAPIError(err))
+	}
+	return toFuturesConditionalOrder(spec, order), nil
+}
+
+// CancelConditionalOrder 撤销未触发的条件单
+func (g *gateExchange) CancelConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) error {
+	var err error
+	if market == exchange.MarketSpot {
+		_, _, err = g.getClient(ctx).SpotApi.CancelSpotPriceTriggeredOrder(ctx, id)
+	} else {
+		_, _, err = g.getClient(ctx).FuturesApi.CancelPriceTriggeredOrder(ctx, strings.ToLower(Settle), id)
+	}
+	if err != nil {
+		return fmt.Errorf("撤销条件单失败: %w", toAPIError(err))
+	}
+	return nil
+}
+
+// placeSpotConditionalOrder 现货条件单。Gate 市价买入按金额下单，数量按触发价折算为金额
+func (g *gateExchange) placeSpotConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	spec, err := g.GetSpotSymbolSpec(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := g.filtersQuantity(spec, req.Price, req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	put := gateapi.SpotPricePutOrder{
+		Type:        "limit",
+		Side:        strings.ToLower(string(req.Side)),
+		Price:       req.Price,
+		Amount:      quantity,
+		Account:     "normal",
+		TimeInForce: "gtc",
+		Text:        toText(clientOrderID),
+	}
+	if !req.Type.IsLimit() {
+		put.Type, put.Price, put.TimeInForce = "market", "0", "ioc"
+		if req.Side == exchange.OrderSideBuy {
+			put.Amount = exchange.ToDecimal(quantity).Mul(exchange.ToDecimal(req.TriggerPrice)).RoundCeil(int32(spec.Precision)).String()
+		}
+	}
+
+	rule := "<="
+	if triggerAbove(req.Side, req.Type) {
+		rule = ">="
+	}
+	resp, _, err := g.getClient(ctx).SpotApi.CreateSpotPriceTriggeredOrder(ctx, gateapi.SpotPriceTriggeredOrder{
+		Trigger: gateapi.SpotPriceTrigger{Price: req.TriggerPrice, Rule: rule, Expiration: TriggerOrderExpiration},
+		Put:     put,
+		Market:  req.Symbol,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("下条件单失败: %w", toAPIError(err))
+	}
+	return newConditionalOrder(req, strconv.FormatInt(resp.Id, 10), clientOrderID, quantity), nil
+}
+
+// placeFuturesConditionalOrder 合约条件单，按最新价触发，数量按合约乘数转换为张数
+func (g *gateExchange) placeFuturesConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	spec, err := g.GetFuturesSymbolSpec(ctx, req.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	size, err := g.filtersFuturesQuantity(spec, req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+	quantity := sizeToQuantity(size, spec.QuantoMultiplier)
+	if req.Side == exchange.OrderSideSell {
+		size = -size
+	}
+
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	initial := gateapi.FuturesInitialOrder{
+		Contract: req.Symbol,
+		Size:     size,
+		Price:    req.Price,
+		Tif:      "gtc",
+		ReduceOnly: req.ReduceOnly ||
+			(req.PositionSide == exchange.PositionSideLong && req.Side == exchange.OrderSideSell) ||
+			(req.PositionSide == exchange.PositionSideShort && req.Side == exchange.OrderSideBuy),
+		Text: toText(clientOrderID),
+	}
+	if !req.Type.IsLimit() {
+		initial.Price, initial.Tif = "0", "ioc"
+	}
+
+	// 触发规则：1 为价格大于等于触发价，2 为小于等于
+	var rule int32 = 2
+	if triggerAbove(req.Side, req.Type) {
+		rule = 1
+	}
+	resp, _, err := g.getClient(ctx).FuturesApi.CreatePriceTriggeredOrder(ctx, strings.ToLower(Settle), gateapi.FuturesPriceTriggeredOrder{
+		Initial: initial,
+		Trigger: gateapi.FuturesPriceTrigger{PriceType: 0, Price: req.TriggerPrice, Rule: rule},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("下条件单失败: %w", toAPIError(err))
+	}
+	return newConditionalOrder(req, strconv.FormatInt(resp.Id, 10), clientOrderID, quantity), nil
+}
+
+// triggerAbove 价格上涨触发：买入止损与卖出止盈，其他为价格下跌触发
+func triggerAbove(side exchange.OrderSide, orderType exchange.ConditionalOrderType) bool {
+	return (side == exchange.OrderSideBuy) != orderType.IsTakeProfit()
+}
+
+// toConditionalType 按触发方向与委托价还原条件单类型
+func toConditionalType(side exchange.OrderSide, above, limit bool) exchange.ConditionalOrderType {
+	takeProfit := (side == exchange.OrderSideBuy) != above
+	switch {
+	case takeProfit && limit:
+		return exchange.ConditionalOrderTypeTakeProfitLimit
+	case takeProfit:
+		return exchange.ConditionalOrderTypeTakeProfitMarket
+	case limit:
+		return exchange.ConditionalOrderTypeStopLimit
+	default:
+		return exchange.ConditionalOrderTypeStopMarket
+	}
+}
+
+// toConditionalStatus 转换条件单状态，finishAs 仅合约返回
+func toConditionalStatus(status, finishAs string) exchange.ConditionalOrderStatus {
+	switch status {
+	case "open":
+		return exchange.ConditionalOrderStatusUntriggered
+	case "cancelled":
+		return exchange.ConditionalOrderStatusCanceled
+	case "finished":
+		switch finishAs {
+		case "", "succeeded":
+			return exchange.ConditionalOrderStatusTriggered
+		case "cancelled":
+			return exchange.ConditionalOrderStatusCanceled
+		}
+	}
+	return exchange.ConditionalOrderStatusFailed
+}
+
+// toSpotConditionalOrder 转换现货条件单
+func toSpotConditionalOrder(order gateapi.SpotPriceTriggeredOrder) *exchange.ConditionalOrder {
+	side := exchange.OrderSide(strings.ToUpper(order.Put.Side))
+	limit := order.Put.Type != "market"
+	conditional := &exchange.ConditionalOrder{
+		ID:            strconv.FormatInt(order.Id, 10),
+		ClientOrderID: toClientOrderID(order.Put.Text),
+		Market:        exchange.MarketSpot,
+		Symbol:        order.Market,
+		Side:          side,
+		Type:          toConditionalType(side, order.Trigger.Rule == ">=", limit),
+		Status:        toConditionalStatus(order.Status, ""),
+		Quantity:      order.Put.Amount,
+		TriggerPrice:  order.Trigger.Price,
+		CreateTime:    order.Ctime * 1000,
+		UpdateTime:    order.Ftime * 1000,
+	}
+	if limit {
+		conditional.Price = order.Put.Price
+	}
+	if order.FiredOrderId != 0 {
+		conditional.OrderID = strconv.FormatInt(order.FiredOrderId, 10)
+	}
+	if conditional.UpdateTime == 0 {
+		conditional.UpdateTime = conditional.CreateTime
+	}
+	return conditional
+}
+
+// toFuturesConditionalOrder 转换合约条件单，数量转换为基础资产数量
+func toFuturesConditionalOrder(spec *futuresSpec, order gateapi.FuturesPriceTriggeredOrder) *exchange.ConditionalOrder {
+	side := exchange.OrderSideBuy
+	size := order.Initial.Size
+	if size < 0 {
+		side, size = exchange.OrderSideSell, -size
+	}
+	limit := order.Initial.Price != "" && order.Initial.Price != "0"
+	conditional := &exchange.ConditionalOrder{
+		ID:            strconv.FormatInt(order.Id, 10),
+		ClientOrderID: toClientOrderID(order.Initial.Text),
+		Market:        exchange.MarketFutures,
+		Symbol:        order.Initial.Contract,
+		Side:          side,
+		Type:          toConditionalType(side, order.Trigger.Rule == 1, limit),
+		Status:        toConditionalStatus(order.Status, order.FinishAs),
+		Quantity:      sizeToQuantity(size, spec.QuantoMultiplier),
+		TriggerPrice:  order.Trigger.Price,
+		CreateTime:    int64(order.CreateTime * 1000),
+		UpdateTime:    int64(order.FinishTime * 1000),
+	}
+	if limit {
+		conditional.Price = order.Initial.Price
+	}
+	if order.MeOrderId != 0 {
+		conditional.OrderID = strconv.FormatInt(order.MeOrderId, 10)
+	}
+	if conditional.UpdateTime == 0 {
+		conditional.UpdateTime = conditional.CreateTime
+	}
+	return conditional
+}
+
+// newConditionalOrder 按请求构造新建的条件单
+func newConditionalOrder(req exchange.ConditionalOrderRequest, id, clientOrderID, quantity string) *exchange.ConditionalOrder {
+	return &exchange.ConditionalOrder{
+		ID:            id,
+		ClientOrderID: clientOrderID,
+		Market:        req.Market,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		Type:          req.Type,
+		Status:        exchange.ConditionalOrderStatusUntriggered,
+		Quantity:      quantity,
+		TriggerPrice:  req.TriggerPrice,
+		Price:         req.Price,
+	}
+}
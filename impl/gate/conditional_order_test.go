@@ -0,0 +1,85 @@
+package gate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestConditionalTrigger 止损止盈按方向确定触发规则，并可由触发规则还原条件单类型
+// go test -v ./impl/gate -run "^TestConditionalTrigger$"
+func TestConditionalTrigger(t *testing.T) {
+	cases := []struct {
+		side      exchange.OrderSide
+		orderType exchange.ConditionalOrderType
+		above     bool
+	}{
+		{exchange.OrderSideSell, exchange.ConditionalOrderTypeStopMarket, false},
+		{exchange.OrderSideBuy, exchange.ConditionalOrderTypeStopLimit, true},
+		{exchange.OrderSideSell, exchange.ConditionalOrderTypeTakeProfitLimit, true},
+		{exchange.OrderSideBuy, exchange.ConditionalOrderTypeTakeProfitMarket, false},
+	}
+	for _, c := range cases {
+		if above := triggerAbove(c.side, c.orderType); above != c.above {
+			t.Errorf("triggerAbove(%s, %s) = %v", c.side, c.orderType, above)
+		}
+		if orderType := toConditionalType(c.side, c.above, c.orderType.IsLimit()); orderType != c.orderType {
+			t.Errorf("toConditionalType(%s, %v) = %s, want %s", c.side, c.above, orderType, c.orderType)
+		}
+	}
+}
+
+// TestToFuturesConditionalOrder 合约条件单张数转换为基础资产数量，状态按结束原因转换
+// go test -v ./impl/gate -run "^TestToFuturesConditionalOrder$"
+func TestToFuturesConditionalOrder(t *testing.T) {
+	spec := &futuresSpec{QuantoMultiplier: "0.01"}
+	order := toFuturesConditionalOrder(spec, gateapi.FuturesPriceTriggeredOrder{
+		Id:         7,
+		Initial:    gateapi.FuturesInitialOrder{Contract: "BTC_USDT", Size: -12, Price: "0", Text: "t-sl1"},
+		Trigger:    gateapi.FuturesPriceTrigger{Price: "90", Rule: 2},
+		Status:     "finished",
+		FinishAs:   "succeeded",
+		MeOrderId:  99,
+		CreateTime: 1700000000.5,
+	})
+	if order.ID != "7" || order.ClientOrderID != "sl1" || order.Side != exchange.OrderSideSell || order.Quantity != "0.12" ||
+		order.Type != exchange.ConditionalOrderTypeStopMarket || order.Status != exchange.ConditionalOrderStatusTriggered ||
+		order.OrderID != "99" || order.CreateTime != 1700000000500 {
+		t.Errorf("合约条件单转换错误: %+v", order)
+	}
+	if status := toConditionalStatus("finished", "cancelled"); status != exchange.ConditionalOrderStatusCanceled {
+		t.Errorf("toConditionalStatus() = %s", status)
+	}
+}
+
+// TestGetConditionalOrder 查询已触发与已撤销的条件单
+// go test -v ./impl/gate -run "^TestGetConditionalOrder$"
+func TestGetConditionalOrder(t *testing.T) {
+	g, _ := newTestGate(t, map[string]string{
+		"GET /spot/price_orders/3": `{"id":3,"market":"BTC_USDT","status":"cancelled","ctime":1700000000,"ftime":1700000100,
+			"trigger":{"price":"110","rule":">=","expiration":604800},"put":{"type":"limit","side":"sell","price":"109","amount":"0.5","text":"t-tp1"}}`,
+		"GET /futures/usdt/price_orders/7": `{"id":7,"status":"finished","finish_as":"succeeded","me_order_id":99,"create_time":1700000000,"finish_time":1700000100,
+			"initial":{"contract":"BTC_USDT","size":-12,"price":"0","text":"t-sl1"},"trigger":{"price":"90","rule":2}}`,
+	})
+	g.getFuturesSpec(context.Background()).SetFuturesSpec("BTC_USDT", &futuresSpec{Name: "BTC_USDT", QuantoMultiplier: "0.01"})
+
+	order, err := g.GetConditionalOrder(context.Background(), exchange.MarketSpot, "BTC_USDT", "3")
+	if err != nil {
+		t.Fatalf("获取现货条件单失败: %v", err)
+	}
+	if order.Type != exchange.ConditionalOrderTypeTakeProfitLimit || order.Status != exchange.ConditionalOrderStatusCanceled ||
+		order.ClientOrderID != "tp1" || order.Price != "109" || order.UpdateTime != 1700000100000 {
+		t.Errorf("现货条件单转换错误: %+v", order)
+	}
+
+	order, err = g.GetConditionalOrder(context.Background(), exchange.MarketFutures, "BTC_USDT", "7")
+	if err != nil {
+		t.Fatalf("获取合约条件单失败: %v", err)
+	}
+	if order.Type != exchange.ConditionalOrderTypeStopMarket || order.Status != exchange.ConditionalOrderStatusTriggered ||
+		order.OrderID != "99" || order.Quantity != "0.12" {
+		t.Errorf("合约条件单转换错误: %+v", order)
+	}
+}
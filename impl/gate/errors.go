@@ -0,0 +1,52 @@
+package gate
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// errorKinds Gate 错误标签对应的错误分类
+var errorKinds = map[string]error{
+	"BALANCE_NOT_ENOUGH":        exchange.ErrInsufficientBalance,
+	"MARGIN_BALANCE_NOT_ENOUGH": exchange.ErrInsufficientBalance,
+	"INSUFFICIENT_AVAILABLE":    exchange.ErrInsufficientBalance,
+	"ORDER_NOT_FOUND":           exchange.ErrOrderNotFound,
+	"TOO_MANY_REQUESTS":         exchange.ErrRateLimited,
+	"INVALID_PRECISION":         exchange.ErrInvalidPrecision,
+	"AMOUNT_TOO_LITTLE":         exchange.ErrMinNotional,
+	"SIZE_TOO_SMALL":            exchange.ErrMinNotional,
+	"INVALID_KEY":               exchange.ErrAuth,
+	"INVALID_CREDENTIALS":       exchange.ErrAuth,
+	"INVALID_SIGNATURE":         exchange.ErrAuth,
+	"IP_FORBIDDEN":              exchange.ErrAuth,
+	"READ_ONLY":                 exchange.ErrAuth,
+	"REQUEST_EXPIRED":           exchange.ErrTimestampSkew,
+	"SERVER_ERROR":              exchange.ErrTemporary,
+	"TOO_BUSY":                  exchange.ErrTemporary,
+}
+
+// toAPIError Gate 接口错误转换为 *exchange.APIError。
+// 无错误标签时 429 为限频，5xx 等网关响应与无法解析的响应体按临时错误返回，其他错误原样返回
+func toAPIError(err error) error {
+	var apiErr gateapi.GateAPIError
+	if errors.As(err, &apiErr) {
+		return exchange.NewAPIError(errorKinds[apiErr.Label], apiErr.Label, apiErr.GetMessage(), err)
+	}
+	var openAPIErr gateapi.GenericOpenAPIError
+	if errors.As(err, &openAPIErr) {
+		// 错误响应的信息以 HTTP 状态开头，响应体解析失败时为解析错误，没有状态码
+		status, _, _ := strings.Cut(openAPIErr.Error(), " ")
+		if _, err := strconv.Atoi(status); err != nil {
+			status = ""
+		}
+		if status == "429" {
+			return exchange.NewAPIError(exchange.ErrRateLimited, status, string(openAPIErr.Body()), err)
+		}
+		return exchange.NewAPIError(exchange.ErrTemporary, status, string(openAPIErr.Body()), err)
+	}
+	return err
+}
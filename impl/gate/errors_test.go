@@ -0,0 +1,77 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+// TestToAPIError Gate 错误标签映射为错误分类
+// go test -v ./impl/gate -run "^TestToAPIError$"
+func TestToAPIError(t *testing.T) {
+	cases := []struct {
+		label string
+		want  error
+	}{
+		{"BALANCE_NOT_ENOUGH", exchange.ErrInsufficientBalance},
+		{"INSUFFICIENT_AVAILABLE", exchange.ErrInsufficientBalance},
+		{"ORDER_NOT_FOUND", exchange.ErrOrderNotFound},
+		{"TOO_MANY_REQUESTS", exchange.ErrRateLimited},
+		{"INVALID_PRECISION", exchange.ErrInvalidPrecision},
+		{"AMOUNT_TOO_LITTLE", exchange.ErrMinNotional},
+		{"INVALID_SIGNATURE", exchange.ErrAuth},
+		{"REQUEST_EXPIRED", exchange.ErrTimestampSkew},
+	}
+	for _, c := range cases {
+		err := fmt.Errorf("下单失败: %w", toAPIError(gateapi.GateAPIError{Label: c.label, Message: "message"}))
+		if !errors.Is(err, c.want) {
+			t.Errorf("toAPIError(%s) = %v, want %v", c.label, err, c.want)
+		}
+		var apiErr *exchange.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != c.label || apiErr.Message != "message" {
+			t.Errorf("原生错误码错误: %+v", apiErr)
+		}
+	}
+
+	// 非接口错误原样返回
+	cause := errors.New("network error")
+	if err := toAPIError(cause); err != cause {
+		t.Errorf("toAPIError() = %v", err)
+	}
+}
+
+// TestGatewayError 无错误标签时 429 为限频，5xx 等网关响应与无法解析的响应体为临时错误，下单时按客户端订单ID对账
+// go test -v ./impl/gate -run "^TestGatewayError$"
+func TestGatewayError(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusBadGateway, "<html><body>502 Bad Gateway</body></html>", exchange.ErrTemporary},
+		{http.StatusServiceUnavailable, "", exchange.ErrTemporary},
+		{http.StatusOK, "<html></html>", exchange.ErrTemporary},
+		{http.StatusTooManyRequests, "", exchange.ErrRateLimited},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		g := newGateExchange(apiKey, secretKey, exchange.WithSpotBaseURL(server.URL))
+		g.getSpotSpec(context.Background()).SetSymbolSpec("BTC_USDT", &symbolSpec{Id: "BTC_USDT", AmountPrecision: 4})
+		_, err := g.ListOpenOrders(context.Background(), exchange.MarketSpot, "BTC_USDT")
+		if !errors.Is(err, tt.want) || idempotent.IsTemporary(err) != (tt.want == exchange.ErrTemporary) {
+			t.Errorf("%d %q 应为 %v: %v", tt.status, tt.body, tt.want, err)
+		}
+		server.Close()
+	}
+	gateSpotSpec.DeleteSymbolsSpec()
+}
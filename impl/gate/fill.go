@@ -0,0 +1,163 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// ListMyTrades 按时间倒序分页查询成交明细，现货游标为页码，合约游标为偏移量。
+// 现货未指定时间时只返回最近七天的成交；合约按订单ID查询时忽略时间范围，成交记录不包含已实现盈亏；
+// 使用点卡或 GT 抵扣的手续费记录为对应币种
+func (g *gateExchange) ListMyTrades(ctx context.Context, market exchange.Market, query exchange.FillQuery) (*exchange.FillPage, error) {
+	limit := int32(query.Limit)
+	if limit <= 0 {
+		limit = OrderListLimit
+	}
+	cursor := int32(0)
+	if query.Cursor != "" {
+		n, err := strconv.ParseInt(query.Cursor, 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("无效的分页游标: %s", query.Cursor)
+		}
+		cursor = int32(n)
+	}
+
+	page := &exchange.FillPage{Fills: make([]*exchange.Fill, 0)}
+	switch market {
+	case exchange.MarketSpot:
+		pageNum := max(cursor, 1)
+		opts := &gateapi.ListMyTradesOpts{
+			CurrencyPair: optional.NewString(query.Symbol),
+			Page:         optional.NewInt32(pageNum),
+			Limit:        optional.NewInt32(limit),
+		}
+		if query.OrderID != "" {
+			opts.OrderId = optional.NewString(query.OrderID)
+		}
+		if !query.StartTime.IsZero() {
+			opts.From = optional.NewInt64(query.StartTime.Unix())
+		}
+		if !query.EndTime.IsZero() {
+			opts.To = optional.NewInt64(query.EndTime.Unix())
+		}
+		trades, _, err := g.getClient(ctx).SpotApi.ListMyTrades(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("获取现货成交明细失败: %w", toAPIError(err))
+		}
+		for _, t := range trades {
+			page.Fills = append(page.Fills, toSpotFill(t))
+		}
+		if len(trades) >= int(limit) {
+			page.NextCursor = strconv.Itoa(int(pageNum + 1))
+		}
+	case exchange.MarketFutures:
+		spec, err := g.GetFuturesSymbolSpec(ctx, query.Symbol)
+		if err != nil {
+			return nil, fmt.Errorf("获取交易规则失败: %w", err)
+		}
+		var count int
+		if query.OrderID != "" || (query.StartTime.IsZero() && query.EndTime.IsZero()) {
+			opts := &gateapi.GetMyTradesOpts{
+				Contract: optional.NewString(query.Symbol),
+				Limit:    optional.NewInt32(limit),
+				Offset:   optional.NewInt32(cursor),
+			}
+			if query.OrderID != "" {
+				orderID, err := strconv.ParseInt(query.OrderID, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("无效的订单ID: %w", err)
+				}
+				opts.Order = optional.NewInt64(orderID)
+			}
+			trades, _, err := g.getClient(ctx).FuturesApi.GetMyTrades(ctx, strings.ToLower(Settle), opts)
+			if err != nil {
+				return nil, fmt.Errorf("获取合约成交明细失败: %w", toAPIError(err))
+			}
+			for _, t := range trades {
+				page.Fills = append(page.Fills, toFuturesFill(spec, t.Contract, t.OrderId, strconv.FormatInt(t.Id, 10), t.Size, t.Price, t.Role, t.Fee, t.PointFee, t.CreateTime))
+			}
+			count = len(trades)
+		} else {
+			opts := &gateapi.GetMyTradesWithTimeRangeOpts{
+				Contract: optional.NewString(query.Symbol),
+				Limit:    optional.NewInt32(limit),
+				Offset:   optional.NewInt32(cursor),
+			}
+			if !query.StartTime.IsZero() {
+				opts.From = optional.NewInt64(query.StartTime.Unix())
+			}
+			if !query.EndTime.IsZero() {
+				opts.To = optional.NewInt64(query.EndTime.Unix())
+			}
+			trades, _, err := g.getClient(ctx).FuturesApi.GetMyTradesWithTimeRange(ctx, strings.ToLower(Settle), opts)
+			if err != nil {
+				return nil, fmt.Errorf("获取合约成交明细失败: %w", toAPIError(err))
+			}
+			for _, t := range trades {
+				page.Fills = append(page.Fills, toFuturesFill(spec, t.Contract, t.OrderId, t.TradeId, t.Size, t.Price, t.Role, t.Fee, t.PointFee, t.CreateTime))
+			}
+			count = len(trades)
+		}
+		if count >= int(limit) {
+			page.NextCursor = strconv.Itoa(int(cursor + limit))
+		}
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+	return page, nil
+}
+
+// toSpotFill 转换现货成交明细，手续费为 0 时使用 GT 或点卡抵扣的手续费
+func toSpotFill(t gateapi.Trade) *exchange.Fill {
+	fee, feeAsset := t.Fee, t.FeeCurrency
+	switch {
+	case exchange.ToDecimal(fee).IsZero() && exchange.ToDecimal(t.GtFee).IsPositive():
+		fee, feeAsset = t.GtFee, "GT"
+	case exchange.ToDecimal(fee).IsZero() && exchange.ToDecimal(t.PointFee).IsPositive():
+		fee, feeAsset = t.PointFee, "POINT"
+	}
+	return &exchange.Fill{
+		Market:   exchange.MarketSpot,
+		Symbol:   t.CurrencyPair,
+		OrderID:  t.OrderId,
+		TradeID:  t.Id,
+		Side:     exchange.OrderSide(strings.ToUpper(t.Side)),
+		Price:    t.Price,
+		Quantity: t.Amount,
+		Fee:      fee,
+		FeeAsset: feeAsset,
+		IsMaker:  t.Role == "maker",
+		Time:     parseTimeMs(t.CreateTimeMs),
+	}
+}
+
+// toFuturesFill 转换合约成交明细，张数按合约乘数转换为基础资产数量，创建时间单位为秒
+func toFuturesFill(spec *futuresSpec, contract, orderID, tradeID string, size int64, price, role, fee, pointFee string, createTime float64) *exchange.Fill {
+	side := exchange.OrderSideBuy
+	if size < 0 {
+		side, size = exchange.OrderSideSell, -size
+	}
+	feeAsset := Settle
+	if exchange.ToDecimal(fee).IsZero() && exchange.ToDecimal(pointFee).IsPositive() {
+		fee, feeAsset = pointFee, "POINT"
+	}
+	return &exchange.Fill{
+		Market:   exchange.MarketFutures,
+		Symbol:   contract,
+		OrderID:  orderID,
+		TradeID:  tradeID,
+		Side:     side,
+		Price:    price,
+		Quantity: sizeToQuantity(size, spec.QuantoMultiplier),
+		Fee:      fee,
+		FeeAsset: feeAsset,
+		IsMaker:  role == "maker",
+		Time:     secondsToMs(createTime),
+	}
+}
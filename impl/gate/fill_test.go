@@ -0,0 +1,52 @@
+package gate
+
+import (
+	"testing"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestToSpotFill 现货手续费为 0 时使用 GT 或点卡抵扣的手续费
+// go test -v ./impl/gate -run "^TestToSpotFill$"
+func TestToSpotFill(t *testing.T) {
+	tests := []struct {
+		trade    gateapi.Trade
+		fee      string
+		feeAsset string
+	}{
+		{gateapi.Trade{Fee: "0.001", FeeCurrency: "BTC", GtFee: "0", PointFee: "0"}, "0.001", "BTC"},
+		{gateapi.Trade{Fee: "0", FeeCurrency: "BTC", GtFee: "0.02", PointFee: "0"}, "0.02", "GT"},
+		{gateapi.Trade{Fee: "0", FeeCurrency: "BTC", GtFee: "0", PointFee: "0.3"}, "0.3", "POINT"},
+	}
+	for _, tt := range tests {
+		tt.trade.Id, tt.trade.OrderId, tt.trade.CurrencyPair = "11", "1", "BTC_USDT"
+		tt.trade.Side, tt.trade.Role, tt.trade.Price, tt.trade.Amount = "buy", "maker", "100", "0.5"
+		tt.trade.CreateTimeMs = "1700000000123.456"
+		fill := toSpotFill(tt.trade)
+		if fill.Fee != tt.fee || fill.FeeAsset != tt.feeAsset {
+			t.Errorf("手续费转换错误: %s %s, want %s %s", fill.Fee, fill.FeeAsset, tt.fee, tt.feeAsset)
+		}
+		if fill.Side != exchange.OrderSideBuy || !fill.IsMaker || fill.TradeID != "11" || fill.Time != 1700000000123 {
+			t.Errorf("现货成交转换错误: %+v", fill)
+		}
+	}
+}
+
+// TestToFuturesFill 合约张数转换为基础资产数量，负数张数为卖出，点卡抵扣时手续费币种为 POINT，不返回已实现盈亏
+// go test -v ./impl/gate -run "^TestToFuturesFill$"
+func TestToFuturesFill(t *testing.T) {
+	spec := &futuresSpec{QuantoMultiplier: "0.01"}
+	fill := toFuturesFill(spec, "BTC_USDT", "2", "21", -30, "101", "taker", "0.06", "0", 1700000001.5)
+	want := exchange.Fill{
+		Market: exchange.MarketFutures, Symbol: "BTC_USDT", OrderID: "2", TradeID: "21", Side: exchange.OrderSideSell,
+		Price: "101", Quantity: "0.3", Fee: "0.06", FeeAsset: "USDT", Time: 1700000001500,
+	}
+	if *fill != want {
+		t.Errorf("合约成交转换错误: %+v", fill)
+	}
+	if fill := toFuturesFill(spec, "BTC_USDT", "2", "22", 10, "101", "maker", "0", "0.05", 1700000001); fill.Fee != "0.05" ||
+		fill.FeeAsset != "POINT" || fill.Side != exchange.OrderSideBuy || !fill.IsMaker {
+		t.Errorf("点卡手续费转换错误: %+v", fill)
+	}
+}
@@ -0,0 +1,103 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// GetFundingRate 获取合约当前资金费率，实时查询合约信息，不使用缓存的合约规格
+func (g *gateExchange) GetFundingRate(ctx context.Context, symbol string) (*exchange.FundingRate, error) {
+	contract, _, err := g.getClient(ctx).FuturesApi.GetFuturesContract(ctx, strings.ToLower(Settle), symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取资金费率失败: %w", toAPIError(err))
+	}
+	return &exchange.FundingRate{
+		Symbol:      contract.Name,
+		FundingRate: contract.FundingRate,
+		FundingTime: secondsToMs(contract.FundingNextApply),
+	}, nil
+}
+
+// GetFundingRateHistory 获取合约历史资金费率，接口按时间倒序返回，转换为升序
+func (g *gateExchange) GetFundingRateHistory(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.FundingRate, error) {
+	opts := &gateapi.ListFuturesFundingRateHistoryOpts{}
+	if !start.IsZero() {
+		opts.From = optional.NewInt64(start.Unix())
+	}
+	if !end.IsZero() {
+		opts.To = optional.NewInt64(end.Unix())
+	}
+	if limit > 0 {
+		opts.Limit = optional.NewInt32(int32(limit))
+	}
+	records, _, err := g.getClient(ctx).FuturesApi.ListFuturesFundingRateHistory(ctx, strings.ToLower(Settle), symbol, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取历史资金费率失败: %w", toAPIError(err))
+	}
+
+	result := make([]*exchange.FundingRate, 0, len(records))
+	for _, record := range records {
+		result = append(result, &exchange.FundingRate{
+			Symbol:      symbol,
+			FundingRate: record.R,
+			FundingTime: record.T * 1000,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FundingTime < result[j].FundingTime
+	})
+	return result, nil
+}
+
+// GetMarkPrice 获取合约标记价格，单个交易对查询合约信息，多个交易对查询全部合约后过滤；
+// 合约信息不包含数据时间，使用本地查询时间
+func (g *gateExchange) GetMarkPrice(ctx context.Context, symbols ...string) ([]*exchange.MarkPrice, error) {
+	var contracts []gateapi.Contract
+	if len(symbols) == 1 {
+		contract, _, err := g.getClient(ctx).FuturesApi.GetFuturesContract(ctx, strings.ToLower(Settle), symbols[0])
+		if err != nil {
+			return nil, fmt.Errorf("获取标记价格失败: %w", toAPIError(err))
+		}
+		contracts = append(contracts, contract)
+	} else {
+		var err error
+		contracts, _, err = g.getClient(ctx).FuturesApi.ListFuturesContracts(ctx, strings.ToLower(Settle), nil)
+		if err != nil {
+			return nil, fmt.Errorf("获取标记价格失败: %w", toAPIError(err))
+		}
+	}
+
+	wanted := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		wanted[symbol] = struct{}{}
+	}
+	now := time.Now().UnixMilli()
+	result := make([]*exchange.MarkPrice, 0, len(contracts))
+	for _, contract := range contracts {
+		if _, ok := wanted[contract.Name]; len(wanted) > 0 && !ok {
+			continue
+		}
+		result = append(result, &exchange.MarkPrice{
+			Symbol:          contract.Name,
+			MarkPrice:       contract.MarkPrice,
+			IndexPrice:      contract.IndexPrice,
+			FundingRate:     contract.FundingRate,
+			NextFundingTime: secondsToMs(contract.FundingNextApply),
+			Time:            now,
+		})
+	}
+	return result, nil
+}
+
+// secondsToMs 秒级时间戳转换为毫秒
+func secondsToMs(seconds float64) int64 {
+	return int64(math.Round(seconds * 1000))
+}
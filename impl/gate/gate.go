@@ -1,29 +1,78 @@
 package gate
 
 import (
+	"context"
+
 	"github.com/gateio/gateapi-go/v6"
 	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/ratelimit"
+	"github.com/so68/exchange-lib/internal/symbolmap"
+)
+
+const (
+	TestnetBaseURL = "https://api-testnet.gateapi.io/api/v4" // 测试网接口地址
 )
 
 // 现货实例
 type gateExchange struct {
-	client *gateapi.APIClient
+	testnet       bool               // 实例是否为测试网
+	client        *gateapi.APIClient // 实例环境客户端
+	testnetClient *gateapi.APIClient // 测试网客户端，用于上下文设置了测试网的请求
+	maxRetries    int                // 下单遇到临时错误时的最大重试次数
 }
 
-// 创建现货实例
-func NewGateExchange(apiKey, secretKey string) exchange.Exchange {
-	cfg := gateapi.NewConfiguration()
-	cfg.Key = apiKey
-	cfg.Secret = secretKey
-	client := gateapi.NewAPIClient(cfg)
-	return &gateExchange{client: client}
+// 创建现货实例，Gate 现货与合约共用接口地址 SpotBaseURL，交易对参数支持统一格式（如 BTC/USDT:USDT）与原生格式，返回值使用统一格式
+func NewGateExchange(apiKey, secretKey string, opts ...exchange.Option) exchange.Exchange {
+	return symbolmap.WrapExchange(newGateExchange(apiKey, secretKey, opts...), symbolFormatter{})
 }
 
 // 创建现货实例
-func newGateExchange(apiKey, secretKey string) *gateExchange {
-	cfg := gateapi.NewConfiguration()
-	cfg.Key = apiKey
-	cfg.Secret = secretKey
-	client := gateapi.NewAPIClient(cfg)
-	return &gateExchange{client: client}
+func newGateExchange(apiKey, secretKey string, opts ...exchange.Option) *gateExchange {
+	options := exchange.NewOptions(opts...)
+	limiter := options.RateLimiter
+	if limiter == nil {
+		limiter = NewRateLimiter(options.RateLimitPolicy)
+	}
+	httpClient := ratelimit.WrapClient(options.NewHTTPClient(), limiter)
+
+	newConfiguration := func(basePath string) *gateapi.Configuration {
+		cfg := gateapi.NewConfiguration()
+		cfg.Key = apiKey
+		cfg.Secret = secretKey
+		if basePath != "" {
+			cfg.BasePath = basePath
+		}
+		cfg.HTTPClient = httpClient
+		return cfg
+	}
+
+	basePath := options.SpotBaseURL
+	if basePath == "" && options.IsTestnet() {
+		basePath = TestnetBaseURL
+	}
+	client := gateapi.NewAPIClient(newConfiguration(basePath))
+	testnetClient := client
+	if !options.IsTestnet() {
+		testnetClient = gateapi.NewAPIClient(newConfiguration(TestnetBaseURL))
+	}
+
+	return &gateExchange{
+		testnet:       options.IsTestnet(),
+		client:        client,
+		testnetClient: testnetClient,
+		maxRetries:    options.Retries(),
+	}
+}
+
+// isTestnet 请求是否使用测试网
+func (g *gateExchange) isTestnet(ctx context.Context) bool {
+	return g.testnet || exchange.IsTestnet(ctx)
+}
+
+// getClient 获取请求对应环境的客户端
+func (g *gateExchange) getClient(ctx context.Context) *gateapi.APIClient {
+	if exchange.IsTestnet(ctx) {
+		return g.testnetClient
+	}
+	return g.client
 }
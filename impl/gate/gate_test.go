@@ -1,6 +1,17 @@
 package gate
 
-import "flag"
+import (
+	"context"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
 
 const (
 	apiKey    = "6bd608e28a45f98ebbea8e7d03f98924"
@@ -15,3 +26,92 @@ var (
 	leverage  = flag.Int("leverage", 1, "杠杆")
 	orderID   = flag.String("orderID", "", "订单ID")
 )
+
+// testRequest 测试服务器收到的请求
+type testRequest struct {
+	Method string
+	Path   string
+	Query  map[string]string
+	Body   string
+}
+
+// testServer 模拟 Gate 接口的测试服务器
+type testServer struct {
+	t        *testing.T
+	routes   map[string]string // "METHOD /path" -> 响应 JSON，{"label": 开头的响应按错误返回 400
+	requests []testRequest
+	mux      sync.Mutex
+}
+
+// newTestGate 创建连接到测试服务器的 Gate 实例
+func newTestGate(t *testing.T, routes map[string]string) (*gateExchange, *testServer) {
+	t.Helper()
+
+	// 清空交易对规格缓存，避免测试之间互相影响
+	gateSpotSpec.DeleteSymbolsSpec()
+	gateFuturesSpec.DeleteFuturesSpec()
+
+	ts := &testServer{t: t, routes: routes}
+	server := httptest.NewServer(http.HandlerFunc(ts.handle))
+	t.Cleanup(server.Close)
+
+	return newGateExchange(apiKey, secretKey, exchange.WithSpotBaseURL(server.URL)), ts
+}
+
+// handle 记录请求并返回预设数据
+func (ts *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	query := map[string]string{}
+	for k := range r.URL.Query() {
+		query[k] = r.URL.Query().Get(k)
+	}
+	ts.mux.Lock()
+	ts.requests = append(ts.requests, testRequest{Method: r.Method, Path: r.URL.Path, Query: query, Body: string(body)})
+	ts.mux.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	data, ok := ts.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		ts.t.Errorf("未预设的请求: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"label":"NOT_FOUND","message":"not found"}`))
+		return
+	}
+	if strings.HasPrefix(data, `{"label":`) {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	w.Write([]byte(data))
+}
+
+// findRequest 查找指定路径的请求
+func (ts *testServer) findRequest(method, path string) *testRequest {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+	for i := range ts.requests {
+		if ts.requests[i].Method == method && ts.requests[i].Path == path {
+			return &ts.requests[i]
+		}
+	}
+	return nil
+}
+
+// TestTestnet 测试网客户端选择
+// go test -v ./impl/gate -run "^TestTestnet$"
+func TestTestnet(t *testing.T) {
+	g := newGateExchange(apiKey, secretKey)
+	ctx := exchange.WithTestnet(context.Background())
+	if path := g.getClient(context.Background()).GetConfig().BasePath; path == TestnetBaseURL {
+		t.Errorf("正式网请求使用了测试网地址")
+	}
+	if path := g.getClient(ctx).GetConfig().BasePath; path != TestnetBaseURL {
+		t.Errorf("测试网地址错误: %s", path)
+	}
+	if g.getSpotSpec(ctx) != gateSpotTestnetSpec || g.getFuturesSpec(context.Background()) != gateFuturesSpec {
+		t.Errorf("交易对规格缓存环境错误")
+	}
+
+	g = newGateExchange(apiKey, secretKey, exchange.WithEnvironment(exchange.EnvironmentTestnet))
+	if path := g.getClient(context.Background()).GetConfig().BasePath; path != TestnetBaseURL {
+		t.Errorf("测试网实例地址错误: %s", path)
+	}
+}
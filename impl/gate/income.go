@@ -0,0 +1,108 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// incomeTypes 统一流水类型对应的交易所流水类型，按类型查询时不包含点卡流水
+var incomeTypes = map[exchange.IncomeType]string{
+	exchange.IncomeTypeFundingFee:  "fund",
+	exchange.IncomeTypeCommission:  "fee",
+	exchange.IncomeTypeRealizedPnl: "pnl",
+	exchange.IncomeTypeTransfer:    "dnw",
+	exchange.IncomeTypeRebate:      "refr",
+}
+
+// GetIncomeHistory 按时间倒序分页查询合约账户变更记录，游标为偏移量。
+// 交易所不单独记录强平清算费，按强平清算费查询时返回空结果
+func (g *gateExchange) GetIncomeHistory(ctx context.Context, query exchange.IncomeQuery) (*exchange.IncomePage, error) {
+	limit := int32(query.Limit)
+	if limit <= 0 {
+		limit = OrderListLimit
+	}
+	cursor := int32(0)
+	if query.Cursor != "" {
+		n, err := strconv.ParseInt(query.Cursor, 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("无效的分页游标: %s", query.Cursor)
+		}
+		cursor = int32(n)
+	}
+
+	page := &exchange.IncomePage{Incomes: make([]*exchange.Income, 0)}
+	if query.Type == exchange.IncomeTypeLiquidationFee {
+		return page, nil
+	}
+	opts := &gateapi.ListFuturesAccountBookOpts{
+		Limit:  optional.NewInt32(limit),
+		Offset: optional.NewInt32(cursor),
+	}
+	if query.Symbol != "" {
+		opts.Contract = optional.NewString(query.Symbol)
+	}
+	if incomeType, ok := incomeTypes[query.Type]; ok {
+		opts.Type_ = optional.NewString(incomeType)
+	}
+	if !query.StartTime.IsZero() {
+		opts.From = optional.NewInt64(query.StartTime.Unix())
+	}
+	if !query.EndTime.IsZero() {
+		opts.To = optional.NewInt64(query.EndTime.Unix())
+	}
+	books, _, err := g.getClient(ctx).FuturesApi.ListFuturesAccountBook(ctx, strings.ToLower(Settle), opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取资金流水失败: %w", toAPIError(err))
+	}
+	for _, book := range books {
+		if income := toIncome(book); query.Type == "" || income.Type == query.Type {
+			page.Incomes = append(page.Incomes, income)
+		}
+	}
+	if len(books) >= int(limit) {
+		page.NextCursor = strconv.Itoa(int(cursor + limit))
+	}
+	return page, nil
+}
+
+// toIncome 转换合约账户变更记录，点卡流水币种为 POINT，时间单位为秒
+func toIncome(book gateapi.FuturesAccountBook) *exchange.Income {
+	asset := Settle
+	if strings.HasPrefix(book.Type, "point_") {
+		asset = "POINT"
+	}
+	return &exchange.Income{
+		ID:      book.Id,
+		Symbol:  book.Contract,
+		Type:    toIncomeType(book.Type),
+		RawType: book.Type,
+		Amount:  book.Change,
+		Asset:   asset,
+		TradeID: book.TradeId,
+		Time:    secondsToMs(book.Time),
+	}
+}
+
+// toIncomeType 转换流水类型
+func toIncomeType(bookType string) exchange.IncomeType {
+	switch bookType {
+	case "fund":
+		return exchange.IncomeTypeFundingFee
+	case "fee", "point_fee":
+		return exchange.IncomeTypeCommission
+	case "pnl":
+		return exchange.IncomeTypeRealizedPnl
+	case "dnw", "point_dnw":
+		return exchange.IncomeTypeTransfer
+	case "refr", "point_refr":
+		return exchange.IncomeTypeRebate
+	default:
+		return exchange.IncomeTypeOther
+	}
+}
@@ -0,0 +1,33 @@
+package gate
+
+import (
+	"testing"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestToIncome 转换合约账户变更记录，点卡流水币种为 POINT，时间转换为毫秒
+// go test -v ./impl/gate -run "^TestToIncome$"
+func TestToIncome(t *testing.T) {
+	tests := []struct {
+		book      gateapi.FuturesAccountBook
+		wantType  exchange.IncomeType
+		wantAsset string
+	}{
+		{gateapi.FuturesAccountBook{Id: "1", Type: "fund", Change: "-0.01", Contract: "BTC_USDT", Time: 1682294400.123}, exchange.IncomeTypeFundingFee, "USDT"},
+		{gateapi.FuturesAccountBook{Id: "2", Type: "point_fee", Change: "-0.02", Contract: "BTC_USDT", TradeId: "9", Time: 1682294400.123}, exchange.IncomeTypeCommission, "POINT"},
+		{gateapi.FuturesAccountBook{Id: "3", Type: "pnl", Change: "1.5", Contract: "BTC_USDT", Time: 1682294400.123}, exchange.IncomeTypeRealizedPnl, "USDT"},
+		{gateapi.FuturesAccountBook{Id: "4", Type: "dnw", Change: "100", Time: 1682294400.123}, exchange.IncomeTypeTransfer, "USDT"},
+		{gateapi.FuturesAccountBook{Id: "5", Type: "bonus_offset", Change: "-1", Time: 1682294400.123}, exchange.IncomeTypeOther, "USDT"},
+	}
+	for _, tt := range tests {
+		income := toIncome(tt.book)
+		if income.Type != tt.wantType || income.Asset != tt.wantAsset || income.RawType != tt.book.Type {
+			t.Errorf("%s 类型或币种错误: %+v", tt.book.Type, income)
+		}
+		if income.ID != tt.book.Id || income.Amount != tt.book.Change || income.Symbol != tt.book.Contract || income.Time != 1682294400123 {
+			t.Errorf("%s 转换错误: %+v", tt.book.Type, income)
+		}
+	}
+}
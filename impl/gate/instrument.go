@@ -0,0 +1,207 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// ListInstruments 获取市场全部交易对元数据，现货仅包含 USDT 计价交易对，合约仅包含 USDT 结算合约
+func (g *gateExchange) ListInstruments(ctx context.Context, market exchange.Market) ([]*exchange.Instrument, error) {
+	var instruments []*exchange.Instrument
+	switch market {
+	case exchange.MarketSpot:
+		pairs, _, err := g.getClient(ctx).SpotApi.ListCurrencyPairs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取现货交易对规则失败: %w", toAPIError(err))
+		}
+		for i := range pairs {
+			if pairs[i].Quote != Settle {
+				continue
+			}
+			spec := toSymbolSpec(&pairs[i])
+			g.getSpotSpec(ctx).SetSymbolSpec(spec.Id, spec)
+			instruments = append(instruments, toSpotInstrument(spec))
+		}
+	case exchange.MarketFutures:
+		contracts, _, err := g.getClient(ctx).FuturesApi.ListFuturesContracts(ctx, strings.ToLower(Settle), nil)
+		if err != nil {
+			return nil, fmt.Errorf("获取合约交易对规则失败: %w", toAPIError(err))
+		}
+		for i := range contracts {
+			spec := toFuturesSpec(&contracts[i])
+			g.getFuturesSpec(ctx).SetFuturesSpec(spec.Name, spec)
+			instruments = append(instruments, toFuturesInstrument(spec))
+		}
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+	return instruments, nil
+}
+
+// GetInstrument 获取交易对元数据
+func (g *gateExchange) GetInstrument(ctx context.Context, market exchange.Market, symbol string) (*exchange.Instrument, error) {
+	switch market {
+	case exchange.MarketSpot:
+		spec, err := g.GetSpotSymbolSpec(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return toSpotInstrument(spec), nil
+	case exchange.MarketFutures:
+		spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return toFuturesInstrument(spec), nil
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+}
+
+// toSymbolSpec 转换现货交易对规则
+func toSymbolSpec(pair *gateapi.CurrencyPair) *symbolSpec {
+	return &symbolSpec{
+		Id:              pair.Id,
+		Base:            pair.Base,
+		BaseName:        pair.BaseName,
+		Quote:           pair.Quote,
+		QuoteName:       pair.QuoteName,
+		Fee:             pair.Fee,
+		MinBaseAmount:   pair.MinBaseAmount,
+		MinQuoteAmount:  pair.MinQuoteAmount,
+		MaxBaseAmount:   pair.MaxBaseAmount,
+		MaxQuoteAmount:  pair.MaxQuoteAmount,
+		AmountPrecision: int(pair.AmountPrecision),
+		Precision:       int(pair.Precision),
+		TradeStatus:     pair.TradeStatus,
+		SellStart:       pair.SellStart,
+		BuyStart:        pair.BuyStart,
+		DelistingTime:   pair.DelistingTime,
+		TradeUrl:        pair.TradeUrl,
+		StTag:           pair.StTag,
+	}
+}
+
+// toFuturesSpec 转换合约规则
+func toFuturesSpec(contract *gateapi.Contract) *futuresSpec {
+	return &futuresSpec{
+		Name:              contract.Name,
+		Type:              contract.Type,
+		QuantoMultiplier:  contract.QuantoMultiplier,
+		LeverageMin:       contract.LeverageMin,
+		LeverageMax:       contract.LeverageMax,
+		MaintenanceRate:   contract.MaintenanceRate,
+		MarkType:          contract.MarkType,
+		MarkPrice:         contract.MarkPrice,
+		IndexPrice:        contract.IndexPrice,
+		LastPrice:         contract.LastPrice,
+		MakerFeeRate:      contract.MakerFeeRate,
+		TakerFeeRate:      contract.TakerFeeRate,
+		OrderPriceRound:   contract.OrderPriceRound,
+		MarkPriceRound:    contract.MarkPriceRound,
+		FundingRate:       contract.FundingRate,
+		FundingInterval:   contract.FundingInterval,
+		FundingNextApply:  contract.FundingNextApply,
+		OrderSizeMin:      contract.OrderSizeMin,
+		OrderSizeMax:      contract.OrderSizeMax,
+		OrderPriceDeviate: contract.OrderPriceDeviate,
+		RefDiscountRate:   contract.RefDiscountRate,
+		RefRebateRate:     contract.RefRebateRate,
+		OrderbookId:       contract.OrderbookId,
+		TradeId:           contract.TradeId,
+		TradeSize:         contract.TradeSize,
+		PositionSize:      contract.PositionSize,
+		ConfigChangeTime:  contract.ConfigChangeTime,
+		InDelisting:       contract.InDelisting,
+		OrdersLimit:       contract.OrdersLimit,
+		EnableBonus:       contract.EnableBonus,
+		EnableCredit:      contract.EnableCredit,
+		CreateTime:        contract.CreateTime,
+		FundingCapRatio:   contract.FundingCapRatio,
+		Status:            contract.Status,
+		LaunchTime:        contract.LaunchTime,
+	}
+}
+
+// toSpotInstrument 现货交易对规则转换为交易对元数据，步长由精度换算，费率由百分比换算
+func toSpotInstrument(spec *symbolSpec) *exchange.Instrument {
+	instrument := &exchange.Instrument{
+		Market:      exchange.MarketSpot,
+		Symbol:      exchange.NewSpotSymbol(spec.Base, spec.Quote).String(),
+		ID:          spec.Id,
+		Base:        spec.Base,
+		Quote:       spec.Quote,
+		TickSize:    precisionToStep(spec.Precision),
+		StepSize:    precisionToStep(spec.AmountPrecision),
+		MinQty:      spec.MinBaseAmount,
+		MaxQty:      spec.MaxBaseAmount,
+		MinNotional: spec.MinQuoteAmount,
+		Status:      exchange.InstrumentStatusSuspended,
+	}
+	if spec.TradeStatus == "tradable" {
+		instrument.Status = exchange.InstrumentStatusTrading
+	}
+	if spec.DelistingTime > 0 {
+		instrument.DelistTime = spec.DelistingTime * 1000
+		if instrument.Status == exchange.InstrumentStatusTrading {
+			instrument.Status = exchange.InstrumentStatusDelisting
+		}
+	}
+	if fee, err := exchange.ParseDecimal(spec.Fee); err == nil && spec.Fee != "" {
+		rate := fee.Shift(-2).String()
+		instrument.MakerFeeRate, instrument.TakerFeeRate = rate, rate
+	}
+	return instrument
+}
+
+// toFuturesInstrument 合约规则转换为交易对元数据，数量由张数按合约乘数换算
+func toFuturesInstrument(spec *futuresSpec) *exchange.Instrument {
+	base, quote, _ := strings.Cut(spec.Name, "_")
+	maxLeverage, _ := strconv.Atoi(spec.LeverageMax)
+	instrument := &exchange.Instrument{
+		Market:       exchange.MarketFutures,
+		Symbol:       exchange.NewPerpetualSymbol(base, quote, Settle).String(),
+		ID:           spec.Name,
+		Base:         base,
+		Quote:        quote,
+		Settle:       Settle,
+		TickSize:     spec.OrderPriceRound,
+		StepSize:     spec.QuantoMultiplier,
+		MinQty:       sizeToQuantity(spec.OrderSizeMin, spec.QuantoMultiplier),
+		MaxQty:       sizeToQuantity(spec.OrderSizeMax, spec.QuantoMultiplier),
+		ContractSize: spec.QuantoMultiplier,
+		MaxLeverage:  maxLeverage,
+		MakerFeeRate: spec.MakerFeeRate,
+		TakerFeeRate: spec.TakerFeeRate,
+	}
+	switch spec.Status {
+	case "prelaunch":
+		instrument.Status = exchange.InstrumentStatusPreTrading
+	case "trading":
+		instrument.Status = exchange.InstrumentStatusTrading
+	case "delisting":
+		instrument.Status = exchange.InstrumentStatusDelisting
+	case "delisted":
+		instrument.Status = exchange.InstrumentStatusDelisted
+	default:
+		instrument.Status = exchange.InstrumentStatusSuspended
+	}
+	// 下架中且无持仓表示已下架
+	if spec.InDelisting {
+		instrument.Status = exchange.InstrumentStatusDelisting
+		if spec.PositionSize == 0 {
+			instrument.Status = exchange.InstrumentStatusDelisted
+		}
+	}
+	return instrument
+}
+
+// precisionToStep 小数位数转换为步长，如 3 为 0.001
+func precisionToStep(precision int) string {
+	return exchange.NewDecimalFromInt(1).Shift(-int32(precision)).String()
+}
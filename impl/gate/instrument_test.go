@@ -0,0 +1,76 @@
+package gate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetInstrument 获取合约交易对元数据
+// go test -v ./impl/gate -run "^TestGetInstrument$" -args --symbol=BTC_USDT
+func TestGetInstrument(t *testing.T) {
+	flag.Parse()
+
+	gateExchange := NewGateExchange("", "")
+	instrument, err := gateExchange.GetInstrument(context.Background(), exchange.MarketFutures, *symbol)
+	if err != nil {
+		t.Fatalf("获取交易对元数据失败: %v", err)
+	}
+	fmt.Printf("instrument %+v\n", instrument)
+}
+
+// TestToInstrument 交易对规则转换为交易对元数据，步长由精度换算，合约数量由张数换算
+// go test -v ./impl/gate -run "^TestToInstrument$"
+func TestToInstrument(t *testing.T) {
+	spot := toSpotInstrument(toSymbolSpec(&gateapi.CurrencyPair{
+		Id:              "BTC_USDT",
+		Base:            "BTC",
+		Quote:           "USDT",
+		Fee:             "0.2",
+		MinBaseAmount:   "0.0001",
+		MinQuoteAmount:  "3",
+		MaxBaseAmount:   "100",
+		AmountPrecision: 4,
+		Precision:       1,
+		TradeStatus:     "tradable",
+	}))
+	if spot.Symbol != "BTC/USDT" || spot.ID != "BTC_USDT" || spot.TickSize != "0.1" || spot.StepSize != "0.0001" || spot.MinNotional != "3" || spot.TakerFeeRate != "0.002" || spot.Status != exchange.InstrumentStatusTrading {
+		t.Errorf("现货交易对元数据错误: %+v", spot)
+	}
+
+	futures := toFuturesInstrument(toFuturesSpec(&gateapi.Contract{
+		Name:             "BTC_USDT",
+		QuantoMultiplier: "0.0001",
+		LeverageMax:      "125",
+		OrderPriceRound:  "0.1",
+		OrderSizeMin:     1,
+		OrderSizeMax:     1000000,
+		MakerFeeRate:     "-0.00025",
+		TakerFeeRate:     "0.00075",
+		Status:           "trading",
+	}))
+	want := exchange.Instrument{
+		Market:       exchange.MarketFutures,
+		Symbol:       "BTC/USDT:USDT",
+		ID:           "BTC_USDT",
+		Base:         "BTC",
+		Quote:        "USDT",
+		Settle:       Settle,
+		TickSize:     "0.1",
+		StepSize:     "0.0001",
+		MinQty:       "0.0001",
+		MaxQty:       "100",
+		ContractSize: "0.0001",
+		MaxLeverage:  125,
+		Status:       exchange.InstrumentStatusTrading,
+		MakerFeeRate: "-0.00025",
+		TakerFeeRate: "0.00075",
+	}
+	if *futures != want {
+		t.Errorf("合约交易对元数据错误: %+v", futures)
+	}
+}
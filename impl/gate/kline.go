@@ -0,0 +1,170 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/kline"
+)
+
+const (
+	// 按时间区间查询时单次最多返回 1000（现货）/ 2000（合约）根，区间按页大小个周期计算，
+	// 起点对齐时区间两端都包含在内，因此页大小比上限少 1
+	SpotKlinePageSize    = 999  // 现货K线单页数量
+	FuturesKlinePageSize = 1999 // 合约K线单页数量
+)
+
+// spotKlineIntervals 现货K线周期，Gate 现货没有自然周，1w 使用 7d（从 unix 0 起每 7 天）
+var spotKlineIntervals = map[exchange.KlineInterval]string{
+	exchange.KlineInterval1m:  "1m",
+	exchange.KlineInterval5m:  "5m",
+	exchange.KlineInterval15m: "15m",
+	exchange.KlineInterval30m: "30m",
+	exchange.KlineInterval1h:  "1h",
+	exchange.KlineInterval4h:  "4h",
+	exchange.KlineInterval8h:  "8h",
+	exchange.KlineInterval1d:  "1d",
+	exchange.KlineInterval1w:  "7d",
+	exchange.KlineInterval1M:  "30d",
+}
+
+// futuresKlineIntervals 合约K线周期
+var futuresKlineIntervals = map[exchange.KlineInterval]string{
+	exchange.KlineInterval1m:  "1m",
+	exchange.KlineInterval5m:  "5m",
+	exchange.KlineInterval15m: "15m",
+	exchange.KlineInterval30m: "30m",
+	exchange.KlineInterval1h:  "1h",
+	exchange.KlineInterval4h:  "4h",
+	exchange.KlineInterval8h:  "8h",
+	exchange.KlineInterval1d:  "1d",
+	exchange.KlineInterval1w:  "1w",
+	exchange.KlineInterval1M:  "30d",
+}
+
+// toKlineInterval 转换为 Gate K线周期
+func toKlineInterval(intervals map[exchange.KlineInterval]string, interval exchange.KlineInterval) (string, error) {
+	value, ok := intervals[interval]
+	if !ok {
+		return "", fmt.Errorf("Gate 不支持的K线周期: %s", interval)
+	}
+	return value, nil
+}
+
+// klineWindowEnd 计算单页查询的结束时间，不超过 end
+func klineWindowEnd(interval exchange.KlineInterval, start, end time.Time, limit int) time.Time {
+	if e := start.Add(time.Duration(limit) * interval.Duration()); e.Before(end) {
+		return e
+	}
+	return end
+}
+
+// GetSpotKlines 获取现货K线，按时间区间自动分页
+func (g *gateExchange) GetSpotKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	gateInterval, err := toKlineInterval(spotKlineIntervals, interval)
+	if err != nil {
+		return nil, err
+	}
+	fetch := func(start, end time.Time, limit int) ([]*exchange.Kline, error) {
+		rows, _, err := g.getClient(ctx).SpotApi.ListCandlesticks(ctx, symbol, &gateapi.ListCandlesticksOpts{
+			From:     optional.NewInt64(start.Unix()),
+			To:       optional.NewInt64(klineWindowEnd(interval, start, end, limit).Unix()),
+			Interval: optional.NewString(gateInterval),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取现货K线失败: %w", toAPIError(err))
+		}
+		klines := make([]*exchange.Kline, 0, len(rows))
+		for _, row := range rows {
+			k, err := toSpotKline(symbol, interval, row)
+			if err != nil {
+				return nil, err
+			}
+			klines = append(klines, k)
+		}
+		return klines, nil
+	}
+	return kline.Paginate(fetch, interval, start, end, limit, SpotKlinePageSize, kline.Forward)
+}
+
+// GetFuturesKlines 获取合约K线，按时间区间自动分页，成交量按合约乘数转换为基础资产数量
+func (g *gateExchange) GetFuturesKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	gateInterval, err := toKlineInterval(futuresKlineIntervals, interval)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	fetch := func(start, end time.Time, limit int) ([]*exchange.Kline, error) {
+		candles, _, err := g.getClient(ctx).FuturesApi.ListFuturesCandlesticks(ctx, Settle, symbol, &gateapi.ListFuturesCandlesticksOpts{
+			From:     optional.NewInt64(start.Unix()),
+			To:       optional.NewInt64(klineWindowEnd(interval, start, end, limit).Unix()),
+			Interval: optional.NewString(gateInterval),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取合约K线失败: %w", toAPIError(err))
+		}
+		klines := make([]*exchange.Kline, 0, len(candles))
+		for _, candle := range candles {
+			klines = append(klines, toFuturesKline(symbol, interval, candle, spec.QuantoMultiplier))
+		}
+		return klines, nil
+	}
+	return kline.Paginate(fetch, interval, start, end, limit, FuturesKlinePageSize, kline.Forward)
+}
+
+// toSpotKline 转换现货K线 [时间(秒), 成交额, 收盘价, 最高价, 最低价, 开盘价, 成交量, 是否已收盘]
+func toSpotKline(symbol string, interval exchange.KlineInterval, row []string) (*exchange.Kline, error) {
+	if len(row) < 7 {
+		return nil, fmt.Errorf("无效的K线数据: %v", row)
+	}
+	seconds, err := strconv.ParseInt(row[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("无效的K线时间: %s", row[0])
+	}
+	openTime := seconds * 1000
+	closeTime := interval.CloseTime(openTime)
+	closed := closeTime < time.Now().UnixMilli()
+	if len(row) > 7 {
+		closed = row[7] == "true"
+	}
+	return &exchange.Kline{
+		Symbol:      symbol,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   closeTime,
+		Open:        row[5],
+		High:        row[3],
+		Low:         row[4],
+		Close:       row[2],
+		Volume:      row[6],
+		QuoteVolume: row[1],
+		Closed:      closed,
+	}, nil
+}
+
+// toFuturesKline 转换合约K线，成交量由张数转换为基础资产数量
+func toFuturesKline(symbol string, interval exchange.KlineInterval, candle gateapi.FuturesCandlestick, quantoMultiplier string) *exchange.Kline {
+	openTime := int64(candle.T) * 1000
+	closeTime := interval.CloseTime(openTime)
+	return &exchange.Kline{
+		Symbol:      symbol,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   closeTime,
+		Open:        candle.O,
+		High:        candle.H,
+		Low:         candle.L,
+		Close:       candle.C,
+		Volume:      sizeToQuantity(candle.V, quantoMultiplier),
+		QuoteVolume: candle.Sum,
+		Closed:      closeTime < time.Now().UnixMilli(),
+	}
+}
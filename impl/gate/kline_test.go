@@ -0,0 +1,67 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetSpotKlines 获取现货K线
+// go test -v ./impl/gate -run "^TestGetSpotKlines$" -args --symbol=BTC_USDT
+func TestGetSpotKlines(t *testing.T) {
+	flag.Parse()
+
+	gateExchange := NewGateExchange("", "")
+	klines, err := gateExchange.GetSpotKlines(context.Background(), *symbol, exchange.KlineInterval1m, time.Time{}, time.Time{}, 1500)
+	if err != nil {
+		t.Fatalf("获取现货K线失败: %v", err)
+	}
+	fmt.Println("klines", len(klines), klines[0], klines[len(klines)-1])
+}
+
+// TestGetFuturesKlines 获取合约K线
+// go test -v ./impl/gate -run "^TestGetFuturesKlines$" -args --symbol=BTC_USDT
+func TestGetFuturesKlines(t *testing.T) {
+	flag.Parse()
+
+	gateExchange := NewGateExchange("", "")
+	start := time.Now().Add(-24 * time.Hour)
+	klines, err := gateExchange.GetFuturesKlines(context.Background(), *symbol, exchange.KlineInterval1h, start, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("获取合约K线失败: %v", err)
+	}
+	fmt.Println("klines", len(klines), klines[0], klines[len(klines)-1])
+}
+
+// TestParseCandlestick 解析K线推送，合约成交量按乘数转换
+// go test -v ./impl/gate -run "^TestParseCandlestick$"
+func TestParseCandlestick(t *testing.T) {
+	spot := `{"t":"1606292580","v":"2362.32035","c":"19128.1","h":"19128.1","l":"19128.1","o":"19128.1","n":"1m_BTC_USDT","a":"3.8283","w":true}`
+	klines, err := parseCandlestick(json.RawMessage(spot), "BTC_USDT", exchange.KlineInterval1m)
+	if err != nil || len(klines) != 1 {
+		t.Fatalf("解析现货K线失败: %v", err)
+	}
+	if k := klines[0]; k.OpenTime != 1606292580000 || k.CloseTime != 1606292639999 || k.Volume != "3.8283" || k.QuoteVolume != "2362.32035" || !k.Closed {
+		t.Errorf("现货K线错误: %+v", k)
+	}
+
+	futures := `[{"t":1545129300,"v":27525555,"c":"95.4","h":"96.9","l":"89.5","o":"94.3","n":"1m_BTC_USDT","w":false}]`
+	klines, err = parseFuturesCandlestick(json.RawMessage(futures), "BTC_USDT", exchange.KlineInterval1m, "0.0001")
+	if err != nil || len(klines) != 1 {
+		t.Fatalf("解析合约K线失败: %v", err)
+	}
+	if k := klines[0]; k.OpenTime != 1545129300000 || k.Volume != "2752.5555" || k.Closed {
+		t.Errorf("合约K线错误: %+v", k)
+	}
+
+	row := []string{"1606292580", "2362.32035", "19128.1", "19130", "19120", "19125", "3.8283", "false"}
+	k, err := toSpotKline("BTC_USDT", exchange.KlineInterval1m, row)
+	if err != nil || k.Open != "19125" || k.High != "19130" || k.Low != "19120" || k.Close != "19128.1" || k.Closed {
+		t.Errorf("现货REST K线错误: %+v, %v", k, err)
+	}
+}
@@ -1,96 +1,195 @@
 package gate
 
 import (
+	"context"
 	"fmt"
-	"math/big"
 	"strconv"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	TextPrefix = "t-" // Gate 用户自定义订单ID前缀
 )
 
-// filtersQuantity 获取交易对数量精度
+// toText 客户端订单ID转换为 Gate 自定义订单ID，需以 t- 开头
+func toText(clientOrderID string) string {
+	if strings.HasPrefix(clientOrderID, TextPrefix) {
+		return clientOrderID
+	}
+	return TextPrefix + clientOrderID
+}
+
+// toClientOrderID Gate 自定义订单ID转换为客户端订单ID，非用户自定义的来源标记（如 api、web）返回空
+func toClientOrderID(text string) string {
+	clientOrderID, ok := strings.CutPrefix(text, TextPrefix)
+	if !ok {
+		return ""
+	}
+	return clientOrderID
+}
+
+// PlaceOrder 下单
+func (g *gateExchange) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Market == exchange.MarketSpot {
+		return g.placeSpotOrder(ctx, req)
+	}
+	return g.placeFuturesOrder(ctx, req)
+}
+
+// filtersQuantity 获取交易对数量精度，限价单同时校验下单金额
 func (g *gateExchange) filtersQuantity(spec *symbolSpec, price, quantity string) (string, error) {
-	// 使用 big.Float 进行精确比较
-	quantityFloat := new(big.Float).SetPrec(64)
-	minQtyFloat := new(big.Float).SetPrec(64)
-	maxQtyFloat := new(big.Float).SetPrec(64)
-	priceFloat := new(big.Float).SetPrec(64)
-	minPriceFloat := new(big.Float).SetPrec(64)
-	maxPriceFloat := new(big.Float).SetPrec(64)
-
-	if _, ok := quantityFloat.SetString(quantity); !ok {
+	quantityDec, err := exchange.ParseDecimal(quantity)
+	if err != nil {
 		return "", fmt.Errorf("无效的数量: %s", quantity)
 	}
-	if _, ok := minQtyFloat.SetString(spec.MinBaseAmount); !ok {
+	minQty, err := exchange.ParseDecimal(spec.MinBaseAmount)
+	if err != nil {
 		return "", fmt.Errorf("无效的最小数量: %s", spec.MinBaseAmount)
 	}
-	if _, ok := maxQtyFloat.SetString(spec.MaxBaseAmount); !ok {
+	maxQty, err := exchange.ParseDecimal(spec.MaxBaseAmount)
+	if err != nil {
 		return "", fmt.Errorf("无效的最大数量: %s", spec.MaxBaseAmount)
 	}
-	if _, ok := priceFloat.SetString(price); !ok {
-		return "", fmt.Errorf("无效的价格: %s", price)
-	}
-	if _, ok := minPriceFloat.SetString(spec.MinQuoteAmount); !ok {
-		return "", fmt.Errorf("无效的最小价格: %s", spec.MinQuoteAmount)
+	// 如果 quantity 小于 minQty，或大于 maxQty，返回错误
+	if quantityDec.LessThan(minQty) {
+		return "", fmt.Errorf("%w: 数量 %s 小于最小数量 %s", exchange.ErrMinNotional, quantity, spec.MinBaseAmount)
 	}
-	if _, ok := maxPriceFloat.SetString(spec.MaxQuoteAmount); !ok {
-		return "", fmt.Errorf("无效的最大价格: %s", spec.MaxQuoteAmount)
+	if quantityDec.GreaterThan(maxQty) {
+		return "", fmt.Errorf("数量 %s 大于最大数量 %s", quantity, spec.MaxBaseAmount)
 	}
-	// 如果价格小于最小价格，或大于最大价格，返回错误
-	if priceFloat.Cmp(minPriceFloat) < 0 || priceFloat.Cmp(maxPriceFloat) > 0 {
-		return "", fmt.Errorf("价格 %s 小于最小价格 %s 或大于最大价格 %s", price, spec.MinQuoteAmount, spec.MaxQuoteAmount)
+
+	// 按数量精度向下取整
+	precision := int32(spec.AmountPrecision)
+	quantityDec = quantityDec.RoundFloor(precision)
+
+	// 限价单校验下单金额，市价单价格为空或 0 不校验
+	priceDec, err := exchange.ParseDecimal(price)
+	if err != nil {
+		return "", fmt.Errorf("无效的价格: %s", price)
 	}
-	// 如果 quantity 小于 minQty，或大于 maxQty，返回错误
-	if quantityFloat.Cmp(minQtyFloat) < 0 || quantityFloat.Cmp(maxQtyFloat) > 0 {
-		return "", fmt.Errorf("数量 %s 小于最小数量 %s 或大于最大数量 %s", quantity, spec.MinBaseAmount, spec.MaxBaseAmount)
+	if priceDec.IsPositive() {
+		if err := checkQuoteAmount(spec, priceDec.Mul(quantityDec)); err != nil {
+			return "", err
+		}
 	}
+	return quantityDec.StringFixed(precision), nil
+}
 
-	// 逻辑：floor(quantity * 10^precision) / 10^precision
-	multiplier := new(big.Float).SetPrec(64).SetInt(big.NewInt(10).Exp(big.NewInt(10), big.NewInt(int64(spec.AmountPrecision)), nil))
-	// 乘以 10^precision
-	quantityFloat = quantityFloat.Mul(quantityFloat, multiplier)
-	// 向下取整
-	quantityInt, _ := quantityFloat.Int(nil)
-	// 除以 10^precision
-	quantityFloat = new(big.Float).SetInt(quantityInt)
-	quantityFloat = quantityFloat.Quo(quantityFloat, multiplier)
+// filtersQuoteAmount 校验现货市价买入的下单金额
+func (g *gateExchange) filtersQuoteAmount(spec *symbolSpec, amount string) (string, error) {
+	amountDec, err := exchange.ParseDecimal(amount)
+	if err != nil {
+		return "", fmt.Errorf("无效的金额: %s", amount)
+	}
+	if err := checkQuoteAmount(spec, amountDec); err != nil {
+		return "", err
+	}
+	return amount, nil
+}
 
-	return quantityFloat.Text('f', spec.AmountPrecision), nil
+// checkQuoteAmount 校验下单金额是否在交易对的最小、最大金额之间，未配置的限制不校验
+func checkQuoteAmount(spec *symbolSpec, amount exchange.Decimal) error {
+	minAmount, err := exchange.ParseDecimal(spec.MinQuoteAmount)
+	if err != nil {
+		return fmt.Errorf("无效的最小金额: %s", spec.MinQuoteAmount)
+	}
+	maxAmount, err := exchange.ParseDecimal(spec.MaxQuoteAmount)
+	if err != nil {
+		return fmt.Errorf("无效的最大金额: %s", spec.MaxQuoteAmount)
+	}
+	if amount.LessThan(minAmount) {
+		return fmt.Errorf("%w: 金额 %s 小于最小金额 %s", exchange.ErrMinNotional, amount.String(), spec.MinQuoteAmount)
+	}
+	if maxAmount.IsPositive() && amount.GreaterThan(maxAmount) {
+		return fmt.Errorf("金额 %s 大于最大金额 %s", amount.String(), spec.MaxQuoteAmount)
+	}
+	return nil
 }
 
 // filtersFuturesSize 获取合约订单数量
 func (g *gateExchange) filtersFuturesSize(spec *futuresSpec, price, amount string) (int64, error) {
-	quantoMultiplierFloat := new(big.Float).SetPrec(64)
-	if _, ok := quantoMultiplierFloat.SetString(spec.QuantoMultiplier); !ok {
+	quantoMultiplier, err := exchange.ParseDecimal(spec.QuantoMultiplier)
+	if err != nil {
 		return 0, fmt.Errorf("无效的转换结算货币的乘数: %s", spec.QuantoMultiplier)
 	}
-	priceFloat := new(big.Float).SetPrec(64)
-	if _, ok := priceFloat.SetString(price); !ok {
+	priceDec, err := exchange.ParseDecimal(price)
+	if err != nil {
 		return 0, fmt.Errorf("无效的价格: %s", price)
 	}
-	amountFloat := new(big.Float).SetPrec(64)
-	if _, ok := amountFloat.SetString(amount); !ok {
+	amountDec, err := exchange.ParseDecimal(amount)
+	if err != nil {
 		return 0, fmt.Errorf("无效的数量: %s", amount)
 	}
 
 	// 合约价值 = 合约单位 × 当前价格
-	contractValue := priceFloat.Mul(priceFloat, quantoMultiplierFloat)
+	contractValue := priceDec.Mul(quantoMultiplier)
+	if contractValue.Sign() <= 0 {
+		return 0, fmt.Errorf("无效的合约价值: %s", contractValue.String())
+	}
 
 	// size = 总价值 / 合约价值 ≈ 200 / 32.07 ≈ 6.24。 向下取整
-	sizeFloat := amountFloat.Quo(amountFloat, contractValue)
-	sizeInt, _ := sizeFloat.Int(nil)
-	sizeFloat = new(big.Float).SetInt(sizeInt)
+	sizeDec, _ := amountDec.QuoRem(contractValue, 0)
 
 	// 验证size 最小值, 最大值
-	minSizeFloat := new(big.Float).SetPrec(64)
-	if _, ok := minSizeFloat.SetString(strconv.FormatInt(spec.OrderSizeMin, 10)); !ok {
-		return 0, fmt.Errorf("无效的最小数量: %d", spec.OrderSizeMin)
+	size := sizeDec.IntPart()
+	if size < spec.OrderSizeMin {
+		return 0, fmt.Errorf("%w: 数量 %d 小于最小值 %d", exchange.ErrMinNotional, size, spec.OrderSizeMin)
+	}
+	if size > spec.OrderSizeMax {
+		return 0, fmt.Errorf("数量 %d 大于最大值 %d", size, spec.OrderSizeMax)
+	}
+
+	return size, nil
+}
+
+// filtersFuturesQuantity 基础资产数量转换为合约张数，按合约乘数向下取整
+func (g *gateExchange) filtersFuturesQuantity(spec *futuresSpec, quantity string) (int64, error) {
+	quantoMultiplier, err := exchange.ParseDecimal(spec.QuantoMultiplier)
+	if err != nil || !quantoMultiplier.IsPositive() {
+		return 0, fmt.Errorf("无效的转换结算货币的乘数: %s", spec.QuantoMultiplier)
+	}
+	quantityDec, err := exchange.ParseDecimal(quantity)
+	if err != nil {
+		return 0, fmt.Errorf("无效的数量: %s", quantity)
 	}
-	maxSizeFloat := new(big.Float).SetPrec(64)
-	if _, ok := maxSizeFloat.SetString(strconv.FormatInt(spec.OrderSizeMax, 10)); !ok {
-		return 0, fmt.Errorf("无效的最大数量: %d", spec.OrderSizeMax)
+
+	sizeDec, _ := quantityDec.QuoRem(quantoMultiplier, 0)
+	size := sizeDec.IntPart()
+	if size < spec.OrderSizeMin {
+		return 0, fmt.Errorf("%w: 数量 %d 小于最小值 %d", exchange.ErrMinNotional, size, spec.OrderSizeMin)
 	}
-	if sizeFloat.Cmp(minSizeFloat) < 0 || sizeFloat.Cmp(maxSizeFloat) > 0 {
-		return 0, fmt.Errorf("数量 %s 小于最小值 %d 或大于最大值 %d", sizeFloat.Text('f', 0), spec.OrderSizeMin, spec.OrderSizeMax)
+	if size > spec.OrderSizeMax {
+		return 0, fmt.Errorf("数量 %d 大于最大值 %d", size, spec.OrderSizeMax)
 	}
+	return size, nil
+}
 
-	return sizeInt.Int64(), nil
+// toTif 转换订单时间类型，只做挂单为 poc
+func toTif(timeInForce exchange.OrderTimeInForce) string {
+	if timeInForce == exchange.OrderTimeInForceGTX {
+		return "poc"
+	}
+	return strings.ToLower(string(timeInForce))
+}
+
+// toTimeInForce 转换 Gate 订单时间类型
+func toTimeInForce(tif string) exchange.OrderTimeInForce {
+	if tif == "poc" {
+		return exchange.OrderTimeInForceGTX
+	}
+	return exchange.OrderTimeInForce(strings.ToUpper(tif))
+}
+
+// sizeToQuantity 合约张数转换为基础资产数量
+func sizeToQuantity(size int64, quantoMultiplier string) string {
+	multiplier, err := exchange.ParseDecimal(quantoMultiplier)
+	if err != nil || multiplier.IsZero() {
+		return strconv.FormatInt(size, 10)
+	}
+	return exchange.NewDecimalFromInt(size).Mul(multiplier).String()
 }
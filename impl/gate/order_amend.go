@@ -0,0 +1,80 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// AmendOrder 修改未完成的限价单，只修改价格时不改变数量
+func (g *gateExchange) AmendOrder(ctx context.Context, market exchange.Market, symbol, orderID, newPrice, newQty string) (*exchange.Order, error) {
+	if err := exchange.ValidateAmend(newPrice, newQty); err != nil {
+		return nil, err
+	}
+
+	switch market {
+	case exchange.MarketSpot:
+		return g.amendSpotOrder(ctx, symbol, orderID, newPrice, newQty)
+	case exchange.MarketFutures:
+		return g.amendFuturesOrder(ctx, symbol, orderID, newPrice, newQty)
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+}
+
+// amendSpotOrder 现货改单，只修改数量时不校验下单金额
+func (g *gateExchange) amendSpotOrder(ctx context.Context, symbol, orderID, newPrice, newQty string) (*exchange.Order, error) {
+	spec, err := g.GetSpotSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := gateapi.OrderPatch{CurrencyPair: symbol, Price: newPrice}
+	if newQty != "" {
+		patch.Amount, err = g.filtersQuantity(spec, newPrice, newQty)
+		if err != nil {
+			return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		}
+	}
+
+	amendedOrder, _, err := g.getClient(ctx).SpotApi.AmendOrder(ctx, orderID, patch, &gateapi.AmendOrderOpts{
+		CurrencyPair: optional.NewString(symbol),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("现货改单失败: %w", toAPIError(err))
+	}
+	return toSpotOrder(spec, amendedOrder)
+}
+
+// amendFuturesOrder 合约改单，数量按合约乘数转换为张数，方向需与原订单一致，修改数量时先查询原订单的方向
+func (g *gateExchange) amendFuturesOrder(ctx context.Context, symbol, orderID, newPrice, newQty string) (*exchange.Order, error) {
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	amendment := gateapi.FuturesOrderAmendment{Price: newPrice}
+	if newQty != "" {
+		size, err := g.filtersFuturesQuantity(spec, newQty)
+		if err != nil {
+			return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		}
+		order, _, err := g.getClient(ctx).FuturesApi.GetFuturesOrder(ctx, strings.ToLower(Settle), orderID)
+		if err != nil {
+			return nil, fmt.Errorf("获取合约订单失败: %w", toAPIError(err))
+		}
+		if order.Size < 0 {
+			size = -size
+		}
+		amendment.Size = size
+	}
+
+	amendedOrder, _, err := g.getClient(ctx).FuturesApi.AmendFuturesOrder(ctx, strings.ToLower(Settle), orderID, amendment, nil)
+	if err != nil {
+		return nil, fmt.Errorf("合约改单失败: %w", toAPIError(err))
+	}
+	return toFuturesOrder(spec, amendedOrder), nil
+}
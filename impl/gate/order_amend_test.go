@@ -0,0 +1,97 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestAmendSpotOrder 现货改单只发送修改的字段，数量按精度截断
+// go test -v ./impl/gate -run "^TestAmendSpotOrder$"
+func TestAmendSpotOrder(t *testing.T) {
+	const amended = `{"id":"1","text":"t-abc","currency_pair":"BTC_USDT","type":"limit","side":"buy","price":"101","amount":"0.5",
+		"filled_amount":"0","fee":"0","status":"open","time_in_force":"gtc"}`
+
+	g, ts := newTestGate(t, map[string]string{"PATCH /spot/orders/1": amended})
+	g.getSpotSpec(context.Background()).SetSymbolSpec("BTC_USDT", &symbolSpec{
+		Id: "BTC_USDT", MinBaseAmount: "0.0001", MaxBaseAmount: "1000", MinQuoteAmount: "1", AmountPrecision: 4,
+	})
+	order, err := g.AmendOrder(context.Background(), exchange.MarketSpot, "BTC_USDT", "1", "101", "0.50009")
+	if err != nil {
+		t.Fatalf("改单失败: %v", err)
+	}
+	if order.OrderID != "1" || order.Price != "101" || order.Status != exchange.OrderStatusNew {
+		t.Errorf("改单结果错误: %+v", order)
+	}
+	req := ts.findRequest("PATCH", "/spot/orders/1")
+	if req == nil {
+		t.Fatalf("未发送改单请求")
+	}
+	var body map[string]string
+	if err := json.Unmarshal([]byte(req.Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if body["price"] != "101" || body["amount"] != "0.5000" || req.Query["currency_pair"] != "BTC_USDT" {
+		t.Errorf("改单参数错误: query=%v body=%v", req.Query, body)
+	}
+
+	g, _ = newTestGate(t, map[string]string{
+		"PATCH /spot/orders/1": `{"label":"ORDER_NOT_FOUND","message":"Order not found"}`,
+	})
+	g.getSpotSpec(context.Background()).SetSymbolSpec("BTC_USDT", &symbolSpec{Id: "BTC_USDT", AmountPrecision: 4})
+	if _, err := g.AmendOrder(context.Background(), exchange.MarketSpot, "BTC_USDT", "1", "101", ""); !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("订单不存在应返回 ErrOrderNotFound: %v", err)
+	}
+}
+
+// TestAmendFuturesOrder 合约改单只修改价格时不查询原订单，修改数量时按原订单方向设置张数符号，返回的订单为基础资产数量与委托价格
+// go test -v ./impl/gate -run "^TestAmendFuturesOrder$"
+func TestAmendFuturesOrder(t *testing.T) {
+	const amended = `{"id":1,"contract":"BTC_USDT","size":-20,"left":-15,"price":"100","fill_price":"0","status":"open","tif":"gtc","text":"t-abc"}`
+
+	g, ts := newTestGate(t, map[string]string{"PUT /futures/usdt/orders/1": amended})
+	g.getFuturesSpec(context.Background()).SetFuturesSpec("BTC_USDT", &futuresSpec{Name: "BTC_USDT", QuantoMultiplier: "0.01"})
+	order, err := g.AmendOrder(context.Background(), exchange.MarketFutures, "BTC_USDT", "1", "100", "")
+	if err != nil {
+		t.Fatalf("改单失败: %v", err)
+	}
+	if order.Price != "100" || order.Quantity != "0.2" || order.ExecutedQty != "0.05" || order.Status != exchange.OrderStatusPartiallyFilled {
+		t.Errorf("改单结果错误: %+v", order)
+	}
+	req := ts.findRequest("PUT", "/futures/usdt/orders/1")
+	if req == nil || ts.findRequest("GET", "/futures/usdt/orders/1") != nil {
+		t.Fatalf("只修改价格时应直接改单: %+v", ts.requests)
+	}
+	var body map[string]any
+	if err := json.Unmarshal([]byte(req.Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if _, ok := body["size"]; ok || body["price"] != "100" {
+		t.Errorf("只修改价格时不应发送张数: %v", body)
+	}
+
+	g, ts = newTestGate(t, map[string]string{
+		"GET /futures/usdt/orders/1": `{"id":1,"contract":"BTC_USDT","size":-10,"price":"100","tif":"gtc"}`,
+		"PUT /futures/usdt/orders/1": amended,
+	})
+	g.getFuturesSpec(context.Background()).SetFuturesSpec("BTC_USDT", &futuresSpec{
+		Name: "BTC_USDT", QuantoMultiplier: "0.01", OrderSizeMin: 1, OrderSizeMax: 1000000,
+	})
+	order, err = g.AmendOrder(context.Background(), exchange.MarketFutures, "BTC_USDT", "1", "", "0.2")
+	if err != nil {
+		t.Fatalf("改单失败: %v", err)
+	}
+	if order.Side != exchange.OrderSideSell || order.Quantity != "0.2" {
+		t.Errorf("改单结果方向错误: %+v", order)
+	}
+	body = nil
+	if err := json.Unmarshal([]byte(ts.findRequest("PUT", "/futures/usdt/orders/1").Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if body["size"] != float64(-20) {
+		t.Errorf("空单张数应为负数: %v", body)
+	}
+}
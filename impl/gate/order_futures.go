@@ -2,6 +2,7 @@ package gate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,6 +10,11 @@ import (
 	"github.com/antihax/optional"
 	"github.com/gateio/gateapi-go/v6"
 	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+const (
+	FinishedOrdersPageSize = 100 // 按客户端订单ID查找已结束订单时查询的数量
 )
 
 // CreateFuturesOrder 创建合约订单 - amount 金额 * 杠杆
@@ -19,150 +25,189 @@ func (g *gateExchange) CreateFuturesOrder(ctx context.Context, symbol string, si
 		return nil, fmt.Errorf("获取交易规则失败: %w", err)
 	}
 
-	// 验证交易规则
+	// 按金额计算张数
 	size, err := g.filtersFuturesSize(spec, limitPrice, amount)
 	if err != nil {
 		return nil, fmt.Errorf("验证交易规则失败: %w", err)
 	}
 
-	// 如果方向为卖出，则取反
-	if side == exchange.OrderSideSell {
-		size = -size
+	req := exchange.NewOrderRequest(exchange.MarketFutures, symbol, side, limitPrice, sizeToQuantity(size, spec.QuantoMultiplier))
+	req.PositionSide = exchange.PositionSideShort
+	if side == exchange.OrderSideBuy {
+		req.PositionSide = exchange.PositionSideLong
 	}
+	return g.PlaceOrder(ctx, req)
+}
 
-	// 创建订单参数
-	orderParams := gateapi.FuturesOrder{
-		Contract: symbol,                                                // 合约符号
-		Size:     size,                                                  // 数量，正数=买多/开多，负数=卖空/开空（例如 -10）
-		Price:    limitPrice,                                            // 价格（限价单，字符串类型）
-		Tif:      strings.ToLower(string(exchange.OrderTimeInForceGTC)), // 时间有效性
+// placeFuturesOrder 合约下单
+func (g *gateExchange) placeFuturesOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	spec, orderParams, err := g.newFuturesOrderParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建订单，结果未知时按客户端订单ID对账
+	return idempotent.PlaceOrder(ctx, g.maxRetries, func() (*exchange.Order, error) {
+		createdOrder, _, err := g.getClient(ctx).FuturesApi.CreateFuturesOrder(ctx, strings.ToLower(Settle), orderParams, nil)
+		if err != nil {
+			return nil, fmt.Errorf("合约下单失败: %w", toAPIError(err))
+		}
+		return toFuturesOrder(spec, createdOrder), nil
+	}, func() (*exchange.Order, error) {
+		return g.GetFuturesOrderByClientID(ctx, req.Symbol, toClientOrderID(orderParams.Text))
+	})
+}
+
+// newFuturesOrderParams 按下单请求创建合约下单参数，返回合约规格，数量按合约乘数转换为张数。
+// Gate 双向持仓按方向区分开平仓，卖出平多、买入平空时设置只减仓
+func (g *gateExchange) newFuturesOrderParams(ctx context.Context, req exchange.OrderRequest) (*futuresSpec, gateapi.FuturesOrder, error) {
+	if req.QuoteQty != "" || req.IcebergQty != "" {
+		return nil, gateapi.FuturesOrder{}, fmt.Errorf("%w: 合约不支持按金额下单与冰山单", exchange.ErrInvalidOrder)
+	}
+
+	// 获取交易规则
+	spec, err := g.GetFuturesSymbolSpec(ctx, req.Symbol)
+	if err != nil {
+		return nil, gateapi.FuturesOrder{}, fmt.Errorf("获取交易规则失败: %w", err)
 	}
 
-	// 创建订单
-	createdOrder, _, err := g.client.FuturesApi.CreateFuturesOrder(ctx, strings.ToLower(Settle), orderParams, nil)
+	// 验证交易规则
+	size, err := g.filtersFuturesQuantity(spec, req.Quantity)
 	if err != nil {
-		return nil, fmt.Errorf("合约下单失败: %w", err)
+		return nil, gateapi.FuturesOrder{}, fmt.Errorf("验证交易规则失败: %w", err)
 	}
 
-	orderType := exchange.OrderTypeLimit
-	if limitPrice == "0" || limitPrice == "" {
-		orderType = exchange.OrderTypeMarket
+	// 如果方向为卖出，则取反
+	if req.Side == exchange.OrderSideSell {
+		size = -size
 	}
+	reduceOnly := req.ReduceOnly ||
+		(req.PositionSide == exchange.PositionSideLong && req.Side == exchange.OrderSideSell) ||
+		(req.PositionSide == exchange.PositionSideShort && req.Side == exchange.OrderSideBuy)
 
-	// 转换数量为字符串
-	sizeStr := strconv.FormatInt(createdOrder.Size, 10)
-	return &exchange.Order{
-		OrderID:       strconv.FormatInt(createdOrder.Id, 10),
-		Symbol:        createdOrder.Contract,
-		Side:          side,
-		Type:          orderType,
-		Status:        exchange.OrderStatusNew, // 默认新订单
-		Price:         createdOrder.FillPrice,
-		Quantity:      sizeStr,
-		ExecutedQty:   sizeStr,
-		ActualQty:     sizeStr,
-		QuoteQuantity: "0",
-		TimeInForce:   exchange.OrderTimeInForce(strings.ToUpper(createdOrder.Tif)),
-		CreateTime:    int64(createdOrder.CreateTime),
-		UpdateTime:    int64(createdOrder.FinishTime),
-	}, nil
+	// 创建订单参数，市价单价格为 0 且时间类型为 ioc
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	orderParams := gateapi.FuturesOrder{
+		Contract:   req.Symbol,             // 合约符号
+		Size:       size,                   // 数量，正数=买多/开多，负数=卖空/开空（例如 -10）
+		Price:      req.Price,              // 价格（限价单，字符串类型）
+		Tif:        toTif(req.TimeInForce), // 时间有效性
+		ReduceOnly: reduceOnly,             // 只减仓
+		Text:       toText(clientOrderID),  // 自定义订单ID
+	}
+	if req.Type == exchange.OrderTypeMarket {
+		orderParams.Price = "0"
+		orderParams.Tif = "ioc"
+	}
+	return spec, orderParams, nil
 }
 
 // GetFuturesOrder 获取合约订单
 func (g *gateExchange) GetFuturesOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
-	order, _, err := g.client.FuturesApi.GetFuturesOrder(ctx, strings.ToLower(Settle), orderID)
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
 	if err != nil {
-		return nil, fmt.Errorf("获取合约订单失败: %w", err)
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
 	}
-
-	status := exchange.OrderStatusNew
-	switch order.FinishAs {
-	case "filled":
-		status = exchange.OrderStatusFilled
-	case "cancelled":
-		status = exchange.OrderStatusCanceled
-	case "small":
-		status = exchange.OrderStatusRejected
-	case "depth_not_enough":
-		status = exchange.OrderStatusRejected
-	case "trader_not_enough":
-		status = exchange.OrderStatusRejected
+	order, _, err := g.getClient(ctx).FuturesApi.GetFuturesOrder(ctx, strings.ToLower(Settle), orderID)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约订单失败: %w", toAPIError(err))
 	}
+	return toFuturesOrder(spec, order), nil
+}
 
-	side := exchange.OrderSideBuy
-	if order.Size < 0 {
-		side = exchange.OrderSideSell
+// GetFuturesOrderByClientID 按客户端订单ID获取合约订单。
+// Gate 只能按自定义订单ID查询挂单中的订单，查询不到时在该合约最近已结束的订单中查找
+func (g *gateExchange) GetFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	text := toText(clientOrderID)
+	order, err := g.GetFuturesOrder(ctx, symbol, text)
+	if err == nil || !errors.Is(err, exchange.ErrOrderNotFound) {
+		return order, err
 	}
 
-	// 转换数量为字符串
-	sizeStr := strconv.FormatInt(order.Size, 10)
-	return &exchange.Order{
-		OrderID:       strconv.FormatInt(order.Id, 10),
-		Symbol:        order.Contract,
-		Side:          side,
-		Type:          exchange.OrderTypeLimit,
-		Status:        status,
-		Price:         order.FillPrice,
-		Quantity:      sizeStr,
-		ExecutedQty:   sizeStr,
-		ActualQty:     sizeStr,
-		QuoteQuantity: "0",
-		TimeInForce:   exchange.OrderTimeInForce(strings.ToUpper(order.Tif)),
-		CreateTime:    int64(order.CreateTime),
-		UpdateTime:    int64(order.FinishTime),
-	}, nil
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	orders, _, err := g.getClient(ctx).FuturesApi.ListFuturesOrders(ctx, strings.ToLower(Settle), "finished", &gateapi.ListFuturesOrdersOpts{
+		Contract: optional.NewString(symbol),
+		Limit:    optional.NewInt32(FinishedOrdersPageSize),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取合约已结束订单失败: %w", toAPIError(err))
+	}
+	for _, o := range orders {
+		if o.Text == text {
+			return toFuturesOrder(spec, o), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", exchange.ErrOrderNotFound, clientOrderID)
 }
 
 // CancelFuturesOrder 取消合约订单
 func (g *gateExchange) CancelFuturesOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
-	canceledOrder, _, err := g.client.FuturesApi.CancelFuturesOrder(ctx, strings.ToLower(Settle), orderID, nil)
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
 	if err != nil {
-		return nil, fmt.Errorf("取消合约订单失败: %w", err)
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	canceledOrder, _, err := g.getClient(ctx).FuturesApi.CancelFuturesOrder(ctx, strings.ToLower(Settle), orderID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("取消合约订单失败: %w", toAPIError(err))
+	}
+	return toFuturesOrder(spec, canceledOrder), nil
+}
+
+// CancelFuturesOrderByClientID 按客户端订单ID取消合约订单
+func (g *gateExchange) CancelFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return g.CancelFuturesOrder(ctx, symbol, toText(clientOrderID))
+}
+
+// toFuturesOrder 转换合约订单，张数按合约乘数转换为基础资产数量，市价单价格为成交均价
+func toFuturesOrder(spec *futuresSpec, order gateapi.FuturesOrder) *exchange.Order {
+	side, size, left := exchange.OrderSideBuy, order.Size, order.Left
+	if size < 0 {
+		side, size, left = exchange.OrderSideSell, -size, -left
 	}
+	executedQty := sizeToQuantity(size-left, spec.QuantoMultiplier)
 
-	status := exchange.OrderStatusNew
-	switch canceledOrder.FinishAs {
-	case "filled":
-		status = exchange.OrderStatusFilled
-	case "cancelled":
-		status = exchange.OrderStatusCanceled
-	case "small":
-		status = exchange.OrderStatusRejected
-	case "depth_not_enough":
-		status = exchange.OrderStatusRejected
-	case "trader_not_enough":
-		status = exchange.OrderStatusRejected
+	orderType := exchange.OrderTypeLimit
+	price := order.Price
+	if order.Price == "" || order.Price == "0" {
+		orderType = exchange.OrderTypeMarket
+		price = order.FillPrice
 	}
 
-	side := exchange.OrderSideBuy
-	if canceledOrder.Size < 0 {
-		side = exchange.OrderSideSell
+	quoteQuantity := "0"
+	if fillPrice, err := exchange.ParseDecimal(order.FillPrice); err == nil {
+		quoteQuantity = exchange.ToDecimal(executedQty).Mul(fillPrice).String()
 	}
 
-	// 转换数量为字符串
-	sizeStr := strconv.FormatInt(canceledOrder.Size, 10)
+	updateTime := secondsToMs(order.FinishTime)
+	if updateTime == 0 {
+		updateTime = secondsToMs(order.CreateTime)
+	}
 	return &exchange.Order{
-		OrderID:       strconv.FormatInt(canceledOrder.Id, 10),
-		Symbol:        canceledOrder.Contract,
+		OrderID:       strconv.FormatInt(order.Id, 10),
+		ClientOrderID: toClientOrderID(order.Text),
+		Symbol:        order.Contract,
 		Side:          side,
-		Type:          exchange.OrderTypeLimit,
-		Status:        status,
-		Price:         canceledOrder.FillPrice,
-		Quantity:      sizeStr,
-		ExecutedQty:   sizeStr,
-		ActualQty:     sizeStr,
-		QuoteQuantity: "0",
-		TimeInForce:   exchange.OrderTimeInForce(strings.ToUpper(canceledOrder.Tif)),
-		CreateTime:    int64(canceledOrder.CreateTime),
-		UpdateTime:    int64(canceledOrder.FinishTime),
-	}, nil
+		Type:          orderType,
+		Status:        toOrderStatus(order.Status == "finished", order.FinishAs, size > left),
+		Price:         price,
+		Quantity:      sizeToQuantity(size, spec.QuantoMultiplier),
+		ExecutedQty:   executedQty,
+		ActualQty:     executedQty,
+		QuoteQuantity: quoteQuantity,
+		TimeInForce:   toTimeInForce(order.Tif),
+		CreateTime:    secondsToMs(order.CreateTime),
+		UpdateTime:    updateTime,
+	}
 }
 
 // GetFuturesPositionRisk 获取合约持仓风险
 func (g *gateExchange) GetFuturesPositionRisk(ctx context.Context, symbol string) (*exchange.SymbolPositionRisk, error) {
-	positions, _, err := g.client.FuturesApi.GetDualModePosition(ctx, strings.ToLower(Settle), symbol)
+	positions, _, err := g.getClient(ctx).FuturesApi.GetDualModePosition(ctx, strings.ToLower(Settle), symbol)
 	if err != nil {
-		return nil, fmt.Errorf("获取合约持仓风险失败: %w", err)
+		return nil, fmt.Errorf("获取合约持仓风险失败: %w", toAPIError(err))
 	}
 
 	exchangePositionRisk := &exchange.SymbolPositionRisk{
@@ -235,9 +280,9 @@ func (g *gateExchange) SetFuturesSLTP(ctx context.Context, symbol string, positi
 
 // SetFuturesLeverage 设置合约杠杆
 func (g *gateExchange) SetFuturesLeverage(ctx context.Context, symbol string, leverage int) error {
-	_, _, err := g.client.FuturesApi.UpdateDualModePositionLeverage(ctx, strings.ToLower(Settle), symbol, strconv.Itoa(leverage), nil)
+	_, _, err := g.getClient(ctx).FuturesApi.UpdateDualModePositionLeverage(ctx, strings.ToLower(Settle), symbol, strconv.Itoa(leverage), nil)
 	if err != nil {
-		return fmt.Errorf("更新杠杆失败: %w", err)
+		return fmt.Errorf("更新杠杆失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -248,21 +293,21 @@ func (g *gateExchange) SetFuturesMarginMode(ctx context.Context, symbol string,
 	if marginMode == exchange.MarginModeCrossed {
 		mode = "CROSS"
 	}
-	_, _, err := g.client.FuturesApi.UpdateDualCompPositionCrossMode(ctx, strings.ToLower(Settle), gateapi.InlineObject{
+	_, _, err := g.getClient(ctx).FuturesApi.UpdateDualCompPositionCrossMode(ctx, strings.ToLower(Settle), gateapi.InlineObject{
 		Mode:     mode,
 		Contract: symbol,
 	})
 	if err != nil {
-		return fmt.Errorf("设置保证金模式失败: %w", err)
+		return fmt.Errorf("设置保证金模式失败: %w", toAPIError(err))
 	}
 	return nil
 }
 
 // SetFuturesDualMode 设置持仓模式
 func (g *gateExchange) SetFuturesDualMode(ctx context.Context, dualMode bool) error {
-	_, _, err := g.client.FuturesApi.SetDualMode(ctx, strings.ToLower(Settle), dualMode)
+	_, _, err := g.getClient(ctx).FuturesApi.SetDualMode(ctx, strings.ToLower(Settle), dualMode)
 	if err != nil {
-		return fmt.Errorf("设置持仓模式失败: %w", err)
+		return fmt.Errorf("设置持仓模式失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -272,9 +317,9 @@ func (g *gateExchange) CancelFuturesSLTP(ctx context.Context, symbol string) err
 	opts := &gateapi.CancelPriceTriggeredOrderListOpts{
 		Contract: optional.NewString(symbol),
 	}
-	_, _, err := g.client.FuturesApi.CancelPriceTriggeredOrderList(ctx, strings.ToLower(Settle), opts)
+	_, _, err := g.getClient(ctx).FuturesApi.CancelPriceTriggeredOrderList(ctx, strings.ToLower(Settle), opts)
 	if err != nil {
-		return fmt.Errorf("撤销合约止损止盈失败: %w", err)
+		return fmt.Errorf("撤销合约止损止盈失败: %w", toAPIError(err))
 	}
 	return nil
 }
@@ -284,58 +329,22 @@ func (g *gateExchange) GetFuturesSymbolSpec(ctx context.Context, symbol string)
 	var spec *futuresSpec
 
 	// 从缓存中获取交易对规格
-	spec, _ = gateFuturesSpec.GetFuturesSpec(symbol)
+	spec, _ = g.getFuturesSpec(ctx).GetFuturesSpec(symbol)
 
 	if spec == nil {
-		contracts, _, err := g.client.FuturesApi.ListFuturesContracts(context.Background(), strings.ToLower(Settle), nil)
+		contracts, _, err := g.getClient(ctx).FuturesApi.ListFuturesContracts(ctx, strings.ToLower(Settle), nil)
 		if err != nil {
-			return nil, fmt.Errorf("获取合约交易对规则失败: %w", err)
+			return nil, fmt.Errorf("获取合约交易对规则失败: %w", toAPIError(err))
 		}
 
 		for _, contract := range contracts {
-			specTmp := &futuresSpec{
-				Name:              contract.Name,
-				Type:              contract.Type,
-				QuantoMultiplier:  contract.QuantoMultiplier,
-				LeverageMin:       contract.LeverageMin,
-				LeverageMax:       contract.LeverageMax,
-				MaintenanceRate:   contract.MaintenanceRate,
-				MarkType:          contract.MarkType,
-				MarkPrice:         contract.MarkPrice,
-				IndexPrice:        contract.IndexPrice,
-				LastPrice:         contract.LastPrice,
-				MakerFeeRate:      contract.MakerFeeRate,
-				TakerFeeRate:      contract.TakerFeeRate,
-				OrderPriceRound:   contract.OrderPriceRound,
-				MarkPriceRound:    contract.MarkPriceRound,
-				FundingRate:       contract.FundingRate,
-				FundingInterval:   contract.FundingInterval,
-				FundingNextApply:  contract.FundingNextApply,
-				OrderSizeMin:      contract.OrderSizeMin,
-				OrderSizeMax:      contract.OrderSizeMax,
-				OrderPriceDeviate: contract.OrderPriceDeviate,
-				RefDiscountRate:   contract.RefDiscountRate,
-				RefRebateRate:     contract.RefRebateRate,
-				OrderbookId:       contract.OrderbookId,
-				TradeId:           contract.TradeId,
-				TradeSize:         contract.TradeSize,
-				PositionSize:      contract.PositionSize,
-				ConfigChangeTime:  contract.ConfigChangeTime,
-				InDelisting:       contract.InDelisting,
-				OrdersLimit:       contract.OrdersLimit,
-				EnableBonus:       contract.EnableBonus,
-				EnableCredit:      contract.EnableCredit,
-				CreateTime:        contract.CreateTime,
-				FundingCapRatio:   contract.FundingCapRatio,
-				Status:            contract.Status,
-				LaunchTime:        contract.LaunchTime,
-			}
+			specTmp := toFuturesSpec(&contract)
 
 			// 如果合约匹配，则设置合约规格
 			if symbol == contract.Name {
 				spec = specTmp
 			}
-			gateFuturesSpec.SetFuturesSpec(contract.Name, specTmp)
+			g.getFuturesSpec(ctx).SetFuturesSpec(contract.Name, specTmp)
 		}
 	}
 
@@ -384,9 +393,9 @@ func (g *gateExchange) setFuturesStopLoss(ctx context.Context, symbol string, po
 	}
 
 	// 设置止损
-	_, _, err := g.client.FuturesApi.CreatePriceTriggeredOrder(ctx, strings.ToLower(Settle), slOrder)
+	_, _, err := g.getClient(ctx).FuturesApi.CreatePriceTriggeredOrder(ctx, strings.ToLower(Settle), slOrder)
 	if err != nil {
-		return fmt.Errorf("设置止损失败: %w", err)
+		return fmt.Errorf("设置止损失败: %w", toAPIError(err))
 	}
 	return err
 }
@@ -417,9 +426,9 @@ func (g *gateExchange) setFuturesTakeProfit(ctx context.Context, symbol string,
 	}
 
 	// 设置止盈
-	_, _, err := g.client.FuturesApi.CreatePriceTriggeredOrder(ctx, strings.ToLower(Settle), tpOrder)
+	_, _, err := g.getClient(ctx).FuturesApi.CreatePriceTriggeredOrder(ctx, strings.ToLower(Settle), tpOrder)
 	if err != nil {
-		return fmt.Errorf("设置止盈失败: %w", err)
+		return fmt.Errorf("设置止盈失败: %w", toAPIError(err))
 	}
 	return err
 }
@@ -0,0 +1,43 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestPlaceFuturesOrder 合约下单数量按合约乘数转换为张数，返回的订单数量为基础资产数量，价格为委托价格
+// go test -v ./impl/gate -run "^TestPlaceFuturesOrder$"
+func TestPlaceFuturesOrder(t *testing.T) {
+	g, ts := newTestGate(t, map[string]string{
+		"POST /futures/usdt/orders": `{"id":1,"contract":"BTC_USDT","size":-20,"left":-20,"price":"100","fill_price":"0","status":"open",
+			"tif":"gtc","text":"t-abc","create_time":1700000000.123}`,
+	})
+	g.getFuturesSpec(context.Background()).SetFuturesSpec("BTC_USDT", &futuresSpec{
+		Name: "BTC_USDT", QuantoMultiplier: "0.01", OrderSizeMin: 1, OrderSizeMax: 1000000,
+	})
+	req := exchange.NewOrderRequest(exchange.MarketFutures, "BTC_USDT", exchange.OrderSideSell, "100", "0.2")
+	req.ClientOrderID = "abc"
+	order, err := g.PlaceOrder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("合约下单失败: %v", err)
+	}
+	want := exchange.Order{
+		OrderID: "1", ClientOrderID: "abc", Symbol: "BTC_USDT", Side: exchange.OrderSideSell, Type: exchange.OrderTypeLimit,
+		Status: exchange.OrderStatusNew, Price: "100", Quantity: "0.2", ExecutedQty: "0", ActualQty: "0", QuoteQuantity: "0",
+		TimeInForce: exchange.OrderTimeInForceGTC, CreateTime: 1700000000123, UpdateTime: 1700000000123,
+	}
+	if *order != want {
+		t.Errorf("下单结果错误: %+v", order)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal([]byte(ts.findRequest("POST", "/futures/usdt/orders").Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if body["size"] != float64(-20) || body["price"] != "100" {
+		t.Errorf("下单参数错误: %v", body)
+	}
+}
@@ -0,0 +1,184 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	OrderListLimit = 100 // 订单列表每页数量
+)
+
+// ListOpenOrders 获取交易对未完成的订单，Gate 条件单单独存储，不在挂单列表中
+func (g *gateExchange) ListOpenOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.Order, error) {
+	result := make([]*exchange.Order, 0)
+	switch market {
+	case exchange.MarketSpot:
+		spec, err := g.GetSpotSymbolSpec(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		for page := int32(1); ; page++ {
+			orders, _, err := g.getClient(ctx).SpotApi.ListOrders(ctx, symbol, "open", &gateapi.ListOrdersOpts{
+				Page:  optional.NewInt32(page),
+				Limit: optional.NewInt32(OrderListLimit),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("获取现货挂单失败: %w", toAPIError(err))
+			}
+			for _, o := range orders {
+				order, err := toSpotOrder(spec, o)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, order)
+			}
+			if len(orders) < OrderListLimit {
+				return result, nil
+			}
+		}
+	case exchange.MarketFutures:
+		spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		for offset := int32(0); ; offset += OrderListLimit {
+			orders, _, err := g.getClient(ctx).FuturesApi.ListFuturesOrders(ctx, strings.ToLower(Settle), "open", &gateapi.ListFuturesOrdersOpts{
+				Contract: optional.NewString(symbol),
+				Limit:    optional.NewInt32(OrderListLimit),
+				Offset:   optional.NewInt32(offset),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("获取合约挂单失败: %w", toAPIError(err))
+			}
+			for _, o := range orders {
+				result = append(result, toFuturesOrder(spec, o))
+			}
+			if len(orders) < OrderListLimit {
+				return result, nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+}
+
+// CancelAllOrders 撤销交易对全部挂单，不包括条件单
+func (g *gateExchange) CancelAllOrders(ctx context.Context, market exchange.Market, symbol string) error {
+	switch market {
+	case exchange.MarketSpot:
+		_, _, err := g.getClient(ctx).SpotApi.CancelOrders(ctx, &gateapi.CancelOrdersOpts{
+			CurrencyPair: optional.NewString(symbol),
+		})
+		if err != nil {
+			return fmt.Errorf("撤销现货全部挂单失败: %w", toAPIError(err))
+		}
+	case exchange.MarketFutures:
+		_, _, err := g.getClient(ctx).FuturesApi.CancelFuturesOrders(ctx, strings.ToLower(Settle), symbol, nil)
+		if err != nil {
+			return fmt.Errorf("撤销合约全部挂单失败: %w", toAPIError(err))
+		}
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+	return nil
+}
+
+// ListOrderHistory 按时间倒序分页查询已结束的订单，现货游标为页码，合约游标为偏移量；
+// 合约指定时间范围时按时间范围接口查询
+func (g *gateExchange) ListOrderHistory(ctx context.Context, query exchange.OrderQuery) (*exchange.OrderPage, error) {
+	limit := int32(query.Limit)
+	if limit <= 0 {
+		limit = OrderListLimit
+	}
+	cursor := int32(0)
+	if query.Cursor != "" {
+		n, err := strconv.ParseInt(query.Cursor, 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("无效的分页游标: %s", query.Cursor)
+		}
+		cursor = int32(n)
+	}
+
+	page := &exchange.OrderPage{Orders: make([]*exchange.Order, 0)}
+	switch query.Market {
+	case exchange.MarketSpot:
+		spec, err := g.GetSpotSymbolSpec(ctx, query.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		pageNum := max(cursor, 1)
+		opts := &gateapi.ListOrdersOpts{
+			Page:  optional.NewInt32(pageNum),
+			Limit: optional.NewInt32(limit),
+		}
+		if !query.StartTime.IsZero() {
+			opts.From = optional.NewInt64(query.StartTime.Unix())
+		}
+		if !query.EndTime.IsZero() {
+			opts.To = optional.NewInt64(query.EndTime.Unix())
+		}
+		orders, _, err := g.getClient(ctx).SpotApi.ListOrders(ctx, query.Symbol, "finished", opts)
+		if err != nil {
+			return nil, fmt.Errorf("获取现货历史订单失败: %w", toAPIError(err))
+		}
+		for _, o := range orders {
+			order, err := toSpotOrder(spec, o)
+			if err != nil {
+				return nil, err
+			}
+			page.Orders = append(page.Orders, order)
+		}
+		if len(orders) >= int(limit) {
+			page.NextCursor = strconv.Itoa(int(pageNum + 1))
+		}
+	case exchange.MarketFutures:
+		spec, err := g.GetFuturesSymbolSpec(ctx, query.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		var orders []gateapi.FuturesOrder
+		if query.StartTime.IsZero() && query.EndTime.IsZero() {
+			orders, _, err = g.getClient(ctx).FuturesApi.ListFuturesOrders(ctx, strings.ToLower(Settle), "finished", &gateapi.ListFuturesOrdersOpts{
+				Contract: optional.NewString(query.Symbol),
+				Limit:    optional.NewInt32(limit),
+				Offset:   optional.NewInt32(cursor),
+			})
+		} else {
+			opts := &gateapi.GetOrdersWithTimeRangeOpts{
+				Contract: optional.NewString(query.Symbol),
+				Limit:    optional.NewInt32(limit),
+				Offset:   optional.NewInt32(cursor),
+			}
+			if !query.StartTime.IsZero() {
+				opts.From = optional.NewInt64(query.StartTime.Unix())
+			}
+			if !query.EndTime.IsZero() {
+				opts.To = optional.NewInt64(query.EndTime.Unix())
+			}
+			orders, _, err = g.getClient(ctx).FuturesApi.GetOrdersWithTimeRange(ctx, strings.ToLower(Settle), opts)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("获取合约历史订单失败: %w", toAPIError(err))
+		}
+		for _, o := range orders {
+			// 时间范围接口同时返回未完成的订单
+			if o.Status == "open" {
+				continue
+			}
+			page.Orders = append(page.Orders, toFuturesOrder(spec, o))
+		}
+		if len(orders) >= int(limit) {
+			page.NextCursor = strconv.Itoa(int(cursor + limit))
+		}
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", query.Market)
+	}
+	return page, nil
+}
@@ -0,0 +1,104 @@
+package gate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestListOrderHistoryCursor 现货游标为页码，合约游标为偏移量，合约指定时间范围时使用时间范围接口并过滤未完成的订单
+// go test -v ./impl/gate -run "^TestListOrderHistoryCursor$"
+func TestListOrderHistoryCursor(t *testing.T) {
+	const spotOrders = `[{"id":"1","currency_pair":"BTC_USDT","type":"limit","side":"buy","price":"100","amount":"1","filled_amount":"1","fee":"0","status":"closed","finish_as":"filled"},
+		{"id":"2","currency_pair":"BTC_USDT","type":"limit","side":"sell","price":"101","amount":"1","filled_amount":"0","fee":"0","status":"cancelled","finish_as":"cancelled"}]`
+	const futuresOrders = `[{"id":1,"contract":"BTC_USDT","size":10,"price":"100","status":"finished","finish_as":"filled"},
+		{"id":2,"contract":"BTC_USDT","size":-10,"price":"101","status":"open"}]`
+
+	tests := []struct {
+		market     exchange.Market
+		path       string
+		query      exchange.OrderQuery
+		params     map[string]string
+		orders     int
+		nextCursor string
+	}{
+		{exchange.MarketSpot, "/spot/orders", exchange.OrderQuery{Limit: 2},
+			map[string]string{"page": "1", "limit": "2", "status": "finished"}, 2, "2"},
+		{exchange.MarketSpot, "/spot/orders", exchange.OrderQuery{Limit: 2, Cursor: "2"},
+			map[string]string{"page": "2", "limit": "2"}, 2, "3"},
+		{exchange.MarketSpot, "/spot/orders", exchange.OrderQuery{Limit: 3, StartTime: time.Unix(1700000000, 0)},
+			map[string]string{"page": "1", "from": "1700000000"}, 2, ""},
+		{exchange.MarketFutures, "/futures/usdt/orders", exchange.OrderQuery{Limit: 2, Cursor: "2"},
+			map[string]string{"offset": "2", "limit": "2", "status": "finished"}, 1, "4"},
+		{exchange.MarketFutures, "/futures/usdt/orders_timerange", exchange.OrderQuery{Limit: 2, EndTime: time.Unix(1700000000, 0)},
+			map[string]string{"offset": "0", "to": "1700000000"}, 1, "2"},
+	}
+	for i, tt := range tests {
+		data := spotOrders
+		if tt.market == exchange.MarketFutures {
+			data = futuresOrders
+		}
+		g, ts := newTestGate(t, map[string]string{"GET " + tt.path: data})
+		g.getSpotSpec(context.Background()).SetSymbolSpec("BTC_USDT", &symbolSpec{Id: "BTC_USDT", AmountPrecision: 4})
+		g.getFuturesSpec(context.Background()).SetFuturesSpec("BTC_USDT", &futuresSpec{Name: "BTC_USDT", QuantoMultiplier: "0.01"})
+		tt.query.Market = tt.market
+		tt.query.Symbol = "BTC_USDT"
+		page, err := g.ListOrderHistory(context.Background(), tt.query)
+		if err != nil {
+			t.Fatalf("第 %d 组获取历史订单失败: %v", i, err)
+		}
+		if len(page.Orders) != tt.orders || page.NextCursor != tt.nextCursor {
+			t.Errorf("第 %d 组分页结果错误: orders=%d next=%q", i, len(page.Orders), page.NextCursor)
+		}
+		req := ts.findRequest("GET", tt.path)
+		for k, v := range tt.params {
+			if req.Query[k] != v {
+				t.Errorf("第 %d 组参数 %s 应为 %s: %+v", i, k, v, req.Query)
+			}
+		}
+	}
+
+	g, _ := newTestGate(t, nil)
+	if _, err := g.ListOrderHistory(context.Background(), exchange.OrderQuery{Market: exchange.MarketFutures, Cursor: "-1"}); err == nil {
+		t.Errorf("无效的游标应返回错误")
+	}
+}
+
+// TestListFuturesOrders 合约挂单与历史订单数量转换为基础资产数量，已成交数量为委托张数减去剩余张数，价格为委托价格
+// go test -v ./impl/gate -run "^TestListFuturesOrders$"
+func TestListFuturesOrders(t *testing.T) {
+	g, _ := newTestGate(t, map[string]string{
+		"GET /futures/usdt/orders": `[{"id":1,"contract":"BTC_USDT","size":-30,"left":-10,"price":"100","fill_price":"100.5","status":"open","tif":"gtc"}]`,
+	})
+	g.getFuturesSpec(context.Background()).SetFuturesSpec("BTC_USDT", &futuresSpec{Name: "BTC_USDT", QuantoMultiplier: "0.01"})
+	orders, err := g.ListOpenOrders(context.Background(), exchange.MarketFutures, "BTC_USDT")
+	if err != nil {
+		t.Fatalf("获取合约挂单失败: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("挂单数量错误: %d", len(orders))
+	}
+	if order := orders[0]; order.Side != exchange.OrderSideSell || order.Status != exchange.OrderStatusPartiallyFilled ||
+		order.Price != "100" || order.Quantity != "0.3" || order.ExecutedQty != "0.2" || order.QuoteQuantity != "20.1" {
+		t.Errorf("挂单转换错误: %+v", order)
+	}
+
+	g, _ = newTestGate(t, map[string]string{
+		"GET /futures/usdt/orders": `[{"id":2,"contract":"BTC_USDT","size":10,"left":0,"price":"0","fill_price":"101","status":"finished",
+			"finish_as":"filled","tif":"ioc","create_time":1700000000,"finish_time":1700000001.5}]`,
+	})
+	g.getFuturesSpec(context.Background()).SetFuturesSpec("BTC_USDT", &futuresSpec{Name: "BTC_USDT", QuantoMultiplier: "0.01"})
+	page, err := g.ListOrderHistory(context.Background(), exchange.OrderQuery{Market: exchange.MarketFutures, Symbol: "BTC_USDT"})
+	if err != nil {
+		t.Fatalf("获取合约历史订单失败: %v", err)
+	}
+	if len(page.Orders) != 1 {
+		t.Fatalf("历史订单数量错误: %d", len(page.Orders))
+	}
+	if order := page.Orders[0]; order.Type != exchange.OrderTypeMarket || order.Status != exchange.OrderStatusFilled || order.Price != "101" ||
+		order.Quantity != "0.1" || order.ExecutedQty != "0.1" || order.CreateTime != 1700000000000 || order.UpdateTime != 1700000001500 {
+		t.Errorf("历史订单转换错误: %+v", order)
+	}
+}
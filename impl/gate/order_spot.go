@@ -3,66 +3,76 @@ package gate
 import (
 	"context"
 	"fmt"
-	"math/big"
 	"strings"
 
 	"github.com/gateio/gateapi-go/v6"
 	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
 )
 
 // CreateSpotOrder 创建现货订单
 func (g *gateExchange) CreateSpotOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
-	spec, err := g.GetSpotSymbolSpec(ctx, symbol)
-	if err != nil {
-		return nil, err
-	}
+	return g.PlaceOrder(ctx, exchange.NewOrderRequest(exchange.MarketSpot, symbol, side, limitPrice, quantity))
+}
 
-	// 验证交易规则
-	quantity, err = g.filtersQuantity(spec, limitPrice, quantity)
+// placeSpotOrder 现货下单
+func (g *gateExchange) placeSpotOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	spec, orderParams, err := g.newSpotOrderParams(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		return nil, err
 	}
 
-	orderParams := gateapi.Order{
-		CurrencyPair: symbol,
-		Side:         strings.ToLower(string(side)), // buy 或 sell
-		Amount:       quantity,                      // 数量（基础币种）
-		Price:        limitPrice,                    // 价格（报价币种）
-		Type:         "limit",                       // limit（限价）或 market（市价）
-	}
+	// 下单，结果未知时按客户端订单ID对账
+	return idempotent.PlaceOrder(ctx, g.maxRetries, func() (*exchange.Order, error) {
+		createdOrder, _, err := g.getClient(ctx).SpotApi.CreateOrder(ctx, orderParams, nil)
+		if err != nil {
+			return nil, fmt.Errorf("下单失败: %w", toAPIError(err))
+		}
+		return toSpotOrder(spec, createdOrder)
+	}, func() (*exchange.Order, error) {
+		return g.GetSpotOrderByClientID(ctx, req.Symbol, toClientOrderID(orderParams.Text))
+	})
+}
 
-	createdOrder, _, err := g.client.SpotApi.CreateOrder(ctx, orderParams, nil)
+// newSpotOrderParams 按下单请求创建现货下单参数。Gate 市价买入按计价资产金额下单，需指定 QuoteQty；市价卖出按基础资产数量下单
+func (g *gateExchange) newSpotOrderParams(ctx context.Context, req exchange.OrderRequest) (*symbolSpec, gateapi.Order, error) {
+	spec, err := g.GetSpotSymbolSpec(ctx, req.Symbol)
 	if err != nil {
-		return nil, fmt.Errorf("下单失败: %w", err)
+		return nil, gateapi.Order{}, err
 	}
 
-	// 计算手续费
-	filledAmount := new(big.Float).SetPrec(64)
-	if _, ok := filledAmount.SetString(createdOrder.FilledAmount); !ok {
-		return nil, fmt.Errorf("无效的已成交数量: %s", createdOrder.FilledAmount)
+	// 验证交易规则
+	var amount string
+	switch {
+	case req.Type == exchange.OrderTypeMarket && req.Side == exchange.OrderSideBuy:
+		if req.QuoteQty == "" {
+			return nil, gateapi.Order{}, fmt.Errorf("%w: Gate 现货市价买入需按金额下单", exchange.ErrInvalidOrder)
+		}
+		amount, err = g.filtersQuoteAmount(spec, req.QuoteQty)
+	case req.QuoteQty != "":
+		return nil, gateapi.Order{}, fmt.Errorf("%w: Gate 现货市价卖出需按数量下单", exchange.ErrInvalidOrder)
+	default:
+		amount, err = g.filtersQuantity(spec, req.Price, req.Quantity)
 	}
-
-	feeAmount := new(big.Float).SetPrec(64)
-	if _, ok := feeAmount.SetString(createdOrder.Fee); !ok {
-		return nil, fmt.Errorf("无效的手续费: %s", createdOrder.Fee)
+	if err != nil {
+		return nil, gateapi.Order{}, fmt.Errorf("验证交易规则失败: %w", err)
 	}
-	actualQty := filledAmount.Sub(filledAmount, feeAmount)
 
-	return &exchange.Order{
-		OrderID:       createdOrder.Id,
-		Symbol:        createdOrder.CurrencyPair,
-		Side:          exchange.OrderSide(strings.ToUpper(createdOrder.Side)),
-		Type:          exchange.OrderType(strings.ToUpper(createdOrder.Type)),
-		Status:        exchange.OrderStatusNew, // 默认新订单
-		Price:         createdOrder.Price,
-		Quantity:      createdOrder.Amount,
-		ExecutedQty:   createdOrder.FilledAmount,
-		ActualQty:     actualQty.Text('f', spec.AmountPrecision),
-		QuoteQuantity: createdOrder.FilledTotal,
-		TimeInForce:   exchange.OrderTimeInForce(strings.ToUpper(createdOrder.TimeInForce)),
-		CreateTime:    createdOrder.CreateTimeMs,
-		UpdateTime:    createdOrder.UpdateTimeMs,
-	}, nil
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	orderParams := gateapi.Order{
+		Text:         toText(clientOrderID),
+		CurrencyPair: req.Symbol,
+		Side:         strings.ToLower(string(req.Side)), // buy 或 sell
+		Amount:       amount,                            // 数量，市价买入为计价币种金额
+		Price:        req.Price,                         // 价格（报价币种）
+		Type:         strings.ToLower(string(req.Type)), // limit（限价）或 market（市价）
+		TimeInForce:  toTif(req.TimeInForce),            // gtc、ioc、fok 或 poc（只做挂单）
+		Iceberg:      req.IcebergQty,                    // 冰山单显示数量
+	}
+	if req.Type == exchange.OrderTypeMarket {
+		orderParams.TimeInForce = "ioc"
+	}
+	return spec, orderParams, nil
 }
 
 // GetSpotOrder 获取现货订单
@@ -72,52 +82,16 @@ func (g *gateExchange) GetSpotOrder(ctx context.Context, symbol string, orderID
 	if err != nil {
 		return nil, err
 	}
-	singleOrder, _, err := g.client.SpotApi.GetOrder(ctx, orderID, symbol, nil)
+	singleOrder, _, err := g.getClient(ctx).SpotApi.GetOrder(ctx, orderID, symbol, nil)
 	if err != nil {
-		return nil, fmt.Errorf("获取单个订单失败: %w", err)
-	}
-
-	// 计算手续费
-	filledAmount := new(big.Float).SetPrec(64)
-	if _, ok := filledAmount.SetString(singleOrder.FilledAmount); !ok {
-		return nil, fmt.Errorf("无效的已成交数量: %s", singleOrder.FilledAmount)
-	}
-
-	feeAmount := new(big.Float).SetPrec(64)
-	if _, ok := feeAmount.SetString(singleOrder.Fee); !ok {
-		return nil, fmt.Errorf("无效的手续费: %s", singleOrder.Fee)
-	}
-	actualQty := filledAmount.Sub(filledAmount, feeAmount)
-
-	status := exchange.OrderStatusNew
-	switch singleOrder.FinishAs {
-	case "filled":
-		status = exchange.OrderStatusFilled
-	case "cancelled":
-		status = exchange.OrderStatusCanceled
-	case "small":
-		status = exchange.OrderStatusRejected
-	case "depth_not_enough":
-		status = exchange.OrderStatusRejected
-	case "trader_not_enough":
-		status = exchange.OrderStatusRejected
+		return nil, fmt.Errorf("获取单个订单失败: %w", toAPIError(err))
 	}
+	return toSpotOrder(spec, singleOrder)
+}
 
-	return &exchange.Order{
-		OrderID:       singleOrder.Id,
-		Symbol:        singleOrder.CurrencyPair,
-		Side:          exchange.OrderSide(strings.ToUpper(singleOrder.Side)),
-		Type:          exchange.OrderType(strings.ToUpper(singleOrder.Type)),
-		Status:        status,
-		Price:         singleOrder.Price,
-		Quantity:      singleOrder.Amount,
-		ExecutedQty:   singleOrder.FilledAmount,
-		ActualQty:     actualQty.Text('f', spec.AmountPrecision),
-		QuoteQuantity: singleOrder.FilledTotal,
-		TimeInForce:   exchange.OrderTimeInForce(strings.ToUpper(singleOrder.TimeInForce)),
-		CreateTime:    singleOrder.CreateTimeMs,
-		UpdateTime:    singleOrder.UpdateTimeMs,
-	}, nil
+// GetSpotOrderByClientID 按客户端订单ID获取现货订单，Gate 订单ID参数支持传入自定义订单ID
+func (g *gateExchange) GetSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return g.GetSpotOrder(ctx, symbol, toText(clientOrderID))
 }
 
 // CancelSpotOrder 撤销现货订单
@@ -128,24 +102,34 @@ func (g *gateExchange) CancelSpotOrder(ctx context.Context, symbol string, order
 		return nil, err
 	}
 
-	canceledOrder, _, err := g.client.SpotApi.CancelOrder(ctx, orderID, symbol, nil)
+	canceledOrder, _, err := g.getClient(ctx).SpotApi.CancelOrder(ctx, orderID, symbol, nil)
 	if err != nil {
-		return nil, fmt.Errorf("取消单个订单失败: %w", err)
+		return nil, fmt.Errorf("取消单个订单失败: %w", toAPIError(err))
 	}
+	return toSpotOrder(spec, canceledOrder)
+}
+
+// CancelSpotOrderByClientID 按客户端订单ID撤销现货订单
+func (g *gateExchange) CancelSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return g.CancelSpotOrder(ctx, symbol, toText(clientOrderID))
+}
+
+// toSpotOrder 转换现货订单，实际数量为已成交数量扣除手续费
+func toSpotOrder(spec *symbolSpec, order gateapi.Order) (*exchange.Order, error) {
 	// 计算手续费
-	filledAmount := new(big.Float).SetPrec(64)
-	if _, ok := filledAmount.SetString(canceledOrder.FilledAmount); !ok {
-		return nil, fmt.Errorf("无效的已成交数量: %s", canceledOrder.FilledAmount)
+	filledAmount, err := exchange.ParseDecimal(order.FilledAmount)
+	if err != nil {
+		return nil, fmt.Errorf("无效的已成交数量: %s", order.FilledAmount)
 	}
 
-	feeAmount := new(big.Float).SetPrec(64)
-	if _, ok := feeAmount.SetString(canceledOrder.Fee); !ok {
-		return nil, fmt.Errorf("无效的手续费: %s", canceledOrder.Fee)
+	feeAmount, err := exchange.ParseDecimal(order.Fee)
+	if err != nil {
+		return nil, fmt.Errorf("无效的手续费: %s", order.Fee)
 	}
-	actualQty := filledAmount.Sub(filledAmount, feeAmount)
+	actualQty := filledAmount.Sub(feeAmount)
 
 	status := exchange.OrderStatusNew
-	switch canceledOrder.FinishAs {
+	switch order.FinishAs {
 	case "filled":
 		status = exchange.OrderStatusFilled
 	case "cancelled":
@@ -159,19 +143,20 @@ func (g *gateExchange) CancelSpotOrder(ctx context.Context, symbol string, order
 	}
 
 	return &exchange.Order{
-		OrderID:       canceledOrder.Id,
-		Symbol:        canceledOrder.CurrencyPair,
-		Side:          exchange.OrderSide(strings.ToUpper(canceledOrder.Side)),
-		Type:          exchange.OrderType(strings.ToUpper(canceledOrder.Type)),
+		OrderID:       order.Id,
+		ClientOrderID: toClientOrderID(order.Text),
+		Symbol:        order.CurrencyPair,
+		Side:          exchange.OrderSide(strings.ToUpper(order.Side)),
+		Type:          exchange.OrderType(strings.ToUpper(order.Type)),
 		Status:        status,
-		Price:         canceledOrder.Price,
-		Quantity:      canceledOrder.Amount,
-		ExecutedQty:   canceledOrder.FilledAmount,
-		ActualQty:     actualQty.Text('f', spec.AmountPrecision),
-		QuoteQuantity: canceledOrder.FilledTotal,
-		TimeInForce:   exchange.OrderTimeInForce(strings.ToUpper(canceledOrder.TimeInForce)),
-		CreateTime:    canceledOrder.CreateTimeMs,
-		UpdateTime:    canceledOrder.UpdateTimeMs,
+		Price:         order.Price,
+		Quantity:      order.Amount,
+		ExecutedQty:   order.FilledAmount,
+		ActualQty:     actualQty.StringFixed(int32(spec.AmountPrecision)),
+		QuoteQuantity: order.FilledTotal,
+		TimeInForce:   toTimeInForce(order.TimeInForce),
+		CreateTime:    order.CreateTimeMs,
+		UpdateTime:    order.UpdateTimeMs,
 	}, nil
 }
 
@@ -180,13 +165,13 @@ func (g *gateExchange) GetSpotSymbolSpec(ctx context.Context, symbol string) (*s
 	var spec *symbolSpec
 
 	// 从缓存中获取交易对规格
-	spec, _ = gateSpotSpec.GetSymbolSpec(symbol)
+	spec, _ = g.getSpotSpec(ctx).GetSymbolSpec(symbol)
 
 	if spec == nil {
 		// 获取所有现货交易对规则
-		pairs, _, err := g.client.SpotApi.ListCurrencyPairs(ctx)
+		pairs, _, err := g.getClient(ctx).SpotApi.ListCurrencyPairs(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("获取现货交易对规则失败: %w", err)
+			return nil, fmt.Errorf("获取现货交易对规则失败: %w", toAPIError(err))
 		}
 
 		for _, pair := range pairs {
@@ -194,29 +179,13 @@ func (g *gateExchange) GetSpotSymbolSpec(ctx context.Context, symbol string) (*s
 			if pair.Quote != Settle {
 				continue
 			}
-			specTmp := &symbolSpec{
-				Id:              pair.Id,
-				Base:            pair.Base,
-				Quote:           pair.Quote,
-				MinBaseAmount:   pair.MinBaseAmount,
-				MinQuoteAmount:  pair.MinQuoteAmount,
-				MaxBaseAmount:   pair.MaxBaseAmount,
-				MaxQuoteAmount:  pair.MaxQuoteAmount,
-				AmountPrecision: int(pair.AmountPrecision),
-				Precision:       int(pair.Precision),
-				TradeStatus:     pair.TradeStatus,
-				SellStart:       pair.SellStart,
-				BuyStart:        pair.BuyStart,
-				DelistingTime:   pair.DelistingTime,
-				TradeUrl:        pair.TradeUrl,
-				StTag:           pair.StTag,
-			}
+			specTmp := toSymbolSpec(&pair)
 
 			// 如果交易对匹配，则设置交易对规格
 			if symbol == pair.Id {
 				spec = specTmp
 			}
-			gateSpotSpec.SetSymbolSpec(pair.Id, specTmp)
+			g.getSpotSpec(ctx).SetSymbolSpec(pair.Id, specTmp)
 		}
 	}
 
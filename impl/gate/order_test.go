@@ -2,8 +2,10 @@ package gate
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -31,7 +33,7 @@ func TestSpotCreateOrder(t *testing.T) {
 		}
 		*lastPrice = tickers.GetTicker(*symbol).LastPrice
 	}
-	quantity := utils.AmountWithPriceToQuantity(*amount, *lastPrice, 8)
+	quantity := utils.AmountWithPriceToQuantity(strconv.FormatFloat(*amount, 'f', -1, 64), *lastPrice, 8)
 
 	// 如果方向不存在, 则默认买入
 	*side = strings.ToUpper(*side)
@@ -183,3 +185,48 @@ func TestFuturesCancelSLTP(t *testing.T) {
 	}
 	fmt.Printf("【Gate】合约撤销止损止盈成功")
 }
+
+// TestClientOrderIDText 客户端订单ID与 Gate 自定义订单ID互相转换，非用户自定义的来源标记不作为客户端订单ID
+// go test -v ./impl/gate -run "^TestClientOrderIDText$"
+func TestClientOrderIDText(t *testing.T) {
+	if text := toText("bot1"); text != "t-bot1" {
+		t.Errorf("应添加 t- 前缀: %s", text)
+	}
+	if text := toText("t-bot1"); text != "t-bot1" {
+		t.Errorf("已有 t- 前缀时不应重复添加: %s", text)
+	}
+	if id := toClientOrderID("t-bot1"); id != "bot1" {
+		t.Errorf("应去除 t- 前缀: %s", id)
+	}
+	if id := toClientOrderID("apiv4"); id != "" {
+		t.Errorf("来源标记不应作为客户端订单ID: %s", id)
+	}
+}
+
+// TestFiltersOrderAmount 合约数量按乘数转换为张数，现货限价单校验下单金额，市价单不校验价格
+// go test -v ./impl/gate -run "^TestFiltersOrderAmount$"
+func TestFiltersOrderAmount(t *testing.T) {
+	g := &gateExchange{}
+
+	size, err := g.filtersFuturesQuantity(&futuresSpec{QuantoMultiplier: "0.01", OrderSizeMin: 1, OrderSizeMax: 1000}, "0.129")
+	if err != nil || size != 12 {
+		t.Errorf("filtersFuturesQuantity() = %d, %v", size, err)
+	}
+	if _, err := g.filtersFuturesQuantity(&futuresSpec{QuantoMultiplier: "0.01", OrderSizeMin: 1, OrderSizeMax: 1000}, "0.005"); !errors.Is(err, exchange.ErrMinNotional) {
+		t.Errorf("不足一张应返回 ErrMinNotional: %v", err)
+	}
+
+	spec := &symbolSpec{MinBaseAmount: "0.001", MaxBaseAmount: "100", MinQuoteAmount: "3", MaxQuoteAmount: "5000000", AmountPrecision: 4}
+	if quantity, err := g.filtersQuantity(spec, "100", "0.12345"); err != nil || quantity != "0.1234" {
+		t.Errorf("filtersQuantity() = %s, %v", quantity, err)
+	}
+	if _, err := g.filtersQuantity(spec, "100", "0.02"); !errors.Is(err, exchange.ErrMinNotional) {
+		t.Errorf("金额低于最小金额应返回 ErrMinNotional: %v", err)
+	}
+	if quantity, err := g.filtersQuantity(spec, "", "0.02"); err != nil || quantity != "0.0200" {
+		t.Errorf("市价单不应校验金额: %s, %v", quantity, err)
+	}
+	if _, err := g.filtersQuoteAmount(spec, "2"); !errors.Is(err, exchange.ErrMinNotional) {
+		t.Errorf("市价买入金额低于最小金额应返回 ErrMinNotional: %v", err)
+	}
+}
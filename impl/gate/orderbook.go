@@ -0,0 +1,87 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// GetSpotOrderBook 获取现货深度快照
+func (g *gateExchange) GetSpotOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	opts := &gateapi.ListOrderBookOpts{WithId: optional.NewBool(true)}
+	if limit > 0 {
+		opts.Limit = optional.NewInt32(int32(limit))
+	}
+	book, _, err := g.getClient(ctx).SpotApi.ListOrderBook(ctx, symbol, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取现货深度失败: %w", toAPIError(err))
+	}
+
+	orderBook := &exchange.OrderBook{
+		Symbol:       symbol,
+		Bids:         toOrderBookLevels(book.Bids),
+		Asks:         toOrderBookLevels(book.Asks),
+		LastUpdateID: book.Id,
+		Time:         book.Current,
+	}
+	orderBook.Sort()
+	return orderBook, nil
+}
+
+// GetFuturesOrderBook 获取合约深度快照，合约张数按合约乘数转换为基础资产数量
+func (g *gateExchange) GetFuturesOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+
+	opts := &gateapi.ListFuturesOrderBookOpts{WithId: optional.NewBool(true)}
+	if limit > 0 {
+		opts.Limit = optional.NewInt32(int32(limit))
+	}
+	book, _, err := g.getClient(ctx).FuturesApi.ListFuturesOrderBook(ctx, strings.ToLower(Settle), symbol, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约深度失败: %w", toAPIError(err))
+	}
+
+	orderBook := &exchange.OrderBook{
+		Symbol:       symbol,
+		Bids:         toFuturesOrderBookLevels(book.Bids, spec.QuantoMultiplier),
+		Asks:         toFuturesOrderBookLevels(book.Asks, spec.QuantoMultiplier),
+		LastUpdateID: book.Id,
+		Time:         int64(book.Current * 1000),
+	}
+	orderBook.Sort()
+	return orderBook, nil
+}
+
+// toOrderBookLevels 转换现货深度档位 [价格, 数量]
+func toOrderBookLevels(levels [][]string) []exchange.OrderBookLevel {
+	res := make([]exchange.OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		res = append(res, exchange.OrderBookLevel{
+			Price:    level[0],
+			Quantity: level[1],
+		})
+	}
+	return res
+}
+
+// toFuturesOrderBookLevels 转换合约深度档位
+func toFuturesOrderBookLevels(levels []gateapi.FuturesOrderBookItem, quantoMultiplier string) []exchange.OrderBookLevel {
+	res := make([]exchange.OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		res = append(res, exchange.OrderBookLevel{
+			Price:    level.P,
+			Quantity: sizeToQuantity(level.S, quantoMultiplier),
+		})
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package gate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+)
+
+// TestGetSpotOrderBook 获取现货深度快照
+// go test -v ./impl/gate -run "^TestGetSpotOrderBook$" -args --symbol=BTC_USDT
+func TestGetSpotOrderBook(t *testing.T) {
+	flag.Parse()
+
+	gateExchange := NewGateExchange("", "")
+	orderBook, err := gateExchange.GetSpotOrderBook(context.Background(), *symbol, 10)
+	if err != nil {
+		t.Fatalf("获取现货深度失败: %v", err)
+	}
+	fmt.Println("orderBook", orderBook.LastUpdateID, orderBook.BestBid(), orderBook.BestAsk())
+}
+
+// TestGetFuturesOrderBook 获取合约深度快照
+// go test -v ./impl/gate -run "^TestGetFuturesOrderBook$" -args --symbol=BTC_USDT
+func TestGetFuturesOrderBook(t *testing.T) {
+	flag.Parse()
+
+	gateExchange := NewGateExchange("", "")
+	orderBook, err := gateExchange.GetFuturesOrderBook(context.Background(), *symbol, 10)
+	if err != nil {
+		t.Fatalf("获取合约深度失败: %v", err)
+	}
+	fmt.Println("orderBook", orderBook.LastUpdateID, orderBook.BestBid(), orderBook.BestAsk())
+}
+
+// TestSizeToQuantity 合约张数转换为基础资产数量
+// go test -v ./impl/gate -run "^TestSizeToQuantity$"
+func TestSizeToQuantity(t *testing.T) {
+	cases := []struct {
+		size       int64
+		multiplier string
+		want       string
+	}{
+		{3, "0.0001", "0.0003"},
+		{25, "0.01", "0.25"},
+		{7, "10", "70"},
+		{5, "", "5"},
+	}
+	for _, c := range cases {
+		if got := sizeToQuantity(c.size, c.multiplier); got != c.want {
+			t.Errorf("sizeToQuantity(%d, %s) = %s, want %s", c.size, c.multiplier, got, c.want)
+		}
+	}
+}
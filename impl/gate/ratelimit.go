@@ -0,0 +1,95 @@
+package gate
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/ratelimit"
+)
+
+const (
+	SpotOrderLimit       = 10  // 现货每秒下单与改单上限
+	SpotCancelLimit      = 200 // 现货每秒撤单上限
+	FuturesOrderLimit    = 100 // 合约每秒下单与改单上限
+	FuturesCancelLimit   = 200 // 合约每秒撤单上限
+	EndpointRequestLimit = 200 // 其他接口每 10 秒请求上限，按接口分别计数
+)
+
+// NewRateLimiter 创建 Gate 限频器，下单、撤单按市场计数，其他接口按接口分别计数，并根据 X-Gate-RateLimit-* 响应头校准。
+// 同一 API Key 的多个实例应共用同一个限频器
+func NewRateLimiter(policy exchange.RateLimitPolicy) exchange.RateLimiter {
+	return ratelimit.New(policy, rateLimitModel{})
+}
+
+// rateLimitModel Gate 限频模型
+type rateLimitModel struct{}
+
+// Costs 请求消耗的额度
+func (rateLimitModel) Costs(req *http.Request) []ratelimit.Cost {
+	return []ratelimit.Cost{{Rule: rateLimitRule(req), Weight: 1}}
+}
+
+// Sync 根据响应头中的剩余次数同步已用额度
+func (rateLimitModel) Sync(limiter *ratelimit.Limiter, req *http.Request, resp *http.Response) {
+	remain, err := strconv.Atoi(resp.Header.Get("X-Gate-RateLimit-Requests-Remain"))
+	if err != nil {
+		return
+	}
+	rule := rateLimitRule(req)
+	if limit, err := strconv.Atoi(resp.Header.Get("X-Gate-RateLimit-Limit")); err == nil && limit > 0 && limit < rule.Limit {
+		rule.Limit = limit
+	}
+	limiter.Sync(rule, rule.Limit-remain)
+}
+
+// rateLimitRule 请求所属的限频规则
+func rateLimitRule(req *http.Request) ratelimit.Rule {
+	endpoint := normalizeEndpoint(req.URL.Path)
+	key := req.URL.Host + ":"
+	switch {
+	case isOrderEndpoint(endpoint, "/spot/") && isCancelMethod(req.Method, endpoint):
+		return ratelimit.Rule{Key: key + "spot:cancel", Limit: SpotCancelLimit, Interval: time.Second}
+	case isOrderEndpoint(endpoint, "/spot/") && req.Method != http.MethodGet:
+		return ratelimit.Rule{Key: key + "spot:order", Limit: SpotOrderLimit, Interval: time.Second}
+	case isOrderEndpoint(endpoint, "/futures/") && isCancelMethod(req.Method, endpoint):
+		return ratelimit.Rule{Key: key + "futures:cancel", Limit: FuturesCancelLimit, Interval: time.Second}
+	case isOrderEndpoint(endpoint, "/futures/") && req.Method != http.MethodGet:
+		return ratelimit.Rule{Key: key + "futures:order", Limit: FuturesOrderLimit, Interval: time.Second}
+	}
+	return ratelimit.Rule{Key: key + req.Method + " " + endpoint, Limit: EndpointRequestLimit, Interval: 10 * time.Second}
+}
+
+// isOrderEndpoint 是否指定市场的下单、改单或撤单接口
+func isOrderEndpoint(endpoint, market string) bool {
+	if !strings.HasPrefix(endpoint, market) {
+		return false
+	}
+	for _, name := range []string{"/orders", "/batch_orders", "/price_orders", "/cancel_batch_orders", "/amend_batch_orders"} {
+		if strings.Contains(endpoint, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCancelMethod 是否撤单请求
+func isCancelMethod(method, endpoint string) bool {
+	return method == http.MethodDelete || strings.Contains(endpoint, "/cancel_batch_orders")
+}
+
+// normalizeEndpoint 去除接口前缀，并将路径中的交易对、订单ID等参数替换为占位符
+func normalizeEndpoint(path string) string {
+	if _, rest, ok := strings.Cut(path, "/api/v4"); ok {
+		path = rest
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.TrimFunc(segment, func(r rune) bool { return r >= 'a' && r <= 'z' || r == '_' }) != "" {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
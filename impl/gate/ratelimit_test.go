@@ -0,0 +1,33 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRateLimitRule 下单、撤单按市场计数，其他接口按去除参数后的路径分别计数
+// go test -v ./impl/gate -run "^TestRateLimitRule$"
+func TestRateLimitRule(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		key    string
+		limit  int
+	}{
+		{http.MethodPost, "/api/v4/spot/orders", "api.gateio.ws:spot:order", SpotOrderLimit},
+		{http.MethodDelete, "/api/v4/spot/orders/123?currency_pair=BTC_USDT", "api.gateio.ws:spot:cancel", SpotCancelLimit},
+		{http.MethodGet, "/api/v4/spot/orders/123?currency_pair=BTC_USDT", "api.gateio.ws:GET /spot/orders/{}", EndpointRequestLimit},
+		{http.MethodPost, "/api/v4/futures/usdt/orders", "api.gateio.ws:futures:order", FuturesOrderLimit},
+		{http.MethodDelete, "/api/v4/futures/usdt/orders/456", "api.gateio.ws:futures:cancel", FuturesCancelLimit},
+		{http.MethodGet, "/api/v4/futures/usdt/contracts/BTC_USDT", "api.gateio.ws:GET /futures/usdt/contracts/{}", EndpointRequestLimit},
+		{http.MethodGet, "/api/v4/spot/order_book?currency_pair=BTC_USDT", "api.gateio.ws:GET /spot/order_book", EndpointRequestLimit},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "https://api.gateio.ws"+tt.path, nil)
+		rule := rateLimitRule(req)
+		if rule.Key != tt.key || rule.Limit != tt.limit {
+			t.Errorf("%s %s 规则错误: %+v", tt.method, tt.path, rule)
+		}
+	}
+}
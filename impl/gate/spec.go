@@ -19,6 +19,7 @@ type symbolSpec struct {
 	BaseName        string // 交易货币名称
 	Quote           string // 计价货币
 	QuoteName       string // 计价货币名称
+	Fee             string // 交易费率，百分比
 	MinBaseAmount   string // 交易货币最低交易数量
 	MinQuoteAmount  string // 计价货币最低交易数量
 	MaxBaseAmount   string // 交易货币最大交易数量
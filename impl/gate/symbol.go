@@ -0,0 +1,35 @@
+package gate
+
+import (
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// symbolFormatter Gate 交易对命名规则，现货与永续合约为 BTC_USDT，交割合约为 BTC_USDT_20251226
+type symbolFormatter struct{}
+
+// Native 统一交易对转换为原生交易对
+func (symbolFormatter) Native(market exchange.Market, symbol exchange.Symbol) string {
+	if symbol.Kind == exchange.SymbolKindFutures {
+		return symbol.Base + "_" + symbol.Quote + "_20" + symbol.Expiry
+	}
+	return symbol.Base + "_" + symbol.Quote
+}
+
+// Canonical 原生交易对转换为统一交易对，合约结算资产与计价资产相同
+func (symbolFormatter) Canonical(market exchange.Market, native string) (exchange.Symbol, bool) {
+	parts := strings.Split(strings.ToUpper(native), "_")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return exchange.Symbol{}, false
+	}
+	base, quote := parts[0], parts[1]
+	switch {
+	case market == exchange.MarketSpot:
+		return exchange.NewSpotSymbol(base, quote), true
+	case len(parts) == 3:
+		return exchange.NewFuturesSymbol(base, quote, quote, strings.TrimPrefix(parts[2], "20")), true
+	default:
+		return exchange.NewPerpetualSymbol(base, quote, quote), true
+	}
+}
@@ -0,0 +1,34 @@
+package gate
+
+import (
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestSymbolFormatter 统一交易对与原生交易对互相转换
+// go test -v ./impl/gate -run "^TestSymbolFormatter$"
+func TestSymbolFormatter(t *testing.T) {
+	cases := []struct {
+		market    exchange.Market
+		canonical string
+		native    string
+	}{
+		{exchange.MarketSpot, "BTC/USDT", "BTC_USDT"},
+		{exchange.MarketFutures, "BTC/USDT:USDT", "BTC_USDT"},
+		{exchange.MarketFutures, "BTC/USDT:USDT-251226", "BTC_USDT_20251226"},
+	}
+	formatter := symbolFormatter{}
+	for _, c := range cases {
+		symbol, _ := exchange.ParseSymbol(c.canonical)
+		if got := formatter.Native(c.market, symbol); got != c.native {
+			t.Errorf("Native(%s) = %s, want %s", c.canonical, got, c.native)
+		}
+		if got, ok := formatter.Canonical(c.market, c.native); !ok || got.String() != c.canonical {
+			t.Errorf("Canonical(%s) = %s, want %s", c.native, got, c.canonical)
+		}
+	}
+	if _, ok := formatter.Canonical(exchange.MarketSpot, "BTCUSDT"); ok {
+		t.Error("无法拆分的交易对应返回 false")
+	}
+}
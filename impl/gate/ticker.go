@@ -3,7 +3,6 @@ package gate
 import (
 	"context"
 	"fmt"
-	"math/big"
 	"slices"
 	"strings"
 
@@ -13,9 +12,9 @@ import (
 
 // GetSpotSymbolTickers 获取现货交易对行情
 func (g *gateExchange) GetSpotSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
-	tickers, _, err := g.client.SpotApi.ListTickers(ctx, nil)
+	tickers, _, err := g.getClient(ctx).SpotApi.ListTickers(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("获取现货交易对行情失败: %w", err)
+		return nil, fmt.Errorf("获取现货交易对行情失败: %w", toAPIError(err))
 	}
 
 	symbols = utils.FormatSymbols(symbols, "_")
@@ -49,9 +48,9 @@ func (g *gateExchange) GetSpotSymbolTickers(ctx context.Context, symbols ...stri
 
 // GetFuturesSymbolTickers 获取合约交易对行情
 func (g *gateExchange) GetFuturesSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
-	tickers, _, err := g.client.FuturesApi.ListFuturesTickers(ctx, strings.ToLower(Settle), nil)
+	tickers, _, err := g.getClient(ctx).FuturesApi.ListFuturesTickers(ctx, strings.ToLower(Settle), nil)
 	if err != nil {
-		return nil, fmt.Errorf("获取合约交易对行情失败: %w", err)
+		return nil, fmt.Errorf("获取合约交易对行情失败: %w", toAPIError(err))
 	}
 
 	symbols = utils.FormatSymbols(symbols, "_")
@@ -99,36 +98,39 @@ func (g *gateExchange) GetFuturesSymbolTickers(ctx context.Context, symbols ...s
 // 因此：OpenPrice = Last / (1 + ChangePercentage / 100)
 // PriceChange = Last - OpenPrice
 func calculateOpenAndChangePrice(lastPrice, changePercentage string) (openPrice, priceChange string) {
-	lastFloat := new(big.Float).SetPrec(128)
-	changeFloat := new(big.Float).SetPrec(128)
-
 	// 解析最新价
-	if _, ok := lastFloat.SetString(lastPrice); !ok {
+	if lastPrice == "" || changePercentage == "" {
+		return "", ""
+	}
+	last, err := exchange.ParseDecimal(lastPrice)
+	if err != nil {
 		return "", ""
 	}
 
 	// 解析涨跌幅百分比
-	if _, ok := changeFloat.SetString(changePercentage); !ok {
+	change, err := exchange.ParseDecimal(changePercentage)
+	if err != nil {
 		return "", ""
 	}
 
 	// 计算：OpenPrice = Last / (1 + ChangePercentage / 100)
 	// 先计算 1 + ChangePercentage / 100
-	oneHundred := big.NewFloat(100)
-	changeRatio := new(big.Float).Quo(changeFloat, oneHundred)
-	denominator := new(big.Float).Add(big.NewFloat(1), changeRatio)
+	denominator := exchange.NewDecimalFromInt(1).Add(change.Div(exchange.NewDecimalFromInt(100)))
+	if denominator.IsZero() {
+		return "", ""
+	}
 
 	// 计算开盘价
-	openPriceFloat := new(big.Float).Quo(lastFloat, denominator)
+	openPriceDec := last.Div(denominator)
 
 	// 计算价格变动：PriceChange = Last - OpenPrice
-	priceChangeFloat := new(big.Float).Sub(lastFloat, openPriceFloat)
+	priceChangeDec := last.Sub(openPriceDec)
 
 	// 获取精度（使用 lastPrice 的精度）
-	precision := utils.GetNumberPrecision(lastPrice)
+	precision := int32(utils.GetNumberPrecision(lastPrice))
 
 	// 格式化为字符串
-	openPrice = openPriceFloat.Text('f', precision)
-	priceChange = priceChangeFloat.Text('f', precision)
+	openPrice = openPriceDec.StringFixed(precision)
+	priceChange = priceChangeDec.StringFixed(precision)
 	return openPrice, priceChange
 }
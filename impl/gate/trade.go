@@ -0,0 +1,197 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/utils"
+)
+
+const (
+	TradesPageSize = 1000 // 成交单页最大数量
+)
+
+// GetSpotRecentTrades 获取现货最近成交
+func (g *gateExchange) GetSpotRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	opts := &gateapi.ListTradesOpts{}
+	if limit > 0 {
+		opts.Limit = optional.NewInt32(int32(limit))
+	}
+	resp, _, err := g.getClient(ctx).SpotApi.ListTrades(ctx, symbol, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取现货最近成交失败: %w", toAPIError(err))
+	}
+	trades := make([]*exchange.Trade, 0, len(resp))
+	for _, t := range resp {
+		trades = append(trades, toSpotTrade(symbol, t))
+	}
+	sortTrades(trades)
+	return trades, nil
+}
+
+// GetFuturesRecentTrades 获取合约最近成交，数量按合约乘数转换为基础资产数量
+func (g *gateExchange) GetFuturesRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	opts := &gateapi.ListFuturesTradesOpts{}
+	if limit > 0 {
+		opts.Limit = optional.NewInt32(int32(limit))
+	}
+	resp, _, err := g.getClient(ctx).FuturesApi.ListFuturesTrades(ctx, Settle, symbol, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约最近成交失败: %w", toAPIError(err))
+	}
+	trades := make([]*exchange.Trade, 0, len(resp))
+	for _, t := range resp {
+		trades = append(trades, toFuturesTrade(symbol, t.Id, t.Price, t.Size, t.CreateTimeMs, spec.QuantoMultiplier))
+	}
+	sortTrades(trades)
+	return trades, nil
+}
+
+// GetSpotAggTrades 获取现货历史成交，Gate 不提供归集成交，返回逐笔成交
+func (g *gateExchange) GetSpotAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	return getHistoryTrades(func(from, to int64, page int) ([]*exchange.Trade, error) {
+		resp, _, err := g.getClient(ctx).SpotApi.ListTrades(ctx, symbol, &gateapi.ListTradesOpts{
+			Limit: optional.NewInt32(TradesPageSize),
+			From:  optional.NewInt64(from),
+			To:    optional.NewInt64(to),
+			Page:  optional.NewInt32(int32(page + 1)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取现货历史成交失败: %w", toAPIError(err))
+		}
+		trades := make([]*exchange.Trade, 0, len(resp))
+		for _, t := range resp {
+			trades = append(trades, toSpotTrade(symbol, t))
+		}
+		return trades, nil
+	}, start, end, limit)
+}
+
+// GetFuturesAggTrades 获取合约历史成交，Gate 不提供归集成交，返回逐笔成交
+func (g *gateExchange) GetFuturesAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	spec, err := g.GetFuturesSymbolSpec(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+	return getHistoryTrades(func(from, to int64, page int) ([]*exchange.Trade, error) {
+		resp, _, err := g.getClient(ctx).FuturesApi.ListFuturesTrades(ctx, Settle, symbol, &gateapi.ListFuturesTradesOpts{
+			Limit:  optional.NewInt32(TradesPageSize),
+			Offset: optional.NewInt32(int32(page * TradesPageSize)),
+			From:   optional.NewInt64(from),
+			To:     optional.NewInt64(to),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取合约历史成交失败: %w", toAPIError(err))
+		}
+		trades := make([]*exchange.Trade, 0, len(resp))
+		for _, t := range resp {
+			trades = append(trades, toFuturesTrade(symbol, t.Id, t.Price, t.Size, t.CreateTimeMs, spec.QuantoMultiplier))
+		}
+		return trades, nil
+	}, start, end, limit)
+}
+
+// getHistoryTrades 在固定的 [from, to] 秒级区间内按页查询全部成交，去重后按时间升序返回 [start, end] 内的成交
+func getHistoryTrades(fetch func(from, to int64, page int) ([]*exchange.Trade, error), start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	if start.IsZero() {
+		return nil, fmt.Errorf("开始时间不能为空")
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	startMs, endMs := start.UnixMilli(), end.UnixMilli()
+	if endMs < startMs {
+		return nil, fmt.Errorf("结束时间不能早于开始时间")
+	}
+
+	var (
+		from   = startMs / 1000
+		to     = (endMs + 999) / 1000
+		seen   = make(map[string]bool)
+		trades []*exchange.Trade
+	)
+	for page := 0; page < utils.MaxPages; page++ {
+		resp, err := fetch(from, to, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range resp {
+			if seen[t.ID] || t.Time < startMs || t.Time > endMs {
+				continue
+			}
+			seen[t.ID] = true
+			trades = append(trades, t)
+		}
+		if len(resp) < TradesPageSize {
+			break
+		}
+	}
+
+	sortTrades(trades)
+	if limit > 0 && len(trades) > limit {
+		trades = trades[:limit]
+	}
+	return trades, nil
+}
+
+// toSpotTrade 转换现货成交
+func toSpotTrade(symbol string, t gateapi.Trade) *exchange.Trade {
+	side := exchange.OrderSideBuy
+	if t.Side == "sell" {
+		side = exchange.OrderSideSell
+	}
+	return &exchange.Trade{
+		Symbol:   symbol,
+		ID:       t.Id,
+		Price:    t.Price,
+		Quantity: t.Amount,
+		Side:     side,
+		Time:     parseTimeMs(t.CreateTimeMs),
+	}
+}
+
+// toFuturesTrade 转换合约成交，张数为负表示主动卖出
+func toFuturesTrade(symbol string, id int64, price string, size int64, createTimeMs float64, quantoMultiplier string) *exchange.Trade {
+	side := exchange.OrderSideBuy
+	if size < 0 {
+		side = exchange.OrderSideSell
+		size = -size
+	}
+	return &exchange.Trade{
+		Symbol:   symbol,
+		ID:       strconv.FormatInt(id, 10),
+		Price:    price,
+		Quantity: sizeToQuantity(size, quantoMultiplier),
+		Side:     side,
+		Time:     int64(createTimeMs),
+	}
+}
+
+// parseTimeMs 解析带小数的毫秒时间戳
+func parseTimeMs(value string) int64 {
+	ms, _ := strconv.ParseFloat(value, 64)
+	return int64(ms)
+}
+
+// sortTrades 按成交时间升序排序，时间相同时按ID排序
+func sortTrades(trades []*exchange.Trade) {
+	sort.SliceStable(trades, func(i, j int) bool {
+		if trades[i].Time != trades[j].Time {
+			return trades[i].Time < trades[j].Time
+		}
+		if len(trades[i].ID) != len(trades[j].ID) {
+			return len(trades[i].ID) < len(trades[j].ID)
+		}
+		return trades[i].ID < trades[j].ID
+	})
+}
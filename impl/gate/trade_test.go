@@ -0,0 +1,101 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetSpotRecentTrades 获取现货最近成交
+// go test -v ./impl/gate -run "^TestGetSpotRecentTrades$" -args --symbol=BTC_USDT
+func TestGetSpotRecentTrades(t *testing.T) {
+	flag.Parse()
+
+	gateExchange := NewGateExchange("", "")
+	trades, err := gateExchange.GetSpotRecentTrades(context.Background(), *symbol, 10)
+	if err != nil {
+		t.Fatalf("获取现货最近成交失败: %v", err)
+	}
+	for _, trade := range trades {
+		fmt.Println("trade", trade)
+	}
+}
+
+// TestGetFuturesAggTrades 获取合约历史成交
+// go test -v ./impl/gate -run "^TestGetFuturesAggTrades$" -args --symbol=BTC_USDT
+func TestGetFuturesAggTrades(t *testing.T) {
+	flag.Parse()
+
+	gateExchange := NewGateExchange("", "")
+	trades, err := gateExchange.GetFuturesAggTrades(context.Background(), *symbol, time.Now().Add(-10*time.Minute), time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("获取合约历史成交失败: %v", err)
+	}
+	fmt.Println("trades", len(trades), trades[0], trades[len(trades)-1])
+}
+
+// TestGetHistoryTradesPagination 按页查询、去重并升序返回
+// go test -v ./impl/gate -run "^TestGetHistoryTradesPagination$"
+func TestGetHistoryTradesPagination(t *testing.T) {
+	// 每 100 毫秒一笔成交，接口按时间倒序返回
+	const count = 2500
+	fetch := func(from, to int64, page int) ([]*exchange.Trade, error) {
+		var all []*exchange.Trade
+		for id := count; id >= 1; id-- {
+			ms := int64(id) * 100
+			if ms >= from*1000 && ms <= to*1000 {
+				all = append(all, &exchange.Trade{ID: strconv.Itoa(id), Time: ms})
+			}
+		}
+		begin := min(page*TradesPageSize, len(all))
+		end := min(begin+TradesPageSize, len(all))
+		return all[begin:end], nil
+	}
+
+	trades, err := getHistoryTrades(fetch, time.UnixMilli(150), time.UnixMilli(200050), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1999 || trades[0].ID != "2" || trades[len(trades)-1].ID != "2000" {
+		t.Fatalf("成交错误: %d %v %v", len(trades), trades[0], trades[len(trades)-1])
+	}
+
+	trades, err = getHistoryTrades(fetch, time.UnixMilli(100), time.UnixMilli(250000), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 10 || trades[0].ID != "1" || trades[9].ID != "10" {
+		t.Fatalf("限制数量错误: %v", trades)
+	}
+}
+
+// TestParseTrades 解析成交推送，合约张数转换为基础资产数量
+// go test -v ./impl/gate -run "^TestParseTrades$"
+func TestParseTrades(t *testing.T) {
+	spot := `{"id":309143071,"create_time":1606292218,"create_time_ms":"1606292218213.4578","side":"sell","currency_pair":"GT_USDT","amount":"16.47","price":"0.4705","range":"2390902-2390902"}`
+	trades, err := parseTrade(json.RawMessage(spot))
+	if err != nil || len(trades) != 1 {
+		t.Fatalf("解析现货成交失败: %v", err)
+	}
+	if trade := trades[0]; trade.Symbol != "GT_USDT" || trade.ID != "309143071" || trade.Time != 1606292218213 || trade.Side != exchange.OrderSideSell || trade.Quantity != "16.47" {
+		t.Errorf("现货成交错误: %+v", trade)
+	}
+
+	futures := `[{"size":-108,"id":27753479,"create_time":1545136464,"create_time_ms":1545136464123,"price":"96.4","contract":"BTC_USDT"},{"size":5,"id":27753480,"create_time":1545136465,"create_time_ms":1545136465000,"price":"96.5","contract":"BTC_USDT"}]`
+	trades, err = parseFuturesTrades(json.RawMessage(futures), map[string]string{"BTC_USDT": "0.0001"})
+	if err != nil || len(trades) != 2 {
+		t.Fatalf("解析合约成交失败: %v", err)
+	}
+	if trade := trades[0]; trade.Side != exchange.OrderSideSell || trade.Quantity != "0.0108" || trade.Time != 1545136464123 {
+		t.Errorf("合约卖出成交错误: %+v", trade)
+	}
+	if trade := trades[1]; trade.Side != exchange.OrderSideBuy || trade.Quantity != "0.0005" {
+		t.Errorf("合约买入成交错误: %+v", trade)
+	}
+}
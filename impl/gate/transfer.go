@@ -0,0 +1,118 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// gateAccounts 账户类型对应的交易所账户，交易所没有独立的资金账户
+var gateAccounts = map[exchange.AccountType]string{
+	exchange.AccountTypeSpot:    "spot",
+	exchange.AccountTypeFutures: "futures",
+}
+
+// Transfer 在现货与 USDT 结算合约账户之间划转，不支持资金账户
+func (g *gateExchange) Transfer(ctx context.Context, asset, amount string, from, to exchange.AccountType) (string, error) {
+	if err := exchange.ValidateTransfer(asset, amount, from, to); err != nil {
+		return "", err
+	}
+	if err := checkTransferAccounts(from, to); err != nil {
+		return "", err
+	}
+	tx, _, err := g.getClient(ctx).WalletApi.Transfer(ctx, gateapi.Transfer{
+		Currency: asset,
+		From:     gateAccounts[from],
+		To:       gateAccounts[to],
+		Amount:   amount,
+		Settle:   strings.ToLower(Settle),
+	})
+	if err != nil {
+		return "", fmt.Errorf("资金划转失败: %w", toAPIError(err))
+	}
+	return strconv.FormatInt(tx.TxId, 10), nil
+}
+
+// ListTransfers 按时间倒序分页查询现货与 USDT 结算合约账户之间的划转，游标为偏移量。
+// 交易所没有划转记录接口，使用合约账户变更记录中的转入转出流水，划转ID为流水ID；
+// 不包括现货与资金账户等其他账户之间的划转，指定资金账户时返回包装 ErrInvalidTransfer 的错误，币种不是 USDT 时返回空页
+func (g *gateExchange) ListTransfers(ctx context.Context, query exchange.TransferQuery) (*exchange.TransferPage, error) {
+	if err := checkTransferAccounts(query.From, query.To); err != nil {
+		return nil, err
+	}
+	limit := int32(query.Limit)
+	if limit <= 0 {
+		limit = OrderListLimit
+	}
+	cursor := int32(0)
+	if query.Cursor != "" {
+		n, err := strconv.ParseInt(query.Cursor, 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("无效的分页游标: %s", query.Cursor)
+		}
+		cursor = int32(n)
+	}
+
+	page := &exchange.TransferPage{Transfers: make([]*exchange.Transfer, 0)}
+	if query.Asset != "" && query.Asset != Settle {
+		return page, nil
+	}
+	opts := &gateapi.ListFuturesAccountBookOpts{
+		Limit:  optional.NewInt32(limit),
+		Offset: optional.NewInt32(cursor),
+		Type_:  optional.NewString("dnw"),
+	}
+	if !query.StartTime.IsZero() {
+		opts.From = optional.NewInt64(query.StartTime.Unix())
+	}
+	if !query.EndTime.IsZero() {
+		opts.To = optional.NewInt64(query.EndTime.Unix())
+	}
+	books, _, err := g.getClient(ctx).FuturesApi.ListFuturesAccountBook(ctx, strings.ToLower(Settle), opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取划转记录失败: %w", toAPIError(err))
+	}
+	for _, book := range books {
+		transfer := toTransfer(book)
+		if (query.From == "" || transfer.From == query.From) && (query.To == "" || transfer.To == query.To) {
+			page.Transfers = append(page.Transfers, transfer)
+		}
+	}
+	if len(books) >= int(limit) {
+		page.NextCursor = strconv.Itoa(int(cursor + limit))
+	}
+	return page, nil
+}
+
+// checkTransferAccounts 检查账户类型是否支持，为空表示不限制
+func checkTransferAccounts(accounts ...exchange.AccountType) error {
+	for _, account := range accounts {
+		if _, ok := gateAccounts[account]; account != "" && !ok {
+			return fmt.Errorf("%w: 不支持的账户类型 %s", exchange.ErrInvalidTransfer, account)
+		}
+	}
+	return nil
+}
+
+// toTransfer 转换合约账户转入转出流水，变更为正数表示从现货转入合约
+func toTransfer(book gateapi.FuturesAccountBook) *exchange.Transfer {
+	transfer := &exchange.Transfer{
+		ID:     book.Id,
+		Asset:  Settle,
+		Amount: book.Change,
+		From:   exchange.AccountTypeSpot,
+		To:     exchange.AccountTypeFutures,
+		Status: exchange.TransferStatusSuccess,
+		Time:   secondsToMs(book.Time),
+	}
+	if change := exchange.ToDecimal(book.Change); change.IsNegative() {
+		transfer.Amount = change.Neg().String()
+		transfer.From, transfer.To = exchange.AccountTypeFutures, exchange.AccountTypeSpot
+	}
+	return transfer
+}
@@ -0,0 +1,45 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestToTransfer 合约账户转入转出流水按变更正负确定划转方向，数量为正数
+// go test -v ./impl/gate -run "^TestToTransfer$"
+func TestToTransfer(t *testing.T) {
+	in := toTransfer(gateapi.FuturesAccountBook{Id: "1", Type: "dnw", Change: "100", Time: 1682294400.5})
+	if in.From != exchange.AccountTypeSpot || in.To != exchange.AccountTypeFutures || in.Amount != "100" || in.Asset != "USDT" || in.Time != 1682294400500 {
+		t.Errorf("转入记录错误: %+v", in)
+	}
+	out := toTransfer(gateapi.FuturesAccountBook{Id: "2", Type: "dnw", Change: "-25.5", Time: 1682294400.5})
+	if out.From != exchange.AccountTypeFutures || out.To != exchange.AccountTypeSpot || out.Amount != "25.5" || out.ID != "2" {
+		t.Errorf("转出记录错误: %+v", out)
+	}
+}
+
+// TestListTransfersFunding 不支持查询资金账户的划转，币种不是 USDT 时返回空页
+// go test -v ./impl/gate -run "^TestListTransfersFunding$"
+func TestListTransfersFunding(t *testing.T) {
+	g, ts := newTestGate(t, nil)
+	queries := []exchange.TransferQuery{
+		{From: exchange.AccountTypeFunding},
+		{From: exchange.AccountTypeSpot, To: exchange.AccountTypeFunding},
+	}
+	for _, query := range queries {
+		if _, err := g.ListTransfers(context.Background(), query); !errors.Is(err, exchange.ErrInvalidTransfer) {
+			t.Errorf("%+v 应返回 ErrInvalidTransfer: %v", query, err)
+		}
+	}
+	page, err := g.ListTransfers(context.Background(), exchange.TransferQuery{Asset: "BTC"})
+	if err != nil || len(page.Transfers) != 0 || page.NextCursor != "" {
+		t.Errorf("非 USDT 币种应返回空页: %+v, %v", page, err)
+	}
+	if len(ts.requests) != 0 {
+		t.Errorf("不应发送请求: %+v", ts.requests)
+	}
+}
@@ -1,6 +1,9 @@
 package gate
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 const (
 	Settle = "USDT" // 默认结算货币
@@ -8,6 +11,8 @@ const (
 
 var gateSpotSpec *exchangeSpec
 var gateFuturesSpec *exchangeFuturesSpec
+var gateSpotTestnetSpec *exchangeSpec
+var gateFuturesTestnetSpec *exchangeFuturesSpec
 
 func init() {
 	gateSpotSpec = &exchangeSpec{
@@ -18,6 +23,14 @@ func init() {
 		Contracts:  make([]*futuresSpec, 0),
 		UpdateTime: time.Now(),
 	}
+	gateSpotTestnetSpec = &exchangeSpec{
+		Symbols:    make([]*symbolSpec, 0),
+		UpdateTime: time.Now(),
+	}
+	gateFuturesTestnetSpec = &exchangeFuturesSpec{
+		Contracts:  make([]*futuresSpec, 0),
+		UpdateTime: time.Now(),
+	}
 
 	// 定时更新交易对规格
 	initSymbolsSpec()
@@ -27,6 +40,24 @@ func init() {
 func initSymbolsSpec() {
 	gateSpotSpec.DeleteSymbolsSpec()
 	gateFuturesSpec.DeleteFuturesSpec()
+	gateSpotTestnetSpec.DeleteSymbolsSpec()
+	gateFuturesTestnetSpec.DeleteFuturesSpec()
 
 	time.AfterFunc(time.Hour, initSymbolsSpec)
 }
+
+// getSpotSpec 获取请求对应环境的现货交易对规格缓存
+func (g *gateExchange) getSpotSpec(ctx context.Context) *exchangeSpec {
+	if g.isTestnet(ctx) {
+		return gateSpotTestnetSpec
+	}
+	return gateSpotSpec
+}
+
+// getFuturesSpec 获取请求对应环境的合约交易对规格缓存
+func (g *gateExchange) getFuturesSpec(ctx context.Context) *exchangeFuturesSpec {
+	if g.isTestnet(ctx) {
+		return gateFuturesTestnetSpec
+	}
+	return gateFuturesSpec
+}
@@ -2,162 +2,120 @@ package gate
 
 import (
 	"encoding/json"
-	"time"
+	"sync"
 
-	"github.com/gateio/gateapi-go/v6"
 	"github.com/so68/exchange-lib/exchange"
 	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/subscription"
+	"github.com/so68/exchange-lib/internal/symbolmap"
 )
 
 const (
 	SpotWebsocketURL    = "wss://api.gateio.ws"
 	FuturesWebsocketURL = "wss://fx-ws.gateio.ws"
+
+	SpotTestnetWebsocketURL    = "wss://ws-testnet.gate.com/v4/ws/spot"         // 现货测试网 Websocket 地址
+	FuturesTestnetWebsocketURL = "wss://ws-testnet.gate.com/v4/ws/futures/usdt" // 合约测试网 Websocket 地址
 )
 
 // gateWebsocket Gate Websocket实例
 type gateWebsocket struct {
-	spotWs    *client.Websocket
-	futuresWs *client.Websocket
+	spotURL    string
+	futuresURL string
+	opts       []exchange.Option
+	group      *client.Group // 全部连接的生命周期
+	mux        sync.Mutex
+
+	tickerPools    map[exchange.Channel]*subscription.Pool              // 行情订阅连接池
+	tickerHandlers map[exchange.Channel]exchange.WebsocketTickerHandler // 行情回调
 }
 
 // SubscribeParams 订阅参数
 type SubscribeParams struct {
-	Time    int64       `json:"time"`
-	Channel string      `json:"channel"`
-	Event   string      `json:"event"`
-	Payload interface{} `json:"payload"`
+	Time    int64          `json:"time"`
+	Channel string         `json:"channel"`
+	Event   string         `json:"event"`
+	Payload interface{}    `json:"payload,omitempty"`
+	Auth    *SubscribeAuth `json:"auth,omitempty"` // 私有频道认证信息
+}
+
+// SubscribeAuth 私有频道认证信息
+type SubscribeAuth struct {
+	Method string `json:"method"` // 认证方式，固定为 api_key
+	Key    string `json:"KEY"`    // API Key
+	Sign   string `json:"SIGN"`   // 签名
 }
 
 // SubscribeResult 订阅结果
 type SubscribeResult struct {
 	Time    int64           `json:"time"`
+	TimeMs  int64           `json:"time_ms"`
 	Channel string          `json:"channel"`
 	Event   string          `json:"event"`
+	Error   *SubscribeError `json:"error"`
 	Result  json.RawMessage `json:"result"`
 }
 
-// NewGateWebsocket 创建Gate Websocket实例
-func NewGateWebsocket() exchange.Websocket {
-	return &gateWebsocket{}
+// SubscribeError 订阅错误
+type SubscribeError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
 }
 
-// StartListenSpotTickers 开始监听现货交易对行情
-func (g *gateWebsocket) StartListenSpotTickers(handler exchange.WebsocketSpotTickerHandler) error {
-	g.spotWs = client.NewWebsocket(SpotWebsocketURL+"/ws/v4/", func(message []byte) {
-		resp := &SubscribeResult{}
-		err := json.Unmarshal(message, resp)
-		if err != nil {
-			return
-		}
-
-		// 解析对应的结果
-		switch resp.Channel {
-		case "spot.tickers":
-			spotTicker := &gateapi.Ticker{}
-			err := json.Unmarshal(resp.Result, spotTicker)
-			if err != nil || spotTicker.CurrencyPair == "" {
-				return
-			}
-
-			// 计算开盘价和价格变动
-			openPrice, priceChange := calculateOpenAndChangePrice(spotTicker.Last, spotTicker.ChangePercentage)
-			handler(&exchange.Ticker{
-				Symbol:             spotTicker.CurrencyPair,
-				PriceChange:        priceChange,
-				PriceChangePercent: spotTicker.ChangePercentage,
-				WeightedAvgPrice:   "", // Gate API 不提供加权平均价
-				LastPrice:          spotTicker.Last,
-				LastQty:            "", // Gate API 不提供最新成交量
-				OpenPrice:          openPrice,
-				HighPrice:          spotTicker.High24h,
-				LowPrice:           spotTicker.Low24h,
-				Volume:             spotTicker.BaseVolume,
-				QuoteVolume:        spotTicker.QuoteVolume,
-				Count:              0, // Gate API 不提供成交笔数
-			})
-		}
+// NewGateWebsocket 创建Gate Websocket实例，交易对映射由公开接口的交易对列表驱动
+func NewGateWebsocket(opts ...exchange.Option) exchange.Websocket {
+	return symbolmap.WrapWebsocket(newGateWebsocket(opts...), newGateExchange("", "", opts...).ListInstruments, symbolFormatter{})
+}
 
-	})
-	// 设置连接成功后的回调处理器
-	g.spotWs.SetAfterConnectionHandler(func() error {
-		gateExchange := newGateExchange("", "")
-		symbols := gateExchange.GetSpotSymbols()
-		subscribeParams := SubscribeParams{
-			Time:    time.Now().Unix(),
-			Channel: "spot.tickers",
-			Event:   "subscribe",
-			Payload: symbols,
-		}
-		subscribeBytes, err := json.Marshal(subscribeParams)
-		if err != nil {
-			return err
-		}
-		return g.spotWs.WriteMessage(subscribeBytes)
-	})
-	return g.spotWs.Start()
+// newGateWebsocket 创建Gate Websocket实例
+func newGateWebsocket(opts ...exchange.Option) *gateWebsocket {
+	options := exchange.NewOptions(opts...)
+
+	g := &gateWebsocket{
+		spotURL:    SpotWebsocketURL + "/ws/v4/",
+		futuresURL: FuturesWebsocketURL + "/v4/ws/usdt",
+		opts:       opts,
+		group:      client.NewGroup(),
+
+		tickerPools:    make(map[exchange.Channel]*subscription.Pool),
+		tickerHandlers: make(map[exchange.Channel]exchange.WebsocketTickerHandler),
+	}
+	if options.IsTestnet() {
+		g.spotURL = SpotTestnetWebsocketURL
+		g.futuresURL = FuturesTestnetWebsocketURL
+	}
+	if options.SpotWebsocketURL != "" {
+		g.spotURL = options.SpotWebsocketURL
+	}
+	if options.FuturesWebsocketURL != "" {
+		g.futuresURL = options.FuturesWebsocketURL
+	}
+	return g
 }
 
-// StartListenFuturesTickers 开始监听合约交易对行情
-func (g *gateWebsocket) StartListenFuturesTickers(handler exchange.WebsocketFuturesTickerHandler) error {
-	g.futuresWs = client.NewWebsocket(FuturesWebsocketURL+"/v4/ws/usdt", func(message []byte) {
-		resp := &SubscribeResult{}
-		err := json.Unmarshal(message, resp)
-		if err != nil {
-			return
-		}
-
-		// 解析对应的结果
-		switch resp.Channel {
-		case "futures.tickers":
-			futuresTickers := make([]*gateapi.FuturesTicker, 0)
-			err := json.Unmarshal(resp.Result, &futuresTickers)
-			if err != nil || len(futuresTickers) == 0 {
-				return
-			}
-
-			futuresTicker := futuresTickers[0]
-			// 优先使用 Volume24hBase 作为 Volume，如果没有则使用 Volume24h
-			volume := futuresTicker.Volume24hBase
-			if volume == "" {
-				volume = futuresTicker.Volume24h
-			}
-			// 优先使用 Volume24hQuote 作为 QuoteVolume，如果没有则使用 Volume24hSettle
-			quoteVolume := futuresTicker.Volume24hQuote
-			if quoteVolume == "" {
-				quoteVolume = futuresTicker.Volume24hSettle
-			}
-			openPrice, priceChange := calculateOpenAndChangePrice(futuresTicker.Last, futuresTicker.ChangePercentage)
-			handler(&exchange.Ticker{
-				Symbol:             futuresTicker.Contract,
-				PriceChange:        priceChange,
-				PriceChangePercent: futuresTicker.ChangePercentage,
-				WeightedAvgPrice:   "", // Gate API 不提供加权平均价
-				LastPrice:          futuresTicker.Last,
-				LastQty:            "", // Gate API 不提供最新成交量
-				OpenPrice:          openPrice,
-				HighPrice:          futuresTicker.High24h,
-				LowPrice:           futuresTicker.Low24h,
-				Volume:             volume,
-				QuoteVolume:        quoteVolume,
-				Count:              0, // Gate API 不提供成交笔数
-			})
-		}
+// SetStateHandler 设置连接状态回调，为 nil 时清除回调
+func (g *gateWebsocket) SetStateHandler(handler exchange.ConnectionStateHandler) {
+	if handler == nil {
+		g.group.SetStateHandler(nil)
+		return
+	}
+	g.group.SetStateHandler(func(url string, state client.State, err error) {
+		handler(url, exchange.ConnectionState(state), err)
 	})
+}
 
-	g.futuresWs.SetAfterConnectionHandler(func() error {
-		gateExchange := newGateExchange("", "")
-		symbols := gateExchange.GetFuturesSymbols()
-		subscribeParams := SubscribeParams{
-			Time:    time.Now().Unix(),
-			Channel: "futures.tickers",
-			Event:   "subscribe",
-			Payload: symbols,
-		}
-		subscribeBytes, err := json.Marshal(subscribeParams)
-		if err != nil {
-			return err
-		}
-		return g.futuresWs.WriteMessage(subscribeBytes)
-	})
-	return g.futuresWs.Start()
+// Errors 异步错误通道
+func (g *gateWebsocket) Errors() <-chan error {
+	return g.group.Errors()
+}
+
+// Done 关闭完成后关闭的通道
+func (g *gateWebsocket) Done() <-chan struct{} {
+	return g.group.Done()
+}
+
+// Close 关闭全部连接并停止后台任务
+func (g *gateWebsocket) Close() error {
+	g.group.Close()
+	return nil
 }
@@ -0,0 +1,135 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenKlines 开始监听K线，订阅 spot.candlesticks / futures.candlesticks
+func (g *gateWebsocket) StartListenKlines(ctx context.Context, market exchange.Market, symbol string, interval exchange.KlineInterval, handler exchange.WebsocketKlineHandler) error {
+	var (
+		dialURL string
+		channel string
+		payload []string
+		parse   func(result json.RawMessage) ([]*exchange.Kline, error)
+	)
+	switch market {
+	case exchange.MarketSpot:
+		gateInterval, err := toKlineInterval(spotKlineIntervals, interval)
+		if err != nil {
+			return err
+		}
+		dialURL = g.spotURL
+		channel = "spot.candlesticks"
+		payload = []string{gateInterval, symbol}
+		parse = func(result json.RawMessage) ([]*exchange.Kline, error) {
+			return parseCandlestick(result, symbol, interval)
+		}
+	case exchange.MarketFutures:
+		gateInterval, err := toKlineInterval(futuresKlineIntervals, interval)
+		if err != nil {
+			return err
+		}
+		// 成交量为合约张数，按合约乘数转换为基础资产数量
+		spec, err := newGateExchange("", "", g.opts...).GetFuturesSymbolSpec(ctx, symbol)
+		if err != nil {
+			return fmt.Errorf("获取交易规则失败: %w", err)
+		}
+		dialURL = g.futuresURL
+		channel = "futures.candlesticks"
+		payload = []string{gateInterval, symbol}
+		parse = func(result json.RawMessage) ([]*exchange.Kline, error) {
+			return parseFuturesCandlestick(result, symbol, interval, spec.QuantoMultiplier)
+		}
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	var ws *client.Websocket
+	ws = client.NewWebsocket(dialURL, func(message []byte) {
+		resp := &SubscribeResult{}
+		if err := json.Unmarshal(message, resp); err != nil || resp.Channel != channel || resp.Event != "update" {
+			return
+		}
+		klines, err := parse(resp.Result)
+		if err != nil {
+			return
+		}
+		for _, k := range klines {
+			if handler != nil {
+				handler(k)
+			}
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		subscribeBytes, err := json.Marshal(SubscribeParams{
+			Time:    time.Now().Unix(),
+			Channel: channel,
+			Event:   "subscribe",
+			Payload: payload,
+		})
+		if err != nil {
+			return err
+		}
+		return ws.WriteMessage(subscribeBytes)
+	})
+	return g.group.Start(ctx, ws)
+}
+
+// parseCandlestick 解析现货K线事件
+func parseCandlestick(result json.RawMessage, symbol string, interval exchange.KlineInterval) ([]*exchange.Kline, error) {
+	event := &WsCandlestick{}
+	if err := json.Unmarshal(result, event); err != nil {
+		return nil, err
+	}
+	seconds, err := strconv.ParseInt(event.Time, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("无效的K线时间: %s", event.Time)
+	}
+	openTime := seconds * 1000
+	return []*exchange.Kline{{
+		Symbol:      symbol,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   interval.CloseTime(openTime),
+		Open:        event.Open,
+		High:        event.High,
+		Low:         event.Low,
+		Close:       event.Close,
+		Volume:      event.Volume,
+		QuoteVolume: event.QuoteVolume,
+		Closed:      event.Closed,
+	}}, nil
+}
+
+// parseFuturesCandlestick 解析合约K线事件，合约推送不包含成交额
+func parseFuturesCandlestick(result json.RawMessage, symbol string, interval exchange.KlineInterval, quantoMultiplier string) ([]*exchange.Kline, error) {
+	var events []WsFuturesCandlestick
+	if err := json.Unmarshal(result, &events); err != nil {
+		return nil, err
+	}
+	klines := make([]*exchange.Kline, 0, len(events))
+	for _, event := range events {
+		openTime := event.Time * 1000
+		klines = append(klines, &exchange.Kline{
+			Symbol:    symbol,
+			Interval:  interval,
+			OpenTime:  openTime,
+			CloseTime: interval.CloseTime(openTime),
+			Open:      event.Open,
+			High:      event.High,
+			Low:       event.Low,
+			Close:     event.Close,
+			Volume:    sizeToQuantity(event.Size, quantoMultiplier),
+			Closed:    event.Closed,
+		})
+	}
+	return klines, nil
+}
@@ -0,0 +1,63 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenMarkPrice 开始监听合约标记价格，订阅 futures.tickers 并取其中的标记价格、指数价格与资金费率，
+// 推送不包含下次资金费结算时间
+func (g *gateWebsocket) StartListenMarkPrice(ctx context.Context, symbols []string, handler exchange.WebsocketMarkPriceHandler) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("交易对不能为空")
+	}
+
+	var ws *client.Websocket
+	ws = client.NewWebsocket(g.futuresURL, func(message []byte) {
+		resp := &SubscribeResult{}
+		if err := json.Unmarshal(message, resp); err != nil || resp.Channel != "futures.tickers" || resp.Event != "update" {
+			return
+		}
+		markPrices, err := parseMarkPrices(resp)
+		if err != nil {
+			return
+		}
+		for _, markPrice := range markPrices {
+			if handler != nil {
+				handler(markPrice)
+			}
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		return writeSubscribe(ws, "futures.tickers", "subscribe", symbols)
+	})
+	return g.group.Start(ctx, ws)
+}
+
+// parseMarkPrices 解析合约行情推送中的标记价格
+func parseMarkPrices(resp *SubscribeResult) ([]*exchange.MarkPrice, error) {
+	var tickers []gateapi.FuturesTicker
+	if err := json.Unmarshal(resp.Result, &tickers); err != nil {
+		return nil, err
+	}
+	markPrices := make([]*exchange.MarkPrice, 0, len(tickers))
+	for _, ticker := range tickers {
+		if ticker.MarkPrice == "" {
+			continue
+		}
+		markPrices = append(markPrices, &exchange.MarkPrice{
+			Symbol:      ticker.Contract,
+			MarkPrice:   ticker.MarkPrice,
+			IndexPrice:  ticker.IndexPrice,
+			FundingRate: ticker.FundingRate,
+			Time:        resp.TimeMs,
+		})
+	}
+	return markPrices, nil
+}
@@ -0,0 +1,28 @@
+package gate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestParseMarkPrices 解析合约行情推送中的标记价格，忽略不含标记价格的行情
+// go test -v ./impl/gate -run "^TestParseMarkPrices$"
+func TestParseMarkPrices(t *testing.T) {
+	message := `{"time":1541659086,"time_ms":1541659086123,"channel":"futures.tickers","event":"update","result":[{"contract":"BTC_USDT","last":"118.4","mark_price":"118.35","index_price":"118.36","funding_rate":"-0.000114","volume_24h":"11"},{"contract":"ETH_USDT","last":"2000"}]}`
+	resp := &SubscribeResult{}
+	if err := json.Unmarshal([]byte(message), resp); err != nil {
+		t.Fatalf("解析消息失败: %v", err)
+	}
+	markPrices, err := parseMarkPrices(resp)
+	if err != nil {
+		t.Fatalf("解析标记价格失败: %v", err)
+	}
+	if len(markPrices) != 1 {
+		t.Fatalf("标记价格数量错误: %d", len(markPrices))
+	}
+	markPrice := markPrices[0]
+	if markPrice.Symbol != "BTC_USDT" || markPrice.MarkPrice != "118.35" || markPrice.IndexPrice != "118.36" ||
+		markPrice.FundingRate != "-0.000114" || markPrice.Time != 1541659086123 {
+		t.Errorf("标记价格错误: %+v", markPrice)
+	}
+}
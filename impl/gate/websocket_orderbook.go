@@ -0,0 +1,138 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/orderbook"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+const (
+	OrderBookSnapshotLimit = 100     // 本地深度快照档位数量
+	OrderBookInterval      = "100ms" // 增量深度推送频率
+)
+
+// StartListenOrderBook 开始维护本地深度
+// 同步流程：订阅 order_book_update 并缓存事件，获取 with_id 的 REST 快照，校验 U/u 连续性，出现缺口时重新同步
+func (g *gateWebsocket) StartListenOrderBook(ctx context.Context, market exchange.Market, symbol string, depth int, handler exchange.WebsocketOrderBookHandler) (exchange.LocalOrderBook, error) {
+	rest := newGateExchange("", "", g.opts...)
+
+	var (
+		dialURL  string
+		channel  string
+		payload  []string
+		snapshot orderbook.SnapshotFunc
+		parse    func(result json.RawMessage) (*orderbook.Update, error)
+	)
+	switch market {
+	case exchange.MarketSpot:
+		dialURL = g.spotURL
+		channel = "spot.order_book_update"
+		payload = []string{symbol, OrderBookInterval}
+		snapshot = func(ctx context.Context) (*exchange.OrderBook, error) {
+			return rest.GetSpotOrderBook(ctx, symbol, OrderBookSnapshotLimit)
+		}
+		parse = parseOrderBookUpdate
+	case exchange.MarketFutures:
+		// 增量数量为合约张数，按合约乘数转换为与快照一致的基础资产数量
+		spec, err := rest.GetFuturesSymbolSpec(ctx, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("获取交易规则失败: %w", err)
+		}
+		dialURL = g.futuresURL
+		channel = "futures.order_book_update"
+		payload = []string{symbol, OrderBookInterval, fmt.Sprint(OrderBookSnapshotLimit)}
+		snapshot = func(ctx context.Context) (*exchange.OrderBook, error) {
+			return rest.GetFuturesOrderBook(ctx, symbol, OrderBookSnapshotLimit)
+		}
+		parse = func(result json.RawMessage) (*orderbook.Update, error) {
+			return parseFuturesOrderBookUpdate(result, spec.QuantoMultiplier)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	syncer := orderbook.NewSyncer(orderbook.NewBook(symbol, ""), snapshot, func(book *orderbook.Book) {
+		if handler != nil {
+			handler(book.Snapshot(depth))
+		}
+	})
+	var ws *client.Websocket
+	ws = client.NewWebsocket(dialURL, func(message []byte) {
+		resp := &SubscribeResult{}
+		if err := json.Unmarshal(message, resp); err != nil || resp.Channel != channel || resp.Event != "update" {
+			return
+		}
+		update, err := parse(resp.Result)
+		if err != nil {
+			return
+		}
+		syncer.Push(update)
+	})
+	// 连接成功后订阅，重连后重新同步
+	ws.SetAfterConnectionHandler(func() error {
+		syncer.Reset()
+		subscribeBytes, err := json.Marshal(SubscribeParams{
+			Time:    time.Now().Unix(),
+			Channel: channel,
+			Event:   "subscribe",
+			Payload: payload,
+		})
+		if err != nil {
+			return err
+		}
+		return ws.WriteMessage(subscribeBytes)
+	})
+	if err := g.group.Start(ctx, ws, syncer.Close); err != nil {
+		return nil, err
+	}
+	return syncer.Book(), nil
+}
+
+// parseOrderBookUpdate 解析现货增量深度事件
+func parseOrderBookUpdate(result json.RawMessage) (*orderbook.Update, error) {
+	event := &WsOrderBookUpdate{}
+	if err := json.Unmarshal(result, event); err != nil {
+		return nil, err
+	}
+	return &orderbook.Update{
+		FirstUpdateID: event.FirstUpdateID,
+		LastUpdateID:  event.LastUpdateID,
+		Bids:          toOrderBookLevels(event.Bids),
+		Asks:          toOrderBookLevels(event.Asks),
+		Time:          event.Time,
+	}, nil
+}
+
+// parseFuturesOrderBookUpdate 解析合约增量深度事件，数量转换为基础资产数量
+func parseFuturesOrderBookUpdate(result json.RawMessage, quantoMultiplier string) (*orderbook.Update, error) {
+	event := &WsFuturesOrderBookUpdate{}
+	if err := json.Unmarshal(result, event); err != nil {
+		return nil, err
+	}
+	toLevels := func(levels []WsFuturesOrderBookLevel) []exchange.OrderBookLevel {
+		res := make([]exchange.OrderBookLevel, 0, len(levels))
+		for _, level := range levels {
+			size, err := level.Size.Int64()
+			if err != nil {
+				continue
+			}
+			res = append(res, exchange.OrderBookLevel{
+				Price:    level.Price,
+				Quantity: sizeToQuantity(size, quantoMultiplier),
+			})
+		}
+		return res
+	}
+	return &orderbook.Update{
+		FirstUpdateID: event.FirstUpdateID,
+		LastUpdateID:  event.LastUpdateID,
+		Bids:          toLevels(event.Bids),
+		Asks:          toLevels(event.Asks),
+		Time:          event.Time,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/orderbook"
+)
+
+// 录制的现货增量深度消息，第 3 条缺少 U=48776312 之前的更新
+var testSpotOrderBookMessages = []string{
+	`{"time":1606294781,"time_ms":1606294781236,"channel":"spot.order_book_update","event":"update","result":{"t":1606294781123,"e":"depthUpdate","E":1606294781,"s":"BTC_USDT","U":48776301,"u":48776306,"b":[["19137.74","0.0001"],["19088.37","0"]],"a":[["19137.75","0.6135"]]}}`,
+	`{"time":1606294781,"time_ms":1606294781336,"channel":"spot.order_book_update","event":"update","result":{"t":1606294781223,"e":"depthUpdate","E":1606294781,"s":"BTC_USDT","U":48776307,"u":48776310,"b":[["19137.74","0"]],"a":[["19137.80","1.2"]]}}`,
+	`{"time":1606294782,"time_ms":1606294782436,"channel":"spot.order_book_update","event":"update","result":{"t":1606294782323,"e":"depthUpdate","E":1606294782,"s":"BTC_USDT","U":48776312,"u":48776315,"b":[["19137.70","2"]],"a":[]}}`,
+	`{"time":1606294782,"time_ms":1606294782536,"channel":"spot.order_book_update","event":"update","result":{"t":1606294782423,"e":"depthUpdate","E":1606294782,"s":"BTC_USDT","U":48776316,"u":48776318,"b":[],"a":[["19137.75","0"]]}}`,
+}
+
+// TestOrderBookReplay 回放录制的增量深度消息，缺口后重新获取快照
+// go test -v ./impl/gate -run "^TestOrderBookReplay$"
+func TestOrderBookReplay(t *testing.T) {
+	snapshots := []*exchange.OrderBook{
+		{
+			Bids:         []exchange.OrderBookLevel{{Price: "19137.70", Quantity: "1"}, {Price: "19088.37", Quantity: "3"}},
+			Asks:         []exchange.OrderBookLevel{{Price: "19137.75", Quantity: "0.5"}},
+			LastUpdateID: 48776303,
+		},
+		{
+			Bids:         []exchange.OrderBookLevel{{Price: "19137.70", Quantity: "2"}},
+			Asks:         []exchange.OrderBookLevel{{Price: "19137.75", Quantity: "0.6135"}, {Price: "19137.80", Quantity: "1.2"}},
+			LastUpdateID: 48776315,
+		},
+	}
+	calls := 0
+	syncer := orderbook.NewSyncer(orderbook.NewBook("BTC_USDT", ""), func(ctx context.Context) (*exchange.OrderBook, error) {
+		snapshot := snapshots[calls]
+		calls++
+		return snapshot, nil
+	}, nil).SetRetryDelay(0).SetRunner(func(f func()) { f() })
+
+	for i, message := range testSpotOrderBookMessages {
+		resp := &SubscribeResult{}
+		if err := json.Unmarshal([]byte(message), resp); err != nil {
+			t.Fatalf("解析第 %d 条消息失败: %v", i, err)
+		}
+		update, err := parseOrderBookUpdate(resp.Result)
+		if err != nil {
+			t.Fatalf("解析第 %d 条深度失败: %v", i, err)
+		}
+		syncer.Push(update)
+	}
+
+	book := syncer.Book()
+	if calls != 2 || !book.Synced() || book.LastUpdateID() != 48776318 {
+		t.Fatalf("同步错误: calls=%d synced=%v lastUpdateId=%d", calls, book.Synced(), book.LastUpdateID())
+	}
+	snapshot := book.Snapshot(5)
+	if len(snapshot.Bids) != 1 || snapshot.Bids[0].Price != "19137.70" || snapshot.Bids[0].Quantity != "2" {
+		t.Errorf("买盘错误: %+v", snapshot.Bids)
+	}
+	if len(snapshot.Asks) != 1 || snapshot.Asks[0].Price != "19137.80" {
+		t.Errorf("卖盘错误: %+v", snapshot.Asks)
+	}
+}
+
+// TestParseFuturesOrderBookUpdate 解析合约增量深度，张数转换为基础资产数量
+// go test -v ./impl/gate -run "^TestParseFuturesOrderBookUpdate$"
+func TestParseFuturesOrderBookUpdate(t *testing.T) {
+	message := `{"time":1615366381,"time_ms":1615366381417,"channel":"futures.order_book_update","event":"update","result":{"t":1615366381417,"s":"BTC_USDT","U":2517661101,"u":2517661113,"b":[{"p":"54672.1","s":0},{"p":"54664.5","s":58794}],"a":[{"p":"54743.6","s":1200}]}}`
+	resp := &SubscribeResult{}
+	if err := json.Unmarshal([]byte(message), resp); err != nil {
+		t.Fatalf("解析消息失败: %v", err)
+	}
+	update, err := parseFuturesOrderBookUpdate(resp.Result, "0.0001")
+	if err != nil {
+		t.Fatalf("解析深度失败: %v", err)
+	}
+	if update.FirstUpdateID != 2517661101 || update.LastUpdateID != 2517661113 || update.Time != 1615366381417 {
+		t.Errorf("更新ID或时间错误: %+v", update)
+	}
+	if update.Bids[0].Quantity != "0" || update.Bids[1].Quantity != "5.8794" || update.Asks[0].Quantity != "0.12" {
+		t.Errorf("数量转换错误: %+v %+v", update.Bids, update.Asks)
+	}
+}
@@ -1,6 +1,7 @@
 package gate
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -11,7 +12,7 @@ import (
 // go test -v ./impl/gate -run "^TestListenSpotTickers$"
 func TestListenSpotTickers(t *testing.T) {
 	gateWebsocket := NewGateWebsocket()
-	err := gateWebsocket.StartListenSpotTickers(func(ticker *exchange.Ticker) {
+	err := gateWebsocket.StartListenSpotTickers(context.Background(), func(ticker *exchange.Ticker) {
 		fmt.Println("===>", ticker)
 	})
 	if err != nil {
@@ -26,7 +27,7 @@ func TestListenSpotTickers(t *testing.T) {
 // go test -v ./impl/gate -run "^TestListenFuturesTickers$"
 func TestListenFuturesTickers(t *testing.T) {
 	gateWebsocket := NewGateWebsocket()
-	err := gateWebsocket.StartListenFuturesTickers(func(ticker *exchange.Ticker) {
+	err := gateWebsocket.StartListenFuturesTickers(context.Background(), func(ticker *exchange.Ticker) {
 		fmt.Println("===>", ticker)
 	})
 	if err != nil {
@@ -0,0 +1,219 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/subscription"
+)
+
+// StartListenSpotTickers 开始监听现货全部交易对行情，ctx 结束时取消该频道的全部订阅
+func (g *gateWebsocket) StartListenSpotTickers(ctx context.Context, handler exchange.WebsocketSpotTickerHandler) error {
+	g.SetTickerHandler(exchange.ChannelSpotTicker, exchange.WebsocketTickerHandler(handler))
+	if err := g.Subscribe(exchange.ChannelSpotTicker); err != nil {
+		return err
+	}
+	g.group.AfterFunc(ctx, func() {
+		_ = g.Unsubscribe(exchange.ChannelSpotTicker)
+	})
+	return nil
+}
+
+// StartListenFuturesTickers 开始监听合约全部交易对行情，ctx 结束时取消该频道的全部订阅
+func (g *gateWebsocket) StartListenFuturesTickers(ctx context.Context, handler exchange.WebsocketFuturesTickerHandler) error {
+	g.SetTickerHandler(exchange.ChannelFuturesTicker, exchange.WebsocketTickerHandler(handler))
+	if err := g.Subscribe(exchange.ChannelFuturesTicker); err != nil {
+		return err
+	}
+	g.group.AfterFunc(ctx, func() {
+		_ = g.Unsubscribe(exchange.ChannelFuturesTicker)
+	})
+	return nil
+}
+
+// SetTickerHandler 设置行情频道的回调
+func (g *gateWebsocket) SetTickerHandler(channel exchange.Channel, handler exchange.WebsocketTickerHandler) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	g.tickerHandlers[channel] = handler
+}
+
+// Subscribe 订阅行情，symbols 为空时获取一次 USDT 交易对列表并全部订阅，重连后按已订阅列表恢复，不再重新获取
+func (g *gateWebsocket) Subscribe(channel exchange.Channel, symbols ...string) error {
+	pool, err := g.tickerPool(channel)
+	if err != nil {
+		return err
+	}
+	if len(symbols) == 0 {
+		rest := newGateExchange("", "", g.opts...)
+		if channel == exchange.ChannelSpotTicker {
+			symbols = rest.GetSpotSymbols()
+		} else {
+			symbols = rest.GetFuturesSymbols()
+		}
+		if len(symbols) == 0 {
+			return fmt.Errorf("获取交易对列表失败")
+		}
+	}
+	return pool.Subscribe(symbols...)
+}
+
+// Unsubscribe 取消订阅行情，symbols 为空时取消该频道的全部订阅
+func (g *gateWebsocket) Unsubscribe(channel exchange.Channel, symbols ...string) error {
+	pool, err := g.tickerPool(channel)
+	if err != nil {
+		return err
+	}
+	if len(symbols) == 0 {
+		symbols = pool.Streams()
+	}
+	return pool.Unsubscribe(symbols...)
+}
+
+// tickerPool 获取行情订阅连接池，不存在时创建，Gate 不限制单个连接的订阅数量
+func (g *gateWebsocket) tickerPool(channel exchange.Channel) (*subscription.Pool, error) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	if pool, ok := g.tickerPools[channel]; ok {
+		return pool, nil
+	}
+	if g.group.Context().Err() != nil {
+		return nil, exchange.ErrClosed
+	}
+
+	var dialURL, wsChannel string
+	switch channel {
+	case exchange.ChannelSpotTicker:
+		dialURL, wsChannel = g.spotURL, "spot.tickers"
+	case exchange.ChannelFuturesTicker:
+		dialURL, wsChannel = g.futuresURL, "futures.tickers"
+	default:
+		return nil, fmt.Errorf("不支持的订阅频道: %s", channel)
+	}
+
+	pool := subscription.NewPool(subscription.Config{
+		NewConn: func(onConnect func() error, onDisconnect func()) subscription.Conn {
+			ws := client.NewWebsocket(dialURL, func(message []byte) {
+				handler := g.tickerHandler(channel)
+				if handler == nil {
+					return
+				}
+				resp := &SubscribeResult{}
+				if err := json.Unmarshal(message, resp); err != nil || resp.Channel != wsChannel || resp.Event != "update" {
+					return
+				}
+				for _, ticker := range parseTickers(resp) {
+					handler(ticker)
+				}
+			})
+			ws.SetBeforeConnectionHandler(func() error {
+				onDisconnect()
+				return nil
+			})
+			ws.SetAfterConnectionHandler(onConnect)
+			// 已关闭时关闭连接，启动将失败
+			if err := g.group.Add(ws); err != nil {
+				ws.Close()
+			}
+			return ws
+		},
+		Subscribe: func(conn subscription.Conn, symbols []string) error {
+			return writeSubscribe(conn, wsChannel, "subscribe", symbols)
+		},
+		Unsubscribe: func(conn subscription.Conn, symbols []string) error {
+			return writeSubscribe(conn, wsChannel, "unsubscribe", symbols)
+		},
+	})
+	g.tickerPools[channel] = pool
+	return pool, nil
+}
+
+// tickerHandler 获取行情频道的回调
+func (g *gateWebsocket) tickerHandler(channel exchange.Channel) exchange.WebsocketTickerHandler {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	return g.tickerHandlers[channel]
+}
+
+// writeSubscribe 发送订阅或取消订阅请求
+func writeSubscribe(conn subscription.Conn, channel, event string, payload []string) error {
+	message, err := json.Marshal(SubscribeParams{
+		Time:    time.Now().Unix(),
+		Channel: channel,
+		Event:   event,
+		Payload: payload,
+	})
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(message)
+}
+
+// parseTickers 解析行情推送，现货推送单个对象，合约推送数组
+func parseTickers(resp *SubscribeResult) []*exchange.Ticker {
+	switch resp.Channel {
+	case "spot.tickers":
+		spotTicker := &gateapi.Ticker{}
+		if err := json.Unmarshal(resp.Result, spotTicker); err != nil || spotTicker.CurrencyPair == "" {
+			return nil
+		}
+
+		// 计算开盘价和价格变动
+		openPrice, priceChange := calculateOpenAndChangePrice(spotTicker.Last, spotTicker.ChangePercentage)
+		return []*exchange.Ticker{{
+			Symbol:             spotTicker.CurrencyPair,
+			PriceChange:        priceChange,
+			PriceChangePercent: spotTicker.ChangePercentage,
+			WeightedAvgPrice:   "", // Gate API 不提供加权平均价
+			LastPrice:          spotTicker.Last,
+			LastQty:            "", // Gate API 不提供最新成交量
+			OpenPrice:          openPrice,
+			HighPrice:          spotTicker.High24h,
+			LowPrice:           spotTicker.Low24h,
+			Volume:             spotTicker.BaseVolume,
+			QuoteVolume:        spotTicker.QuoteVolume,
+			Count:              0, // Gate API 不提供成交笔数
+		}}
+	case "futures.tickers":
+		var futuresTickers []*gateapi.FuturesTicker
+		if err := json.Unmarshal(resp.Result, &futuresTickers); err != nil {
+			return nil
+		}
+
+		tickers := make([]*exchange.Ticker, 0, len(futuresTickers))
+		for _, futuresTicker := range futuresTickers {
+			// 优先使用 Volume24hBase 作为 Volume，如果没有则使用 Volume24h
+			volume := futuresTicker.Volume24hBase
+			if volume == "" {
+				volume = futuresTicker.Volume24h
+			}
+			// 优先使用 Volume24hQuote 作为 QuoteVolume，如果没有则使用 Volume24hSettle
+			quoteVolume := futuresTicker.Volume24hQuote
+			if quoteVolume == "" {
+				quoteVolume = futuresTicker.Volume24hSettle
+			}
+			openPrice, priceChange := calculateOpenAndChangePrice(futuresTicker.Last, futuresTicker.ChangePercentage)
+			tickers = append(tickers, &exchange.Ticker{
+				Symbol:             futuresTicker.Contract,
+				PriceChange:        priceChange,
+				PriceChangePercent: futuresTicker.ChangePercentage,
+				WeightedAvgPrice:   "", // Gate API 不提供加权平均价
+				LastPrice:          futuresTicker.Last,
+				LastQty:            "", // Gate API 不提供最新成交量
+				OpenPrice:          openPrice,
+				HighPrice:          futuresTicker.High24h,
+				LowPrice:           futuresTicker.Low24h,
+				Volume:             volume,
+				QuoteVolume:        quoteVolume,
+				Count:              0, // Gate API 不提供成交笔数
+			})
+		}
+		return tickers
+	}
+	return nil
+}
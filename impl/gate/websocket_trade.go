@@ -0,0 +1,109 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gateio/gateapi-go/v6"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenTrades 开始监听公开成交，订阅 spot.trades / futures.trades
+func (g *gateWebsocket) StartListenTrades(ctx context.Context, market exchange.Market, symbols []string, handler exchange.WebsocketTradeHandler) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("交易对不能为空")
+	}
+
+	var (
+		dialURL string
+		channel string
+		parse   func(result json.RawMessage) ([]*exchange.Trade, error)
+	)
+	switch market {
+	case exchange.MarketSpot:
+		dialURL = g.spotURL
+		channel = "spot.trades"
+		parse = parseTrade
+	case exchange.MarketFutures:
+		// 成交数量为合约张数，按合约乘数转换为基础资产数量
+		rest := newGateExchange("", "", g.opts...)
+		multipliers := make(map[string]string, len(symbols))
+		for _, symbol := range symbols {
+			spec, err := rest.GetFuturesSymbolSpec(ctx, symbol)
+			if err != nil {
+				return fmt.Errorf("获取交易规则失败: %w", err)
+			}
+			multipliers[symbol] = spec.QuantoMultiplier
+		}
+		dialURL = g.futuresURL
+		channel = "futures.trades"
+		parse = func(result json.RawMessage) ([]*exchange.Trade, error) {
+			return parseFuturesTrades(result, multipliers)
+		}
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	var ws *client.Websocket
+	ws = client.NewWebsocket(dialURL, func(message []byte) {
+		resp := &SubscribeResult{}
+		if err := json.Unmarshal(message, resp); err != nil || resp.Channel != channel || resp.Event != "update" {
+			return
+		}
+		trades, err := parse(resp.Result)
+		if err != nil {
+			return
+		}
+		for _, trade := range trades {
+			if handler != nil {
+				handler(trade)
+			}
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		subscribeBytes, err := json.Marshal(SubscribeParams{
+			Time:    time.Now().Unix(),
+			Channel: channel,
+			Event:   "subscribe",
+			Payload: symbols,
+		})
+		if err != nil {
+			return err
+		}
+		return ws.WriteMessage(subscribeBytes)
+	})
+	return g.group.Start(ctx, ws)
+}
+
+// parseTrade 解析现货成交事件
+func parseTrade(result json.RawMessage) ([]*exchange.Trade, error) {
+	event := &WsTrade{}
+	if err := json.Unmarshal(result, event); err != nil {
+		return nil, err
+	}
+	return []*exchange.Trade{toSpotTrade(event.CurrencyPair, gateapi.Trade{
+		Id:           strconv.FormatInt(event.ID, 10),
+		CreateTimeMs: event.CreateTimeMs,
+		Side:         event.Side,
+		Amount:       event.Amount,
+		Price:        event.Price,
+	})}, nil
+}
+
+// parseFuturesTrades 解析合约成交事件，数量按合约乘数转换为基础资产数量
+func parseFuturesTrades(result json.RawMessage, multipliers map[string]string) ([]*exchange.Trade, error) {
+	var events []WsFuturesTrade
+	if err := json.Unmarshal(result, &events); err != nil {
+		return nil, err
+	}
+	trades := make([]*exchange.Trade, 0, len(events))
+	for _, event := range events {
+		trades = append(trades, toFuturesTrade(event.Contract, event.ID, event.Price, event.Size, event.CreateTimeMs, multipliers[event.Contract]))
+	}
+	return trades, nil
+}
@@ -1 +1,169 @@
 package gate
+
+import "encoding/json"
+
+// WsOrderBookUpdate 现货增量深度事件 spot.order_book_update
+type WsOrderBookUpdate struct {
+	Time          int64      `json:"t"` // 更新时间，毫秒
+	Symbol        string     `json:"s"` // 交易对
+	FirstUpdateID int64      `json:"U"` // 第一个更新ID
+	LastUpdateID  int64      `json:"u"` // 最后一个更新ID
+	Bids          [][]string `json:"b"` // 买盘变化 [价格, 数量]
+	Asks          [][]string `json:"a"` // 卖盘变化 [价格, 数量]
+}
+
+// WsFuturesOrderBookUpdate 合约增量深度事件 futures.order_book_update
+type WsFuturesOrderBookUpdate struct {
+	Time          int64                     `json:"t"` // 更新时间，毫秒
+	Contract      string                    `json:"s"` // 合约
+	FirstUpdateID int64                     `json:"U"` // 第一个更新ID
+	LastUpdateID  int64                     `json:"u"` // 最后一个更新ID
+	Bids          []WsFuturesOrderBookLevel `json:"b"` // 买盘变化
+	Asks          []WsFuturesOrderBookLevel `json:"a"` // 卖盘变化
+}
+
+// WsFuturesOrderBookLevel 合约深度档位
+type WsFuturesOrderBookLevel struct {
+	Price string      `json:"p"` // 价格
+	Size  json.Number `json:"s"` // 数量，单位：张
+}
+
+// WsCandlestick 现货K线事件 spot.candlesticks
+type WsCandlestick struct {
+	Time        string `json:"t"` // 开盘时间，秒
+	QuoteVolume string `json:"v"` // 成交额
+	Close       string `json:"c"` // 收盘价
+	High        string `json:"h"` // 最高价
+	Low         string `json:"l"` // 最低价
+	Open        string `json:"o"` // 开盘价
+	Name        string `json:"n"` // 订阅名称 <周期>_<交易对>
+	Volume      string `json:"a"` // 成交量
+	Closed      bool   `json:"w"` // 是否已收盘
+}
+
+// WsFuturesCandlestick 合约K线事件 futures.candlesticks
+type WsFuturesCandlestick struct {
+	Time   int64  `json:"t"` // 开盘时间，秒
+	Size   int64  `json:"v"` // 成交量，单位：张
+	Close  string `json:"c"` // 收盘价
+	High   string `json:"h"` // 最高价
+	Low    string `json:"l"` // 最低价
+	Open   string `json:"o"` // 开盘价
+	Name   string `json:"n"` // 订阅名称 <周期>_<合约>
+	Closed bool   `json:"w"` // 是否已收盘
+}
+
+// WsTrade 现货成交事件 spot.trades
+type WsTrade struct {
+	ID           int64  `json:"id"`             // 成交ID
+	CreateTime   int64  `json:"create_time"`    // 成交时间，秒
+	CreateTimeMs string `json:"create_time_ms"` // 成交时间，毫秒，带小数
+	Side         string `json:"side"`           // 主动成交方向 buy sell
+	CurrencyPair string `json:"currency_pair"`  // 交易对
+	Amount       string `json:"amount"`         // 成交数量
+	Price        string `json:"price"`          // 成交价格
+}
+
+// WsFuturesTrade 合约成交事件 futures.trades
+type WsFuturesTrade struct {
+	ID           int64   `json:"id"`             // 成交ID
+	Size         int64   `json:"size"`           // 成交张数，负数表示主动卖出
+	CreateTime   int64   `json:"create_time"`    // 成交时间，秒
+	CreateTimeMs float64 `json:"create_time_ms"` // 成交时间，毫秒
+	Price        string  `json:"price"`          // 成交价格
+	Contract     string  `json:"contract"`       // 合约
+}
+
+// WsSpotOrder 现货订单更新 spot.orders
+type WsSpotOrder struct {
+	ID           string `json:"id"`             // 订单ID
+	Text         string `json:"text"`           // 用户自定义信息
+	CreateTimeMs string `json:"create_time_ms"` // 创建时间，毫秒
+	UpdateTimeMs string `json:"update_time_ms"` // 更新时间，毫秒
+	Event        string `json:"event"`          // 订单事件 put update finish
+	CurrencyPair string `json:"currency_pair"`  // 交易对
+	Type         string `json:"type"`           // 订单类型 limit market
+	Side         string `json:"side"`           // 订单方向 buy sell
+	Amount       string `json:"amount"`         // 委托数量
+	Price        string `json:"price"`          // 委托价格
+	TimeInForce  string `json:"time_in_force"`  // 有效方式
+	Left         string `json:"left"`           // 剩余未成交数量
+	FilledTotal  string `json:"filled_total"`   // 已成交金额
+	AvgDealPrice string `json:"avg_deal_price"` // 成交均价
+	Fee          string `json:"fee"`            // 累计手续费
+	FeeCurrency  string `json:"fee_currency"`   // 手续费币种
+	FinishAs     string `json:"finish_as"`      // 订单结束方式
+}
+
+// WsSpotUserTrade 现货成交 spot.usertrades
+type WsSpotUserTrade struct {
+	ID           int64  `json:"id"`             // 成交ID
+	OrderID      string `json:"order_id"`       // 订单ID
+	CurrencyPair string `json:"currency_pair"`  // 交易对
+	CreateTimeMs string `json:"create_time_ms"` // 成交时间，毫秒，带小数
+	Side         string `json:"side"`           // 订单方向 buy sell
+	Amount       string `json:"amount"`         // 成交数量
+	Role         string `json:"role"`           // 成交角色 maker taker
+	Price        string `json:"price"`          // 成交价格
+	Fee          string `json:"fee"`            // 手续费
+	FeeCurrency  string `json:"fee_currency"`   // 手续费币种
+}
+
+// WsSpotBalance 现货余额更新 spot.balances
+type WsSpotBalance struct {
+	TimestampMs string `json:"timestamp_ms"` // 更新时间，毫秒
+	Currency    string `json:"currency"`     // 币种
+	Total       string `json:"total"`        // 总余额
+	Available   string `json:"available"`    // 可用余额
+	Freeze      string `json:"freeze"`       // 冻结余额
+}
+
+// WsFuturesOrder 合约订单更新 futures.orders，数量单位：张
+type WsFuturesOrder struct {
+	ID           int64       `json:"id"`             // 订单ID
+	Contract     string      `json:"contract"`       // 合约
+	CreateTimeMs int64       `json:"create_time_ms"` // 创建时间，毫秒
+	FinishTimeMs int64       `json:"finish_time_ms"` // 结束时间，毫秒
+	Size         int64       `json:"size"`           // 委托张数，负数表示卖出
+	Left         int64       `json:"left"`           // 剩余未成交张数
+	Price        json.Number `json:"price"`          // 委托价格，市价单为 0
+	FillPrice    json.Number `json:"fill_price"`     // 成交均价
+	Tif          string      `json:"tif"`            // 有效方式
+	Status       string      `json:"status"`         // 订单状态 open finished
+	FinishAs     string      `json:"finish_as"`      // 订单结束方式
+	Text         string      `json:"text"`           // 用户自定义信息
+}
+
+// WsFuturesUserTrade 合约成交 futures.usertrades，数量单位：张
+type WsFuturesUserTrade struct {
+	ID           string      `json:"id"`             // 成交ID
+	OrderID      string      `json:"order_id"`       // 订单ID
+	Contract     string      `json:"contract"`       // 合约
+	CreateTimeMs int64       `json:"create_time_ms"` // 成交时间，毫秒
+	Size         int64       `json:"size"`           // 成交张数，负数表示卖出
+	Price        string      `json:"price"`          // 成交价格
+	Role         string      `json:"role"`           // 成交角色 maker taker
+	Fee          json.Number `json:"fee"`            // 手续费
+}
+
+// WsFuturesBalance 合约余额更新 futures.balances
+type WsFuturesBalance struct {
+	Balance  json.Number `json:"balance"`  // 变化后的余额
+	Change   json.Number `json:"change"`   // 变化数量
+	TimeMs   int64       `json:"time_ms"`  // 更新时间，毫秒
+	Type     string      `json:"type"`     // 变化类型
+	Currency string      `json:"currency"` // 币种
+}
+
+// WsFuturesPosition 合约持仓更新 futures.positions，数量单位：张
+type WsFuturesPosition struct {
+	Contract    string      `json:"contract"`     // 合约
+	EntryPrice  json.Number `json:"entry_price"`  // 开仓均价
+	Leverage    json.Number `json:"leverage"`     // 杠杆倍数，0 表示全仓
+	LiqPrice    json.Number `json:"liq_price"`    // 强平价格
+	Margin      json.Number `json:"margin"`       // 保证金
+	Mode        string      `json:"mode"`         // 持仓模式 single dual_long dual_short
+	RealisedPnl json.Number `json:"realised_pnl"` // 已实现盈亏
+	Size        int64       `json:"size"`         // 持仓张数，负数表示空头
+	TimeMs      int64       `json:"time_ms"`      // 更新时间，毫秒
+}
@@ -0,0 +1,450 @@
+package gate
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/symbolmap"
+)
+
+// gateUserDataStream Gate 私有数据流实例
+type gateUserDataStream struct {
+	apiKey    string
+	secretKey string
+	ws        *gateWebsocket
+	rest      *gateExchange
+}
+
+// NewGateUserDataStream 创建 Gate 私有数据流实例，订阅私有频道时携带签名认证
+func NewGateUserDataStream(apiKey, secretKey string, opts ...exchange.Option) exchange.UserDataStream {
+	rest := newGateExchange(apiKey, secretKey, opts...)
+	stream := &gateUserDataStream{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		ws:        newGateWebsocket(opts...),
+		rest:      rest,
+	}
+	return symbolmap.WrapUserDataStream(stream, rest.ListInstruments, symbolFormatter{})
+}
+
+// StartListenUserData 开始监听账户私有数据
+// 现货订阅 spot.orders、spot.usertrades、spot.balances，
+// 合约订阅 futures.orders、futures.usertrades、futures.balances、futures.positions，
+// 每次连接成功后重新签名订阅
+func (g *gateUserDataStream) StartListenUserData(ctx context.Context, market exchange.Market, handler exchange.UserDataHandler) error {
+	var (
+		dialURL  string
+		requests []SubscribeParams
+		parse    func(resp *SubscribeResult) error
+	)
+	switch market {
+	case exchange.MarketSpot:
+		dialURL = g.ws.spotURL
+		requests = []SubscribeParams{
+			{Channel: "spot.orders", Payload: []string{"!all"}},
+			{Channel: "spot.usertrades", Payload: []string{"!all"}},
+			{Channel: "spot.balances"},
+		}
+		parse = func(resp *SubscribeResult) error {
+			return handleSpotUserDataEvent(resp, handler)
+		}
+	case exchange.MarketFutures:
+		// 合约私有频道需要用户ID
+		detail, _, err := g.rest.getClient(ctx).AccountApi.GetAccountDetail(ctx)
+		if err != nil {
+			return fmt.Errorf("获取账户信息失败: %w", toAPIError(err))
+		}
+		uid := strconv.FormatInt(detail.UserId, 10)
+		dialURL = g.ws.futuresURL
+		requests = []SubscribeParams{
+			{Channel: "futures.orders", Payload: []string{uid, "!all"}},
+			{Channel: "futures.usertrades", Payload: []string{uid, "!all"}},
+			{Channel: "futures.balances", Payload: []string{uid}},
+			{Channel: "futures.positions", Payload: []string{uid, "!all"}},
+		}
+		multipliers := &quantoMultipliers{rest: g.rest}
+		parse = func(resp *SubscribeResult) error {
+			return handleFuturesUserDataEvent(resp, handler, multipliers.get)
+		}
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	var ws *client.Websocket
+	ws = client.NewWebsocket(dialURL, func(message []byte) {
+		resp := &SubscribeResult{}
+		if err := json.Unmarshal(message, resp); err != nil {
+			return
+		}
+		if resp.Error != nil {
+			slog.Error("Gate user data subscribe failed", "channel", resp.Channel, "code", resp.Error.Code, "message", resp.Error.Message)
+			g.ws.group.ReportError(fmt.Errorf("订阅 %s 失败: %d %s", resp.Channel, resp.Error.Code, resp.Error.Message))
+			return
+		}
+		if resp.Event != "update" {
+			return
+		}
+		if err := parse(resp); err != nil {
+			slog.Warn("Gate user data parse failed", "channel", resp.Channel, "error", err.Error())
+		}
+	})
+	// 连接成功后签名订阅，重连后重新认证
+	ws.SetAfterConnectionHandler(func() error {
+		for _, request := range requests {
+			request.Time = time.Now().Unix()
+			request.Event = "subscribe"
+			request.Auth = &SubscribeAuth{
+				Method: "api_key",
+				Key:    g.apiKey,
+				Sign:   g.sign(request.Channel, request.Event, request.Time),
+			}
+			subscribeBytes, err := json.Marshal(request)
+			if err != nil {
+				return err
+			}
+			if err := ws.WriteMessage(subscribeBytes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return g.ws.group.Start(ctx, ws)
+}
+
+// SetStateHandler 设置连接状态回调
+func (g *gateUserDataStream) SetStateHandler(handler exchange.ConnectionStateHandler) {
+	g.ws.SetStateHandler(handler)
+}
+
+// Errors 异步错误通道
+func (g *gateUserDataStream) Errors() <-chan error {
+	return g.ws.Errors()
+}
+
+// Done 关闭完成后关闭的通道
+func (g *gateUserDataStream) Done() <-chan struct{} {
+	return g.ws.Done()
+}
+
+// Close 关闭全部连接
+func (g *gateUserDataStream) Close() error {
+	return g.ws.Close()
+}
+
+// sign 私有频道签名，HMAC-SHA512(channel=<channel>&event=<event>&time=<time>)
+func (g *gateUserDataStream) sign(channel, event string, t int64) string {
+	h := hmac.New(sha512.New, []byte(g.secretKey))
+	h.Write([]byte(fmt.Sprintf("channel=%s&event=%s&time=%d", channel, event, t)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// quantoMultipliers 合约乘数缓存，用于将张数转换为基础资产数量
+type quantoMultipliers struct {
+	rest   *gateExchange
+	values sync.Map // contract -> quanto multiplier
+}
+
+// get 获取合约乘数，获取失败时返回空字符串，数量保持张数
+func (m *quantoMultipliers) get(contract string) string {
+	if value, ok := m.values.Load(contract); ok {
+		return value.(string)
+	}
+	spec, err := m.rest.GetFuturesSymbolSpec(context.Background(), contract)
+	if err != nil {
+		return ""
+	}
+	m.values.Store(contract, spec.QuantoMultiplier)
+	return spec.QuantoMultiplier
+}
+
+// handleSpotUserDataEvent 解析现货私有频道推送并回调
+func handleSpotUserDataEvent(resp *SubscribeResult, handler exchange.UserDataHandler) error {
+	switch resp.Channel {
+	case "spot.orders":
+		var orders []WsSpotOrder
+		if err := json.Unmarshal(resp.Result, &orders); err != nil {
+			return err
+		}
+		for i := range orders {
+			if handler.OnOrder != nil {
+				handler.OnOrder(&exchange.OrderUpdate{
+					Market: exchange.MarketSpot,
+					Order:  toSpotOrderFromUpdate(&orders[i]),
+					Time:   resp.TimeMs,
+				})
+			}
+		}
+	case "spot.usertrades":
+		var trades []WsSpotUserTrade
+		if err := json.Unmarshal(resp.Result, &trades); err != nil {
+			return err
+		}
+		for _, trade := range trades {
+			if handler.OnFill != nil {
+				handler.OnFill(&exchange.Fill{
+					Market:   exchange.MarketSpot,
+					Symbol:   trade.CurrencyPair,
+					OrderID:  trade.OrderID,
+					TradeID:  strconv.FormatInt(trade.ID, 10),
+					Side:     exchange.OrderSide(strings.ToUpper(trade.Side)),
+					Price:    trade.Price,
+					Quantity: trade.Amount,
+					Fee:      trade.Fee,
+					FeeAsset: trade.FeeCurrency,
+					IsMaker:  trade.Role == "maker",
+					Time:     parseTimeMs(trade.CreateTimeMs),
+				})
+			}
+		}
+	case "spot.balances":
+		var items []WsSpotBalance
+		if err := json.Unmarshal(resp.Result, &items); err != nil {
+			return err
+		}
+		if handler.OnBalance != nil && len(items) > 0 {
+			balances := make([]exchange.Balance, 0, len(items))
+			for _, item := range items {
+				balances = append(balances, exchange.Balance{
+					Symbol: item.Currency,
+					Free:   item.Available,
+					Locked: item.Freeze,
+					Total:  item.Total,
+				})
+			}
+			handler.OnBalance(&exchange.BalanceUpdate{
+				Market:   exchange.MarketSpot,
+				Balances: balances,
+				Time:     parseTimeMs(items[len(items)-1].TimestampMs),
+			})
+		}
+	}
+	return nil
+}
+
+// handleFuturesUserDataEvent 解析合约私有频道推送并回调，数量按合约乘数转换为基础资产数量
+func handleFuturesUserDataEvent(resp *SubscribeResult, handler exchange.UserDataHandler, multiplier func(contract string) string) error {
+	switch resp.Channel {
+	case "futures.orders":
+		var orders []WsFuturesOrder
+		if err := json.Unmarshal(resp.Result, &orders); err != nil {
+			return err
+		}
+		for i := range orders {
+			if handler.OnOrder != nil {
+				handler.OnOrder(&exchange.OrderUpdate{
+					Market: exchange.MarketFutures,
+					Order:  toFuturesOrderFromUpdate(&orders[i], multiplier(orders[i].Contract)),
+					Time:   resp.TimeMs,
+				})
+			}
+		}
+	case "futures.usertrades":
+		var trades []WsFuturesUserTrade
+		if err := json.Unmarshal(resp.Result, &trades); err != nil {
+			return err
+		}
+		for _, trade := range trades {
+			side, size := exchange.OrderSideBuy, trade.Size
+			if size < 0 {
+				side, size = exchange.OrderSideSell, -size
+			}
+			if handler.OnFill != nil {
+				handler.OnFill(&exchange.Fill{
+					Market:   exchange.MarketFutures,
+					Symbol:   trade.Contract,
+					OrderID:  trade.OrderID,
+					TradeID:  trade.ID,
+					Side:     side,
+					Price:    trade.Price,
+					Quantity: sizeToQuantity(size, multiplier(trade.Contract)),
+					Fee:      trade.Fee.String(),
+					FeeAsset: Settle,
+					IsMaker:  trade.Role == "maker",
+					Time:     trade.CreateTimeMs,
+				})
+			}
+		}
+	case "futures.balances":
+		var items []WsFuturesBalance
+		if err := json.Unmarshal(resp.Result, &items); err != nil {
+			return err
+		}
+		if handler.OnBalance != nil && len(items) > 0 {
+			balances := make([]exchange.Balance, 0, len(items))
+			for _, item := range items {
+				currency := strings.ToUpper(item.Currency)
+				if currency == "" {
+					currency = Settle
+				}
+				// 推送中仅包含变化后的账户余额
+				balances = append(balances, exchange.Balance{
+					Symbol: currency,
+					Total:  item.Balance.String(),
+				})
+			}
+			handler.OnBalance(&exchange.BalanceUpdate{
+				Market:   exchange.MarketFutures,
+				Balances: balances,
+				Time:     items[len(items)-1].TimeMs,
+			})
+		}
+	case "futures.positions":
+		var items []WsFuturesPosition
+		if err := json.Unmarshal(resp.Result, &items); err != nil {
+			return err
+		}
+		if handler.OnPosition != nil && len(items) > 0 {
+			positions := make([]*exchange.PositionRisk, 0, len(items))
+			for i := range items {
+				positions = append(positions, toPositionFromUpdate(&items[i], multiplier(items[i].Contract)))
+			}
+			handler.OnPosition(&exchange.PositionUpdate{
+				Market:    exchange.MarketFutures,
+				Positions: positions,
+				Time:      items[len(items)-1].TimeMs,
+			})
+		}
+	}
+	return nil
+}
+
+// toSpotOrderFromUpdate 转换现货订单推送，实际数量为扣除手续费后的到账数量
+func toSpotOrderFromUpdate(order *WsSpotOrder) *exchange.Order {
+	amount, _ := decimal.NewFromString(order.Amount)
+	left, _ := decimal.NewFromString(order.Left)
+	executedQty := amount.Sub(left)
+
+	// 买入到账基础资产，卖出到账计价资产，手续费使用到账币种时扣除
+	fee, _ := decimal.NewFromString(order.Fee)
+	base, quote, _ := strings.Cut(order.CurrencyPair, "_")
+	actualQty := executedQty
+	if order.Side == "buy" && order.FeeCurrency == base {
+		actualQty = executedQty.Sub(fee)
+	}
+	if order.Side == "sell" {
+		filledTotal, _ := decimal.NewFromString(order.FilledTotal)
+		actualQty = filledTotal
+		if order.FeeCurrency == quote {
+			actualQty = filledTotal.Sub(fee)
+		}
+	}
+
+	return &exchange.Order{
+		OrderID:       order.ID,
+		ClientOrderID: toClientOrderID(order.Text),
+		Symbol:        order.CurrencyPair,
+		Side:          exchange.OrderSide(strings.ToUpper(order.Side)),
+		Type:          exchange.OrderType(strings.ToUpper(order.Type)),
+		Status:        toOrderStatus(order.Event == "finish", order.FinishAs, executedQty.IsPositive()),
+		Price:         order.Price,
+		Quantity:      order.Amount,
+		ExecutedQty:   executedQty.String(),
+		ActualQty:     actualQty.String(),
+		QuoteQuantity: order.FilledTotal,
+		TimeInForce:   toTimeInForce(order.TimeInForce),
+		CreateTime:    parseTimeMs(order.CreateTimeMs),
+		UpdateTime:    parseTimeMs(order.UpdateTimeMs),
+	}
+}
+
+// toFuturesOrderFromUpdate 转换合约订单推送，数量按合约乘数转换为基础资产数量
+func toFuturesOrderFromUpdate(order *WsFuturesOrder, quantoMultiplier string) *exchange.Order {
+	side, size, left := exchange.OrderSideBuy, order.Size, order.Left
+	if size < 0 {
+		side, size, left = exchange.OrderSideSell, -size, -left
+	}
+	executedQty := sizeToQuantity(size-left, quantoMultiplier)
+
+	orderType := exchange.OrderTypeLimit
+	price := order.Price.String()
+	if order.Price == "" || order.Price == "0" {
+		orderType = exchange.OrderTypeMarket
+		price = order.FillPrice.String()
+	}
+
+	updateTime := order.FinishTimeMs
+	if updateTime == 0 {
+		updateTime = order.CreateTimeMs
+	}
+
+	quoteQuantity := "0"
+	if executed, err := decimal.NewFromString(executedQty); err == nil {
+		if fillPrice, err := decimal.NewFromString(order.FillPrice.String()); err == nil {
+			quoteQuantity = executed.Mul(fillPrice).String()
+		}
+	}
+
+	return &exchange.Order{
+		OrderID:       strconv.FormatInt(order.ID, 10),
+		ClientOrderID: toClientOrderID(order.Text),
+		Symbol:        order.Contract,
+		Side:          side,
+		Type:          orderType,
+		Status:        toOrderStatus(order.Status == "finished", order.FinishAs, size > left),
+		Price:         price,
+		Quantity:      sizeToQuantity(size, quantoMultiplier),
+		ExecutedQty:   executedQty,
+		ActualQty:     executedQty,
+		QuoteQuantity: quoteQuantity,
+		TimeInForce:   toTimeInForce(order.Tif),
+		CreateTime:    order.CreateTimeMs,
+		UpdateTime:    updateTime,
+	}
+}
+
+// toPositionFromUpdate 转换合约持仓推送，数量按合约乘数转换为基础资产数量，空头为负数
+func toPositionFromUpdate(position *WsFuturesPosition, quantoMultiplier string) *exchange.PositionRisk {
+	side := exchange.PositionSideLong
+	switch {
+	case position.Mode == "dual_short":
+		side = exchange.PositionSideShort
+	case position.Mode != "dual_long" && position.Size < 0:
+		side = exchange.PositionSideShort
+	}
+
+	marginMode := exchange.MarginModeIsolated
+	if position.Leverage == "0" {
+		marginMode = exchange.MarginModeCrossed
+	}
+
+	return &exchange.PositionRisk{
+		Symbol:           position.Contract,
+		PositionSide:     side,
+		PositionAmt:      sizeToQuantity(position.Size, quantoMultiplier),
+		EntryPrice:       position.EntryPrice.String(),
+		Leverage:         position.Leverage.String(),
+		LiquidationPrice: position.LiqPrice.String(),
+		MarginType:       string(marginMode),
+		IsolatedMargin:   position.Margin.String(),
+	}
+}
+
+// toOrderStatus 转换订单状态，finished 表示订单已结束，partial 表示未结束订单已部分成交
+func toOrderStatus(finished bool, finishAs string, partial bool) exchange.OrderStatus {
+	if !finished {
+		if partial {
+			return exchange.OrderStatusPartiallyFilled
+		}
+		return exchange.OrderStatusNew
+	}
+	switch finishAs {
+	case "filled":
+		return exchange.OrderStatusFilled
+	case "small", "depth_not_enough", "trader_not_enough":
+		return exchange.OrderStatusRejected
+	default:
+		return exchange.OrderStatusCanceled
+	}
+}
@@ -0,0 +1,144 @@
+package gate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestUserDataSign 私有频道签名
+// go test -v ./impl/gate -run "^TestUserDataSign$"
+func TestUserDataSign(t *testing.T) {
+	g := &gateUserDataStream{apiKey: "key", secretKey: "secret"}
+	sign := g.sign("spot.orders", "subscribe", 1700000000)
+	if len(sign) != 128 {
+		t.Fatalf("签名长度错误: %s", sign)
+	}
+	if sign == g.sign("spot.orders", "subscribe", 1700000001) {
+		t.Error("不同时间的签名应不同")
+	}
+}
+
+// TestHandleSpotUserDataEvent 解析现货私有频道推送
+// go test -v ./impl/gate -run "^TestHandleSpotUserDataEvent$"
+func TestHandleSpotUserDataEvent(t *testing.T) {
+	var (
+		orders   []*exchange.OrderUpdate
+		fills    []*exchange.Fill
+		balances []*exchange.BalanceUpdate
+	)
+	handler := exchange.UserDataHandler{
+		OnOrder:   func(update *exchange.OrderUpdate) { orders = append(orders, update) },
+		OnFill:    func(fill *exchange.Fill) { fills = append(fills, fill) },
+		OnBalance: func(update *exchange.BalanceUpdate) { balances = append(balances, update) },
+	}
+
+	order := `{"time":1700000000,"time_ms":1700000000100,"channel":"spot.orders","event":"update","result":[{"id":"123","text":"t-1","create_time_ms":"1700000000000","update_time_ms":"1700000000099","event":"update","currency_pair":"BTC_USDT","type":"limit","side":"buy","amount":"1","price":"30000","time_in_force":"gtc","left":"0.6","filled_total":"12000","avg_deal_price":"30000","fee":"0.0004","fee_currency":"BTC","finish_as":"open"}]}`
+	if err := handleSpotUserDataEvent(parseResult(t, order), handler); err != nil {
+		t.Fatalf("解析订单推送失败: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("订单回调次数错误: %d", len(orders))
+	}
+	o := orders[0].Order
+	if o.OrderID != "123" || o.Side != exchange.OrderSideBuy || o.Type != exchange.OrderTypeLimit || o.Status != exchange.OrderStatusPartiallyFilled ||
+		o.ExecutedQty != "0.4" || o.ActualQty != "0.3996" || o.QuoteQuantity != "12000" || o.UpdateTime != 1700000000099 || orders[0].Time != 1700000000100 {
+		t.Errorf("订单数据错误: %+v", o)
+	}
+
+	finished := `{"channel":"spot.orders","event":"update","result":[{"id":"124","event":"finish","currency_pair":"BTC_USDT","side":"sell","amount":"1","left":"0","filled_total":"30000","fee":"30","fee_currency":"USDT","finish_as":"filled"}]}`
+	if err := handleSpotUserDataEvent(parseResult(t, finished), handler); err != nil {
+		t.Fatalf("解析订单推送失败: %v", err)
+	}
+	if o := orders[1].Order; o.Status != exchange.OrderStatusFilled || o.ActualQty != "29970" {
+		t.Errorf("完成订单数据错误: %+v", o)
+	}
+
+	trade := `{"channel":"spot.usertrades","event":"update","result":[{"id":5736713,"user_id":1000001,"order_id":"30784428","currency_pair":"BTC_USDT","create_time":1700000000,"create_time_ms":"1700000000123.456","side":"sell","amount":"1","role":"taker","price":"10000","fee":"0.002","fee_currency":"USDT"}]}`
+	if err := handleSpotUserDataEvent(parseResult(t, trade), handler); err != nil {
+		t.Fatalf("解析成交推送失败: %v", err)
+	}
+	if len(fills) != 1 {
+		t.Fatalf("成交回调次数错误: %d", len(fills))
+	}
+	if f := fills[0]; f.TradeID != "5736713" || f.OrderID != "30784428" || f.Side != exchange.OrderSideSell || f.IsMaker || f.Time != 1700000000123 || f.Fee != "0.002" {
+		t.Errorf("成交数据错误: %+v", f)
+	}
+
+	balance := `{"channel":"spot.balances","event":"update","result":[{"timestamp":"1700000000","timestamp_ms":"1700000000456","user":"1000001","currency":"USDT","change":"100","total":"1100","available":"1000","freeze":"100"}]}`
+	if err := handleSpotUserDataEvent(parseResult(t, balance), handler); err != nil {
+		t.Fatalf("解析余额推送失败: %v", err)
+	}
+	if len(balances) != 1 || balances[0].Time != 1700000000456 {
+		t.Fatalf("余额回调错误: %+v", balances)
+	}
+	if b := balances[0].Balances[0]; b.Symbol != "USDT" || b.Free != "1000" || b.Locked != "100" || b.Total != "1100" {
+		t.Errorf("余额数据错误: %+v", b)
+	}
+}
+
+// TestHandleFuturesUserDataEvent 解析合约私有频道推送，数量按合约乘数转换
+// go test -v ./impl/gate -run "^TestHandleFuturesUserDataEvent$"
+func TestHandleFuturesUserDataEvent(t *testing.T) {
+	var (
+		orders    []*exchange.OrderUpdate
+		fills     []*exchange.Fill
+		balances  []*exchange.BalanceUpdate
+		positions []*exchange.PositionUpdate
+	)
+	handler := exchange.UserDataHandler{
+		OnOrder:    func(update *exchange.OrderUpdate) { orders = append(orders, update) },
+		OnFill:     func(fill *exchange.Fill) { fills = append(fills, fill) },
+		OnBalance:  func(update *exchange.BalanceUpdate) { balances = append(balances, update) },
+		OnPosition: func(update *exchange.PositionUpdate) { positions = append(positions, update) },
+	}
+	multiplier := func(string) string { return "0.0001" }
+
+	order := `{"channel":"futures.orders","event":"update","time_ms":1700000000100,"result":[{"contract":"BTC_USDT","create_time_ms":1700000000000,"fill_price":30000.5,"finish_as":"filled","finish_time_ms":1700000000099,"id":93496,"left":0,"price":30000.5,"size":-100,"status":"finished","text":"t-1","tif":"gtc"}]}`
+	if err := handleFuturesUserDataEvent(parseResult(t, order), handler, multiplier); err != nil {
+		t.Fatalf("解析订单推送失败: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("订单回调次数错误: %d", len(orders))
+	}
+	if o := orders[0].Order; o.OrderID != "93496" || o.Side != exchange.OrderSideSell || o.Status != exchange.OrderStatusFilled ||
+		o.Quantity != "0.01" || o.ExecutedQty != "0.01" || o.Price != "30000.5" || o.QuoteQuantity != "300.005" || o.UpdateTime != 1700000000099 {
+		t.Errorf("订单数据错误: %+v", o)
+	}
+
+	trade := `{"channel":"futures.usertrades","event":"update","result":[{"id":"3335259","create_time_ms":1700000000123,"contract":"BTC_USDT","order_id":"93496","size":-100,"price":"30000.5","role":"maker","fee":-0.0015}]}`
+	if err := handleFuturesUserDataEvent(parseResult(t, trade), handler, multiplier); err != nil {
+		t.Fatalf("解析成交推送失败: %v", err)
+	}
+	if f := fills[0]; f.TradeID != "3335259" || f.Side != exchange.OrderSideSell || f.Quantity != "0.01" || !f.IsMaker || f.Fee != "-0.0015" || f.FeeAsset != Settle {
+		t.Errorf("成交数据错误: %+v", f)
+	}
+
+	balance := `{"channel":"futures.balances","event":"update","result":[{"balance":9.998739899488,"change":-0.000002074115,"text":"BTC_USDT:3914424","time":1700000000,"time_ms":1700000000456,"type":"fee","user":"211","currency":"usdt"}]}`
+	if err := handleFuturesUserDataEvent(parseResult(t, balance), handler, multiplier); err != nil {
+		t.Fatalf("解析余额推送失败: %v", err)
+	}
+	if b := balances[0].Balances[0]; b.Symbol != "USDT" || b.Total != "9.998739899488" || balances[0].Time != 1700000000456 {
+		t.Errorf("余额数据错误: %+v", b)
+	}
+
+	position := `{"channel":"futures.positions","event":"update","result":[{"contract":"BTC_USDT","entry_price":30000.5,"leverage":0,"liq_price":50000,"margin":10,"mode":"single","realised_pnl":-0.01,"size":-100,"time_ms":1700000000789}]}`
+	if err := handleFuturesUserDataEvent(parseResult(t, position), handler, multiplier); err != nil {
+		t.Fatalf("解析持仓推送失败: %v", err)
+	}
+	if p := positions[0].Positions[0]; p.PositionSide != exchange.PositionSideShort || p.PositionAmt != "-0.01" || p.EntryPrice != "30000.5" ||
+		p.MarginType != string(exchange.MarginModeCrossed) || p.LiquidationPrice != "50000" {
+		t.Errorf("持仓数据错误: %+v", p)
+	}
+}
+
+// parseResult 解析推送消息
+func parseResult(t *testing.T, message string) *SubscribeResult {
+	t.Helper()
+	resp := &SubscribeResult{}
+	if err := json.Unmarshal([]byte(message), resp); err != nil {
+		t.Fatalf("解析推送消息失败: %v", err)
+	}
+	return resp
+}
@@ -8,38 +8,74 @@ import (
 	"github.com/so68/exchange-lib/exchange"
 )
 
-// SpotBalance 获取现货余额
+// GetSpotBalance 获取现货余额
 func (o *okx) GetSpotBalance(ctx context.Context) ([]exchange.Balance, error) {
-	resp, err := o.authRequest("GET", "/api/v5/account/balance", nil)
+	details, err := o.getBalanceDetails(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var data []map[string]interface{}
-	err = json.Unmarshal(resp, &data)
+	balances := make([]exchange.Balance, 0)
+	for _, detail := range details {
+		balances = append(balances, toSpotBalance(detail))
+	}
+	return balances, nil
+}
+
+// GetFuturesBalance 获取合约余额
+// OKX 为统一账户，合约可用保证金使用币种权益计算
+func (o *okx) GetFuturesBalance(ctx context.Context) ([]exchange.Balance, error) {
+	details, err := o.getBalanceDetails(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal spot balance data error: %w", err)
+		return nil, err
 	}
 
 	balances := make([]exchange.Balance, 0)
-	for _, balance := range data {
-		if _, ok := balance["details"]; ok {
-			details := balance["details"].([]interface{})
-			for _, detail := range details {
-				detailMap := detail.(map[string]interface{})
-				balances = append(balances, exchange.Balance{
-					Symbol: detailMap["ccy"].(string),
-					Free:   detailMap["availBal"].(string),
-					Locked: detailMap["frozenBal"].(string),
-					Total:  detailMap["cashBal"].(string),
-				})
-			}
-		}
+	for _, detail := range details {
+		balances = append(balances, toFuturesBalance(detail))
 	}
 	return balances, nil
 }
 
-// FuturesBalance 获取合约余额
-func (o *okx) GetFuturesBalance(ctx context.Context) ([]exchange.Balance, error) {
-	return o.GetSpotBalance(ctx)
+// getBalanceDetails 获取账户各币种余额
+func (o *okx) getBalanceDetails(ctx context.Context) ([]*okxBalanceDetail, error) {
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/account/balance", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取账户余额失败: %w", err)
+	}
+
+	var data []*okxBalance
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal balance data error: %w", err)
+	}
+
+	var details []*okxBalanceDetail
+	for _, balance := range data {
+		details = append(details, balance.Details...)
+	}
+	return details, nil
+}
+
+// toSpotBalance 转换为现货余额
+func toSpotBalance(detail *okxBalanceDetail) exchange.Balance {
+	return exchange.Balance{
+		Symbol: detail.Ccy,
+		Free:   detail.AvailBal,
+		Locked: detail.FrozenBal,
+		Total:  detail.CashBal,
+	}
+}
+
+// toFuturesBalance 转换为合约余额，可用保证金为空时使用可用余额
+func toFuturesBalance(detail *okxBalanceDetail) exchange.Balance {
+	free := detail.AvailEq
+	if free == "" {
+		free = detail.AvailBal
+	}
+	return exchange.Balance{
+		Symbol: detail.Ccy,
+		Free:   free,
+		Locked: detail.FrozenBal,
+		Total:  detail.Eq,
+	}
 }
@@ -2,34 +2,48 @@ package okx
 
 import (
 	"context"
-	"fmt"
 	"testing"
 )
 
+const testBalanceData = `[{"totalEq":"1000","details":[
+	{"ccy":"USDT","eq":"900.5","cashBal":"900","availBal":"800","availEq":"850","frozenBal":"100"},
+	{"ccy":"BTC","eq":"0.01","cashBal":"0.01","availBal":"0.01","availEq":"","frozenBal":"0"}
+]}]`
+
 // TestSpotBalance 获取现货余额
 // go test -v ./impl/okx -run "^TestSpotBalance$"
 func TestSpotBalance(t *testing.T) {
-	okxExchange := NewOKX(apiKey, secretKey, passphrase)
-	balances, err := okxExchange.GetSpotBalance(context.Background())
+	o, _ := newTestOKX(t, map[string]string{
+		"GET /api/v5/account/balance": testBalanceData,
+	})
+
+	balances, err := o.GetSpotBalance(context.Background())
 	if err != nil {
 		t.Fatalf("获取现货余额失败: %v", err)
 	}
-
-	for _, balance := range balances {
-		fmt.Printf("币种: %s, 可用余额: %s, 锁定余额: %s, 总余额: %s\n", balance.Symbol, balance.Free, balance.Locked, balance.Total)
+	if len(balances) != 2 {
+		t.Fatalf("余额数量错误: %d", len(balances))
+	}
+	if b := balances[0]; b.Symbol != "USDT" || b.Free != "800" || b.Locked != "100" || b.Total != "900" {
+		t.Errorf("USDT 余额错误: %+v", b)
 	}
 }
 
 // TestFuturesBalance 获取合约余额
 // go test -v ./impl/okx -run "^TestFuturesBalance$"
 func TestFuturesBalance(t *testing.T) {
-	okxExchange := NewOKX(apiKey, secretKey, passphrase)
-	balances, err := okxExchange.GetFuturesBalance(context.Background())
+	o, _ := newTestOKX(t, map[string]string{
+		"GET /api/v5/account/balance": testBalanceData,
+	})
+
+	balances, err := o.GetFuturesBalance(context.Background())
 	if err != nil {
 		t.Fatalf("获取合约余额失败: %v", err)
 	}
-
-	for _, balance := range balances {
-		fmt.Printf("币种: %s, 可用余额: %s, 锁定余额: %s, 总余额: %s\n", balance.Symbol, balance.Free, balance.Locked, balance.Total)
+	if b := balances[0]; b.Free != "850" || b.Total != "900.5" {
+		t.Errorf("USDT 合约余额错误: %+v", b)
+	}
+	if b := balances[1]; b.Free != "0.01" {
+		t.Errorf("BTC 合约可用余额应回退到 availBal: %+v", b)
 	}
 }
@@ -0,0 +1,109 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/batch"
+)
+
+const (
+	BatchOrderLimit = 20 // 批量下单与批量撤单每次最多订单数
+)
+
+// batchOrder 批量下单请求与下单参数
+type batchOrder struct {
+	req    exchange.OrderRequest
+	params map[string]string
+}
+
+// PlaceOrders 批量下单，批量接口只返回订单ID，下单成功的订单按请求内容返回，状态记为新建
+func (o *okx) PlaceOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	return batch.SplitMarket(reqs, func(market exchange.Market, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+		prepare := func(req exchange.OrderRequest) (batchOrder, error) {
+			if err := req.Validate(); err != nil {
+				return batchOrder{}, err
+			}
+			if req.IcebergQty != "" {
+				return batchOrder{}, fmt.Errorf("%w: OKX 不支持冰山单", exchange.ErrInvalidOrder)
+			}
+
+			var params map[string]string
+			var err error
+			if market == exchange.MarketSpot {
+				params, err = o.newSpotOrderParams(ctx, req)
+			} else {
+				params, err = o.newFuturesOrderParams(ctx, req)
+			}
+			return batchOrder{req: req, params: params}, err
+		}
+		return batch.Do(ctx, reqs, BatchOrderLimit, prepare, func(orders []batchOrder) ([]exchange.OrderResult, error) {
+			params := make([]map[string]string, len(orders))
+			for i, order := range orders {
+				params[i] = order.params
+			}
+			results, err := o.batchRequest(ctx, "/api/v5/trade/batch-orders", params)
+			if err != nil {
+				return nil, fmt.Errorf("批量下单失败: %w", err)
+			}
+			return toBatchResults(results, len(orders), func(i int, result okxOrderResult) *exchange.Order {
+				order := toPlacedOrder(orders[i].req, orders[i].params, result.OrdId)
+				order.ClientOrderID = result.ClOrdId
+				return order
+			})
+		})
+	})
+}
+
+// CancelOrders 批量撤单，撤销成功的订单状态记为已撤销
+func (o *okx) CancelOrders(ctx context.Context, market exchange.Market, symbol string, orderIDs []string) ([]exchange.OrderResult, error) {
+	var instId string
+	switch market {
+	case exchange.MarketSpot:
+		instId = formatSpotInstId(symbol)
+	case exchange.MarketFutures:
+		instId = formatSwapInstId(symbol)
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	prepare := func(orderID string) (map[string]string, error) {
+		if strings.TrimSpace(orderID) == "" {
+			return nil, fmt.Errorf("无效的订单ID: %q", orderID)
+		}
+		return orderParams(instId, orderID, ""), nil
+	}
+	return batch.Do(ctx, orderIDs, BatchOrderLimit, prepare, func(params []map[string]string) ([]exchange.OrderResult, error) {
+		results, err := o.batchRequest(ctx, "/api/v5/trade/cancel-batch-orders", params)
+		if err != nil {
+			return nil, fmt.Errorf("批量撤单失败: %w", err)
+		}
+		return toBatchResults(results, len(params), func(i int, result okxOrderResult) *exchange.Order {
+			return &exchange.Order{
+				OrderID:       params[i]["ordId"],
+				ClientOrderID: result.ClOrdId,
+				Symbol:        symbol,
+				Status:        exchange.OrderStatusCanceled,
+			}
+		})
+	})
+}
+
+// toBatchResults 转换批量接口的执行结果，sCode 不为 0 的订单记录对应的错误
+func toBatchResults(results []okxOrderResult, n int, toOrder func(i int, result okxOrderResult) *exchange.Order) ([]exchange.OrderResult, error) {
+	if len(results) != n {
+		return nil, fmt.Errorf("批量结果数量 %d 与请求数量 %d 不一致", len(results), n)
+	}
+
+	orderResults := make([]exchange.OrderResult, n)
+	for i, result := range results {
+		if result.SCode != "0" {
+			orderResults[i].Err = newAPIError(result.SCode, result.SMsg)
+			continue
+		}
+		orderResults[i].Order = toOrder(i, result)
+	}
+	return orderResults, nil
+}
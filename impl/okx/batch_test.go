@@ -0,0 +1,91 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestPlaceOrders 批量下单，单个订单失败不影响其他订单
+// go test -v ./impl/okx -run "^TestPlaceOrders$"
+func TestPlaceOrders(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/batch-orders": `[
+			{"ordId":"1001","clOrdId":"c1","sCode":"0","sMsg":""},
+			{"ordId":"","clOrdId":"c3","sCode":"51008","sMsg":"Insufficient balance"}
+		]`,
+	})
+
+	results, err := o.PlaceOrders(context.Background(), []exchange.OrderRequest{
+		{Market: exchange.MarketSpot, Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeLimit, Price: "100", Quantity: "0.01", ClientOrderID: "c1"},
+		{Market: exchange.MarketSpot, Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeLimit, Price: "100", Quantity: "0.0001", ClientOrderID: "c2"},
+		{Market: exchange.MarketSpot, Symbol: "BTCUSDT", Side: exchange.OrderSideSell, Type: exchange.OrderTypeLimit, Price: "100", Quantity: "0.02", ClientOrderID: "c3"},
+	})
+	if err != nil {
+		t.Fatalf("批量下单失败: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("结果数量错误: %d", len(results))
+	}
+	if results[0].Err != nil || results[0].Order.OrderID != "1001" || results[0].Order.ClientOrderID != "c1" || results[0].Order.Status != exchange.OrderStatusNew {
+		t.Errorf("第 1 个订单结果错误: %+v, %v", results[0].Order, results[0].Err)
+	}
+	if results[1].Err == nil || results[1].Order != nil {
+		t.Errorf("第 2 个订单低于最小数量应失败: %+v", results[1])
+	}
+	if !errors.Is(results[2].Err, exchange.ErrInsufficientBalance) {
+		t.Errorf("第 3 个订单应返回余额不足: %v", results[2].Err)
+	}
+
+	// 校验失败的订单不发送
+	var body []map[string]string
+	if err := json.Unmarshal([]byte(ts.findRequest("POST", "/api/v5/trade/batch-orders").Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if len(body) != 2 || body[0]["clOrdId"] != "c1" || body[1]["clOrdId"] != "c3" || body[1]["side"] != "sell" {
+		t.Errorf("批量下单参数错误: %+v", body)
+	}
+}
+
+// TestCancelOrders 批量撤单按批量上限分批发送
+// go test -v ./impl/okx -run "^TestCancelOrders$"
+func TestCancelOrders(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"POST /api/v5/trade/cancel-batch-orders": `[{"ordId":"1","sCode":"0","sMsg":""}]`,
+	})
+
+	orderIDs := make([]string, BatchOrderLimit+1)
+	for i := range orderIDs {
+		orderIDs[i] = "1"
+	}
+	results, err := o.CancelOrders(context.Background(), exchange.MarketFutures, "BTCUSDT", orderIDs)
+	if err != nil {
+		t.Fatalf("批量撤单失败: %v", err)
+	}
+
+	// 测试服务器每批只返回一个结果，第一批结果数量不一致全部失败，第二批成功
+	for i := 0; i < BatchOrderLimit; i++ {
+		if results[i].Err == nil {
+			t.Fatalf("第 %d 个订单应失败", i+1)
+		}
+	}
+	last := results[BatchOrderLimit]
+	if last.Err != nil || last.Order.OrderID != "1" || last.Order.Status != exchange.OrderStatusCanceled {
+		t.Errorf("最后一个订单结果错误: %+v, %v", last.Order, last.Err)
+	}
+	if len(ts.requests) != 2 {
+		t.Errorf("应分 2 批撤单: %d", len(ts.requests))
+	}
+
+	var body []map[string]string
+	if err := json.Unmarshal([]byte(ts.requests[1].Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if len(body) != 1 || body[0]["instId"] != "BTC-USDT-SWAP" || body[0]["ordId"] != "1" {
+		t.Errorf("批量撤单参数错误: %+v", body)
+	}
+}
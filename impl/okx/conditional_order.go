@@ -0,0 +1,282 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+// 查询未触发策略委托单时使用的订单类型，conditional 与 oco 可合并查询
+var algoOrdTypes = []string{"conditional,oco", "move_order_stop"}
+
+// PlaceConditionalOrder 下条件单，使用策略委托：止损止盈为 conditional，跟踪止损为 move_order_stop，OCO 为 oco
+func (o *okx) PlaceConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	instId, instType := formatSpotInstId(req.Symbol), InstTypeSpot
+	if req.Market == exchange.MarketFutures {
+		instId, instType = formatSwapInstId(req.Symbol), InstTypeSwap
+	}
+	spec, err := o.getInstrumentSpec(ctx, instType, instId)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+
+	// 合约张数 = 数量 / 合约面值
+	quantityDec, err := exchange.ParseDecimal(req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("无效的数量: %s", req.Quantity)
+	}
+	ctVal := ""
+	if req.Market == exchange.MarketFutures {
+		ctValDec, err := exchange.ParseDecimal(spec.CtVal)
+		if err != nil || ctValDec.Sign() <= 0 {
+			return nil, fmt.Errorf("无效的合约面值: %s", spec.CtVal)
+		}
+		ctVal, quantityDec = spec.CtVal, quantityDec.Div(ctValDec)
+	}
+	size, err := o.filtersSize(spec, quantityDec)
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+
+	clientOrderID := idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	params := map[string]string{
+		"instId":      instId,
+		"tdMode":      "cash",
+		"side":        strings.ToLower(string(req.Side)),
+		"sz":          size,
+		"algoClOrdId": clientOrderID,
+	}
+	if req.Market == exchange.MarketFutures {
+		params["tdMode"] = o.getTdMode(instId)
+		switch req.PositionSide {
+		case exchange.PositionSideLong:
+			params["posSide"] = "long"
+		case exchange.PositionSideShort:
+			params["posSide"] = "short"
+		}
+		if req.ReduceOnly {
+			params["reduceOnly"] = "true"
+		}
+	} else {
+		params["tgtCcy"] = "base_ccy"
+	}
+
+	// 委托价 -1 表示市价
+	ordPx := req.Price
+	if !req.Type.IsLimit() {
+		ordPx = "-1"
+	}
+	switch req.Type {
+	case exchange.ConditionalOrderTypeStopMarket, exchange.ConditionalOrderTypeStopLimit:
+		params["ordType"] = "conditional"
+		params["slTriggerPx"], params["slOrdPx"] = req.TriggerPrice, ordPx
+	case exchange.ConditionalOrderTypeTakeProfitMarket, exchange.ConditionalOrderTypeTakeProfitLimit:
+		params["ordType"] = "conditional"
+		params["tpTriggerPx"], params["tpOrdPx"] = req.TriggerPrice, ordPx
+	case exchange.ConditionalOrderTypeTrailingStop:
+		// 回调幅度百分比转换为比例
+		params["ordType"] = "move_order_stop"
+		params["callbackRatio"] = exchange.ToDecimal(req.CallbackRate).Div(exchange.NewDecimalFromInt(100)).String()
+		if req.TriggerPrice != "" {
+			params["activePx"] = req.TriggerPrice
+		}
+	case exchange.ConditionalOrderTypeOCO:
+		// 止盈按委托价触发并挂限价单，止损触发后按止损委托价或市价下单
+		params["ordType"] = "oco"
+		params["tpTriggerPx"], params["tpOrdPx"] = req.Price, req.Price
+		params["slTriggerPx"], params["slOrdPx"] = req.TriggerPrice, "-1"
+		if req.StopLimitPrice != "" {
+			params["slOrdPx"] = req.StopLimitPrice
+		}
+	}
+
+	resp, err := o.authRequest(ctx, "POST", "/api/v5/trade/order-algo", nil, params)
+	if err != nil {
+		return nil, fmt.Errorf("下条件单失败: %w", err)
+	}
+	var results []okxOrderResult
+	if err := json.Unmarshal(resp, &results); err != nil {
+		return nil, fmt.Errorf("unmarshal algo order result error: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("下条件单结果为空")
+	}
+	if results[0].SCode != "0" {
+		return nil, fmt.Errorf("下条件单失败: %w", newAPIError(results[0].SCode, results[0].SMsg))
+	}
+
+	quantity := size
+	if ctVal != "" {
+		quantity = mulDecimal(size, ctVal)
+	}
+	return &exchange.ConditionalOrder{
+		ID:             results[0].AlgoId,
+		ClientOrderID:  clientOrderID,
+		Market:         req.Market,
+		Symbol:         instId,
+		Side:           req.Side,
+		Type:           req.Type,
+		Status:         exchange.ConditionalOrderStatusUntriggered,
+		Quantity:       quantity,
+		TriggerPrice:   req.TriggerPrice,
+		Price:          req.Price,
+		StopLimitPrice: req.StopLimitPrice,
+		CallbackRate:   req.CallbackRate,
+	}, nil
+}
+
+// ListConditionalOrders 获取交易对未触发的条件单
+func (o *okx) ListConditionalOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.ConditionalOrder, error) {
+	instId, instType := formatSpotInstId(symbol), InstTypeSpot
+	ctVal := ""
+	if market == exchange.MarketFutures {
+		instId, instType = formatSwapInstId(symbol), InstTypeSwap
+		spec, err := o.getInstrumentSpec(ctx, instType, instId)
+		if err != nil {
+			return nil, err
+		}
+		ctVal = spec.CtVal
+	}
+
+	result := make([]*exchange.ConditionalOrder, 0)
+	for _, ordType := range algoOrdTypes {
+		resp, err := o.authRequest(ctx, "GET", "/api/v5/trade/orders-algo-pending", map[string]string{
+			"instType": instType,
+			"instId":   instId,
+			"ordType":  ordType,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("获取 %s 策略委托单失败: %w", instId, err)
+		}
+
+		var algoOrders []*okxAlgoOrder
+		if err := json.Unmarshal(resp, &algoOrders); err != nil {
+			return nil, fmt.Errorf("unmarshal algo orders error: %w", err)
+		}
+		for _, algoOrder := range algoOrders {
+			result = append(result, toConditionalOrder(market, algoOrder, ctVal))
+		}
+	}
+	return result, nil
+}
+
+// GetConditionalOrder 获取策略委托单
+func (o *okx) GetConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) (*exchange.ConditionalOrder, error) {
+	ctVal := ""
+	if market == exchange.MarketFutures {
+		spec, err := o.getInstrumentSpec(ctx, InstTypeSwap, formatSwapInstId(symbol))
+		if err != nil {
+			return nil, err
+		}
+		ctVal = spec.CtVal
+	}
+
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/trade/order-algo", map[string]string{"algoId": id}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取策略委托单失败: %w", err)
+	}
+	var algoOrders []*okxAlgoOrder
+	if err := json.Unmarshal(resp, &algoOrders); err != nil {
+		return nil, fmt.Errorf("unmarshal algo orders error: %w", err)
+	}
+	if len(algoOrders) == 0 {
+		return nil, fmt.Errorf("%w: 策略委托单 %s", exchange.ErrOrderNotFound, id)
+	}
+	return toConditionalOrder(market, algoOrders[0], ctVal), nil
+}
+
+// CancelConditionalOrder 撤销未触发的条件单
+func (o *okx) CancelConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) error {
+	instId := formatSpotInstId(symbol)
+	if market == exchange.MarketFutures {
+		instId = formatSwapInstId(symbol)
+	}
+	resp, err := o.authRequest(ctx, "POST", "/api/v5/trade/cancel-algos", nil, []map[string]string{
+		{"algoId": id, "instId": instId},
+	})
+	if err != nil {
+		return fmt.Errorf("撤销条件单失败: %w", err)
+	}
+
+	var results []okxOrderResult
+	if err := json.Unmarshal(resp, &results); err != nil {
+		return fmt.Errorf("unmarshal cancel algo result error: %w", err)
+	}
+	if len(results) > 0 && results[0].SCode != "0" {
+		return fmt.Errorf("撤销条件单失败: %w", newAPIError(results[0].SCode, results[0].SMsg))
+	}
+	return nil
+}
+
+// toConditionalOrder 转换策略委托单，ctVal 为合约面值，现货传空字符串
+func toConditionalOrder(market exchange.Market, order *okxAlgoOrder, ctVal string) *exchange.ConditionalOrder {
+	quantity := order.Sz
+	if ctVal != "" {
+		quantity = mulDecimal(order.Sz, ctVal)
+	}
+	createTime, _ := strconv.ParseInt(order.CTime, 10, 64)
+	updateTime, _ := strconv.ParseInt(order.UTime, 10, 64)
+
+	conditional := &exchange.ConditionalOrder{
+		ID:            order.AlgoId,
+		ClientOrderID: order.AlgoClOrdId,
+		Market:        market,
+		Symbol:        order.InstId,
+		Side:          exchange.OrderSide(strings.ToUpper(order.Side)),
+		Status:        toConditionalStatus(order.State),
+		Quantity:      quantity,
+		OrderID:       order.OrdId,
+		CreateTime:    createTime,
+		UpdateTime:    updateTime,
+	}
+	switch {
+	case order.OrdType == "move_order_stop":
+		conditional.Type = exchange.ConditionalOrderTypeTrailingStop
+		conditional.TriggerPrice = order.ActivePx
+		if ratio, err := exchange.ParseDecimal(order.CallbackRatio); err == nil {
+			conditional.CallbackRate = ratio.Mul(exchange.NewDecimalFromInt(100)).String()
+		}
+	case order.OrdType == "oco":
+		conditional.Type = exchange.ConditionalOrderTypeOCO
+		conditional.Price, conditional.TriggerPrice = order.TpOrdPx, order.SlTriggerPx
+		if order.SlOrdPx != "-1" {
+			conditional.StopLimitPrice = order.SlOrdPx
+		}
+	case order.SlTriggerPx != "":
+		conditional.Type = exchange.ConditionalOrderTypeStopMarket
+		conditional.TriggerPrice = order.SlTriggerPx
+		if order.SlOrdPx != "-1" {
+			conditional.Type, conditional.Price = exchange.ConditionalOrderTypeStopLimit, order.SlOrdPx
+		}
+	default:
+		conditional.Type = exchange.ConditionalOrderTypeTakeProfitMarket
+		conditional.TriggerPrice = order.TpTriggerPx
+		if order.TpOrdPx != "-1" {
+			conditional.Type, conditional.Price = exchange.ConditionalOrderTypeTakeProfitLimit, order.TpOrdPx
+		}
+	}
+	return conditional
+}
+
+// toConditionalStatus 转换策略委托单状态
+func toConditionalStatus(state string) exchange.ConditionalOrderStatus {
+	switch state {
+	case "live", "pause":
+		return exchange.ConditionalOrderStatusUntriggered
+	case "effective", "partially_effective":
+		return exchange.ConditionalOrderStatusTriggered
+	case "canceled":
+		return exchange.ConditionalOrderStatusCanceled
+	default:
+		return exchange.ConditionalOrderStatusFailed
+	}
+}
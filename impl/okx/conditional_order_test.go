@@ -0,0 +1,104 @@
+package okx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestPlaceConditionalOrder 合约跟踪止损转换为 move_order_stop 策略委托
+// go test -v ./impl/okx -run "^TestPlaceConditionalOrder$"
+func TestPlaceConditionalOrder(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"POST /api/v5/trade/order-algo":  `[{"algoId":"a1","algoClOrdId":"trail1","sCode":"0","sMsg":""}]`,
+	})
+
+	order, err := o.PlaceConditionalOrder(context.Background(), exchange.ConditionalOrderRequest{
+		Market: exchange.MarketFutures, Symbol: "BTCUSDT", Side: exchange.OrderSideSell, Type: exchange.ConditionalOrderTypeTrailingStop,
+		Quantity: "0.05", CallbackRate: "1.5", TriggerPrice: "110", ReduceOnly: true, ClientOrderID: "trail1",
+	})
+	if err != nil {
+		t.Fatalf("下条件单失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/order-algo").bodyMap(t)
+	if body["ordType"] != "move_order_stop" || body["callbackRatio"] != "0.015" || body["activePx"] != "110" ||
+		body["sz"] != "5" || body["reduceOnly"] != "true" || body["algoClOrdId"] != "trail1" {
+		t.Errorf("下条件单参数错误: %+v", body)
+	}
+	if order.ID != "a1" || order.Status != exchange.ConditionalOrderStatusUntriggered || order.Quantity != "0.05" {
+		t.Errorf("条件单数据错误: %+v", order)
+	}
+}
+
+// TestListConditionalOrders 合并查询止损止盈、OCO 与跟踪止损策略委托并转换类型
+// go test -v ./impl/okx -run "^TestListConditionalOrders$"
+func TestListConditionalOrders(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/trade/orders-algo-pending": `[{"instId":"BTC-USDT","algoId":"a2","algoClOrdId":"sl1","ordType":"conditional","state":"live","side":"sell","sz":"0.01","slTriggerPx":"90","slOrdPx":"89","cTime":"1700000000000","uTime":"1700000000000"},` +
+			`{"instId":"BTC-USDT","algoId":"a3","ordType":"oco","state":"live","side":"sell","sz":"0.01","tpTriggerPx":"110","tpOrdPx":"110","slTriggerPx":"90","slOrdPx":"-1"}]`,
+		"POST /api/v5/trade/cancel-algos": `[{"algoId":"a2","sCode":"0","sMsg":""}]`,
+	})
+
+	orders, err := o.ListConditionalOrders(context.Background(), exchange.MarketSpot, "BTCUSDT")
+	if err != nil {
+		t.Fatalf("获取条件单失败: %v", err)
+	}
+	// 两种订单类型分别查询，测试服务返回相同数据
+	if len(orders) != 4 {
+		t.Fatalf("条件单数量错误: %d", len(orders))
+	}
+	var ordTypes []string
+	for _, r := range ts.requests {
+		if r.Path == "/api/v5/trade/orders-algo-pending" {
+			ordTypes = append(ordTypes, r.Query["ordType"])
+		}
+	}
+	if len(ordTypes) != 2 || ordTypes[0] != "conditional,oco" || ordTypes[1] != "move_order_stop" {
+		t.Errorf("策略委托查询类型错误: %v", ordTypes)
+	}
+	if stop := orders[0]; stop.Type != exchange.ConditionalOrderTypeStopLimit || stop.TriggerPrice != "90" || stop.Price != "89" || stop.ClientOrderID != "sl1" {
+		t.Errorf("限价止损转换错误: %+v", stop)
+	}
+	if oco := orders[1]; oco.Type != exchange.ConditionalOrderTypeOCO || oco.Price != "110" || oco.TriggerPrice != "90" || oco.StopLimitPrice != "" {
+		t.Errorf("OCO 转换错误: %+v", oco)
+	}
+
+	if err := o.CancelConditionalOrder(context.Background(), exchange.MarketSpot, "BTCUSDT", "a2"); err != nil {
+		t.Fatalf("撤销条件单失败: %v", err)
+	}
+}
+
+// TestGetConditionalOrder 按策略委托单ID查询，已触发的合约条件单返回生成的订单ID
+// go test -v ./impl/okx -run "^TestGetConditionalOrder$"
+func TestGetConditionalOrder(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"GET /api/v5/trade/order-algo": `[{"instId":"BTC-USDT-SWAP","algoId":"a4","algoClOrdId":"tp1","ordType":"conditional","state":"effective","side":"sell",` +
+			`"sz":"5","tpTriggerPx":"110","tpOrdPx":"-1","ordId":"3001","cTime":"1700000000000","uTime":"1700000001000"}]`,
+	})
+
+	order, err := o.GetConditionalOrder(context.Background(), exchange.MarketFutures, "BTCUSDT", "a4")
+	if err != nil {
+		t.Fatalf("获取条件单失败: %v", err)
+	}
+	if req := ts.findRequest("GET", "/api/v5/trade/order-algo"); req.Query["algoId"] != "a4" {
+		t.Errorf("查询参数错误: %+v", req.Query)
+	}
+	if order.Type != exchange.ConditionalOrderTypeTakeProfitMarket || order.Status != exchange.ConditionalOrderStatusTriggered ||
+		order.OrderID != "3001" || order.Quantity != "0.05" || order.TriggerPrice != "110" {
+		t.Errorf("条件单数据错误: %+v", order)
+	}
+
+	o, _ = newTestOKX(t, map[string]string{
+		"GET /api/v5/trade/order-algo": `[{"instId":"BTC-USDT","algoId":"a5","ordType":"conditional","state":"order_failed","side":"buy","sz":"0.01","slTriggerPx":"110","slOrdPx":"-1"}]`,
+	})
+	order, err = o.GetConditionalOrder(context.Background(), exchange.MarketSpot, "BTCUSDT", "a5")
+	if err != nil {
+		t.Fatalf("获取条件单失败: %v", err)
+	}
+	if order.Status != exchange.ConditionalOrderStatusFailed || order.Type != exchange.ConditionalOrderTypeStopMarket {
+		t.Errorf("条件单数据错误: %+v", order)
+	}
+}
@@ -0,0 +1,57 @@
+package okx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/utils"
+)
+
+// errorKinds OKX 错误码对应的错误分类
+var errorKinds = map[string]error{
+	"50001": exchange.ErrMaintenance,         // 服务暂时不可用
+	"50004": exchange.ErrTemporary,           // 接口请求超时，不代表请求成功或失败
+	"50013": exchange.ErrTemporary,           // 系统繁忙
+	"50026": exchange.ErrTemporary,           // 系统错误
+	"50011": exchange.ErrRateLimited,         // 请求频率过高
+	"50061": exchange.ErrRateLimited,         // 子账户请求频率过高
+	"50102": exchange.ErrTimestampSkew,       // 请求时间戳过期
+	"50112": exchange.ErrTimestampSkew,       // 无效的 OK-ACCESS-TIMESTAMP
+	"50105": exchange.ErrAuth,                // Passphrase 错误
+	"50110": exchange.ErrAuth,                // IP 不在白名单
+	"50111": exchange.ErrAuth,                // 无效的 OK-ACCESS-KEY
+	"50113": exchange.ErrAuth,                // 无效的签名
+	"50114": exchange.ErrAuth,                // 无效的授权
+	"51008": exchange.ErrInsufficientBalance, // 可用余额不足
+	"51119": exchange.ErrInsufficientBalance, // 保证金不足
+	"51131": exchange.ErrInsufficientBalance, // 余额不足
+	"51016": exchange.ErrDuplicateOrder,      // 客户端订单ID重复
+	"51020": exchange.ErrMinNotional,         // 下单数量小于最小数量
+	"51121": exchange.ErrInvalidPrecision,    // 下单数量不是下单精度的整数倍
+	"51400": exchange.ErrOrderNotFound,       // 撤单失败，订单已成交、已撤销或不存在
+	"51603": exchange.ErrOrderNotFound,       // 订单不存在
+	"60004": exchange.ErrTimestampSkew,       // Websocket 登录时间戳无效
+	"60005": exchange.ErrAuth,                // Websocket 登录 apiKey 无效
+	"60006": exchange.ErrTimestampSkew,       // Websocket 登录时间戳过期
+	"60007": exchange.ErrAuth,                // Websocket 登录签名无效
+	"60009": exchange.ErrAuth,                // Websocket 登录失败
+}
+
+// newAPIError 根据 OKX 错误码创建 *exchange.APIError，code 为 sCode 或 code
+func newAPIError(code, message string) error {
+	return exchange.NewAPIError(errorKinds[code], code, message, nil)
+}
+
+// decodeResponse 解析接口响应，网关返回的 5xx 响应与无法解析的响应体按临时错误返回，请求结果未知；网络错误原样返回
+func decodeResponse(httpResp *utils.HTTPResponse, resp *okxResp) error {
+	if httpResp.Error != nil {
+		return httpResp.Error
+	}
+	err := json.Unmarshal(httpResp.Body, resp)
+	if err == nil && (resp.Code != "" || httpResp.StatusCode < http.StatusInternalServerError) {
+		return nil
+	}
+	return exchange.NewAPIError(exchange.ErrTemporary, strconv.Itoa(httpResp.StatusCode), string(httpResp.Body), err)
+}
@@ -0,0 +1,45 @@
+package okx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+	"github.com/so68/exchange-lib/internal/utils"
+)
+
+// TestDecodeResponse 网关返回的 5xx 响应与无法解析的响应体为临时错误，带错误码的响应按错误码处理
+// go test -v ./impl/okx -run "^TestDecodeResponse$"
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		status    int
+		body      string
+		temporary bool
+		code      string
+	}{
+		{502, "<html><body>502 Bad Gateway</body></html>", true, ""},
+		{504, `{"message":"gateway timeout"}`, true, ""},
+		{200, "<html></html>", true, ""},
+		{503, `{"code":"50001","msg":"Service temporarily unavailable","data":[]}`, false, "50001"},
+		{200, `{"code":"0","msg":"","data":[]}`, false, "0"},
+	}
+	for _, tt := range tests {
+		var resp okxResp
+		err := decodeResponse(&utils.HTTPResponse{StatusCode: tt.status, Body: []byte(tt.body)}, &resp)
+		if tt.temporary {
+			if !errors.Is(err, exchange.ErrTemporary) || !idempotent.IsTemporary(err) {
+				t.Errorf("%d %s 应为临时错误: %v", tt.status, tt.body, err)
+			}
+			continue
+		}
+		if err != nil || resp.Code != tt.code {
+			t.Errorf("%d %s 解析错误: %v %+v", tt.status, tt.body, err, resp)
+		}
+	}
+
+	cause := errors.New("request error")
+	if err := decodeResponse(&utils.HTTPResponse{Error: cause}, &okxResp{}); err != cause {
+		t.Errorf("网络错误应原样返回: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// ListMyTrades 按时间倒序分页查询近三个月的成交明细，游标为上一页最后一条明细的账单ID，合约张数按合约面值转换
+func (o *okx) ListMyTrades(ctx context.Context, market exchange.Market, query exchange.FillQuery) (*exchange.FillPage, error) {
+	instType, instId, err := toInstId(market, query.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	ctVal := ""
+	if instType == InstTypeSwap {
+		spec, err := o.getInstrumentSpec(ctx, instType, instId)
+		if err != nil {
+			return nil, err
+		}
+		ctVal = spec.CtVal
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > OrderListLimit {
+		limit = OrderListLimit
+	}
+	params := map[string]string{
+		"instType": instType,
+		"instId":   instId,
+		"ordId":    query.OrderID,
+		"limit":    strconv.Itoa(limit),
+		"after":    query.Cursor,
+	}
+	if !query.StartTime.IsZero() {
+		params["begin"] = strconv.FormatInt(query.StartTime.UnixMilli(), 10)
+	}
+	if !query.EndTime.IsZero() {
+		params["end"] = strconv.FormatInt(query.EndTime.UnixMilli(), 10)
+	}
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/trade/fills-history", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取成交明细失败: %w", err)
+	}
+	var fills []*okxFill
+	if err := json.Unmarshal(resp, &fills); err != nil {
+		return nil, fmt.Errorf("unmarshal fills error: %w", err)
+	}
+
+	page := &exchange.FillPage{Fills: make([]*exchange.Fill, 0, len(fills))}
+	for _, fill := range fills {
+		page.Fills = append(page.Fills, toExchangeFill(fill, market, ctVal))
+	}
+	if len(fills) >= limit {
+		page.NextCursor = fills[len(fills)-1].BillId
+	}
+	return page, nil
+}
+
+// toExchangeFill 转换成交明细，OKX 手续费负数表示扣除，转换为正数表示支出；ctVal 为合约面值，现货传空字符串
+func toExchangeFill(fill *okxFill, market exchange.Market, ctVal string) *exchange.Fill {
+	quantity := fill.FillSz
+	realizedPnl := ""
+	if ctVal != "" {
+		quantity = mulDecimal(fill.FillSz, ctVal)
+		realizedPnl = fill.FillPnl
+	}
+	fee := fill.Fee
+	if value, err := decimal.NewFromString(fill.Fee); err == nil {
+		fee = value.Neg().String()
+	}
+	fillTime, _ := strconv.ParseInt(fill.Ts, 10, 64)
+	return &exchange.Fill{
+		Market:      market,
+		Symbol:      fill.InstId,
+		OrderID:     fill.OrdId,
+		TradeID:     fill.TradeId,
+		Side:        exchange.OrderSide(strings.ToUpper(fill.Side)),
+		Price:       fill.FillPx,
+		Quantity:    quantity,
+		Fee:         fee,
+		FeeAsset:    fill.FeeCcy,
+		IsMaker:     fill.ExecType == "M",
+		RealizedPnl: realizedPnl,
+		Time:        fillTime,
+	}
+}
@@ -0,0 +1,39 @@
+package okx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestListMyTrades 合约成交数量按合约面值转换，手续费转换为正数表示支出，以最后一条账单ID作为下一页游标
+// go test -v ./impl/okx -run "^TestListMyTrades$"
+func TestListMyTrades(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"GET /api/v5/trade/fills-history": `[
+			{"instType":"SWAP","instId":"BTC-USDT-SWAP","tradeId":"t2","ordId":"3002","billId":"b2","fillPx":"101","fillSz":"5","fillPnl":"0.05","side":"sell","execType":"M","fee":"0.001","feeCcy":"USDT","ts":"1700000002000"},
+			{"instType":"SWAP","instId":"BTC-USDT-SWAP","tradeId":"t1","ordId":"3001","billId":"b1","fillPx":"100","fillSz":"5","fillPnl":"0","side":"buy","execType":"T","fee":"-0.0025","feeCcy":"USDT","ts":"1700000001000"}
+		]`,
+	})
+
+	page, err := o.ListMyTrades(context.Background(), exchange.MarketFutures, exchange.FillQuery{Symbol: "BTCUSDT", Limit: 2})
+	if err != nil {
+		t.Fatalf("获取成交明细失败: %v", err)
+	}
+	if len(page.Fills) != 2 || page.NextCursor != "b1" {
+		t.Fatalf("成交明细分页错误: %+v", page)
+	}
+	maker, taker := page.Fills[0], page.Fills[1]
+	if maker.Quantity != "0.05" || maker.Fee != "-0.001" || !maker.IsMaker || maker.RealizedPnl != "0.05" || maker.Side != exchange.OrderSideSell {
+		t.Errorf("挂单成交数据错误: %+v", maker)
+	}
+	if taker.OrderID != "3001" || taker.TradeID != "t1" || taker.Fee != "0.0025" || taker.IsMaker || taker.Time != 1700000001000 {
+		t.Errorf("吃单成交数据错误: %+v", taker)
+	}
+	query := ts.findRequest("GET", "/api/v5/trade/fills-history").Query
+	if query["instType"] != "SWAP" || query["instId"] != "BTC-USDT-SWAP" || query["limit"] != "2" || query["after"] != "" {
+		t.Errorf("查询参数错误: %+v", query)
+	}
+}
@@ -0,0 +1,144 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	FundingRateHistoryLimit = 100 // 历史资金费率每次最大数量
+)
+
+// GetFundingRate 获取合约当前资金费率，fundingTime 为本期资金费结算时间
+func (o *okx) GetFundingRate(ctx context.Context, symbol string) (*exchange.FundingRate, error) {
+	resp, err := o.publicRequest(ctx, "/api/v5/public/funding-rate", map[string]string{
+		"instId": formatSwapInstId(symbol),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取资金费率失败: %w", err)
+	}
+	var rates []*okxFundingRate
+	if err := json.Unmarshal(resp, &rates); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate error: %w", err)
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("资金费率为空: %s", symbol)
+	}
+	fundingTime, _ := strconv.ParseInt(rates[0].FundingTime, 10, 64)
+	return &exchange.FundingRate{
+		Symbol:      rates[0].InstId,
+		FundingRate: rates[0].FundingRate,
+		FundingTime: fundingTime,
+	}, nil
+}
+
+// GetFundingRateHistory 获取合约历史资金费率，接口按时间倒序返回，转换为升序，费率使用实际结算费率
+func (o *okx) GetFundingRateHistory(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.FundingRate, error) {
+	if limit <= 0 || limit > FundingRateHistoryLimit {
+		limit = FundingRateHistoryLimit
+	}
+	params := map[string]string{
+		"instId": formatSwapInstId(symbol),
+		"limit":  strconv.Itoa(limit),
+	}
+	// before/after 不包含边界，向外扩展 1 毫秒
+	if !start.IsZero() {
+		params["before"] = strconv.FormatInt(start.UnixMilli()-1, 10)
+	}
+	if !end.IsZero() {
+		params["after"] = strconv.FormatInt(end.UnixMilli()+1, 10)
+	}
+	resp, err := o.publicRequest(ctx, "/api/v5/public/funding-rate-history", params)
+	if err != nil {
+		return nil, fmt.Errorf("获取历史资金费率失败: %w", err)
+	}
+	var rates []*okxFundingRate
+	if err := json.Unmarshal(resp, &rates); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate history error: %w", err)
+	}
+
+	result := make([]*exchange.FundingRate, 0, len(rates))
+	for _, rate := range rates {
+		fundingRate := rate.RealizedRate
+		if fundingRate == "" {
+			fundingRate = rate.FundingRate
+		}
+		fundingTime, _ := strconv.ParseInt(rate.FundingTime, 10, 64)
+		result = append(result, &exchange.FundingRate{
+			Symbol:      rate.InstId,
+			FundingRate: fundingRate,
+			FundingTime: fundingTime,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FundingTime < result[j].FundingTime
+	})
+	return result, nil
+}
+
+// GetMarkPrice 获取合约标记价格与指数价格，symbols 为空时返回全部 USDT 结算永续合约；
+// OKX 标记价格接口不包含资金费率，需使用 GetFundingRate 查询
+func (o *okx) GetMarkPrice(ctx context.Context, symbols ...string) ([]*exchange.MarkPrice, error) {
+	markParams := map[string]string{"instType": InstTypeSwap}
+	indexParams := map[string]string{"quoteCcy": Settle}
+	if len(symbols) == 1 {
+		instId := formatSwapInstId(symbols[0])
+		markParams["instId"] = instId
+		indexParams = map[string]string{"instId": toIndexId(instId)}
+	}
+
+	resp, err := o.publicRequest(ctx, "/api/v5/public/mark-price", markParams)
+	if err != nil {
+		return nil, fmt.Errorf("获取标记价格失败: %w", err)
+	}
+	var markPrices []*okxMarkPrice
+	if err := json.Unmarshal(resp, &markPrices); err != nil {
+		return nil, fmt.Errorf("unmarshal mark price error: %w", err)
+	}
+	resp, err = o.publicRequest(ctx, "/api/v5/market/index-tickers", indexParams)
+	if err != nil {
+		return nil, fmt.Errorf("获取指数价格失败: %w", err)
+	}
+	var indexTickers []*okxIndexTicker
+	if err := json.Unmarshal(resp, &indexTickers); err != nil {
+		return nil, fmt.Errorf("unmarshal index tickers error: %w", err)
+	}
+	indexPrices := make(map[string]string, len(indexTickers))
+	for _, ticker := range indexTickers {
+		indexPrices[ticker.InstId] = ticker.IdxPx
+	}
+
+	wanted := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		wanted[formatSwapInstId(symbol)] = struct{}{}
+	}
+	result := make([]*exchange.MarkPrice, 0, len(markPrices))
+	for _, markPrice := range markPrices {
+		if _, ok := wanted[markPrice.InstId]; len(wanted) > 0 && !ok {
+			continue
+		}
+		if len(wanted) == 0 && !strings.HasSuffix(markPrice.InstId, "-"+Settle+"-"+InstTypeSwap) {
+			continue
+		}
+		ts, _ := strconv.ParseInt(markPrice.Ts, 10, 64)
+		result = append(result, &exchange.MarkPrice{
+			Symbol:     markPrice.InstId,
+			MarkPrice:  markPrice.MarkPx,
+			IndexPrice: indexPrices[toIndexId(markPrice.InstId)],
+			Time:       ts,
+		})
+	}
+	return result, nil
+}
+
+// toIndexId 永续合约产品ID转换为指数ID，如 BTC-USDT-SWAP -> BTC-USDT
+func toIndexId(instId string) string {
+	return strings.TrimSuffix(instId, "-"+InstTypeSwap)
+}
@@ -0,0 +1,53 @@
+package okx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestGetFundingRateHistory 历史资金费率按结算时间升序，时间范围转换为不含边界的分页参数
+// go test -v ./impl/okx -run "^TestGetFundingRateHistory$"
+func TestGetFundingRateHistory(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/funding-rate-history": `[
+			{"instId":"BTC-USDT-SWAP","fundingRate":"0.0002","realizedRate":"0.00019","fundingTime":"1700028800000","method":"current_period"},
+			{"instId":"BTC-USDT-SWAP","fundingRate":"0.0001","realizedRate":"0.0001","fundingTime":"1700000000000","method":"current_period"}
+		]`,
+	})
+
+	rates, err := o.GetFundingRateHistory(context.Background(), "BTCUSDT", time.UnixMilli(1700000000000), time.UnixMilli(1700028800000), 0)
+	if err != nil {
+		t.Fatalf("获取历史资金费率失败: %v", err)
+	}
+	if len(rates) != 2 || rates[0].FundingTime != 1700000000000 || rates[1].FundingRate != "0.00019" {
+		t.Errorf("历史资金费率错误: %+v %+v", rates[0], rates[1])
+	}
+	query := ts.findRequest("GET", "/api/v5/public/funding-rate-history").Query
+	if query["instId"] != "BTC-USDT-SWAP" || query["before"] != "1699999999999" || query["after"] != "1700028800001" || query["limit"] != "100" {
+		t.Errorf("查询参数错误: %+v", query)
+	}
+}
+
+// TestGetMarkPrice 标记价格按指数ID合并指数价格，未指定交易对时只返回 USDT 结算永续合约
+// go test -v ./impl/okx -run "^TestGetMarkPrice$"
+func TestGetMarkPrice(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/mark-price": `[
+			{"instType":"SWAP","instId":"BTC-USDT-SWAP","markPx":"100.5","ts":"1700000000000"},
+			{"instType":"SWAP","instId":"BTC-USD-SWAP","markPx":"100.4","ts":"1700000000000"}
+		]`,
+		"GET /api/v5/market/index-tickers": `[{"instId":"BTC-USDT","idxPx":"100.2","ts":"1700000000000"}]`,
+	})
+
+	markPrices, err := o.GetMarkPrice(context.Background())
+	if err != nil {
+		t.Fatalf("获取标记价格失败: %v", err)
+	}
+	if len(markPrices) != 1 || markPrices[0].Symbol != "BTC-USDT-SWAP" || markPrices[0].MarkPrice != "100.5" || markPrices[0].IndexPrice != "100.2" || markPrices[0].Time != 1700000000000 {
+		t.Errorf("标记价格错误: %+v", markPrices)
+	}
+	if query := ts.findRequest("GET", "/api/v5/market/index-tickers").Query; query["quoteCcy"] != "USDT" {
+		t.Errorf("指数查询参数错误: %+v", query)
+	}
+}
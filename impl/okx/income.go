@@ -0,0 +1,107 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	BillListLimit = 100 // 账单流水每页最大数量
+)
+
+// billTypes 统一流水类型对应的账单类型，一对多的类型不按类型查询，在本地过滤
+var billTypes = map[exchange.IncomeType]string{
+	exchange.IncomeTypeTransfer:       "1",
+	exchange.IncomeTypeCommission:     "2",
+	exchange.IncomeTypeLiquidationFee: "5",
+	exchange.IncomeTypeFundingFee:     "8",
+}
+
+// GetIncomeHistory 按时间倒序分页查询近七天 USDT 的账单流水，游标为上一页最后一条账单ID。
+// 只返回永续合约与划转等与产品无关的账单；交易、强平与自动减仓账单拆分为已实现盈亏与手续费两条流水
+func (o *okx) GetIncomeHistory(ctx context.Context, query exchange.IncomeQuery) (*exchange.IncomePage, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > BillListLimit {
+		limit = BillListLimit
+	}
+	params := map[string]string{
+		"ccy":   Settle,
+		"type":  billTypes[query.Type],
+		"limit": strconv.Itoa(limit),
+		"after": query.Cursor,
+	}
+	if query.Symbol != "" {
+		params["instType"] = InstTypeSwap
+		params["instId"] = formatSwapInstId(query.Symbol)
+	}
+	if !query.StartTime.IsZero() {
+		params["begin"] = strconv.FormatInt(query.StartTime.UnixMilli(), 10)
+	}
+	if !query.EndTime.IsZero() {
+		params["end"] = strconv.FormatInt(query.EndTime.UnixMilli(), 10)
+	}
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/account/bills", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取资金流水失败: %w", err)
+	}
+	var bills []*okxBill
+	if err := json.Unmarshal(resp, &bills); err != nil {
+		return nil, fmt.Errorf("unmarshal bills error: %w", err)
+	}
+
+	page := &exchange.IncomePage{Incomes: make([]*exchange.Income, 0, len(bills))}
+	for _, bill := range bills {
+		if bill.InstType != "" && bill.InstType != InstTypeSwap {
+			continue
+		}
+		for _, income := range toIncomes(bill) {
+			if query.Type == "" || income.Type == query.Type {
+				page.Incomes = append(page.Incomes, income)
+			}
+		}
+	}
+	if len(bills) >= limit {
+		page.NextCursor = bills[len(bills)-1].BillId
+	}
+	return page, nil
+}
+
+// toIncomes 转换账单流水，交易类账单的收益为已实现盈亏、手续费为交易或强平手续费，收益为 0 时不返回已实现盈亏
+func toIncomes(bill *okxBill) []*exchange.Income {
+	ts, _ := strconv.ParseInt(bill.Ts, 10, 64)
+	newIncome := func(incomeType exchange.IncomeType, amount string) *exchange.Income {
+		return &exchange.Income{
+			ID:      bill.BillId,
+			Symbol:  bill.InstId,
+			Type:    incomeType,
+			RawType: bill.Type,
+			Amount:  amount,
+			Asset:   bill.Ccy,
+			TradeID: bill.TradeId,
+			Time:    ts,
+		}
+	}
+
+	switch bill.Type {
+	case "1":
+		return []*exchange.Income{newIncome(exchange.IncomeTypeTransfer, bill.BalChg)}
+	case "8":
+		return []*exchange.Income{newIncome(exchange.IncomeTypeFundingFee, bill.BalChg)}
+	case "2", "5", "9":
+		feeType := exchange.IncomeTypeCommission
+		if bill.Type == "5" {
+			feeType = exchange.IncomeTypeLiquidationFee
+		}
+		incomes := make([]*exchange.Income, 0, 2)
+		if !exchange.ToDecimal(bill.Pnl).IsZero() {
+			incomes = append(incomes, newIncome(exchange.IncomeTypeRealizedPnl, bill.Pnl))
+		}
+		return append(incomes, newIncome(feeType, bill.Fee))
+	default:
+		return []*exchange.Income{newIncome(exchange.IncomeTypeOther, bill.BalChg)}
+	}
+}
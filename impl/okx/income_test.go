@@ -0,0 +1,58 @@
+package okx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestGetIncomeHistory 交易账单拆分为已实现盈亏与手续费，忽略非永续合约账单，以最后一条账单ID作为下一页游标
+// go test -v ./impl/okx -run "^TestGetIncomeHistory$"
+func TestGetIncomeHistory(t *testing.T) {
+	bills := `[
+		{"billId":"b4","instType":"SWAP","instId":"BTC-USDT-SWAP","ccy":"USDT","type":"2","subType":"6","balChg":"1.49","pnl":"1.5","fee":"-0.01","tradeId":"t1","ts":"1700000004000"},
+		{"billId":"b3","instType":"SWAP","instId":"BTC-USDT-SWAP","ccy":"USDT","type":"8","subType":"173","balChg":"-0.02","pnl":"0","fee":"0","ts":"1700000003000"},
+		{"billId":"b2","instType":"SPOT","instId":"BTC-USDT","ccy":"USDT","type":"2","subType":"1","balChg":"-100","pnl":"0","fee":"-0.1","ts":"1700000002000"},
+		{"billId":"b1","instType":"","instId":"","ccy":"USDT","type":"1","subType":"11","balChg":"100","pnl":"0","fee":"0","ts":"1700000001000"}
+	]`
+	o, ts := newTestOKX(t, map[string]string{"GET /api/v5/account/bills": bills})
+
+	page, err := o.GetIncomeHistory(context.Background(), exchange.IncomeQuery{Limit: 4})
+	if err != nil {
+		t.Fatalf("获取资金流水失败: %v", err)
+	}
+	if len(page.Incomes) != 4 || page.NextCursor != "b1" {
+		t.Fatalf("资金流水分页错误: %+v", page)
+	}
+	pnl, fee, funding, transfer := page.Incomes[0], page.Incomes[1], page.Incomes[2], page.Incomes[3]
+	if pnl.Type != exchange.IncomeTypeRealizedPnl || pnl.Amount != "1.5" || pnl.ID != "b4" || pnl.TradeID != "t1" || pnl.Symbol != "BTC-USDT-SWAP" {
+		t.Errorf("已实现盈亏错误: %+v", pnl)
+	}
+	if fee.Type != exchange.IncomeTypeCommission || fee.Amount != "-0.01" || fee.Time != 1700000004000 {
+		t.Errorf("手续费错误: %+v", fee)
+	}
+	if funding.Type != exchange.IncomeTypeFundingFee || funding.Amount != "-0.02" {
+		t.Errorf("资金费错误: %+v", funding)
+	}
+	if transfer.Type != exchange.IncomeTypeTransfer || transfer.Amount != "100" || transfer.Symbol != "" || transfer.Asset != "USDT" {
+		t.Errorf("划转错误: %+v", transfer)
+	}
+	query := ts.findRequest("GET", "/api/v5/account/bills").Query
+	if query["ccy"] != "USDT" || query["limit"] != "4" || query["instType"] != "" || query["type"] != "" {
+		t.Errorf("查询参数错误: %+v", query)
+	}
+
+	o, ts = newTestOKX(t, map[string]string{"GET /api/v5/account/bills": bills})
+	page, err = o.GetIncomeHistory(context.Background(), exchange.IncomeQuery{Symbol: "BTCUSDT", Type: exchange.IncomeTypeCommission, Cursor: "b5"})
+	if err != nil {
+		t.Fatalf("获取资金流水失败: %v", err)
+	}
+	if len(page.Incomes) != 1 || page.Incomes[0].Type != exchange.IncomeTypeCommission || page.NextCursor != "" {
+		t.Errorf("按类型过滤错误: %+v", page)
+	}
+	query = ts.findRequest("GET", "/api/v5/account/bills").Query
+	if query["instType"] != "SWAP" || query["instId"] != "BTC-USDT-SWAP" || query["type"] != "2" || query["after"] != "b5" {
+		t.Errorf("查询参数错误: %+v", query)
+	}
+}
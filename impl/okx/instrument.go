@@ -0,0 +1,149 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// ListInstruments 获取市场全部交易对元数据，现货仅包含 USDT 计价产品，合约仅包含 USDT 结算永续合约，OKX 产品信息不包含手续费率
+func (o *okx) ListInstruments(ctx context.Context, market exchange.Market) ([]*exchange.Instrument, error) {
+	instType, err := toInstType(market)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := o.loadInstrumentSpecs(ctx, instType, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var instruments []*exchange.Instrument
+	for _, spec := range specs {
+		if (instType == InstTypeSpot && spec.QuoteCcy != Settle) || (instType == InstTypeSwap && spec.SettleCcy != Settle) {
+			continue
+		}
+		instruments = append(instruments, toInstrument(market, spec))
+	}
+	return instruments, nil
+}
+
+// GetInstrument 获取交易对元数据，symbol 支持 BTCUSDT 与产品ID两种格式
+func (o *okx) GetInstrument(ctx context.Context, market exchange.Market, symbol string) (*exchange.Instrument, error) {
+	instType, err := toInstType(market)
+	if err != nil {
+		return nil, err
+	}
+	instId := formatSpotInstId(symbol)
+	if instType == InstTypeSwap {
+		instId = formatSwapInstId(symbol)
+	}
+	spec, err := o.getInstrumentSpec(ctx, instType, instId)
+	if err != nil {
+		return nil, err
+	}
+	return toInstrument(market, spec), nil
+}
+
+// loadInstrumentSpecs 获取产品规格并写入缓存，instId 为空时获取该类型的全部产品
+func (o *okx) loadInstrumentSpecs(ctx context.Context, instType, instId string) ([]*instrumentSpec, error) {
+	params := map[string]string{"instType": instType}
+	if instId != "" {
+		params["instId"] = instId
+	}
+	resp, err := o.publicRequest(ctx, "/api/v5/public/instruments", params)
+	if err != nil {
+		return nil, fmt.Errorf("获取产品规格失败: %w", err)
+	}
+
+	var instruments []*okxInstrument
+	if err := json.Unmarshal(resp, &instruments); err != nil {
+		return nil, fmt.Errorf("unmarshal instruments data error: %w", err)
+	}
+
+	cache := o.getSpec(ctx, instType)
+	specs := make([]*instrumentSpec, 0, len(instruments))
+	for _, inst := range instruments {
+		spec := &instrumentSpec{
+			InstId:    inst.InstId,
+			InstType:  inst.InstType,
+			BaseCcy:   inst.BaseCcy,
+			QuoteCcy:  inst.QuoteCcy,
+			SettleCcy: inst.SettleCcy,
+			CtVal:     inst.CtVal,
+			TickSz:    inst.TickSz,
+			LotSz:     inst.LotSz,
+			MinSz:     inst.MinSz,
+			MaxLmtSz:  inst.MaxLmtSz,
+			MaxMktSz:  inst.MaxMktSz,
+			Lever:     inst.Lever,
+			State:     inst.State,
+			ExpTime:   inst.ExpTime,
+		}
+		// 永续合约的交易货币与计价货币从产品ID中解析
+		if inst.InstType == InstTypeSwap {
+			parts := strings.Split(inst.InstId, "-")
+			if len(parts) >= 2 {
+				spec.BaseCcy, spec.QuoteCcy = parts[0], parts[1]
+			}
+		}
+		cache.SetInstrumentSpec(inst.InstId, spec)
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// toInstType 市场类型转换为产品类型
+func toInstType(market exchange.Market) (string, error) {
+	switch market {
+	case exchange.MarketSpot:
+		return InstTypeSpot, nil
+	case exchange.MarketFutures:
+		return InstTypeSwap, nil
+	default:
+		return "", fmt.Errorf("不支持的市场类型: %s", market)
+	}
+}
+
+// toInstrument 产品规格转换为交易对元数据，合约数量由张数按合约面值换算
+func toInstrument(market exchange.Market, spec *instrumentSpec) *exchange.Instrument {
+	maxLeverage, _ := strconv.Atoi(spec.Lever)
+	expTime, _ := strconv.ParseInt(spec.ExpTime, 10, 64)
+	instrument := &exchange.Instrument{
+		Market:      market,
+		Symbol:      exchange.NewSpotSymbol(spec.BaseCcy, spec.QuoteCcy).String(),
+		ID:          spec.InstId,
+		Base:        spec.BaseCcy,
+		Quote:       spec.QuoteCcy,
+		TickSize:    spec.TickSz,
+		StepSize:    spec.LotSz,
+		MinQty:      spec.MinSz,
+		MaxQty:      spec.MaxLmtSz,
+		MaxLeverage: maxLeverage,
+		DelistTime:  expTime,
+	}
+	if market == exchange.MarketFutures {
+		instrument.Symbol = exchange.NewPerpetualSymbol(spec.BaseCcy, spec.QuoteCcy, spec.SettleCcy).String()
+		instrument.Settle = spec.SettleCcy
+		instrument.ContractSize = spec.CtVal
+		instrument.StepSize = mulDecimal(spec.LotSz, spec.CtVal)
+		instrument.MinQty = mulDecimal(spec.MinSz, spec.CtVal)
+		instrument.MaxQty = mulDecimal(spec.MaxLmtSz, spec.CtVal)
+	}
+
+	switch spec.State {
+	case "live":
+		instrument.Status = exchange.InstrumentStatusTrading
+		if expTime > 0 {
+			instrument.Status = exchange.InstrumentStatusDelisting
+		}
+	case "preopen", "test":
+		instrument.Status = exchange.InstrumentStatusPreTrading
+	default:
+		instrument.Status = exchange.InstrumentStatusSuspended
+	}
+	return instrument
+}
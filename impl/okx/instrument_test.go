@@ -0,0 +1,79 @@
+package okx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestListInstruments 获取合约全部交易对元数据，数量按合约面值换算，非 USDT 结算合约过滤
+// go test -v ./impl/okx -run "^TestListInstruments$"
+func TestListInstruments(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": `[
+			{"instType":"SWAP","instId":"BTC-USDT-SWAP","settleCcy":"USDT","ctVal":"0.01","ctValCcy":"BTC","tickSz":"0.1","lotSz":"1","minSz":"1","maxLmtSz":"10000","lever":"100","state":"live"},
+			{"instType":"SWAP","instId":"BTC-USD-SWAP","settleCcy":"BTC","ctVal":"100","ctValCcy":"USD","tickSz":"0.1","lotSz":"1","minSz":"1","maxLmtSz":"10000","lever":"100","state":"live"}
+		]`,
+	})
+
+	instruments, err := o.ListInstruments(context.Background(), exchange.MarketFutures)
+	if err != nil {
+		t.Fatalf("获取交易对元数据失败: %v", err)
+	}
+	if query := ts.findRequest("GET", "/api/v5/public/instruments").Query; query["instType"] != InstTypeSwap || query["instId"] != "" {
+		t.Errorf("请求参数错误: %+v", query)
+	}
+	if len(instruments) != 1 {
+		t.Fatalf("交易对数量错误: %d", len(instruments))
+	}
+	got := instruments[0]
+	want := exchange.Instrument{
+		Market:       exchange.MarketFutures,
+		Symbol:       "BTC/USDT:USDT",
+		ID:           "BTC-USDT-SWAP",
+		Base:         "BTC",
+		Quote:        "USDT",
+		Settle:       "USDT",
+		TickSize:     "0.1",
+		StepSize:     "0.01",
+		MinQty:       "0.01",
+		MaxQty:       "100",
+		ContractSize: "0.01",
+		MaxLeverage:  100,
+		Status:       exchange.InstrumentStatusTrading,
+	}
+	if *got != want {
+		t.Errorf("交易对元数据错误: %+v", got)
+	}
+
+	// 列表写入缓存，获取单个交易对不再请求
+	if _, err := o.GetInstrument(context.Background(), exchange.MarketFutures, "BTCUSDT"); err != nil {
+		t.Fatalf("获取交易对元数据失败: %v", err)
+	}
+	if len(ts.requests) != 1 {
+		t.Errorf("请求次数错误: %d", len(ts.requests))
+	}
+}
+
+// TestGetInstrument 获取现货交易对元数据
+// go test -v ./impl/okx -run "^TestGetInstrument$"
+func TestGetInstrument(t *testing.T) {
+	o, _ := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+	})
+
+	instrument, err := o.GetInstrument(context.Background(), exchange.MarketSpot, "BTCUSDT")
+	if err != nil {
+		t.Fatalf("获取交易对元数据失败: %v", err)
+	}
+	if instrument.Symbol != "BTC/USDT" || instrument.ID != "BTC-USDT" || instrument.StepSize != "0.0001" || instrument.MinQty != "0.001" || instrument.ContractSize != "" {
+		t.Errorf("交易对元数据错误: %+v", instrument)
+	}
+	if qty := instrument.FloorQuantity(exchange.ToDecimal("0.01234")); qty.String() != "0.0123" {
+		t.Errorf("数量取整错误: %s", qty)
+	}
+	if _, err := o.GetInstrument(context.Background(), exchange.Market("OPTION"), "BTCUSDT"); err == nil {
+		t.Error("不支持的市场类型应返回错误")
+	}
+}
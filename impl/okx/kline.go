@@ -0,0 +1,124 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/kline"
+)
+
+const (
+	KlinePageSize = 100 // 历史K线单页最大数量
+)
+
+// klineBars K线周期，6 小时及以上使用 UTC 对齐的周期，与其他交易所保持一致
+var klineBars = map[exchange.KlineInterval]string{
+	exchange.KlineInterval1m:  "1m",
+	exchange.KlineInterval3m:  "3m",
+	exchange.KlineInterval5m:  "5m",
+	exchange.KlineInterval15m: "15m",
+	exchange.KlineInterval30m: "30m",
+	exchange.KlineInterval1h:  "1H",
+	exchange.KlineInterval2h:  "2H",
+	exchange.KlineInterval4h:  "4H",
+	exchange.KlineInterval6h:  "6Hutc",
+	exchange.KlineInterval12h: "12Hutc",
+	exchange.KlineInterval1d:  "1Dutc",
+	exchange.KlineInterval3d:  "3Dutc",
+	exchange.KlineInterval1w:  "1Wutc",
+	exchange.KlineInterval1M:  "1Mutc",
+}
+
+// toKlineBar 转换为 OKX K线周期
+func toKlineBar(interval exchange.KlineInterval) (string, error) {
+	bar, ok := klineBars[interval]
+	if !ok {
+		return "", fmt.Errorf("OKX 不支持的K线周期: %s", interval)
+	}
+	return bar, nil
+}
+
+// GetSpotKlines 获取现货K线，从结束时间向前自动分页
+func (o *okx) GetSpotKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	klines, err := o.getKlines(ctx, formatSpotInstId(symbol), interval, start, end, limit, false)
+	if err != nil {
+		return nil, fmt.Errorf("获取现货K线失败: %w", err)
+	}
+	return klines, nil
+}
+
+// GetFuturesKlines 获取合约K线，从结束时间向前自动分页
+func (o *okx) GetFuturesKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	klines, err := o.getKlines(ctx, formatSwapInstId(symbol), interval, start, end, limit, true)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约K线失败: %w", err)
+	}
+	return klines, nil
+}
+
+// getKlines 获取历史K线，接口返回 (before, after) 区间内最新的数据，按时间倒序
+func (o *okx) getKlines(ctx context.Context, instId string, interval exchange.KlineInterval, start, end time.Time, limit int, swap bool) ([]*exchange.Kline, error) {
+	bar, err := toKlineBar(interval)
+	if err != nil {
+		return nil, err
+	}
+	fetch := func(start, end time.Time, limit int) ([]*exchange.Kline, error) {
+		data, err := o.publicRequest(ctx, "/api/v5/market/history-candles", map[string]string{
+			"instId": instId,
+			"bar":    bar,
+			"after":  strconv.FormatInt(end.UnixMilli()+1, 10),
+			"before": strconv.FormatInt(start.UnixMilli()-1, 10),
+			"limit":  strconv.Itoa(limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		var rows [][]string
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("解析K线数据失败: %w", err)
+		}
+		klines := make([]*exchange.Kline, len(rows))
+		for i, row := range rows {
+			k, err := toKline(instId, interval, row, swap)
+			if err != nil {
+				return nil, err
+			}
+			klines[len(rows)-1-i] = k
+		}
+		return klines, nil
+	}
+	return kline.Paginate(fetch, interval, start, end, limit, KlinePageSize, kline.Backward)
+}
+
+// toKline 转换K线 [ts, o, h, l, c, vol, volCcy, volCcyQuote, confirm]
+// 现货 vol 为基础资产数量；合约 vol 为张数，volCcy 为基础资产数量
+func toKline(instId string, interval exchange.KlineInterval, row []string, swap bool) (*exchange.Kline, error) {
+	if len(row) < 9 {
+		return nil, fmt.Errorf("无效的K线数据: %v", row)
+	}
+	openTime, err := strconv.ParseInt(row[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("无效的K线时间: %s", row[0])
+	}
+	volume := row[5]
+	if swap {
+		volume = row[6]
+	}
+	return &exchange.Kline{
+		Symbol:      instId,
+		Interval:    interval,
+		OpenTime:    openTime,
+		CloseTime:   interval.CloseTime(openTime),
+		Open:        row[1],
+		High:        row[2],
+		Low:         row[3],
+		Close:       row[4],
+		Volume:      volume,
+		QuoteVolume: row[7],
+		Closed:      row[8] == "1",
+	}, nil
+}
@@ -0,0 +1,68 @@
+package okx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// 按时间倒序返回的历史K线，最新一根未收盘
+const testKlineData = `[
+	["1700000120000","102","103","101","102.5","30","0.3","30.75","0"],
+	["1700000060000","101","102","100","102","20","0.2","20.4","1"],
+	["1700000000000","100","101","99","101","10","0.1","10.1","1"]
+]`
+
+// TestSpotKlines 获取现货K线，转换为升序并标记收盘状态
+// go test -v ./impl/okx -run "^TestSpotKlines$"
+func TestSpotKlines(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/market/history-candles": testKlineData,
+	})
+
+	end := time.UnixMilli(1700000120000)
+	klines, err := o.GetSpotKlines(context.Background(), "BTCUSDT", exchange.KlineInterval1m, time.Time{}, end, 3)
+	if err != nil {
+		t.Fatalf("获取现货K线失败: %v", err)
+	}
+	req := ts.findRequest("GET", "/api/v5/market/history-candles")
+	if req.Query["instId"] != "BTC-USDT" || req.Query["bar"] != "1m" || req.Query["after"] != "1700000120001" || req.Query["limit"] != "100" {
+		t.Errorf("请求参数错误: %+v", req.Query)
+	}
+	if len(klines) != 3 {
+		t.Fatalf("K线数量错误: %d", len(klines))
+	}
+	first, last := klines[0], klines[2]
+	if first.OpenTime != 1700000000000 || first.CloseTime != 1700000059999 || first.Open != "100" || first.Volume != "10" || first.QuoteVolume != "10.1" || !first.Closed {
+		t.Errorf("第一根K线错误: %+v", first)
+	}
+	if last.OpenTime != 1700000120000 || last.Closed {
+		t.Errorf("最后一根K线错误: %+v", last)
+	}
+}
+
+// TestFuturesKlines 获取合约K线，成交量使用基础资产数量
+// go test -v ./impl/okx -run "^TestFuturesKlines$"
+func TestFuturesKlines(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/market/history-candles": testKlineData,
+	})
+
+	end := time.UnixMilli(1700000120000)
+	klines, err := o.GetFuturesKlines(context.Background(), "BTCUSDT", exchange.KlineInterval1d, time.Time{}, end, 2)
+	if err != nil {
+		t.Fatalf("获取合约K线失败: %v", err)
+	}
+	if req := ts.findRequest("GET", "/api/v5/market/history-candles"); req.Query["instId"] != "BTC-USDT-SWAP" || req.Query["bar"] != "1Dutc" {
+		t.Errorf("请求参数错误: %+v", req.Query)
+	}
+	if len(klines) != 2 || klines[0].Volume != "0.2" || klines[1].Volume != "0.3" {
+		t.Errorf("K线错误: %+v", klines)
+	}
+
+	if _, err := o.GetFuturesKlines(context.Background(), "BTCUSDT", exchange.KlineInterval8h, time.Time{}, time.Time{}, 2); err == nil {
+		t.Error("不支持的周期应返回错误")
+	}
+}
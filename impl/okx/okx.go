@@ -1,74 +1,205 @@
 package okx
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/ratelimit"
+	"github.com/so68/exchange-lib/internal/symbolmap"
 	"github.com/so68/exchange-lib/internal/utils"
 )
 
+const (
+	BaseURL = "https://www.okx.com" // 默认接口地址
+
+	InstTypeSpot = "SPOT" // 现货
+	InstTypeSwap = "SWAP" // 永续合约
+
+	Settle = "USDT" // 默认结算货币
+
+	HeaderSimulatedTrading = "x-simulated-trading" // 模拟盘请求头
+)
+
+// okx OKX 实例
 type okx struct {
-	apiKey     string
-	secretKey  string
-	passphrase string
-	baseURL    string
-	client     *http.Client
+	apiKey      string
+	secretKey   string
+	passphrase  string
+	baseURL     string
+	client      *http.Client
+	testnet     bool     // 是否为模拟盘实例
+	marginModes sync.Map // 合约保证金模式 instId -> exchange.MarginMode
+	maxRetries  int      // 下单遇到临时错误时的最大重试次数
+}
+
+// NewOKX 创建 OKX 实例，OKX 现货与合约共用接口地址 SpotBaseURL，交易对参数支持统一格式（如 BTC/USDT:USDT）与原生格式，返回值使用统一格式
+func NewOKX(apiKey, secretKey string, passphrase string, opts ...exchange.Option) exchange.Exchange {
+	return symbolmap.WrapExchange(newOKX(apiKey, secretKey, passphrase, opts...), symbolFormatter{})
 }
 
-func NewOKX(apiKey, secretKey string, passphrase string) *okx {
-	return &okx{
+// newOKX 创建 OKX 实例
+func newOKX(apiKey, secretKey string, passphrase string, opts ...exchange.Option) *okx {
+	options := exchange.NewOptions(opts...)
+
+	o := &okx{
 		apiKey:     apiKey,
 		secretKey:  secretKey,
 		passphrase: passphrase,
-		baseURL:    "https://www.okx.com",
+		baseURL:    BaseURL,
 		client:     &http.Client{Timeout: 30 * time.Second},
+		testnet:    options.IsTestnet(),
+		maxRetries: options.Retries(),
+	}
+	if options.SpotBaseURL != "" {
+		o.baseURL = options.SpotBaseURL
+	}
+	if httpClient := options.NewHTTPClient(); httpClient != nil {
+		o.client = httpClient
 	}
+	limiter := options.RateLimiter
+	if limiter == nil {
+		limiter = NewRateLimiter(options.RateLimitPolicy)
+	}
+	o.client = ratelimit.WrapClient(o.client, limiter)
+	return o
 }
 
-// 生成认证请求
-func (o *okx) authRequest(method, requestPath string, body map[string]string) (json.RawMessage, error) {
-	method = strings.ToUpper(method)
+// publicRequest 公共接口请求
+func (o *okx) publicRequest(ctx context.Context, requestPath string, params map[string]string) (json.RawMessage, error) {
 	var resp okxResp
-	var err error
+	if err := decodeResponse(o.newHTTPClient(ctx).Get(requestPath+encodeQuery(params), nil), &resp); err != nil {
+		return nil, err
+	}
+	if resp.Code != "0" {
+		return nil, newAPIError(resp.Code, resp.Msg)
+	}
+	return resp.Data, nil
+}
+
+// authRequest 生成认证请求, GET 请求使用 params 作为查询参数, POST 请求使用 body 作为请求体
+func (o *okx) authRequest(ctx context.Context, method, requestPath string, params map[string]string, body interface{}) (json.RawMessage, error) {
+	resp, err := o.doAuthRequest(ctx, method, requestPath, params, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != "0" {
+		// 下单类接口的具体错误在 data[].sMsg 中
+		var results []okxOrderResult
+		if json.Unmarshal(resp.Data, &results) == nil && len(results) > 0 && results[0].SCode != "0" {
+			return nil, newAPIError(results[0].SCode, results[0].SMsg)
+		}
+		return nil, newAPIError(resp.Code, resp.Msg)
+	}
+	return resp.Data, nil
+}
+
+// batchRequest 批量下单与撤单请求，code 为 1（全部失败）或 2（部分成功）时返回每个订单的执行结果
+func (o *okx) batchRequest(ctx context.Context, requestPath string, body interface{}) ([]okxOrderResult, error) {
+	resp, err := o.doAuthRequest(ctx, "POST", requestPath, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != "0" && resp.Code != "1" && resp.Code != "2" {
+		return nil, newAPIError(resp.Code, resp.Msg)
+	}
+
+	var results []okxOrderResult
+	if err := json.Unmarshal(resp.Data, &results); err != nil {
+		return nil, fmt.Errorf("unmarshal batch result error: %w", err)
+	}
+	return results, nil
+}
+
+// doAuthRequest 发送认证请求，返回未检查错误码的原始响应
+func (o *okx) doAuthRequest(ctx context.Context, method, requestPath string, params map[string]string, body interface{}) (*okxResp, error) {
+	method = strings.ToUpper(method)
+	requestPath += encodeQuery(params)
+
 	var bodyString string
-	if body == nil {
-		bodyString = ""
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal request body error: %w", err)
+		}
+		bodyString = string(bodyBytes)
 	}
 
-	client := utils.NewHTTPClient(o.baseURL).SetHeaders(map[string]string{
+	// 签名与请求头必须使用同一个时间戳
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	client := o.newHTTPClient(ctx).SetHeaders(map[string]string{
 		"OK-ACCESS-KEY":        o.apiKey,
-		"OK-ACCESS-SIGN":       o.generateSignature(method, requestPath, bodyString),
-		"OK-ACCESS-TIMESTAMP":  time.Now().UTC().Format(time.RFC3339),
+		"OK-ACCESS-SIGN":       o.generateSignature(timestamp, method, requestPath, bodyString),
+		"OK-ACCESS-TIMESTAMP":  timestamp,
 		"OK-ACCESS-PASSPHRASE": o.passphrase,
-		"Content-Type":         "application/json",
 	})
+
+	var httpResp *utils.HTTPResponse
 	switch method {
 	case "POST":
+		var data interface{}
+		if bodyString != "" {
+			data = json.RawMessage(bodyString)
+		}
+		httpResp = client.Post(requestPath, data)
 	default:
-		err = client.Get(requestPath, body).JSON(&resp)
+		httpResp = client.Get(requestPath, nil)
 	}
-	if err != nil {
+	var resp okxResp
+	if err := decodeResponse(httpResp, &resp); err != nil {
 		return nil, err
 	}
-	if resp.Code != "0" {
-		return nil, fmt.Errorf("API 返回错误: code=%s, msg=%s", resp.Code, resp.Msg)
-	}
-	return resp.Data, nil
+	return &resp, nil
 }
 
-// 生成签名
-func (o *okx) generateSignature(method, requestPath string, body string) string {
-	// 生成时间戳（ISO 8601 格式）
-	timestamp := time.Now().UTC().Format(time.RFC3339)
+// isTestnet 请求是否使用模拟盘
+func (o *okx) isTestnet(ctx context.Context) bool {
+	return o.testnet || exchange.IsTestnet(ctx)
+}
 
+// newHTTPClient 创建 HTTP 客户端，模拟盘请求需要携带 x-simulated-trading 请求头
+func (o *okx) newHTTPClient(ctx context.Context) *utils.HTTPClient {
+	client := utils.NewHTTPClient(o.baseURL).
+		SetHTTPClient(o.client).
+		SetContext(ctx).
+		SetContentType("application/json")
+	if o.isTestnet(ctx) {
+		client.SetHeader(HeaderSimulatedTrading, "1")
+	}
+	return client
+}
+
+// generateSignature 生成签名
+func (o *okx) generateSignature(timestamp, method, requestPath string, body string) string {
 	message := timestamp + method + requestPath + body
 	h := hmac.New(sha256.New, []byte(o.secretKey))
 	h.Write([]byte(message))
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
+
+// encodeQuery 编码查询参数, 签名需要与请求路径完全一致
+func encodeQuery(params map[string]string) string {
+	if len(params) == 0 {
+		return ""
+	}
+	values := url.Values{}
+	for k, v := range params {
+		if v == "" {
+			continue
+		}
+		values.Set(k, v)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
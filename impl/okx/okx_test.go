@@ -1,18 +1,148 @@
 package okx
 
-import "flag"
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
 
-const (
-	apiKey     = "c035a2cd-7c2d-4d5f-a91b-ee51a0807bd5"
-	secretKey  = "50BF980FA9F02F16DF30A0CDDFBA2ADE"
-	passphrase = "Aa123098.."
+	"github.com/so68/exchange-lib/exchange"
 )
 
-var (
-	symbol    = flag.String("symbol", "", "交易对")
-	lastPrice = flag.String("lastPrice", "", "最新价格")
-	amount    = flag.Float64("amount", 0.0, "数量")
-	side      = flag.String("side", "BUY", "方向")
-	leverage  = flag.Int("leverage", 1, "杠杆")
-	orderID   = flag.String("orderID", "", "订单ID")
+const (
+	apiKey     = "test-api-key"
+	secretKey  = "test-secret-key"
+	passphrase = "test-passphrase"
 )
+
+// testRequest 测试服务器收到的请求
+type testRequest struct {
+	Method    string
+	Path      string
+	Query     map[string]string
+	Body      string
+	Simulated bool // 是否携带模拟盘请求头
+}
+
+// testServer 模拟 OKX 接口的测试服务器
+type testServer struct {
+	t        *testing.T
+	routes   map[string]string // "METHOD /path" -> data 字段的 JSON 或完整的错误响应
+	requests []testRequest
+	mux      sync.Mutex
+}
+
+// newTestOKX 创建连接到测试服务器的 OKX 实例
+func newTestOKX(t *testing.T, routes map[string]string) (*okx, *testServer) {
+	t.Helper()
+
+	// 清空产品规格缓存，避免测试之间互相影响
+	okxSpotSpec.DeleteInstrumentsSpec()
+	okxSwapSpec.DeleteInstrumentsSpec()
+	okxSpotTestnetSpec.DeleteInstrumentsSpec()
+	okxSwapTestnetSpec.DeleteInstrumentsSpec()
+
+	ts := &testServer{t: t, routes: routes}
+	server := httptest.NewServer(http.HandlerFunc(ts.handle))
+	t.Cleanup(server.Close)
+
+	o := newOKX(apiKey, secretKey, passphrase)
+	o.baseURL = server.URL
+	return o, ts
+}
+
+// handle 处理请求，校验签名并返回预设数据
+func (ts *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	// 私有接口校验签名
+	if r.Header.Get("OK-ACCESS-KEY") != "" {
+		message := r.Header.Get("OK-ACCESS-TIMESTAMP") + r.Method + r.URL.RequestURI() + string(body)
+		h := hmac.New(sha256.New, []byte(secretKey))
+		h.Write([]byte(message))
+		if sign := base64.StdEncoding.EncodeToString(h.Sum(nil)); sign != r.Header.Get("OK-ACCESS-SIGN") {
+			ts.t.Errorf("签名错误: %s %s", r.Method, r.URL.RequestURI())
+		}
+		if r.Header.Get("OK-ACCESS-PASSPHRASE") != passphrase {
+			ts.t.Errorf("passphrase 错误: %s", r.Header.Get("OK-ACCESS-PASSPHRASE"))
+		}
+	}
+
+	query := map[string]string{}
+	for k := range r.URL.Query() {
+		query[k] = r.URL.Query().Get(k)
+	}
+	ts.mux.Lock()
+	ts.requests = append(ts.requests, testRequest{Method: r.Method, Path: r.URL.Path, Query: query, Body: string(body),
+		Simulated: r.Header.Get(HeaderSimulatedTrading) == "1"})
+	ts.mux.Unlock()
+
+	data, ok := ts.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		ts.t.Errorf("未预设的请求: %s %s", r.Method, r.URL.Path)
+		w.Write([]byte(`{"code":"50000","msg":"not found","data":[]}`))
+		return
+	}
+	// 以 {"code": 开头的预设数据为完整响应，用于模拟错误
+	if strings.HasPrefix(data, `{"code":`) {
+		w.Write([]byte(data))
+		return
+	}
+	w.Write([]byte(`{"code":"0","msg":"","data":` + data + `}`))
+}
+
+// findRequest 查找指定路径的请求
+func (ts *testServer) findRequest(method, path string) *testRequest {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+	for i := range ts.requests {
+		if ts.requests[i].Method == method && ts.requests[i].Path == path {
+			return &ts.requests[i]
+		}
+	}
+	return nil
+}
+
+// bodyMap 解析请求体
+func (r *testRequest) bodyMap(t *testing.T) map[string]string {
+	t.Helper()
+	data := map[string]string{}
+	if err := json.Unmarshal([]byte(r.Body), &data); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	return data
+}
+
+// TestSimulatedTrading 模拟盘请求头
+// go test -v ./impl/okx -run "^TestSimulatedTrading$"
+func TestSimulatedTrading(t *testing.T) {
+	routes := map[string]string{"GET /api/v5/account/balance": testBalanceData}
+
+	o, ts := newTestOKX(t, routes)
+	if _, err := o.GetSpotBalance(context.Background()); err != nil {
+		t.Fatalf("获取现货余额失败: %v", err)
+	}
+	if _, err := o.GetSpotBalance(exchange.WithTestnet(context.Background())); err != nil {
+		t.Fatalf("获取模拟盘现货余额失败: %v", err)
+	}
+	if len(ts.requests) != 2 || ts.requests[0].Simulated || !ts.requests[1].Simulated {
+		t.Fatalf("模拟盘请求头错误: %+v", ts.requests)
+	}
+
+	// 实例级别的测试网配置
+	o, ts = newTestOKX(t, routes)
+	o.testnet = newOKX(apiKey, secretKey, passphrase, exchange.WithEnvironment(exchange.EnvironmentTestnet)).testnet
+	if _, err := o.GetSpotBalance(context.Background()); err != nil {
+		t.Fatalf("获取模拟盘现货余额失败: %v", err)
+	}
+	if req := ts.findRequest("GET", "/api/v5/account/balance"); req == nil || !req.Simulated {
+		t.Fatalf("实例模拟盘请求头错误: %+v", req)
+	}
+}
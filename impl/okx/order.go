@@ -2,30 +2,288 @@ package okx
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/utils"
 )
 
-func (o *okx) SpotCreateOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
-	return nil, nil
+// formatSpotInstId 格式化现货产品ID，如 BTCUSDT -> BTC-USDT
+func formatSpotInstId(symbol string) string {
+	return utils.FormatSymbol(strings.ToUpper(symbol), "-")
 }
 
-func (o *okx) SpotGetOrder(ctx context.Context, symbol string, orderID int64) (*exchange.Order, error) {
-	return nil, nil
+// formatSwapInstId 格式化永续合约产品ID，如 BTCUSDT -> BTC-USDT-SWAP
+func formatSwapInstId(symbol string) string {
+	instId := formatSpotInstId(symbol)
+	if !strings.HasSuffix(instId, "-"+InstTypeSwap) {
+		instId += "-" + InstTypeSwap
+	}
+	return instId
 }
 
-func (o *okx) SpotCancelOrder(ctx context.Context, symbol string, orderID int64) (*exchange.Order, error) {
-	return nil, nil
+// getInstrumentSpec 获取产品规格
+func (o *okx) getInstrumentSpec(ctx context.Context, instType, instId string) (*instrumentSpec, error) {
+	cache := o.getSpec(ctx, instType)
+
+	// 从缓存中获取产品规格
+	spec, _ := cache.GetInstrumentSpec(instId)
+	if spec != nil {
+		return spec, nil
+	}
+
+	specs, err := o.loadInstrumentSpecs(ctx, instType, instId)
+	if err != nil {
+		return nil, err
+	}
+	for _, specTmp := range specs {
+		if specTmp.InstId == instId {
+			spec = specTmp
+		}
+	}
+
+	if spec == nil {
+		return nil, fmt.Errorf("产品规格不存在: %s", instId)
+	}
+	return spec, nil
+}
+
+// PlaceOrder 下单
+func (o *okx) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.IcebergQty != "" {
+		return nil, fmt.Errorf("%w: OKX 不支持冰山单", exchange.ErrInvalidOrder)
+	}
+	if req.Market == exchange.MarketSpot {
+		return o.placeSpotOrder(ctx, req)
+	}
+	return o.placeFuturesOrder(ctx, req)
+}
+
+// filtersSize 按照下单数量精度向下取整，并验证最小下单数量
+func (o *okx) filtersSize(spec *instrumentSpec, size exchange.Decimal) (string, error) {
+	lotSz, err := exchange.ParseDecimal(spec.LotSz)
+	if err != nil || lotSz.Sign() <= 0 {
+		return "", fmt.Errorf("无效的数量精度: %s", spec.LotSz)
+	}
+	minSz, err := exchange.ParseDecimal(spec.MinSz)
+	if err != nil {
+		return "", fmt.Errorf("无效的最小数量: %s", spec.MinSz)
+	}
+
+	// 逻辑：floor(size / lotSz) * lotSz
+	size = exchange.FloorToStep(size, lotSz)
+
+	precision := int32(decimalPlaces(spec.LotSz))
+	if size.LessThan(minSz) {
+		return "", fmt.Errorf("%w: 处理后的数量 %s 小于最小数量 %s", exchange.ErrMinNotional, size.StringFixed(precision), spec.MinSz)
+	}
+
+	// 验证最大数量
+	if maxSz, err := exchange.ParseDecimal(spec.MaxLmtSz); err == nil && maxSz.Sign() > 0 && size.GreaterThan(maxSz) {
+		return "", fmt.Errorf("数量 %s 大于最大数量 %s", size.StringFixed(precision), spec.MaxLmtSz)
+	}
+	return size.StringFixed(precision), nil
+}
+
+// placeOrder 下单，返回订单ID
+func (o *okx) placeOrder(ctx context.Context, params map[string]string) (string, error) {
+	resp, err := o.authRequest(ctx, "POST", "/api/v5/trade/order", nil, params)
+	if err != nil {
+		return "", err
+	}
+
+	var results []okxOrderResult
+	if err := json.Unmarshal(resp, &results); err != nil {
+		return "", fmt.Errorf("unmarshal order result error: %w", err)
+	}
+	if len(results) == 0 {
+		return "", fmt.Errorf("下单结果为空")
+	}
+	if results[0].SCode != "0" {
+		return "", newAPIError(results[0].SCode, results[0].SMsg)
+	}
+	return results[0].OrdId, nil
+}
+
+// toPlacedOrder 按下单请求与参数创建新订单，用于下单成功但未查询订单详情的情况
+func toPlacedOrder(req exchange.OrderRequest, params map[string]string, orderID string) *exchange.Order {
+	return &exchange.Order{
+		OrderID:       orderID,
+		ClientOrderID: params["clOrdId"],
+		Symbol:        params["instId"],
+		Side:          req.Side,
+		Type:          req.Type,
+		Status:        exchange.OrderStatusNew,
+		Price:         req.Price,
+		Quantity:      req.Quantity,
+		TimeInForce:   toTimeInForce(params["ordType"]),
+	}
+}
+
+// getOrder 获取订单信息，clientOrderID 不为空时按客户端订单ID查询
+func (o *okx) getOrder(ctx context.Context, instId, orderID, clientOrderID string) (*okxOrder, error) {
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/trade/order", orderParams(instId, orderID, clientOrderID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*okxOrder
+	if err := json.Unmarshal(resp, &orders); err != nil {
+		return nil, fmt.Errorf("unmarshal order data error: %w", err)
+	}
+	if len(orders) == 0 {
+		return nil, fmt.Errorf("%w: %s%s", exchange.ErrOrderNotFound, orderID, clientOrderID)
+	}
+	return orders[0], nil
+}
+
+// cancelOrder 撤销订单，clientOrderID 不为空时按客户端订单ID撤销
+func (o *okx) cancelOrder(ctx context.Context, instId, orderID, clientOrderID string) error {
+	resp, err := o.authRequest(ctx, "POST", "/api/v5/trade/cancel-order", nil, orderParams(instId, orderID, clientOrderID))
+	if err != nil {
+		return err
+	}
+
+	var results []okxOrderResult
+	if err := json.Unmarshal(resp, &results); err != nil {
+		return fmt.Errorf("unmarshal cancel result error: %w", err)
+	}
+	if len(results) > 0 && results[0].SCode != "0" {
+		return newAPIError(results[0].SCode, results[0].SMsg)
+	}
+	return nil
+}
+
+// orderParams 订单查询与撤单参数，clientOrderID 不为空时使用 clOrdId，否则使用 ordId
+func orderParams(instId, orderID, clientOrderID string) map[string]string {
+	if clientOrderID != "" {
+		return map[string]string{"instId": instId, "clOrdId": clientOrderID}
+	}
+	return map[string]string{"instId": instId, "ordId": orderID}
+}
+
+// toExchangeOrder 转换为通用订单，ctVal 为合约面值，现货传空字符串
+func toExchangeOrder(order *okxOrder, ctVal string) *exchange.Order {
+	quantity := order.Sz
+	executedQty := order.AccFillSz
+	// 合约数量以张为单位，转换为币的数量
+	if ctVal != "" {
+		quantity = mulDecimal(order.Sz, ctVal)
+		executedQty = mulDecimal(order.AccFillSz, ctVal)
+	}
+
+	price := order.Px
+	if price == "" {
+		price = order.AvgPx
+	}
+
+	createTime, _ := strconv.ParseInt(order.CTime, 10, 64)
+	updateTime, _ := strconv.ParseInt(order.UTime, 10, 64)
+	return &exchange.Order{
+		OrderID:       order.OrdId,
+		ClientOrderID: order.ClOrdId,
+		Symbol:        order.InstId,
+		Side:          exchange.OrderSide(strings.ToUpper(order.Side)),
+		Type:          toOrderType(order.OrdType),
+		Status:        toOrderStatus(order.State),
+		Price:         price,
+		Quantity:      quantity,
+		ExecutedQty:   executedQty,
+		ActualQty:     executedQty,
+		QuoteQuantity: mulDecimal(executedQty, order.AvgPx),
+		TimeInForce:   toTimeInForce(order.OrdType),
+		CreateTime:    createTime,
+		UpdateTime:    updateTime,
+	}
+}
+
+// toOrderStatus 转换订单状态
+func toOrderStatus(state string) exchange.OrderStatus {
+	switch state {
+	case "partially_filled":
+		return exchange.OrderStatusPartiallyFilled
+	case "filled":
+		return exchange.OrderStatusFilled
+	case "canceled", "mmp_canceled":
+		return exchange.OrderStatusCanceled
+	default:
+		return exchange.OrderStatusNew
+	}
+}
+
+// toOrderType 转换订单类型
+func toOrderType(ordType string) exchange.OrderType {
+	if ordType == "market" {
+		return exchange.OrderTypeMarket
+	}
+	return exchange.OrderTypeLimit
+}
+
+// toOrdType 转换为 OKX 订单类型，限价单按时间类型区分
+func toOrdType(req exchange.OrderRequest) string {
+	if req.Type == exchange.OrderTypeMarket {
+		return "market"
+	}
+	switch req.TimeInForce {
+	case exchange.OrderTimeInForceGTX:
+		return "post_only"
+	case exchange.OrderTimeInForceIOC:
+		return "ioc"
+	case exchange.OrderTimeInForceFOK:
+		return "fok"
+	default:
+		return "limit"
+	}
+}
+
+// toTimeInForce 转换订单时间类型
+func toTimeInForce(ordType string) exchange.OrderTimeInForce {
+	switch ordType {
+	case "post_only":
+		return exchange.OrderTimeInForceGTX
+	case "fok":
+		return exchange.OrderTimeInForceFOK
+	case "ioc", "optimal_limit_ioc":
+		return exchange.OrderTimeInForceIOC
+	case "market":
+		return ""
+	default:
+		return exchange.OrderTimeInForceGTC
+	}
 }
 
-func (o *okx) FuturesCreateOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
-	return nil, nil
+// mulDecimal 两个十进制字符串相乘
+func mulDecimal(a, b string) string {
+	aDec, err := exchange.ParseDecimal(a)
+	if err != nil {
+		return "0"
+	}
+	bDec, err := exchange.ParseDecimal(b)
+	if err != nil {
+		return "0"
+	}
+	return aDec.Mul(bDec).String()
 }
 
-func (o *okx) FuturesGetOrder(ctx context.Context, symbol string, orderID int64) (*exchange.Order, error) {
-	return nil, nil
+// decimalPlaces 获取十进制字符串的小数位数
+func decimalPlaces(number string) int {
+	if !strings.Contains(number, ".") {
+		return 0
+	}
+	return utils.GetNumberPrecision(number)
 }
 
-func (o *okx) FuturesCancelOrder(ctx context.Context, symbol string, orderID int64) (*exchange.Order, error) {
-	return nil, nil
+// trimZeros 去除小数末尾的 0
+func trimZeros(number string) string {
+	if !strings.Contains(number, ".") {
+		return number
+	}
+	number = strings.TrimRight(number, "0")
+	return strings.TrimSuffix(number, ".")
 }
@@ -0,0 +1,75 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// AmendOrder 修改未完成的限价单，合约数量按合约面值转换为张数，改单成功后查询返回修改后的订单
+func (o *okx) AmendOrder(ctx context.Context, market exchange.Market, symbol, orderID, newPrice, newQty string) (*exchange.Order, error) {
+	if err := exchange.ValidateAmend(newPrice, newQty); err != nil {
+		return nil, err
+	}
+	instType, instId, err := toInstId(market, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{"instId": instId, "ordId": orderID}
+	if newPrice != "" {
+		params["newPx"] = newPrice
+	}
+	if newQty != "" {
+		spec, err := o.getInstrumentSpec(ctx, instType, instId)
+		if err != nil {
+			return nil, fmt.Errorf("获取交易规则失败: %w", err)
+		}
+		size, err := exchange.ParseDecimal(newQty)
+		if err != nil {
+			return nil, fmt.Errorf("无效的数量: %s", newQty)
+		}
+		// 张数 = 数量 / 合约面值
+		if instType == InstTypeSwap {
+			ctVal, err := exchange.ParseDecimal(spec.CtVal)
+			if err != nil || ctVal.Sign() <= 0 {
+				return nil, fmt.Errorf("无效的合约面值: %s", spec.CtVal)
+			}
+			size = size.Div(ctVal)
+		}
+		params["newSz"], err = o.filtersSize(spec, size)
+		if err != nil {
+			return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		}
+	}
+
+	if err := o.amendOrder(ctx, params); err != nil {
+		return nil, fmt.Errorf("改单失败: %w", err)
+	}
+	if instType == InstTypeSwap {
+		return o.GetFuturesOrder(ctx, symbol, orderID)
+	}
+	return o.GetSpotOrder(ctx, symbol, orderID)
+}
+
+// amendOrder 修改订单
+func (o *okx) amendOrder(ctx context.Context, params map[string]string) error {
+	resp, err := o.authRequest(ctx, "POST", "/api/v5/trade/amend-order", nil, params)
+	if err != nil {
+		return err
+	}
+
+	var results []okxOrderResult
+	if err := json.Unmarshal(resp, &results); err != nil {
+		return fmt.Errorf("unmarshal amend result error: %w", err)
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("改单结果为空")
+	}
+	if results[0].SCode != "0" {
+		return newAPIError(results[0].SCode, results[0].SMsg)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestAmendOrder 合约改单数量按合约面值转换为张数，改单后查询订单
+// go test -v ./impl/okx -run "^TestAmendOrder$"
+func TestAmendOrder(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"POST /api/v5/trade/amend-order": `[{"ordId":"3001","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        `[{"instType":"SWAP","instId":"BTC-USDT-SWAP","ordId":"3001","px":"101","sz":"5","ordType":"limit","side":"buy","accFillSz":"0","state":"live"}]`,
+	})
+
+	order, err := o.AmendOrder(context.Background(), exchange.MarketFutures, "BTCUSDT", "3001", "101", "0.05")
+	if err != nil {
+		t.Fatalf("改单失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/amend-order").bodyMap(t)
+	if body["instId"] != "BTC-USDT-SWAP" || body["ordId"] != "3001" || body["newSz"] != "5" || body["newPx"] != "101" {
+		t.Errorf("改单参数错误: %+v", body)
+	}
+	if order.OrderID != "3001" || order.Price != "101" || order.Quantity != "0.05" {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+
+	// 价格与数量都不修改时不发送请求
+	_, err = o.AmendOrder(context.Background(), exchange.MarketSpot, "BTCUSDT", "1001", "", "")
+	if !errors.Is(err, exchange.ErrInvalidOrder) {
+		t.Errorf("期望 ErrInvalidOrder，实际: %v", err)
+	}
+}
@@ -0,0 +1,437 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+// CreateFuturesOrder 创建合约订单，quantity 为币的数量，按合约面值转换为张数
+func (o *okx) CreateFuturesOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
+	req := exchange.NewOrderRequest(exchange.MarketFutures, symbol, side, limitPrice, quantity)
+	req.PositionSide = exchange.PositionSideShort
+	if side == exchange.OrderSideBuy {
+		req.PositionSide = exchange.PositionSideLong
+	}
+	return o.PlaceOrder(ctx, req)
+}
+
+// placeFuturesOrder 合约下单
+func (o *okx) placeFuturesOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	params, err := o.newFuturesOrderParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 下单，结果未知时按客户端订单ID对账
+	return idempotent.PlaceOrder(ctx, o.maxRetries, func() (*exchange.Order, error) {
+		orderID, err := o.placeOrder(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("合约下单失败: %w", err)
+		}
+		// 下单已成功，查询订单失败（如订单尚未可查）时按下单请求返回订单，不再重试下单
+		order, err := o.GetFuturesOrder(ctx, req.Symbol, orderID)
+		if err != nil {
+			return toPlacedOrder(req, params, orderID), nil
+		}
+		return order, nil
+	}, func() (*exchange.Order, error) {
+		return o.GetFuturesOrderByClientID(ctx, req.Symbol, params["clOrdId"])
+	})
+}
+
+// newFuturesOrderParams 按下单请求创建合约下单参数，数量按合约面值转换为张数，持仓方向为空或 BOTH 时按单向持仓下单
+func (o *okx) newFuturesOrderParams(ctx context.Context, req exchange.OrderRequest) (map[string]string, error) {
+	if req.QuoteQty != "" {
+		return nil, fmt.Errorf("%w: 合约不支持按金额下单", exchange.ErrInvalidOrder)
+	}
+
+	instId := formatSwapInstId(req.Symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSwap, instId)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易规则失败: %w", err)
+	}
+
+	// 张数 = 数量 / 合约面值
+	quantityDec, err := exchange.ParseDecimal(req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("无效的数量: %s", req.Quantity)
+	}
+	ctVal, err := exchange.ParseDecimal(spec.CtVal)
+	if err != nil || ctVal.Sign() <= 0 {
+		return nil, fmt.Errorf("无效的合约面值: %s", spec.CtVal)
+	}
+	size, err := o.filtersSize(spec, quantityDec.Div(ctVal))
+	if err != nil {
+		return nil, fmt.Errorf("验证交易规则失败: %w", err)
+	}
+
+	params := map[string]string{
+		"instId":  instId,
+		"tdMode":  o.getTdMode(instId),
+		"side":    strings.ToLower(string(req.Side)),
+		"ordType": toOrdType(req),
+		"sz":      size,
+	}
+	switch req.PositionSide {
+	case exchange.PositionSideLong:
+		params["posSide"] = "long"
+	case exchange.PositionSideShort:
+		params["posSide"] = "short"
+	}
+	if req.ReduceOnly {
+		params["reduceOnly"] = "true"
+	}
+	if req.Type == exchange.OrderTypeLimit {
+		params["px"] = req.Price
+	}
+	params["clOrdId"] = idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	return params, nil
+}
+
+// GetFuturesOrder 获取合约订单
+func (o *okx) GetFuturesOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	return o.getFuturesOrder(ctx, symbol, orderID, "")
+}
+
+// GetFuturesOrderByClientID 按客户端订单ID获取合约订单
+func (o *okx) GetFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return o.getFuturesOrder(ctx, symbol, "", clientOrderID)
+}
+
+// getFuturesOrder 查询合约订单，clientOrderID 不为空时按客户端订单ID查询
+func (o *okx) getFuturesOrder(ctx context.Context, symbol, orderID, clientOrderID string) (*exchange.Order, error) {
+	instId := formatSwapInstId(symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSwap, instId)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := o.getOrder(ctx, instId, orderID, clientOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约订单失败: %w", err)
+	}
+	return toExchangeOrder(order, spec.CtVal), nil
+}
+
+// CancelFuturesOrder 撤销合约订单
+func (o *okx) CancelFuturesOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	instId := formatSwapInstId(symbol)
+	if err := o.cancelOrder(ctx, instId, orderID, ""); err != nil {
+		return nil, fmt.Errorf("撤销合约订单失败: %w", err)
+	}
+	return o.GetFuturesOrder(ctx, symbol, orderID)
+}
+
+// CancelFuturesOrderByClientID 按客户端订单ID撤销合约订单
+func (o *okx) CancelFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	instId := formatSwapInstId(symbol)
+	if err := o.cancelOrder(ctx, instId, "", clientOrderID); err != nil {
+		return nil, fmt.Errorf("撤销合约订单失败: %w", err)
+	}
+	return o.getFuturesOrder(ctx, symbol, "", clientOrderID)
+}
+
+// GetFuturesPositionRisk 获取合约持仓风险
+func (o *okx) GetFuturesPositionRisk(ctx context.Context, symbol string) (*exchange.SymbolPositionRisk, error) {
+	instId := formatSwapInstId(symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSwap, instId)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := o.getPositions(ctx, instId)
+	if err != nil {
+		return nil, fmt.Errorf("获取持仓风险失败: %w", err)
+	}
+
+	data := &exchange.SymbolPositionRisk{
+		Data: []*exchange.PositionRisk{},
+	}
+	for _, p := range positions {
+		data.Data = append(data.Data, toPositionRisk(p, spec.CtVal))
+	}
+	return data, nil
+}
+
+// CloseFuturesPositionRisk 平仓合约持仓风险
+func (o *okx) CloseFuturesPositionRisk(ctx context.Context, symbol string, positionSide exchange.PositionSide) error {
+	instId := formatSwapInstId(symbol)
+	position, err := o.getSidePosition(ctx, instId, positionSide)
+	if err != nil {
+		return err
+	}
+
+	// 如果持仓为0，则不进行平仓
+	if position.Pos == "0" || position.Pos == "" {
+		return nil
+	}
+
+	_, err = o.authRequest(ctx, "POST", "/api/v5/trade/close-position", nil, map[string]string{
+		"instId":  instId,
+		"posSide": position.PosSide,
+		"mgnMode": position.MgnMode,
+	})
+	if err != nil {
+		return fmt.Errorf("平仓失败: %w", err)
+	}
+	return nil
+}
+
+// SetFuturesSLTP 设置合约止损止盈
+func (o *okx) SetFuturesSLTP(ctx context.Context, symbol string, positionSide exchange.PositionSide, stopPrice string, takeProfitPrice string) error {
+	instId := formatSwapInstId(symbol)
+	position, err := o.getSidePosition(ctx, instId, positionSide)
+	if err != nil {
+		return err
+	}
+
+	// 设置止损(市价止损)
+	if stopPrice != "" {
+		if err := o.setFuturesAlgoOrder(ctx, position, "slTriggerPx", "slOrdPx", "slTriggerPxType", stopPrice); err != nil {
+			return fmt.Errorf("设置止损失败: %w", err)
+		}
+	}
+
+	// 设置止盈(市价止盈)
+	if takeProfitPrice != "" {
+		if err := o.setFuturesAlgoOrder(ctx, position, "tpTriggerPx", "tpOrdPx", "tpTriggerPxType", takeProfitPrice); err != nil {
+			return fmt.Errorf("设置止盈失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetFuturesLeverage 设置合约杠杆
+func (o *okx) SetFuturesLeverage(ctx context.Context, symbol string, leverage int) error {
+	instId := formatSwapInstId(symbol)
+	tdMode := o.getTdMode(instId)
+
+	// 逐仓开平仓模式下需要分别设置多空方向的杠杆，买卖模式不传持仓方向
+	posSides := []string{""}
+	if tdMode == "isolated" {
+		posMode, err := o.getPosMode(ctx)
+		if err != nil {
+			return fmt.Errorf("设置杠杆失败: %w", err)
+		}
+		if posMode == "long_short_mode" {
+			posSides = []string{"long", "short"}
+		}
+	}
+	for _, posSide := range posSides {
+		params := map[string]string{
+			"instId":  instId,
+			"lever":   strconv.Itoa(leverage),
+			"mgnMode": tdMode,
+		}
+		if posSide != "" {
+			params["posSide"] = posSide
+		}
+		if _, err := o.authRequest(ctx, "POST", "/api/v5/account/set-leverage", nil, params); err != nil {
+			return fmt.Errorf("设置杠杆失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// getPosMode 获取账户持仓方式
+func (o *okx) getPosMode(ctx context.Context) (string, error) {
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/account/config", nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("获取账户配置失败: %w", err)
+	}
+	var configs []okxAccountConfig
+	if err := json.Unmarshal(resp, &configs); err != nil {
+		return "", fmt.Errorf("unmarshal account config error: %w", err)
+	}
+	if len(configs) == 0 {
+		return "", fmt.Errorf("账户配置为空")
+	}
+	return configs[0].PosMode, nil
+}
+
+// SetFuturesMarginMode 设置合约保证金模式
+// OKX 的保证金模式由下单时的 tdMode 决定，这里记录交易对的保证金模式供后续下单和设置杠杆使用
+func (o *okx) SetFuturesMarginMode(ctx context.Context, symbol string, marginMode exchange.MarginMode) error {
+	if marginMode != exchange.MarginModeCrossed && marginMode != exchange.MarginModeIsolated {
+		return fmt.Errorf("无效的保证金模式: %s", marginMode)
+	}
+	o.marginModes.Store(formatSwapInstId(symbol), marginMode)
+	return nil
+}
+
+// SetFuturesDualMode 设置持仓模式
+func (o *okx) SetFuturesDualMode(ctx context.Context, dualMode bool) error {
+	posMode := "net_mode"
+	if dualMode {
+		posMode = "long_short_mode"
+	}
+	_, err := o.authRequest(ctx, "POST", "/api/v5/account/set-position-mode", nil, map[string]string{
+		"posMode": posMode,
+	})
+	if err != nil {
+		return fmt.Errorf("设置持仓模式失败: %w", err)
+	}
+	return nil
+}
+
+// CancelFuturesSLTP 撤销合约止损止盈
+func (o *okx) CancelFuturesSLTP(ctx context.Context, symbol string) error {
+	instId := formatSwapInstId(symbol)
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/trade/orders-algo-pending", map[string]string{
+		"instType": InstTypeSwap,
+		"instId":   instId,
+		"ordType":  "conditional",
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("获取 %s 策略委托单失败: %w", instId, err)
+	}
+
+	var algoOrders []*okxAlgoOrder
+	if err := json.Unmarshal(resp, &algoOrders); err != nil {
+		return fmt.Errorf("unmarshal algo orders error: %w", err)
+	}
+	if len(algoOrders) == 0 {
+		return nil
+	}
+
+	cancelParams := make([]map[string]string, 0, len(algoOrders))
+	for _, algoOrder := range algoOrders {
+		cancelParams = append(cancelParams, map[string]string{
+			"algoId": algoOrder.AlgoId,
+			"instId": algoOrder.InstId,
+		})
+	}
+	if _, err := o.authRequest(ctx, "POST", "/api/v5/trade/cancel-algos", nil, cancelParams); err != nil {
+		return fmt.Errorf("撤销合约止损止盈失败: %w", err)
+	}
+	return nil
+}
+
+// getPositions 获取持仓列表
+func (o *okx) getPositions(ctx context.Context, instId string) ([]*okxPosition, error) {
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/account/positions", map[string]string{
+		"instType": InstTypeSwap,
+		"instId":   instId,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []*okxPosition
+	if err := json.Unmarshal(resp, &positions); err != nil {
+		return nil, fmt.Errorf("unmarshal positions data error: %w", err)
+	}
+	return positions, nil
+}
+
+// getSidePosition 获取指定方向持仓
+func (o *okx) getSidePosition(ctx context.Context, instId string, positionSide exchange.PositionSide) (*okxPosition, error) {
+	positions, err := o.getPositions(ctx, instId)
+	if err != nil {
+		return nil, fmt.Errorf("获取持仓风险失败: %w", err)
+	}
+	for _, p := range positions {
+		if toPositionSide(p) == positionSide {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("获取指定方向 %s 持仓风险失败: 未找到该方向的持仓", positionSide)
+}
+
+// setFuturesAlgoOrder 设置市价止盈止损策略委托单
+func (o *okx) setFuturesAlgoOrder(ctx context.Context, position *okxPosition, triggerPxKey, ordPxKey, triggerPxTypeKey, triggerPrice string) error {
+	// 确定平仓方向：多头持仓用 sell 平仓，空头持仓用 buy 平仓
+	side := "sell"
+	if toPositionSide(position) == exchange.PositionSideShort {
+		side = "buy"
+	}
+
+	params := map[string]string{
+		"instId":         position.InstId,
+		"tdMode":         position.MgnMode,
+		"side":           side,
+		"posSide":        position.PosSide,
+		"ordType":        "conditional",
+		"sz":             strings.TrimPrefix(position.Pos, "-"),
+		triggerPxKey:     triggerPrice,
+		ordPxKey:         "-1",   // 市价
+		triggerPxTypeKey: "mark", // 使用标记价格触发
+	}
+	// 单向持仓模式下使用只减仓
+	if position.PosSide == "net" {
+		params["reduceOnly"] = "true"
+	}
+
+	resp, err := o.authRequest(ctx, "POST", "/api/v5/trade/order-algo", nil, params)
+	if err != nil {
+		return err
+	}
+
+	var results []okxOrderResult
+	if err := json.Unmarshal(resp, &results); err != nil {
+		return fmt.Errorf("unmarshal algo order result error: %w", err)
+	}
+	if len(results) > 0 && results[0].SCode != "0" {
+		return newAPIError(results[0].SCode, results[0].SMsg)
+	}
+	return nil
+}
+
+// getTdMode 获取交易对的交易模式，默认全仓
+func (o *okx) getTdMode(instId string) string {
+	if marginMode, ok := o.marginModes.Load(instId); ok && marginMode.(exchange.MarginMode) == exchange.MarginModeIsolated {
+		return "isolated"
+	}
+	return "cross"
+}
+
+// toPositionRisk 转换为通用持仓风险，ctVal 为合约面值
+func toPositionRisk(p *okxPosition, ctVal string) *exchange.PositionRisk {
+	marginMode := exchange.MarginModeCrossed
+	if p.MgnMode == "isolated" {
+		marginMode = exchange.MarginModeIsolated
+	}
+
+	// 持仓数量以张为单位，转换为币的数量，空头为负数
+	positionAmt := p.Pos
+	if ctVal != "" {
+		positionAmt = mulDecimal(p.Pos, ctVal)
+	}
+	if p.PosSide == "short" && !strings.HasPrefix(positionAmt, "-") && positionAmt != "0" {
+		positionAmt = "-" + positionAmt
+	}
+
+	return &exchange.PositionRisk{
+		Symbol:           p.InstId,
+		PositionSide:     toPositionSide(p),
+		PositionAmt:      positionAmt,
+		EntryPrice:       p.AvgPx,
+		MarkPrice:        p.MarkPx,
+		UnRealizedProfit: p.Upl,
+		Leverage:         p.Lever,
+		LiquidationPrice: p.LiqPx,
+		MarginType:       string(marginMode),
+		IsolatedMargin:   p.Margin,
+		Notional:         p.NotionalUsd,
+	}
+}
+
+// toPositionSide 转换持仓方向，单向持仓模式下按持仓数量正负判断
+func toPositionSide(position *okxPosition) exchange.PositionSide {
+	switch position.PosSide {
+	case "long":
+		return exchange.PositionSideLong
+	case "short":
+		return exchange.PositionSideShort
+	}
+	if strings.HasPrefix(position.Pos, "-") {
+		return exchange.PositionSideShort
+	}
+	return exchange.PositionSideLong
+}
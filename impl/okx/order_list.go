@@ -0,0 +1,152 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	OrderListLimit = 100 // 订单列表每页最大数量
+)
+
+// ListOpenOrders 获取交易对未完成的订单，OKX 策略委托单单独存储，不在挂单列表中
+func (o *okx) ListOpenOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.Order, error) {
+	instType, instId, err := toInstId(market, symbol)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := o.getInstrumentSpec(ctx, instType, instId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*exchange.Order, 0)
+	params := map[string]string{
+		"instType": instType,
+		"instId":   instId,
+		"limit":    strconv.Itoa(OrderListLimit),
+	}
+	for {
+		orders, err := o.listOrders(ctx, "/api/v5/trade/orders-pending", params)
+		if err != nil {
+			return nil, fmt.Errorf("获取挂单失败: %w", err)
+		}
+		for _, order := range orders {
+			result = append(result, toListOrder(instType, spec, order))
+		}
+		if len(orders) < OrderListLimit {
+			return result, nil
+		}
+		params["after"] = orders[len(orders)-1].OrdId
+	}
+}
+
+// CancelAllOrders 撤销交易对全部挂单，OKX 没有全部撤单接口，查询挂单后批量撤单，撤单时已结束的订单不返回错误
+func (o *okx) CancelAllOrders(ctx context.Context, market exchange.Market, symbol string) error {
+	orders, err := o.ListOpenOrders(ctx, market, symbol)
+	if err != nil {
+		return err
+	}
+	if len(orders) == 0 {
+		return nil
+	}
+
+	orderIDs := make([]string, len(orders))
+	for i, order := range orders {
+		orderIDs[i] = order.OrderID
+	}
+	results, err := o.CancelOrders(ctx, market, symbol, orderIDs)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, exchange.ErrOrderNotFound) {
+			errs = append(errs, fmt.Errorf("撤销订单 %s 失败: %w", orderIDs[i], result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ListOrderHistory 按时间倒序分页查询近七天已结束的订单，游标为上一页最后一个订单ID
+func (o *okx) ListOrderHistory(ctx context.Context, query exchange.OrderQuery) (*exchange.OrderPage, error) {
+	instType, instId, err := toInstId(query.Market, query.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := o.getInstrumentSpec(ctx, instType, instId)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > OrderListLimit {
+		limit = OrderListLimit
+	}
+	params := map[string]string{
+		"instType": instType,
+		"instId":   instId,
+		"limit":    strconv.Itoa(limit),
+		"after":    query.Cursor,
+	}
+	if !query.StartTime.IsZero() {
+		params["begin"] = strconv.FormatInt(query.StartTime.UnixMilli(), 10)
+	}
+	if !query.EndTime.IsZero() {
+		params["end"] = strconv.FormatInt(query.EndTime.UnixMilli(), 10)
+	}
+	orders, err := o.listOrders(ctx, "/api/v5/trade/orders-history", params)
+	if err != nil {
+		return nil, fmt.Errorf("获取历史订单失败: %w", err)
+	}
+
+	page := &exchange.OrderPage{Orders: make([]*exchange.Order, 0, len(orders))}
+	for _, order := range orders {
+		page.Orders = append(page.Orders, toListOrder(instType, spec, order))
+	}
+	if len(orders) >= limit {
+		page.NextCursor = orders[len(orders)-1].OrdId
+	}
+	return page, nil
+}
+
+// listOrders 查询订单列表
+func (o *okx) listOrders(ctx context.Context, requestPath string, params map[string]string) ([]*okxOrder, error) {
+	resp, err := o.authRequest(ctx, "GET", requestPath, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*okxOrder
+	if err := json.Unmarshal(resp, &orders); err != nil {
+		return nil, fmt.Errorf("unmarshal orders error: %w", err)
+	}
+	return orders, nil
+}
+
+// toInstId 市场类型与交易对转换为产品类型与产品ID
+func toInstId(market exchange.Market, symbol string) (string, string, error) {
+	instType, err := toInstType(market)
+	if err != nil {
+		return "", "", err
+	}
+	if instType == InstTypeSwap {
+		return instType, formatSwapInstId(symbol), nil
+	}
+	return instType, formatSpotInstId(symbol), nil
+}
+
+// toListOrder 转换订单列表中的订单，现货计算实际数量，合约张数按合约面值转换
+func toListOrder(instType string, spec *instrumentSpec, order *okxOrder) *exchange.Order {
+	if instType == InstTypeSwap {
+		return toExchangeOrder(order, spec.CtVal)
+	}
+	data := toExchangeOrder(order, "")
+	data.ActualQty = spotActualQty(spec, order, data.QuoteQuantity)
+	return data
+}
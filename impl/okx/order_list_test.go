@@ -0,0 +1,97 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestListOpenOrders 合约挂单数量按合约面值转换
+// go test -v ./impl/okx -run "^TestListOpenOrders$"
+func TestListOpenOrders(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments":   testSwapInstrument,
+		"GET /api/v5/trade/orders-pending": `[{"instType":"SWAP","instId":"BTC-USDT-SWAP","ordId":"3001","px":"100","sz":"5","ordType":"limit","side":"buy","accFillSz":"0","state":"live"}]`,
+	})
+
+	orders, err := o.ListOpenOrders(context.Background(), exchange.MarketFutures, "BTCUSDT")
+	if err != nil {
+		t.Fatalf("获取挂单失败: %v", err)
+	}
+	if len(orders) != 1 || orders[0].OrderID != "3001" || orders[0].Quantity != "0.05" || orders[0].Status != exchange.OrderStatusNew {
+		t.Errorf("挂单数据错误: %+v", orders)
+	}
+	query := ts.findRequest("GET", "/api/v5/trade/orders-pending").Query
+	if query["instType"] != "SWAP" || query["instId"] != "BTC-USDT-SWAP" {
+		t.Errorf("查询参数错误: %+v", query)
+	}
+}
+
+// TestListOrderHistory 历史订单以最后一个订单ID作为下一页游标
+// go test -v ./impl/okx -run "^TestListOrderHistory$"
+func TestListOrderHistory(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"GET /api/v5/trade/orders-history": `[
+			{"instType":"SPOT","instId":"BTC-USDT","ordId":"1002","px":"100","sz":"0.01","ordType":"limit","side":"buy","accFillSz":"0.01","avgPx":"100","state":"filled"},
+			{"instType":"SPOT","instId":"BTC-USDT","ordId":"1001","px":"100","sz":"0.01","ordType":"limit","side":"buy","accFillSz":"0","state":"canceled"}
+		]`,
+	})
+
+	start := time.UnixMilli(1700000000000)
+	page, err := o.ListOrderHistory(context.Background(), exchange.OrderQuery{
+		Market: exchange.MarketSpot, Symbol: "BTCUSDT", StartTime: start, Limit: 2, Cursor: "1003",
+	})
+	if err != nil {
+		t.Fatalf("获取历史订单失败: %v", err)
+	}
+	if len(page.Orders) != 2 || page.Orders[0].Status != exchange.OrderStatusFilled || page.Orders[1].Status != exchange.OrderStatusCanceled {
+		t.Errorf("历史订单数据错误: %+v", page.Orders)
+	}
+	if page.NextCursor != "1001" {
+		t.Errorf("下一页游标错误: %s", page.NextCursor)
+	}
+	query := ts.findRequest("GET", "/api/v5/trade/orders-history").Query
+	if query["after"] != "1003" || query["begin"] != "1700000000000" || query["limit"] != "2" || query["end"] != "" {
+		t.Errorf("查询参数错误: %+v", query)
+	}
+
+	// 不足一页时没有下一页
+	page, err = o.ListOrderHistory(context.Background(), exchange.OrderQuery{Market: exchange.MarketSpot, Symbol: "BTCUSDT"})
+	if err != nil {
+		t.Fatalf("获取历史订单失败: %v", err)
+	}
+	if page.NextCursor != "" {
+		t.Errorf("最后一页游标应为空: %s", page.NextCursor)
+	}
+}
+
+// TestCancelAllOrders 查询挂单后批量撤单，已结束的订单不返回错误
+// go test -v ./impl/okx -run "^TestCancelAllOrders$"
+func TestCancelAllOrders(t *testing.T) {
+	pending := make([]string, 0, 2)
+	for _, id := range []int{1, 2} {
+		pending = append(pending, `{"instType":"SPOT","instId":"BTC-USDT","ordId":"`+strconv.Itoa(id)+`","px":"100","sz":"0.01","ordType":"limit","side":"buy","state":"live"}`)
+	}
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments":         testSpotInstrument,
+		"GET /api/v5/trade/orders-pending":       "[" + strings.Join(pending, ",") + "]",
+		"POST /api/v5/trade/cancel-batch-orders": `[{"ordId":"1","sCode":"0","sMsg":""},{"ordId":"2","sCode":"51400","sMsg":"Order does not exist"}]`,
+	})
+
+	if err := o.CancelAllOrders(context.Background(), exchange.MarketSpot, "BTCUSDT"); err != nil {
+		t.Fatalf("撤销全部挂单失败: %v", err)
+	}
+	var body []map[string]string
+	if err := json.Unmarshal([]byte(ts.findRequest("POST", "/api/v5/trade/cancel-batch-orders").Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if len(body) != 2 || body[0]["ordId"] != "1" || body[1]["ordId"] != "2" || body[0]["instId"] != "BTC-USDT" {
+		t.Errorf("批量撤单参数错误: %+v", body)
+	}
+}
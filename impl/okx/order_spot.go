@@ -0,0 +1,153 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/idempotent"
+)
+
+// CreateSpotOrder 创建现货订单，quantity 为交易货币数量
+func (o *okx) CreateSpotOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
+	return o.PlaceOrder(ctx, exchange.NewOrderRequest(exchange.MarketSpot, symbol, side, limitPrice, quantity))
+}
+
+// placeSpotOrder 现货下单
+func (o *okx) placeSpotOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	params, err := o.newSpotOrderParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 下单，结果未知时按客户端订单ID对账
+	return idempotent.PlaceOrder(ctx, o.maxRetries, func() (*exchange.Order, error) {
+		orderID, err := o.placeOrder(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("现货下单失败: %w", err)
+		}
+		// 下单已成功，查询订单失败（如订单尚未可查）时按下单请求返回订单，不再重试下单
+		order, err := o.GetSpotOrder(ctx, req.Symbol, orderID)
+		if err != nil {
+			return toPlacedOrder(req, params, orderID), nil
+		}
+		return order, nil
+	}, func() (*exchange.Order, error) {
+		return o.GetSpotOrderByClientID(ctx, req.Symbol, params["clOrdId"])
+	})
+}
+
+// newSpotOrderParams 按下单请求创建现货下单参数，市价单按数量或金额下单
+func (o *okx) newSpotOrderParams(ctx context.Context, req exchange.OrderRequest) (map[string]string, error) {
+	instId := formatSpotInstId(req.Symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSpot, instId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		"instId":  instId,
+		"tdMode":  "cash",
+		"side":    strings.ToLower(string(req.Side)),
+		"ordType": toOrdType(req),
+	}
+
+	// 按金额下单，数量以计价货币为单位
+	if req.QuoteQty != "" {
+		params["tgtCcy"] = "quote_ccy"
+		params["sz"] = req.QuoteQty
+	} else {
+		// 验证交易规则
+		quantityDec, err := exchange.ParseDecimal(req.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("无效的数量: %s", req.Quantity)
+		}
+		size, err := o.filtersSize(spec, quantityDec)
+		if err != nil {
+			return nil, fmt.Errorf("验证交易规则失败: %w", err)
+		}
+		params["sz"] = size
+	}
+
+	// 市价单，数量以交易货币为单位
+	if req.Type == exchange.OrderTypeMarket {
+		if req.QuoteQty == "" {
+			params["tgtCcy"] = "base_ccy"
+		}
+	} else {
+		params["px"] = req.Price
+	}
+
+	params["clOrdId"] = idempotent.ClientOrderID(ctx, req.ClientOrderID)
+	return params, nil
+}
+
+// GetSpotOrder 获取现货订单
+func (o *okx) GetSpotOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	return o.getSpotOrder(ctx, symbol, orderID, "")
+}
+
+// GetSpotOrderByClientID 按客户端订单ID获取现货订单
+func (o *okx) GetSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	return o.getSpotOrder(ctx, symbol, "", clientOrderID)
+}
+
+// getSpotOrder 查询现货订单，clientOrderID 不为空时按客户端订单ID查询
+func (o *okx) getSpotOrder(ctx context.Context, symbol, orderID, clientOrderID string) (*exchange.Order, error) {
+	instId := formatSpotInstId(symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSpot, instId)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := o.getOrder(ctx, instId, orderID, clientOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("获取现货订单失败: %w", err)
+	}
+
+	data := toExchangeOrder(order, "")
+	data.ActualQty = spotActualQty(spec, order, data.QuoteQuantity)
+	return data, nil
+}
+
+// CancelSpotOrder 撤销现货订单
+func (o *okx) CancelSpotOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	instId := formatSpotInstId(symbol)
+	if err := o.cancelOrder(ctx, instId, orderID, ""); err != nil {
+		return nil, fmt.Errorf("撤销现货订单失败: %w", err)
+	}
+	return o.GetSpotOrder(ctx, symbol, orderID)
+}
+
+// CancelSpotOrderByClientID 按客户端订单ID撤销现货订单
+func (o *okx) CancelSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	instId := formatSpotInstId(symbol)
+	if err := o.cancelOrder(ctx, instId, "", clientOrderID); err != nil {
+		return nil, fmt.Errorf("撤销现货订单失败: %w", err)
+	}
+	return o.getSpotOrder(ctx, symbol, "", clientOrderID)
+}
+
+// spotActualQty 计算实际数量（扣除手续费后的数量）
+// 买入：已成交数量 - 交易货币手续费；卖出：成交金额 - 计价货币手续费
+// OKX 手续费为负数，因此直接相加
+func spotActualQty(spec *instrumentSpec, order *okxOrder, quoteQuantity string) string {
+	fee := exchange.ToDecimal(order.Fee)
+
+	amount := order.AccFillSz
+	feeCcy := spec.BaseCcy
+	if strings.EqualFold(order.Side, string(exchange.OrderSideSell)) {
+		amount = quoteQuantity
+		feeCcy = spec.QuoteCcy
+	}
+	if order.FeeCcy != feeCcy {
+		fee = exchange.Decimal{}
+	}
+
+	amountDec, err := exchange.ParseDecimal(amount)
+	if err != nil || amount == "" {
+		return amount
+	}
+	return amountDec.Add(fee).String()
+}
@@ -0,0 +1,479 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	testSpotInstrument = `[{"instType":"SPOT","instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","tickSz":"0.1","lotSz":"0.0001","minSz":"0.001","maxLmtSz":"1000","state":"live"}]`
+	testSwapInstrument = `[{"instType":"SWAP","instId":"BTC-USDT-SWAP","settleCcy":"USDT","ctVal":"0.01","ctValCcy":"BTC","tickSz":"0.1","lotSz":"1","minSz":"1","maxLmtSz":"10000","lever":"100","state":"live"}]`
+	testPositions      = `[
+		{"instType":"SWAP","instId":"BTC-USDT-SWAP","mgnMode":"cross","posSide":"long","pos":"5","avgPx":"100","markPx":"101","upl":"0.05","lever":"10","liqPx":"90","margin":"","notionalUsd":"5.05"},
+		{"instType":"SWAP","instId":"BTC-USDT-SWAP","mgnMode":"isolated","posSide":"short","pos":"3","avgPx":"102","markPx":"101","upl":"0.03","lever":"5","liqPx":"120","margin":"0.6","notionalUsd":"3.03"}
+	]`
+)
+
+// TestSpotCreateOrder 创建现货订单
+// go test -v ./impl/okx -run "^TestSpotCreateOrder$"
+func TestSpotCreateOrder(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"1001","clOrdId":"","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        `[{"instId":"BTC-USDT","ordId":"1001","px":"100","sz":"0.0123","ordType":"limit","side":"buy","accFillSz":"0.01","avgPx":"100","state":"partially_filled","fee":"-0.00001","feeCcy":"BTC","cTime":"1700000000000","uTime":"1700000001000"}]`,
+	})
+
+	order, err := o.CreateSpotOrder(context.Background(), "BTCUSDT", exchange.OrderSideBuy, "100", "0.01234")
+	if err != nil {
+		t.Fatalf("现货下单失败: %v", err)
+	}
+
+	body := ts.findRequest("POST", "/api/v5/trade/order").bodyMap(t)
+	if body["instId"] != "BTC-USDT" || body["side"] != "buy" || body["ordType"] != "limit" || body["sz"] != "0.0123" || body["px"] != "100" || body["tdMode"] != "cash" {
+		t.Errorf("下单参数错误: %+v", body)
+	}
+	if order.OrderID != "1001" || order.Status != exchange.OrderStatusPartiallyFilled || order.TimeInForce != exchange.OrderTimeInForceGTC {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+	if order.ActualQty != "0.00999" || order.QuoteQuantity != "1" || order.CreateTime != 1700000000000 {
+		t.Errorf("订单成交数据错误: %+v", order)
+	}
+}
+
+// TestSpotCreateMarketOrder 创建现货市价订单
+// go test -v ./impl/okx -run "^TestSpotCreateMarketOrder$"
+func TestSpotCreateMarketOrder(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"1002","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        `[{"instId":"BTC-USDT","ordId":"1002","px":"","sz":"0.5","ordType":"market","side":"sell","accFillSz":"0.5","avgPx":"100","state":"filled","fee":"-0.05","feeCcy":"USDT"}]`,
+	})
+
+	order, err := o.CreateSpotOrder(context.Background(), "BTCUSDT", exchange.OrderSideSell, "", "0.5")
+	if err != nil {
+		t.Fatalf("现货下单失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/order").bodyMap(t)
+	if body["ordType"] != "market" || body["tgtCcy"] != "base_ccy" || body["px"] != "" {
+		t.Errorf("市价单参数错误: %+v", body)
+	}
+	if order.Type != exchange.OrderTypeMarket || order.Price != "100" || order.ActualQty != "49.95" {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+}
+
+// TestSpotCreateOrderBelowMinSize 现货下单数量小于最小数量
+// go test -v ./impl/okx -run "^TestSpotCreateOrderBelowMinSize$"
+func TestSpotCreateOrderBelowMinSize(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+	})
+
+	_, err := o.CreateSpotOrder(context.Background(), "BTCUSDT", exchange.OrderSideBuy, "100", "0.0005")
+	if !errors.Is(err, exchange.ErrMinNotional) {
+		t.Fatalf("数量小于最小数量时应返回 ErrMinNotional: %v", err)
+	}
+	if ts.findRequest("POST", "/api/v5/trade/order") != nil {
+		t.Errorf("验证失败时不应下单")
+	}
+}
+
+// TestSpotCreateOrderRejected 下单被拒绝时返回 sMsg
+// go test -v ./impl/okx -run "^TestSpotCreateOrderRejected$"
+func TestSpotCreateOrderRejected(t *testing.T) {
+	o, _ := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"","sCode":"51008","sMsg":"Insufficient balance"}]`,
+	})
+
+	_, err := o.CreateSpotOrder(context.Background(), "BTCUSDT", exchange.OrderSideBuy, "100", "0.01")
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Fatalf("余额不足时应返回 ErrInsufficientBalance: %v", err)
+	}
+	var apiErr *exchange.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "51008" || apiErr.Message != "Insufficient balance" {
+		t.Errorf("原生错误码错误: %+v", apiErr)
+	}
+}
+
+// TestSpotCreateOrderClientID 下单携带客户端订单ID，结果未知时按客户端订单ID对账，不重复下单
+// go test -v ./impl/okx -run "^TestSpotCreateOrderClientID$"
+func TestSpotCreateOrderClientID(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"","clOrdId":"bot1","sCode":"50004","sMsg":"API endpoint request timeout"}]`,
+		"GET /api/v5/trade/order":        `[{"instId":"BTC-USDT","ordId":"1003","clOrdId":"bot1","px":"100","sz":"0.01","ordType":"limit","side":"buy","accFillSz":"0","avgPx":"","state":"live","fee":"0","feeCcy":"BTC"}]`,
+	})
+
+	ctx := exchange.WithClientOrderID(context.Background(), "bot1")
+	order, err := o.CreateSpotOrder(ctx, "BTCUSDT", exchange.OrderSideBuy, "100", "0.01")
+	if err != nil {
+		t.Fatalf("对账后应返回已存在的订单: %v", err)
+	}
+	if order.OrderID != "1003" || order.ClientOrderID != "bot1" {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+
+	var places int
+	for _, r := range ts.requests {
+		if r.Method == "POST" && r.Path == "/api/v5/trade/order" {
+			places++
+		}
+	}
+	if places != 1 {
+		t.Errorf("订单已存在时不应重复下单: %d", places)
+	}
+	if body := ts.findRequest("POST", "/api/v5/trade/order").bodyMap(t); body["clOrdId"] != "bot1" {
+		t.Errorf("下单应携带客户端订单ID: %+v", body)
+	}
+	if query := ts.findRequest("GET", "/api/v5/trade/order").Query; query["clOrdId"] != "bot1" || query["ordId"] != "" {
+		t.Errorf("应按客户端订单ID查询: %+v", query)
+	}
+}
+
+// TestFuturesCancelOrderByClientID 按客户端订单ID撤销合约订单
+// go test -v ./impl/okx -run "^TestFuturesCancelOrderByClientID$"
+func TestFuturesCancelOrderByClientID(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments":  testSwapInstrument,
+		"POST /api/v5/trade/cancel-order": `[{"ordId":"2001","clOrdId":"bot2","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":         `[{"instId":"BTC-USDT-SWAP","ordId":"2001","clOrdId":"bot2","px":"100","sz":"2","ordType":"limit","side":"buy","accFillSz":"0","avgPx":"","state":"canceled"}]`,
+	})
+
+	order, err := o.CancelFuturesOrderByClientID(context.Background(), "BTCUSDT", "bot2")
+	if err != nil {
+		t.Fatalf("撤单失败: %v", err)
+	}
+	if body := ts.findRequest("POST", "/api/v5/trade/cancel-order").bodyMap(t); body["clOrdId"] != "bot2" || body["ordId"] != "" {
+		t.Errorf("撤单参数错误: %+v", body)
+	}
+	if order.OrderID != "2001" || order.ClientOrderID != "bot2" || order.Status != exchange.OrderStatusCanceled || order.Quantity != "0.02" {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+}
+
+// TestSpotCancelOrder 撤销现货订单
+// go test -v ./impl/okx -run "^TestSpotCancelOrder$"
+func TestSpotCancelOrder(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments":  testSpotInstrument,
+		"POST /api/v5/trade/cancel-order": `[{"ordId":"1001","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":         `[{"instId":"BTC-USDT","ordId":"1001","px":"100","sz":"0.01","ordType":"post_only","side":"buy","accFillSz":"0","avgPx":"","state":"canceled","fee":"0","feeCcy":"BTC"}]`,
+	})
+
+	order, err := o.CancelSpotOrder(context.Background(), "BTCUSDT", "1001")
+	if err != nil {
+		t.Fatalf("撤销现货订单失败: %v", err)
+	}
+	if body := ts.findRequest("POST", "/api/v5/trade/cancel-order").bodyMap(t); body["ordId"] != "1001" || body["instId"] != "BTC-USDT" {
+		t.Errorf("撤单参数错误: %+v", body)
+	}
+	if order.Status != exchange.OrderStatusCanceled || order.TimeInForce != exchange.OrderTimeInForceGTX {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+}
+
+// TestFuturesCreateOrder 创建合约订单
+// go test -v ./impl/okx -run "^TestFuturesCreateOrder$"
+func TestFuturesCreateOrder(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"2001","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        `[{"instId":"BTC-USDT-SWAP","ordId":"2001","px":"100","sz":"12","ordType":"limit","side":"sell","posSide":"short","accFillSz":"2","avgPx":"100","state":"partially_filled"}]`,
+	})
+
+	order, err := o.CreateFuturesOrder(context.Background(), "BTCUSDT", exchange.OrderSideSell, "100", "0.1234")
+	if err != nil {
+		t.Fatalf("合约下单失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/order").bodyMap(t)
+	if body["instId"] != "BTC-USDT-SWAP" || body["sz"] != "12" || body["posSide"] != "short" || body["tdMode"] != "cross" {
+		t.Errorf("下单参数错误: %+v", body)
+	}
+	if order.Quantity != "0.12" || order.ExecutedQty != "0.02" || order.QuoteQuantity != "2" {
+		t.Errorf("合约数量应转换为币的数量: %+v", order)
+	}
+}
+
+// TestFuturesGetPositionRisk 获取合约持仓风险
+// go test -v ./impl/okx -run "^TestFuturesGetPositionRisk$"
+func TestFuturesGetPositionRisk(t *testing.T) {
+	o, _ := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"GET /api/v5/account/positions":  testPositions,
+	})
+
+	positions, err := o.GetFuturesPositionRisk(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatalf("获取持仓风险失败: %v", err)
+	}
+	long := positions.GetSidePositionRisk(exchange.PositionSideLong)
+	short := positions.GetSidePositionRisk(exchange.PositionSideShort)
+	if long == nil || long.PositionAmt != "0.05" || long.MarginType != string(exchange.MarginModeCrossed) {
+		t.Errorf("多头持仓错误: %+v", long)
+	}
+	if short == nil || short.PositionAmt != "-0.03" || short.MarginType != string(exchange.MarginModeIsolated) || short.IsolatedMargin != "0.6" {
+		t.Errorf("空头持仓错误: %+v", short)
+	}
+}
+
+// TestFuturesSetSLTP 设置合约止损止盈
+// go test -v ./impl/okx -run "^TestFuturesSetSLTP$"
+func TestFuturesSetSLTP(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/account/positions": testPositions,
+		"POST /api/v5/trade/order-algo": `[{"algoId":"3001","sCode":"0","sMsg":""}]`,
+	})
+
+	if err := o.SetFuturesSLTP(context.Background(), "BTCUSDT", exchange.PositionSideShort, "110", ""); err != nil {
+		t.Fatalf("设置止损止盈失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/order-algo").bodyMap(t)
+	if body["side"] != "buy" || body["posSide"] != "short" || body["sz"] != "3" || body["slTriggerPx"] != "110" || body["slOrdPx"] != "-1" || body["tdMode"] != "isolated" {
+		t.Errorf("止损参数错误: %+v", body)
+	}
+}
+
+// TestFuturesCancelSLTP 撤销合约止损止盈
+// go test -v ./impl/okx -run "^TestFuturesCancelSLTP$"
+func TestFuturesCancelSLTP(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/trade/orders-algo-pending": `[{"instId":"BTC-USDT-SWAP","algoId":"3001","ordType":"conditional"},{"instId":"BTC-USDT-SWAP","algoId":"3002","ordType":"conditional"}]`,
+		"POST /api/v5/trade/cancel-algos":       `[{"algoId":"3001","sCode":"0"},{"algoId":"3002","sCode":"0"}]`,
+	})
+
+	if err := o.CancelFuturesSLTP(context.Background(), "BTCUSDT"); err != nil {
+		t.Fatalf("撤销止损止盈失败: %v", err)
+	}
+	var body []map[string]string
+	if err := json.Unmarshal([]byte(ts.findRequest("POST", "/api/v5/trade/cancel-algos").Body), &body); err != nil {
+		t.Fatalf("解析请求体失败: %v", err)
+	}
+	if len(body) != 2 || body[1]["algoId"] != "3002" {
+		t.Errorf("撤销参数错误: %+v", body)
+	}
+}
+
+// TestFuturesClosePositionRisk 平仓合约持仓风险
+// go test -v ./impl/okx -run "^TestFuturesClosePositionRisk$"
+func TestFuturesClosePositionRisk(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/account/positions":     testPositions,
+		"POST /api/v5/trade/close-position": `[{"instId":"BTC-USDT-SWAP","posSide":"long"}]`,
+	})
+
+	if err := o.CloseFuturesPositionRisk(context.Background(), "BTCUSDT", exchange.PositionSideLong); err != nil {
+		t.Fatalf("平仓失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/close-position").bodyMap(t)
+	if body["posSide"] != "long" || body["mgnMode"] != "cross" {
+		t.Errorf("平仓参数错误: %+v", body)
+	}
+}
+
+// TestFuturesSetLeverage 设置逐仓杠杆，开平仓模式分别设置多空杠杆，买卖模式不传持仓方向
+// go test -v ./impl/okx -run "^TestFuturesSetLeverage$"
+func TestFuturesSetLeverage(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/account/config":             `[{"posMode":"long_short_mode"}]`,
+		"POST /api/v5/account/set-leverage":      `[{"lever":"20"}]`,
+		"POST /api/v5/account/set-position-mode": `[{"posMode":"long_short_mode"}]`,
+	})
+
+	ctx := context.Background()
+	if err := o.SetFuturesDualMode(ctx, true); err != nil {
+		t.Fatalf("设置持仓模式失败: %v", err)
+	}
+	if err := o.SetFuturesMarginMode(ctx, "BTCUSDT", exchange.MarginModeIsolated); err != nil {
+		t.Fatalf("设置保证金模式失败: %v", err)
+	}
+	if err := o.SetFuturesLeverage(ctx, "BTCUSDT", 20); err != nil {
+		t.Fatalf("设置杠杆失败: %v", err)
+	}
+
+	if body := ts.findRequest("POST", "/api/v5/account/set-position-mode").bodyMap(t); body["posMode"] != "long_short_mode" {
+		t.Errorf("持仓模式参数错误: %+v", body)
+	}
+	count := 0
+	for _, req := range ts.requests {
+		if req.Path == "/api/v5/account/set-leverage" {
+			body := req.bodyMap(t)
+			if body["mgnMode"] != "isolated" || body["lever"] != "20" {
+				t.Errorf("杠杆参数错误: %+v", body)
+			}
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("逐仓模式应分别设置多空杠杆, 实际请求 %d 次", count)
+	}
+
+	// 买卖模式
+	o, ts = newTestOKX(t, map[string]string{
+		"GET /api/v5/account/config":        `[{"posMode":"net_mode"}]`,
+		"POST /api/v5/account/set-leverage": `[{"lever":"10"}]`,
+	})
+	if err := o.SetFuturesMarginMode(ctx, "BTCUSDT", exchange.MarginModeIsolated); err != nil {
+		t.Fatalf("设置保证金模式失败: %v", err)
+	}
+	if err := o.SetFuturesLeverage(ctx, "BTCUSDT", 10); err != nil {
+		t.Fatalf("设置杠杆失败: %v", err)
+	}
+	count = 0
+	for _, req := range ts.requests {
+		if req.Path == "/api/v5/account/set-leverage" {
+			if body := req.bodyMap(t); body["mgnMode"] != "isolated" || body["posSide"] != "" {
+				t.Errorf("买卖模式不应传持仓方向: %+v", body)
+			}
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("买卖模式应只设置一次杠杆, 实际请求 %d 次", count)
+	}
+
+	// 全仓模式不查询持仓方式
+	o, ts = newTestOKX(t, map[string]string{"POST /api/v5/account/set-leverage": `[{"lever":"5"}]`})
+	if err := o.SetFuturesLeverage(ctx, "BTCUSDT", 5); err != nil {
+		t.Fatalf("设置杠杆失败: %v", err)
+	}
+	if ts.findRequest("GET", "/api/v5/account/config") != nil {
+		t.Errorf("全仓模式不应查询账户配置")
+	}
+}
+
+// TestPlaceOrderPostOnly 只做挂单转换为 post_only 订单类型并使用请求指定的客户端订单ID
+// go test -v ./impl/okx -run "^TestPlaceOrderPostOnly$"
+func TestPlaceOrderPostOnly(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"1004","clOrdId":"maker1","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        `[{"instId":"BTC-USDT","ordId":"1004","clOrdId":"maker1","px":"100","sz":"0.01","ordType":"post_only","side":"buy","accFillSz":"0","avgPx":"","state":"live","fee":"0","feeCcy":"BTC"}]`,
+	})
+
+	order, err := o.PlaceOrder(context.Background(), exchange.OrderRequest{
+		Market: exchange.MarketSpot, Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeLimit,
+		Price: "100", Quantity: "0.01", PostOnly: true, ClientOrderID: "maker1",
+	})
+	if err != nil {
+		t.Fatalf("下单失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/order").bodyMap(t)
+	if body["ordType"] != "post_only" || body["px"] != "100" || body["clOrdId"] != "maker1" {
+		t.Errorf("下单参数错误: %+v", body)
+	}
+	if order.TimeInForce != exchange.OrderTimeInForceGTX || order.Type != exchange.OrderTypeLimit {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+}
+
+// TestPlaceOrderLookupFailed 下单成功但查询订单失败时按下单请求返回订单，不重试下单
+// go test -v ./impl/okx -run "^TestPlaceOrderLookupFailed$"
+func TestPlaceOrderLookupFailed(t *testing.T) {
+	const notFound = `{"code":"51603","msg":"Order does not exist","data":[]}`
+
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"1006","clOrdId":"lookup1","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        notFound,
+	})
+	order, err := o.PlaceOrder(context.Background(), exchange.OrderRequest{
+		Market: exchange.MarketSpot, Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeLimit,
+		Price: "100", Quantity: "0.01", ClientOrderID: "lookup1",
+	})
+	if err != nil {
+		t.Fatalf("查询订单失败不应返回错误: %v", err)
+	}
+	want := exchange.Order{
+		OrderID: "1006", ClientOrderID: "lookup1", Symbol: "BTC-USDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeLimit,
+		Status: exchange.OrderStatusNew, Price: "100", Quantity: "0.01", TimeInForce: exchange.OrderTimeInForceGTC,
+	}
+	if *order != want {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+	count := 0
+	for _, req := range ts.requests {
+		if req.Method == "POST" && req.Path == "/api/v5/trade/order" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("查询订单失败不应重试下单, 实际下单 %d 次", count)
+	}
+
+	o, _ = newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"2003","clOrdId":"lookup2","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        notFound,
+	})
+	order, err = o.PlaceOrder(context.Background(), exchange.OrderRequest{
+		Market: exchange.MarketFutures, Symbol: "BTCUSDT", Side: exchange.OrderSideSell, Type: exchange.OrderTypeMarket,
+		Quantity: "0.05", ClientOrderID: "lookup2",
+	})
+	if err != nil {
+		t.Fatalf("查询订单失败不应返回错误: %v", err)
+	}
+	if order.OrderID != "2003" || order.ClientOrderID != "lookup2" || order.Symbol != "BTC-USDT-SWAP" ||
+		order.Quantity != "0.05" || order.Status != exchange.OrderStatusNew {
+		t.Errorf("订单数据错误: %+v", order)
+	}
+}
+
+// TestPlaceOrderQuoteQty 按金额市价买入使用计价货币下单
+// go test -v ./impl/okx -run "^TestPlaceOrderQuoteQty$"
+func TestPlaceOrderQuoteQty(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSpotInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"1005","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        `[{"instId":"BTC-USDT","ordId":"1005","px":"","sz":"25","ordType":"market","side":"buy","accFillSz":"0.25","avgPx":"100","state":"filled","fee":"0","feeCcy":"BTC"}]`,
+	})
+
+	_, err := o.PlaceOrder(context.Background(), exchange.OrderRequest{
+		Market: exchange.MarketSpot, Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeMarket, QuoteQty: "25",
+	})
+	if err != nil {
+		t.Fatalf("下单失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/order").bodyMap(t)
+	if body["ordType"] != "market" || body["tgtCcy"] != "quote_ccy" || body["sz"] != "25" || body["px"] != "" {
+		t.Errorf("下单参数错误: %+v", body)
+	}
+}
+
+// TestPlaceOrderReduceOnly 合约只减仓按请求的持仓方向下单，单向持仓不发送 posSide
+// go test -v ./impl/okx -run "^TestPlaceOrderReduceOnly$"
+func TestPlaceOrderReduceOnly(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"POST /api/v5/trade/order":       `[{"ordId":"2002","sCode":"0","sMsg":""}]`,
+		"GET /api/v5/trade/order":        `[{"instId":"BTC-USDT-SWAP","ordId":"2002","px":"","sz":"5","ordType":"market","side":"sell","accFillSz":"5","avgPx":"100","state":"filled"}]`,
+	})
+
+	_, err := o.PlaceOrder(context.Background(), exchange.OrderRequest{
+		Market: exchange.MarketFutures, Symbol: "BTCUSDT", Side: exchange.OrderSideSell, Type: exchange.OrderTypeMarket,
+		Quantity: "0.05", ReduceOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("下单失败: %v", err)
+	}
+	body := ts.findRequest("POST", "/api/v5/trade/order").bodyMap(t)
+	if body["reduceOnly"] != "true" || body["sz"] != "5" || body["ordType"] != "market" {
+		t.Errorf("下单参数错误: %+v", body)
+	}
+	if _, ok := body["posSide"]; ok {
+		t.Errorf("单向持仓不应发送 posSide: %+v", body)
+	}
+
+	// 不支持冰山单
+	_, err = o.PlaceOrder(context.Background(), exchange.OrderRequest{
+		Market: exchange.MarketFutures, Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeLimit,
+		Price: "100", Quantity: "0.05", IcebergQty: "0.01",
+	})
+	if !errors.Is(err, exchange.ErrInvalidOrder) {
+		t.Errorf("冰山单应返回 ErrInvalidOrder: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// GetSpotOrderBook 获取现货深度快照
+func (o *okx) GetSpotOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	orderBook, err := o.getOrderBook(ctx, formatSpotInstId(symbol), limit, "")
+	if err != nil {
+		return nil, fmt.Errorf("获取现货深度失败: %w", err)
+	}
+	return orderBook, nil
+}
+
+// GetFuturesOrderBook 获取合约深度快照，合约张数按合约面值转换为基础资产数量
+func (o *okx) GetFuturesOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	instId := formatSwapInstId(symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSwap, instId)
+	if err != nil {
+		return nil, fmt.Errorf("获取产品规格失败: %w", err)
+	}
+
+	orderBook, err := o.getOrderBook(ctx, instId, limit, spec.CtVal)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约深度失败: %w", err)
+	}
+	return orderBook, nil
+}
+
+// getOrderBook 获取深度快照，ctVal 不为空时数量乘以合约面值
+func (o *okx) getOrderBook(ctx context.Context, instId string, limit int, ctVal string) (*exchange.OrderBook, error) {
+	params := map[string]string{"instId": instId}
+	if limit > 0 {
+		params["sz"] = strconv.Itoa(limit)
+	}
+	data, err := o.publicRequest(ctx, "/api/v5/market/books", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var books []okxOrderBook
+	if err := json.Unmarshal(data, &books); err != nil {
+		return nil, fmt.Errorf("解析深度数据失败: %w", err)
+	}
+	if len(books) == 0 {
+		return nil, fmt.Errorf("深度数据为空: %s", instId)
+	}
+
+	ts, _ := strconv.ParseInt(books[0].Ts, 10, 64)
+	orderBook := &exchange.OrderBook{
+		Symbol:       instId,
+		Bids:         toOrderBookLevels(books[0].Bids, ctVal),
+		Asks:         toOrderBookLevels(books[0].Asks, ctVal),
+		LastUpdateID: books[0].SeqId,
+		Time:         ts,
+	}
+	orderBook.Sort()
+	return orderBook, nil
+}
+
+// toOrderBookLevels 转换深度档位
+func toOrderBookLevels(levels [][]string, ctVal string) []exchange.OrderBookLevel {
+	res := make([]exchange.OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		quantity := level[1]
+		if ctVal != "" {
+			quantity = mulDecimal(quantity, ctVal)
+		}
+		res = append(res, exchange.OrderBookLevel{
+			Price:    level[0],
+			Quantity: quantity,
+		})
+	}
+	return res
+}
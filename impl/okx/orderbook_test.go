@@ -0,0 +1,56 @@
+package okx
+
+import (
+	"context"
+	"testing"
+)
+
+const testOrderBookData = `[{"asks":[["101.5","3","0","1"],["101","2","0","2"]],"bids":[["100","5","0","1"],["100.5","1","0","1"]],"ts":"1700000000000","seqId":123456}]`
+
+// TestSpotOrderBook 获取现货深度快照
+// go test -v ./impl/okx -run "^TestSpotOrderBook$"
+func TestSpotOrderBook(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/market/books": testOrderBookData,
+	})
+
+	book, err := o.GetSpotOrderBook(context.Background(), "BTCUSDT", 5)
+	if err != nil {
+		t.Fatalf("获取现货深度失败: %v", err)
+	}
+	if req := ts.findRequest("GET", "/api/v5/market/books"); req.Query["instId"] != "BTC-USDT" || req.Query["sz"] != "5" {
+		t.Errorf("请求参数错误: %+v", req.Query)
+	}
+	if book.LastUpdateID != 123456 || book.Time != 1700000000000 {
+		t.Errorf("序列号或时间错误: %+v", book)
+	}
+	if bid := book.BestBid(); bid == nil || bid.Price != "100.5" || bid.Quantity != "1" {
+		t.Errorf("买一错误: %+v", bid)
+	}
+	if ask := book.BestAsk(); ask == nil || ask.Price != "101" || ask.Quantity != "2" {
+		t.Errorf("卖一错误: %+v", ask)
+	}
+}
+
+// TestFuturesOrderBook 获取合约深度快照，数量按合约面值转换
+// go test -v ./impl/okx -run "^TestFuturesOrderBook$"
+func TestFuturesOrderBook(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"GET /api/v5/market/books":       testOrderBookData,
+	})
+
+	book, err := o.GetFuturesOrderBook(context.Background(), "BTCUSDT", 0)
+	if err != nil {
+		t.Fatalf("获取合约深度失败: %v", err)
+	}
+	if req := ts.findRequest("GET", "/api/v5/market/books"); req.Query["instId"] != "BTC-USDT-SWAP" || req.Query["sz"] != "" {
+		t.Errorf("请求参数错误: %+v", req.Query)
+	}
+	if len(book.Bids) != 2 || book.Bids[0].Quantity != "0.01" || book.Bids[1].Quantity != "0.05" {
+		t.Errorf("买盘数量错误: %+v", book.Bids)
+	}
+	if len(book.Asks) != 2 || book.Asks[0].Quantity != "0.02" || book.Asks[1].Price != "101.5" {
+		t.Errorf("卖盘错误: %+v", book.Asks)
+	}
+}
@@ -0,0 +1,77 @@
+package okx
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/ratelimit"
+)
+
+const (
+	RateLimitInterval    = 2 * time.Second // 限频窗口
+	DefaultEndpointLimit = 10              // 未列出接口每窗口请求上限
+)
+
+// endpointLimits 各接口每 2 秒请求上限，交易类接口按账户计数，行情类接口按 IP 计数
+var endpointLimits = map[string]int{
+	"/api/v5/trade/order":               60,
+	"/api/v5/trade/cancel-order":        60,
+	"/api/v5/trade/amend-order":         60,
+	"/api/v5/trade/batch-orders":        300,
+	"/api/v5/trade/cancel-batch-orders": 300,
+	"/api/v5/trade/amend-batch-orders":  300,
+	"/api/v5/trade/orders-pending":      60,
+	"/api/v5/trade/orders-history":      40,
+	"/api/v5/trade/fills":               60,
+	"/api/v5/trade/order-algo":          20,
+	"/api/v5/trade/cancel-algos":        20,
+	"/api/v5/trade/orders-algo-pending": 20,
+	"/api/v5/account/balance":           10,
+	"/api/v5/account/positions":         10,
+	"/api/v5/account/set-leverage":      20,
+	"/api/v5/account/set-position-mode": 5,
+	"/api/v5/account/config":            5,
+	"/api/v5/asset/transfer":            4,
+	"/api/v5/asset/bills":               12,
+	"/api/v5/public/instruments":        20,
+	"/api/v5/public/funding-rate":       20,
+	"/api/v5/market/books":              40,
+	"/api/v5/market/candles":            40,
+	"/api/v5/market/history-candles":    20,
+	"/api/v5/market/tickers":            20,
+	"/api/v5/market/ticker":             20,
+	"/api/v5/market/trades":             100,
+	"/api/v5/market/history-trades":     20,
+	"/api/v5/market/index-tickers":      20,
+}
+
+// NewRateLimiter 创建 OKX 限频器，按接口与请求方法分别计数，模拟盘与实盘分开计数。
+// 交易类接口按账户限频，同一 API Key 的多个实例应共用同一个限频器
+func NewRateLimiter(policy exchange.RateLimitPolicy) exchange.RateLimiter {
+	return ratelimit.New(policy, rateLimitModel{})
+}
+
+// rateLimitModel OKX 限频模型
+type rateLimitModel struct{}
+
+// Costs 请求消耗的额度
+func (rateLimitModel) Costs(req *http.Request) []ratelimit.Cost {
+	return []ratelimit.Cost{{Rule: rateLimitRule(req), Weight: 1}}
+}
+
+// Sync OKX 不返回限频响应头，无需同步
+func (rateLimitModel) Sync(*ratelimit.Limiter, *http.Request, *http.Response) {}
+
+// rateLimitRule 请求所属的限频规则
+func rateLimitRule(req *http.Request) ratelimit.Rule {
+	key := req.URL.Host + ":"
+	if req.Header.Get(HeaderSimulatedTrading) == "1" {
+		key += "simulated:"
+	}
+	limit, ok := endpointLimits[req.URL.Path]
+	if !ok {
+		limit = DefaultEndpointLimit
+	}
+	return ratelimit.Rule{Key: key + req.Method + " " + req.URL.Path, Limit: limit, Interval: RateLimitInterval}
+}
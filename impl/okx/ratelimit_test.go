@@ -0,0 +1,27 @@
+package okx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRateLimitRule 按接口与请求方法分别计数，模拟盘单独计数
+// go test -v ./impl/okx -run "^TestRateLimitRule$"
+func TestRateLimitRule(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, BaseURL+"/api/v5/trade/order", nil)
+	rule := rateLimitRule(req)
+	if rule.Key != "www.okx.com:POST /api/v5/trade/order" || rule.Limit != 60 || rule.Interval != RateLimitInterval {
+		t.Errorf("下单规则错误: %+v", rule)
+	}
+
+	req.Header.Set(HeaderSimulatedTrading, "1")
+	if simulated := rateLimitRule(req); simulated.Key == rule.Key {
+		t.Errorf("模拟盘应单独计数: %+v", simulated)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, BaseURL+"/api/v5/asset/balances", nil)
+	if rule = rateLimitRule(req); rule.Limit != DefaultEndpointLimit {
+		t.Errorf("未列出接口应使用默认上限: %+v", rule)
+	}
+}
@@ -0,0 +1,69 @@
+package okx
+
+import (
+	"sync"
+	"time"
+)
+
+// exchangeSpec 交易所规格
+type exchangeSpec struct {
+	Instruments []*instrumentSpec `json:"instruments"`
+	sync.RWMutex
+	UpdateTime time.Time // 更新时间
+}
+
+// instrumentSpec 产品规格
+type instrumentSpec struct {
+	InstId    string // 产品ID
+	InstType  string // 产品类型
+	BaseCcy   string // 交易货币
+	QuoteCcy  string // 计价货币
+	SettleCcy string // 结算货币
+	CtVal     string // 合约面值
+	TickSz    string // 下单价格精度
+	LotSz     string // 下单数量精度
+	MinSz     string // 最小下单数量
+	MaxLmtSz  string // 限价单最大委托数量
+	MaxMktSz  string // 市价单最大委托数量
+	Lever     string // 最大杠杆倍数
+	State     string // 产品状态
+	ExpTime   string // 下线时间，毫秒
+}
+
+// SetInstrumentSpec 设置产品规格
+func (e *exchangeSpec) SetInstrumentSpec(instId string, spec *instrumentSpec) error {
+	instrumentSpec, index := e.GetInstrumentSpec(instId)
+
+	e.Lock()
+	defer e.Unlock()
+
+	if instrumentSpec == nil {
+		e.Instruments = append(e.Instruments, spec)
+		return nil
+	}
+
+	// 更新产品规格
+	e.Instruments[index] = spec
+	return nil
+}
+
+// GetInstrumentSpec 获取产品规格
+func (e *exchangeSpec) GetInstrumentSpec(instId string) (*instrumentSpec, int) {
+	e.RLock()
+	defer e.RUnlock()
+	for i, s := range e.Instruments {
+		if s.InstId == instId {
+			return s, i
+		}
+	}
+	return nil, -1
+}
+
+// DeleteInstrumentsSpec 删除所有产品规格
+func (e *exchangeSpec) DeleteInstrumentsSpec() {
+	e.Lock()
+	defer e.Unlock()
+
+	e.Instruments = make([]*instrumentSpec, 0)
+	e.UpdateTime = time.Now()
+}
@@ -0,0 +1,42 @@
+package okx
+
+import (
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// symbolFormatter OKX 产品ID命名规则，现货为 BTC-USDT，永续合约为 BTC-USDT-SWAP，交割合约为 BTC-USDT-251226
+type symbolFormatter struct{}
+
+// Native 统一交易对转换为产品ID
+func (symbolFormatter) Native(market exchange.Market, symbol exchange.Symbol) string {
+	switch symbol.Kind {
+	case exchange.SymbolKindPerpetual:
+		return symbol.Base + "-" + symbol.Quote + "-" + InstTypeSwap
+	case exchange.SymbolKindFutures:
+		return symbol.Base + "-" + symbol.Quote + "-" + symbol.Expiry
+	default:
+		return symbol.Base + "-" + symbol.Quote
+	}
+}
+
+// Canonical 产品ID转换为统一交易对，币本位合约（USD 计价）以交易货币结算，其余以计价货币结算
+func (symbolFormatter) Canonical(market exchange.Market, native string) (exchange.Symbol, bool) {
+	parts := strings.Split(strings.ToUpper(native), "-")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return exchange.Symbol{}, false
+	}
+	base, quote := parts[0], parts[1]
+	if len(parts) == 2 {
+		return exchange.NewSpotSymbol(base, quote), true
+	}
+	settle := quote
+	if quote == "USD" {
+		settle = base
+	}
+	if parts[2] == InstTypeSwap {
+		return exchange.NewPerpetualSymbol(base, quote, settle), true
+	}
+	return exchange.NewFuturesSymbol(base, quote, settle, parts[2]), true
+}
@@ -2,14 +2,138 @@ package okx
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
 
 	"github.com/so68/exchange-lib/exchange"
 )
 
-func (o *okx) GetSpotSymbolTickers(ctx context.Context, symbol ...string) (*exchange.Tickers, error) {
-	return nil, nil
+// GetSpotSymbolTickers 获取现货交易对行情
+func (o *okx) GetSpotSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
+	tickers, err := o.getTickers(ctx, InstTypeSpot)
+	if err != nil {
+		return nil, fmt.Errorf("获取现货交易对行情失败: %w", err)
+	}
+
+	instIds := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		instIds = append(instIds, formatSpotInstId(symbol))
+	}
+
+	var data []*exchange.Ticker
+	for _, ticker := range tickers {
+		// 如果传入了 symbols，并且当前交易对不在 symbols 中，则跳过
+		if len(instIds) > 0 && !slices.Contains(instIds, ticker.InstId) {
+			continue
+		}
+		data = append(data, toSpotTicker(ticker))
+	}
+
+	return &exchange.Tickers{
+		Tickers: data,
+	}, nil
+}
+
+// GetFuturesSymbolTickers 获取合约交易对行情
+func (o *okx) GetFuturesSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
+	tickers, err := o.getTickers(ctx, InstTypeSwap)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约交易对行情失败: %w", err)
+	}
+
+	instIds := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		instIds = append(instIds, formatSwapInstId(symbol))
+	}
+
+	var data []*exchange.Ticker
+	for _, ticker := range tickers {
+		// 如果传入了 symbols，并且当前交易对不在 symbols 中，则跳过
+		if len(instIds) > 0 && !slices.Contains(instIds, ticker.InstId) {
+			continue
+		}
+		data = append(data, toSwapTicker(ticker))
+	}
+
+	return &exchange.Tickers{
+		Tickers: data,
+	}, nil
+}
+
+// getTickers 获取指定产品类型的全部行情
+func (o *okx) getTickers(ctx context.Context, instType string) ([]*okxTicker, error) {
+	resp, err := o.publicRequest(ctx, "/api/v5/market/tickers", map[string]string{
+		"instType": instType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tickers []*okxTicker
+	if err := json.Unmarshal(resp, &tickers); err != nil {
+		return nil, fmt.Errorf("unmarshal tickers data error: %w", err)
+	}
+	return tickers, nil
 }
 
-func (o *okx) GetFuturesSymbolTickers(ctx context.Context, symbol ...string) (*exchange.Tickers, error) {
-	return nil, nil
+// toSpotTicker 转换现货行情
+func toSpotTicker(ticker *okxTicker) *exchange.Ticker {
+	openPrice, priceChange, priceChangePercent := calculateChangePrice(ticker.Last, ticker.Open24h)
+	return &exchange.Ticker{
+		Symbol:             ticker.InstId,
+		PriceChange:        priceChange,
+		PriceChangePercent: priceChangePercent,
+		WeightedAvgPrice:   "", // OKX API 不提供加权平均价
+		LastPrice:          ticker.Last,
+		LastQty:            ticker.LastSz,
+		OpenPrice:          openPrice,
+		HighPrice:          ticker.High24h,
+		LowPrice:           ticker.Low24h,
+		Volume:             ticker.Vol24h,
+		QuoteVolume:        ticker.VolCcy24h,
+		Count:              0, // OKX API 不提供成交笔数
+	}
+}
+
+// toSwapTicker 转换永续合约行情
+func toSwapTicker(ticker *okxTicker) *exchange.Ticker {
+	// 合约 vol24h 以张为单位，volCcy24h 以币为单位，成交额 = volCcy24h * 最新价
+	quoteVolume := mulDecimal(ticker.VolCcy24h, ticker.Last)
+
+	openPrice, priceChange, priceChangePercent := calculateChangePrice(ticker.Last, ticker.Open24h)
+	return &exchange.Ticker{
+		Symbol:             ticker.InstId,
+		PriceChange:        priceChange,
+		PriceChangePercent: priceChangePercent,
+		WeightedAvgPrice:   "", // OKX API 不提供加权平均价
+		LastPrice:          ticker.Last,
+		LastQty:            ticker.LastSz,
+		OpenPrice:          openPrice,
+		HighPrice:          ticker.High24h,
+		LowPrice:           ticker.Low24h,
+		Volume:             ticker.VolCcy24h,
+		QuoteVolume:        quoteVolume,
+		Count:              0, // OKX API 不提供成交笔数
+	}
+}
+
+// calculateChangePrice 根据最新价和24小时开盘价计算价格变动和涨跌幅
+// PriceChange = Last - Open24h
+// PriceChangePercent = PriceChange / Open24h * 100
+func calculateChangePrice(lastPrice, openPrice string) (open, priceChange, priceChangePercent string) {
+	last, err := exchange.ParseDecimal(lastPrice)
+	if err != nil || lastPrice == "" {
+		return openPrice, "", ""
+	}
+	open24h, err := exchange.ParseDecimal(openPrice)
+	if err != nil || open24h.IsZero() {
+		return openPrice, "", ""
+	}
+
+	change := last.Sub(open24h)
+	percent := change.Div(open24h).Mul(exchange.NewDecimalFromInt(100))
+
+	precision := int32(decimalPlaces(lastPrice))
+	return openPrice, change.StringFixed(precision), percent.StringFixed(2)
 }
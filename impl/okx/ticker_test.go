@@ -0,0 +1,60 @@
+package okx
+
+import (
+	"context"
+	"testing"
+)
+
+// TestGetSpotSymbolTickers 获取现货交易对行情
+// go test -v ./impl/okx -run "^TestGetSpotSymbolTickers$"
+func TestGetSpotSymbolTickers(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/market/tickers": `[
+			{"instType":"SPOT","instId":"BTC-USDT","last":"110","lastSz":"0.1","open24h":"100","high24h":"120","low24h":"90","vol24h":"10","volCcy24h":"1000"},
+			{"instType":"SPOT","instId":"ETH-USDT","last":"3000","lastSz":"1","open24h":"3000","high24h":"3100","low24h":"2900","vol24h":"5","volCcy24h":"15000"}
+		]`,
+	})
+
+	tickers, err := o.GetSpotSymbolTickers(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatalf("获取交易对行情失败: %v", err)
+	}
+	if len(tickers.Tickers) != 1 {
+		t.Fatalf("行情数量错误: %d", len(tickers.Tickers))
+	}
+	ticker := tickers.GetTicker("BTC-USDT")
+	if ticker == nil {
+		t.Fatalf("未找到 BTC-USDT 行情")
+	}
+	if ticker.PriceChange != "10" || ticker.PriceChangePercent != "10.00" || ticker.Volume != "10" || ticker.QuoteVolume != "1000" {
+		t.Errorf("行情数据错误: %+v", ticker)
+	}
+	if req := ts.findRequest("GET", "/api/v5/market/tickers"); req == nil || req.Query["instType"] != "SPOT" {
+		t.Errorf("请求参数错误: %+v", req)
+	}
+}
+
+// TestGetFuturesSymbolTickers 获取合约交易对行情
+// go test -v ./impl/okx -run "^TestGetFuturesSymbolTickers$"
+func TestGetFuturesSymbolTickers(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/market/tickers": `[
+			{"instType":"SWAP","instId":"BTC-USDT-SWAP","last":"100.5","lastSz":"2","open24h":"100","high24h":"101","low24h":"99","vol24h":"2000","volCcy24h":"20"}
+		]`,
+	})
+
+	tickers, err := o.GetFuturesSymbolTickers(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatalf("获取交易对行情失败: %v", err)
+	}
+	ticker := tickers.GetTicker("BTC-USDT-SWAP")
+	if ticker == nil {
+		t.Fatalf("未找到 BTC-USDT-SWAP 行情")
+	}
+	if ticker.Volume != "20" || ticker.QuoteVolume != "2010" {
+		t.Errorf("成交量数据错误: %+v", ticker)
+	}
+	if req := ts.findRequest("GET", "/api/v5/market/tickers"); req == nil || req.Query["instType"] != "SWAP" {
+		t.Errorf("请求参数错误: %+v", req)
+	}
+}
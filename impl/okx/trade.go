@@ -0,0 +1,176 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/utils"
+)
+
+const (
+	HistoryTradesPageSize = 100 // 历史成交单页最大数量
+)
+
+// GetSpotRecentTrades 获取现货最近成交
+func (o *okx) GetSpotRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	trades, err := o.getRecentTrades(ctx, formatSpotInstId(symbol), limit, "")
+	if err != nil {
+		return nil, fmt.Errorf("获取现货最近成交失败: %w", err)
+	}
+	return trades, nil
+}
+
+// GetFuturesRecentTrades 获取合约最近成交，合约张数按合约面值转换为基础资产数量
+func (o *okx) GetFuturesRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	instId := formatSwapInstId(symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSwap, instId)
+	if err != nil {
+		return nil, fmt.Errorf("获取产品规格失败: %w", err)
+	}
+	trades, err := o.getRecentTrades(ctx, instId, limit, spec.CtVal)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约最近成交失败: %w", err)
+	}
+	return trades, nil
+}
+
+// GetSpotAggTrades 获取现货历史成交，OKX 成交已按吃单订单聚合
+func (o *okx) GetSpotAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	trades, err := o.getHistoryTrades(ctx, formatSpotInstId(symbol), start, end, limit, "")
+	if err != nil {
+		return nil, fmt.Errorf("获取现货历史成交失败: %w", err)
+	}
+	return trades, nil
+}
+
+// GetFuturesAggTrades 获取合约历史成交，合约张数按合约面值转换为基础资产数量
+func (o *okx) GetFuturesAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	instId := formatSwapInstId(symbol)
+	spec, err := o.getInstrumentSpec(ctx, InstTypeSwap, instId)
+	if err != nil {
+		return nil, fmt.Errorf("获取产品规格失败: %w", err)
+	}
+	trades, err := o.getHistoryTrades(ctx, instId, start, end, limit, spec.CtVal)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约历史成交失败: %w", err)
+	}
+	return trades, nil
+}
+
+// getRecentTrades 获取最近成交，接口按时间倒序返回
+func (o *okx) getRecentTrades(ctx context.Context, instId string, limit int, ctVal string) ([]*exchange.Trade, error) {
+	params := map[string]string{"instId": instId}
+	if limit > 0 {
+		params["limit"] = strconv.Itoa(limit)
+	}
+	data, err := o.publicRequest(ctx, "/api/v5/market/trades", params)
+	if err != nil {
+		return nil, err
+	}
+	trades, err := parseTrades(data, ctVal)
+	if err != nil {
+		return nil, err
+	}
+	reverseTrades(trades)
+	return trades, nil
+}
+
+// getHistoryTrades 从 end 向前分页查询历史成交，直到早于 start
+// 首页按时间戳定位（type=2），之后按成交ID分页（type=1），避免同一毫秒的成交跨页丢失
+func (o *okx) getHistoryTrades(ctx context.Context, instId string, start, end time.Time, limit int, ctVal string) ([]*exchange.Trade, error) {
+	if start.IsZero() {
+		return nil, fmt.Errorf("开始时间不能为空")
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	startMs, endMs := start.UnixMilli(), end.UnixMilli()
+	if endMs < startMs {
+		return nil, fmt.Errorf("结束时间不能早于开始时间")
+	}
+
+	params := map[string]string{
+		"instId": instId,
+		"type":   "2",
+		"after":  strconv.FormatInt(endMs+1, 10),
+		"limit":  strconv.Itoa(HistoryTradesPageSize),
+	}
+	var trades []*exchange.Trade
+	for i := 0; i < utils.MaxPages; i++ {
+		data, err := o.publicRequest(ctx, "/api/v5/market/history-trades", params)
+		if err != nil {
+			return nil, err
+		}
+		page, err := parseTrades(data, ctVal)
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(page) < HistoryTradesPageSize
+		for _, trade := range page {
+			if trade.Time < startMs {
+				done = true
+				break
+			}
+			if trade.Time <= endMs {
+				trades = append(trades, trade)
+			}
+		}
+		if done {
+			break
+		}
+		params["type"] = "1"
+		params["after"] = page[len(page)-1].ID
+	}
+
+	reverseTrades(trades)
+	if limit > 0 && len(trades) > limit {
+		trades = trades[:limit]
+	}
+	return trades, nil
+}
+
+// parseTrades 解析成交数据，ctVal 不为空时数量乘以合约面值
+func parseTrades(data json.RawMessage, ctVal string) ([]*exchange.Trade, error) {
+	var items []*okxTrade
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("解析成交数据失败: %w", err)
+	}
+	trades := make([]*exchange.Trade, 0, len(items))
+	for _, item := range items {
+		trades = append(trades, toTrade(item, ctVal))
+	}
+	return trades, nil
+}
+
+// toTrade 转换成交，ctVal 不为空时数量乘以合约面值
+func toTrade(item *okxTrade, ctVal string) *exchange.Trade {
+	quantity := item.Sz
+	if ctVal != "" {
+		quantity = mulDecimal(quantity, ctVal)
+	}
+	side := exchange.OrderSideBuy
+	if item.Side == "sell" {
+		side = exchange.OrderSideSell
+	}
+	ts, _ := strconv.ParseInt(item.Ts, 10, 64)
+	return &exchange.Trade{
+		Symbol:   item.InstId,
+		ID:       item.TradeId,
+		Price:    item.Px,
+		Quantity: quantity,
+		Side:     side,
+		Time:     ts,
+	}
+}
+
+// reverseTrades 倒序排列的成交转换为升序
+func reverseTrades(trades []*exchange.Trade) {
+	for i, j := 0, len(trades)-1; i < j; i, j = i+1, j-1 {
+		trades[i], trades[j] = trades[j], trades[i]
+	}
+}
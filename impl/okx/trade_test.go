@@ -0,0 +1,66 @@
+package okx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// 按时间倒序返回的成交
+const testTradeData = `[
+	{"instId":"BTC-USDT-SWAP","side":"sell","sz":"3","px":"101","source":"0","tradeId":"1003","ts":"1700000003000"},
+	{"instId":"BTC-USDT-SWAP","side":"buy","sz":"2","px":"100.5","source":"0","tradeId":"1002","ts":"1700000002000"},
+	{"instId":"BTC-USDT-SWAP","side":"buy","sz":"1","px":"100","source":"0","tradeId":"1001","ts":"1700000001000"}
+]`
+
+// TestFuturesRecentTrades 获取合约最近成交，转换为升序并按面值转换数量
+// go test -v ./impl/okx -run "^TestFuturesRecentTrades$"
+func TestFuturesRecentTrades(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/public/instruments": testSwapInstrument,
+		"GET /api/v5/market/trades":      testTradeData,
+	})
+
+	trades, err := o.GetFuturesRecentTrades(context.Background(), "BTCUSDT", 3)
+	if err != nil {
+		t.Fatalf("获取合约最近成交失败: %v", err)
+	}
+	if req := ts.findRequest("GET", "/api/v5/market/trades"); req.Query["instId"] != "BTC-USDT-SWAP" || req.Query["limit"] != "3" {
+		t.Errorf("请求参数错误: %+v", req.Query)
+	}
+	if len(trades) != 3 {
+		t.Fatalf("成交数量错误: %d", len(trades))
+	}
+	if trade := trades[0]; trade.ID != "1001" || trade.Quantity != "0.01" || trade.Side != exchange.OrderSideBuy || trade.Time != 1700000001000 {
+		t.Errorf("第一笔成交错误: %+v", trade)
+	}
+	if trade := trades[2]; trade.ID != "1003" || trade.Quantity != "0.03" || trade.Side != exchange.OrderSideSell {
+		t.Errorf("最后一笔成交错误: %+v", trade)
+	}
+}
+
+// TestSpotAggTrades 获取现货历史成交，过滤早于开始时间的成交
+// go test -v ./impl/okx -run "^TestSpotAggTrades$"
+func TestSpotAggTrades(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/market/history-trades": testTradeData,
+	})
+
+	trades, err := o.GetSpotAggTrades(context.Background(), "BTCUSDT", time.UnixMilli(1700000002000), time.UnixMilli(1700000003000), 0)
+	if err != nil {
+		t.Fatalf("获取现货历史成交失败: %v", err)
+	}
+	req := ts.findRequest("GET", "/api/v5/market/history-trades")
+	if req.Query["instId"] != "BTC-USDT" || req.Query["type"] != "2" || req.Query["after"] != "1700000003001" || req.Query["limit"] != "100" {
+		t.Errorf("请求参数错误: %+v", req.Query)
+	}
+	if len(trades) != 2 || trades[0].ID != "1002" || trades[1].ID != "1003" || trades[1].Quantity != "3" {
+		t.Errorf("成交错误: %+v", trades)
+	}
+
+	if _, err := o.GetSpotAggTrades(context.Background(), "BTCUSDT", time.Time{}, time.Time{}, 0); err == nil {
+		t.Error("开始时间为空应返回错误")
+	}
+}
@@ -0,0 +1,129 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	AccountFunding = "6"  // 资金账户
+	AccountTrading = "18" // 交易账户，现货与合约共用
+
+	AssetBillTransferIn  = "130" // 资金账户账单类型：从交易账户转入
+	AssetBillTransferOut = "131" // 资金账户账单类型：转出至交易账户
+
+	TransferListLimit = 100 // 划转记录每页最大数量
+)
+
+// toOKXAccount 账户类型转换为交易所账户，现货与合约均为交易账户
+func toOKXAccount(account exchange.AccountType) string {
+	if account == exchange.AccountTypeFunding {
+		return AccountFunding
+	}
+	return AccountTrading
+}
+
+// Transfer 在资金账户与交易账户之间划转，现货与合约共用交易账户，两者之间无需划转
+func (o *okx) Transfer(ctx context.Context, asset, amount string, from, to exchange.AccountType) (string, error) {
+	if err := exchange.ValidateTransfer(asset, amount, from, to); err != nil {
+		return "", err
+	}
+	if toOKXAccount(from) == toOKXAccount(to) {
+		return "", fmt.Errorf("%w: 现货与合约共用交易账户，无需划转", exchange.ErrInvalidTransfer)
+	}
+	resp, err := o.authRequest(ctx, "POST", "/api/v5/asset/transfer", nil, map[string]string{
+		"ccy":  asset,
+		"amt":  amount,
+		"from": toOKXAccount(from),
+		"to":   toOKXAccount(to),
+		"type": "0",
+	})
+	if err != nil {
+		return "", fmt.Errorf("资金划转失败: %w", err)
+	}
+	var transfers []*okxTransfer
+	if err := json.Unmarshal(resp, &transfers); err != nil {
+		return "", fmt.Errorf("unmarshal transfer error: %w", err)
+	}
+	if len(transfers) == 0 {
+		return "", fmt.Errorf("划转结果为空")
+	}
+	return transfers[0].TransId, nil
+}
+
+// ListTransfers 按时间倒序分页查询资金账户与交易账户之间的划转，游标为上一页最后一条账单的时间。
+// 使用资金账户账单流水，划转ID为账单ID，交易账户记为合约账户
+func (o *okx) ListTransfers(ctx context.Context, query exchange.TransferQuery) (*exchange.TransferPage, error) {
+	if query.From != "" && query.To != "" && toOKXAccount(query.From) == toOKXAccount(query.To) {
+		return nil, fmt.Errorf("%w: 转出与转入账户相同或均为交易账户", exchange.ErrInvalidTransfer)
+	}
+	// 按划转方向查询，未指定账户时查询全部账单后过滤
+	billType := ""
+	switch {
+	case query.From == exchange.AccountTypeFunding, query.To != "" && query.To != exchange.AccountTypeFunding:
+		billType = AssetBillTransferOut
+	case query.To == exchange.AccountTypeFunding, query.From != "":
+		billType = AssetBillTransferIn
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > TransferListLimit {
+		limit = TransferListLimit
+	}
+	params := map[string]string{
+		"ccy":   query.Asset,
+		"type":  billType,
+		"limit": strconv.Itoa(limit),
+		"after": query.Cursor,
+	}
+	// before/after 不包含边界，向外扩展 1 毫秒
+	if !query.StartTime.IsZero() {
+		params["before"] = strconv.FormatInt(query.StartTime.UnixMilli()-1, 10)
+	}
+	if query.Cursor == "" && !query.EndTime.IsZero() {
+		params["after"] = strconv.FormatInt(query.EndTime.UnixMilli()+1, 10)
+	}
+	resp, err := o.authRequest(ctx, "GET", "/api/v5/asset/bills", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取划转记录失败: %w", err)
+	}
+	var bills []*okxAssetBill
+	if err := json.Unmarshal(resp, &bills); err != nil {
+		return nil, fmt.Errorf("unmarshal asset bills error: %w", err)
+	}
+
+	page := &exchange.TransferPage{Transfers: make([]*exchange.Transfer, 0, len(bills))}
+	for _, bill := range bills {
+		if transfer := toTransfer(bill); transfer != nil {
+			page.Transfers = append(page.Transfers, transfer)
+		}
+	}
+	if len(bills) >= limit {
+		page.NextCursor = bills[len(bills)-1].Ts
+	}
+	return page, nil
+}
+
+// toTransfer 转换资金账户划转账单，非划转账单返回 nil
+func toTransfer(bill *okxAssetBill) *exchange.Transfer {
+	transfer := &exchange.Transfer{
+		ID:     bill.BillId,
+		Asset:  bill.Ccy,
+		Amount: exchange.ToDecimal(bill.BalChg).Abs().String(),
+		Status: exchange.TransferStatusSuccess,
+	}
+	switch bill.Type {
+	case AssetBillTransferIn:
+		transfer.From, transfer.To = exchange.AccountTypeFutures, exchange.AccountTypeFunding
+	case AssetBillTransferOut:
+		transfer.From, transfer.To = exchange.AccountTypeFunding, exchange.AccountTypeFutures
+	default:
+		return nil
+	}
+	transfer.Time, _ = strconv.ParseInt(bill.Ts, 10, 64)
+	return transfer
+}
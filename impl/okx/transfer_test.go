@@ -0,0 +1,75 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestTransfer 资金账户与交易账户之间划转，现货与合约之间划转返回 ErrInvalidTransfer
+// go test -v ./impl/okx -run "^TestTransfer$"
+func TestTransfer(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"POST /api/v5/asset/transfer": `[{"transId":"754147","ccy":"USDT","from":"6","amt":"100","to":"18","clientId":""}]`,
+	})
+
+	id, err := o.Transfer(context.Background(), "USDT", "100", exchange.AccountTypeFunding, exchange.AccountTypeFutures)
+	if err != nil {
+		t.Fatalf("资金划转失败: %v", err)
+	}
+	if id != "754147" {
+		t.Errorf("划转ID错误: %s", id)
+	}
+	body := ts.findRequest("POST", "/api/v5/asset/transfer").bodyMap(t)
+	if body["ccy"] != "USDT" || body["amt"] != "100" || body["from"] != "6" || body["to"] != "18" || body["type"] != "0" {
+		t.Errorf("请求参数错误: %+v", body)
+	}
+
+	if _, err := o.Transfer(context.Background(), "USDT", "100", exchange.AccountTypeSpot, exchange.AccountTypeFutures); !errors.Is(err, exchange.ErrInvalidTransfer) {
+		t.Errorf("现货划转到合约应返回 ErrInvalidTransfer, got %v", err)
+	}
+}
+
+// TestListTransfers 资金账户划转账单转换为划转记录，按方向查询对应账单类型，以最后一条账单时间作为下一页游标
+// go test -v ./impl/okx -run "^TestListTransfers$"
+func TestListTransfers(t *testing.T) {
+	o, ts := newTestOKX(t, map[string]string{
+		"GET /api/v5/asset/bills": `[
+			{"billId":"b3","ccy":"USDT","balChg":"-100","bal":"0","type":"131","ts":"1700000003000"},
+			{"billId":"b2","ccy":"USDT","balChg":"5","bal":"100","type":"1","ts":"1700000002000"},
+			{"billId":"b1","ccy":"USDT","balChg":"95","bal":"95","type":"130","ts":"1700000001000"}
+		]`,
+	})
+
+	page, err := o.ListTransfers(context.Background(), exchange.TransferQuery{Asset: "USDT", Limit: 3})
+	if err != nil {
+		t.Fatalf("获取划转记录失败: %v", err)
+	}
+	if len(page.Transfers) != 2 || page.NextCursor != "1700000001000" {
+		t.Fatalf("划转记录分页错误: %+v", page)
+	}
+	out, in := page.Transfers[0], page.Transfers[1]
+	if out.ID != "b3" || out.Amount != "100" || out.From != exchange.AccountTypeFunding || out.To != exchange.AccountTypeFutures || out.Time != 1700000003000 {
+		t.Errorf("转出记录错误: %+v", out)
+	}
+	if in.Amount != "95" || in.From != exchange.AccountTypeFutures || in.To != exchange.AccountTypeFunding || in.Status != exchange.TransferStatusSuccess {
+		t.Errorf("转入记录错误: %+v", in)
+	}
+	query := ts.findRequest("GET", "/api/v5/asset/bills").Query
+	if query["ccy"] != "USDT" || query["limit"] != "3" || query["type"] != "" {
+		t.Errorf("查询参数错误: %+v", query)
+	}
+
+	o, ts = newTestOKX(t, map[string]string{"GET /api/v5/asset/bills": `[]`})
+	if _, err := o.ListTransfers(context.Background(), exchange.TransferQuery{From: exchange.AccountTypeSpot}); err != nil {
+		t.Fatalf("获取划转记录失败: %v", err)
+	}
+	if query := ts.findRequest("GET", "/api/v5/asset/bills").Query; query["type"] != AssetBillTransferIn {
+		t.Errorf("转出交易账户应查询转入资金账户的账单: %+v", query)
+	}
+	if _, err := o.ListTransfers(context.Background(), exchange.TransferQuery{From: exchange.AccountTypeSpot, To: exchange.AccountTypeFutures}); !errors.Is(err, exchange.ErrInvalidTransfer) {
+		t.Errorf("现货与合约之间应返回 ErrInvalidTransfer, got %v", err)
+	}
+}
@@ -1,9 +1,280 @@
 package okx
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+var okxSpotSpec *exchangeSpec
+var okxSwapSpec *exchangeSpec
+var okxSpotTestnetSpec *exchangeSpec
+var okxSwapTestnetSpec *exchangeSpec
+
+func init() {
+	okxSpotSpec = &exchangeSpec{
+		Instruments: make([]*instrumentSpec, 0),
+		UpdateTime:  time.Now(),
+	}
+	okxSwapSpec = &exchangeSpec{
+		Instruments: make([]*instrumentSpec, 0),
+		UpdateTime:  time.Now(),
+	}
+	okxSpotTestnetSpec = &exchangeSpec{
+		Instruments: make([]*instrumentSpec, 0),
+		UpdateTime:  time.Now(),
+	}
+	okxSwapTestnetSpec = &exchangeSpec{
+		Instruments: make([]*instrumentSpec, 0),
+		UpdateTime:  time.Now(),
+	}
+
+	// 定时更新交易对规格
+	initSymbolsSpec()
+}
+
+// initSymbolsSpec 重新加载交易对规格
+func initSymbolsSpec() {
+	okxSpotSpec.DeleteInstrumentsSpec()
+	okxSwapSpec.DeleteInstrumentsSpec()
+	okxSpotTestnetSpec.DeleteInstrumentsSpec()
+	okxSwapTestnetSpec.DeleteInstrumentsSpec()
+
+	time.AfterFunc(time.Hour, initSymbolsSpec)
+}
+
+// getSpec 获取请求对应环境的产品规格缓存
+func (o *okx) getSpec(ctx context.Context, instType string) *exchangeSpec {
+	if o.isTestnet(ctx) {
+		if instType == InstTypeSwap {
+			return okxSwapTestnetSpec
+		}
+		return okxSpotTestnetSpec
+	}
+	if instType == InstTypeSwap {
+		return okxSwapSpec
+	}
+	return okxSpotSpec
+}
 
 type okxResp struct {
 	Code string          `json:"code"` // 0 成功
 	Msg  string          `json:"msg"`  // 错误信息
 	Data json.RawMessage `json:"data"` // 数据
 }
+
+// okxOrderResult 下单/撤单结果
+type okxOrderResult struct {
+	OrdId   string `json:"ordId"`   // 订单ID
+	ClOrdId string `json:"clOrdId"` // 客户自定义订单ID
+	AlgoId  string `json:"algoId"`  // 策略委托单ID
+	SCode   string `json:"sCode"`   // 事件执行结果的code，0代表成功
+	SMsg    string `json:"sMsg"`    // 事件执行失败时的msg
+}
+
+// okxAccountConfig 账户配置
+type okxAccountConfig struct {
+	PosMode string `json:"posMode"` // 持仓方式 long_short_mode: 开平仓模式 net_mode: 买卖模式
+}
+
+// okxBalance 账户余额
+type okxBalance struct {
+	TotalEq string              `json:"totalEq"` // 美金层面权益
+	UTime   string              `json:"uTime"`   // 更新时间
+	Details []*okxBalanceDetail `json:"details"` // 各币种资产详细信息
+}
+
+// okxBalanceDetail 币种余额
+type okxBalanceDetail struct {
+	Ccy       string `json:"ccy"`       // 币种
+	Eq        string `json:"eq"`        // 币种总权益
+	CashBal   string `json:"cashBal"`   // 币种余额
+	AvailBal  string `json:"availBal"`  // 可用余额
+	AvailEq   string `json:"availEq"`   // 可用保证金
+	FrozenBal string `json:"frozenBal"` // 币种占用金额
+}
+
+// okxTicker 行情
+type okxTicker struct {
+	InstType  string `json:"instType"`  // 产品类型
+	InstId    string `json:"instId"`    // 产品ID
+	Last      string `json:"last"`      // 最新成交价
+	LastSz    string `json:"lastSz"`    // 最新成交的数量
+	Open24h   string `json:"open24h"`   // 24小时开盘价
+	High24h   string `json:"high24h"`   // 24小时最高价
+	Low24h    string `json:"low24h"`    // 24小时最低价
+	Vol24h    string `json:"vol24h"`    // 24小时成交量，现货以交易货币为单位，合约以张为单位
+	VolCcy24h string `json:"volCcy24h"` // 24小时成交量，现货以计价货币为单位，合约以币为单位
+	Ts        string `json:"ts"`        // 数据产生时间
+}
+
+// okxInstrument 产品信息
+type okxInstrument struct {
+	InstType  string `json:"instType"`  // 产品类型
+	InstId    string `json:"instId"`    // 产品ID
+	BaseCcy   string `json:"baseCcy"`   // 交易货币币种，仅适用于现货
+	QuoteCcy  string `json:"quoteCcy"`  // 计价货币币种，仅适用于现货
+	SettleCcy string `json:"settleCcy"` // 盈亏结算和保证金币种，仅适用于合约
+	CtVal     string `json:"ctVal"`     // 合约面值，仅适用于合约
+	CtValCcy  string `json:"ctValCcy"`  // 合约面值计价币种，仅适用于合约
+	TickSz    string `json:"tickSz"`    // 下单价格精度
+	LotSz     string `json:"lotSz"`     // 下单数量精度
+	MinSz     string `json:"minSz"`     // 最小下单数量
+	MaxLmtSz  string `json:"maxLmtSz"`  // 限价单的单笔最大委托数量
+	MaxMktSz  string `json:"maxMktSz"`  // 市价单的单笔最大委托数量
+	Lever     string `json:"lever"`     // 该instId支持的最大杠杆倍数
+	State     string `json:"state"`     // 产品状态 live: 交易中 suspend: 暂停中 preopen: 预上线
+	ExpTime   string `json:"expTime"`   // 产品下线时间，毫秒
+}
+
+// okxOrder 订单
+type okxOrder struct {
+	InstType  string `json:"instType"`  // 产品类型
+	InstId    string `json:"instId"`    // 产品ID
+	OrdId     string `json:"ordId"`     // 订单ID
+	ClOrdId   string `json:"clOrdId"`   // 客户自定义订单ID
+	Px        string `json:"px"`        // 委托价格
+	Sz        string `json:"sz"`        // 委托数量
+	OrdType   string `json:"ordType"`   // 订单类型 market limit post_only fok ioc
+	Side      string `json:"side"`      // 订单方向 buy sell
+	PosSide   string `json:"posSide"`   // 持仓方向
+	TdMode    string `json:"tdMode"`    // 交易模式
+	AccFillSz string `json:"accFillSz"` // 累计成交数量
+	AvgPx     string `json:"avgPx"`     // 成交均价
+	State     string `json:"state"`     // 订单状态 live partially_filled filled canceled mmp_canceled
+	Fee       string `json:"fee"`       // 订单交易累计的手续费与返佣，手续费为负数
+	FeeCcy    string `json:"feeCcy"`    // 交易手续费币种
+	TgtCcy    string `json:"tgtCcy"`    // 市价单委托数量sz的单位
+	CTime     string `json:"cTime"`     // 订单创建时间
+	UTime     string `json:"uTime"`     // 订单状态更新时间
+}
+
+// okxFundingRate 资金费率
+type okxFundingRate struct {
+	InstId          string `json:"instId"`          // 产品ID
+	FundingRate     string `json:"fundingRate"`     // 资金费率，当前费率为本期预测费率
+	RealizedRate    string `json:"realizedRate"`    // 实际资金费率，仅历史记录
+	FundingTime     string `json:"fundingTime"`     // 资金费时间，当前费率为本期结算时间
+	NextFundingTime string `json:"nextFundingTime"` // 下一期资金费时间
+}
+
+// okxMarkPrice 标记价格
+type okxMarkPrice struct {
+	InstId string `json:"instId"` // 产品ID
+	MarkPx string `json:"markPx"` // 标记价格
+	Ts     string `json:"ts"`     // 数据产生时间
+}
+
+// okxIndexTicker 指数行情
+type okxIndexTicker struct {
+	InstId string `json:"instId"` // 指数，如 BTC-USDT
+	IdxPx  string `json:"idxPx"`  // 最新指数价格
+	Ts     string `json:"ts"`     // 数据产生时间
+}
+
+// okxFill 成交明细
+type okxFill struct {
+	InstType string `json:"instType"` // 产品类型
+	InstId   string `json:"instId"`   // 产品ID
+	TradeId  string `json:"tradeId"`  // 最新成交ID
+	OrdId    string `json:"ordId"`    // 订单ID
+	BillId   string `json:"billId"`   // 账单ID
+	FillPx   string `json:"fillPx"`   // 最新成交价格
+	FillSz   string `json:"fillSz"`   // 最新成交数量，合约以张为单位
+	FillPnl  string `json:"fillPnl"`  // 最新成交收益，仅适用于平仓
+	Side     string `json:"side"`     // 订单方向 buy sell
+	ExecType string `json:"execType"` // 流动性方向 T：taker M：maker
+	Fee      string `json:"fee"`      // 手续费，负数表示扣除，正数表示返佣
+	FeeCcy   string `json:"feeCcy"`   // 手续费币种
+	Ts       string `json:"ts"`       // 成交明细产生时间
+}
+
+// okxBill 账户账单流水
+type okxBill struct {
+	BillId   string `json:"billId"`   // 账单ID
+	InstType string `json:"instType"` // 产品类型，与产品无关的账单为空
+	InstId   string `json:"instId"`   // 产品ID
+	Ccy      string `json:"ccy"`      // 账户余额币种
+	Type     string `json:"type"`     // 账单类型 1：划转 2：交易 5：强平 8：资金费 9：自动减仓
+	SubType  string `json:"subType"`  // 账单子类型
+	BalChg   string `json:"balChg"`   // 账户层面的余额变动数量
+	Pnl      string `json:"pnl"`      // 收益
+	Fee      string `json:"fee"`      // 手续费，负数表示扣除，正数表示返佣
+	TradeId  string `json:"tradeId"`  // 最新成交ID
+	Ts       string `json:"ts"`       // 账单创建时间
+}
+
+// okxTransfer 资金划转结果
+type okxTransfer struct {
+	TransId string `json:"transId"` // 划转ID
+	Ccy     string `json:"ccy"`     // 划转币种
+	From    string `json:"from"`    // 转出账户 6：资金账户 18：交易账户
+	To      string `json:"to"`      // 转入账户
+	Amt     string `json:"amt"`     // 划转数量
+}
+
+// okxAssetBill 资金账户账单流水
+type okxAssetBill struct {
+	BillId string `json:"billId"` // 账单ID
+	Ccy    string `json:"ccy"`    // 币种
+	BalChg string `json:"balChg"` // 余额变动数量
+	Bal    string `json:"bal"`    // 账户余额
+	Type   string `json:"type"`   // 账单类型 130：从交易账户转入 131：转出至交易账户
+	Ts     string `json:"ts"`     // 账单创建时间
+}
+
+// okxPosition 持仓
+type okxPosition struct {
+	InstType    string `json:"instType"`    // 产品类型
+	InstId      string `json:"instId"`      // 产品ID
+	MgnMode     string `json:"mgnMode"`     // 保证金模式 cross isolated
+	PosSide     string `json:"posSide"`     // 持仓方向 long short net
+	Pos         string `json:"pos"`         // 持仓数量（张）
+	AvgPx       string `json:"avgPx"`       // 开仓平均价
+	MarkPx      string `json:"markPx"`      // 最新标记价格
+	Upl         string `json:"upl"`         // 未实现收益
+	Lever       string `json:"lever"`       // 杠杆倍数
+	LiqPx       string `json:"liqPx"`       // 预估强平价
+	Margin      string `json:"margin"`      // 保证金余额，仅适用于逐仓
+	NotionalUsd string `json:"notionalUsd"` // 以美金价值为单位的持仓数量
+	UTime       string `json:"uTime"`       // 最近一次持仓更新时间
+}
+
+// okxAlgoOrder 策略委托单
+type okxAlgoOrder struct {
+	InstType      string `json:"instType"`      // 产品类型
+	InstId        string `json:"instId"`        // 产品ID
+	AlgoId        string `json:"algoId"`        // 策略委托单ID
+	AlgoClOrdId   string `json:"algoClOrdId"`   // 客户自定义策略订单ID
+	OrdType       string `json:"ordType"`       // 订单类型 conditional oco move_order_stop
+	State         string `json:"state"`         // 订单状态
+	Side          string `json:"side"`          // 订单方向
+	Sz            string `json:"sz"`            // 委托数量，合约为张数
+	SlTriggerPx   string `json:"slTriggerPx"`   // 止损触发价
+	SlOrdPx       string `json:"slOrdPx"`       // 止损委托价，-1 为市价
+	TpTriggerPx   string `json:"tpTriggerPx"`   // 止盈触发价
+	TpOrdPx       string `json:"tpOrdPx"`       // 止盈委托价，-1 为市价
+	CallbackRatio string `json:"callbackRatio"` // 跟踪止损回调比例，如 0.01 表示 1%
+	ActivePx      string `json:"activePx"`      // 跟踪止损激活价
+	OrdId         string `json:"ordId"`         // 触发后生成的订单ID
+	CTime         string `json:"cTime"`         // 创建时间
+	UTime         string `json:"uTime"`         // 更新时间
+}
+
+// okxOrderBook 深度数据，档位格式 [价格, 数量, 已废弃, 订单数量]
+type okxOrderBook struct {
+	Asks  [][]string `json:"asks"`  // 卖盘
+	Bids  [][]string `json:"bids"`  // 买盘
+	Ts    string     `json:"ts"`    // 时间戳，毫秒
+	SeqId int64      `json:"seqId"` // 序列号
+}
+
+// okxTrade 公开成交，REST 与 Websocket trades 频道共用
+type okxTrade struct {
+	InstId  string `json:"instId"`  // 产品ID
+	TradeId string `json:"tradeId"` // 成交ID
+	Px      string `json:"px"`      // 成交价格
+	Sz      string `json:"sz"`      // 成交数量，合约为张数
+	Side    string `json:"side"`    // 主动成交方向 buy sell
+	Ts      string `json:"ts"`      // 成交时间，毫秒
+}
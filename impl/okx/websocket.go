@@ -1 +1,114 @@
 package okx
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/subscription"
+	"github.com/so68/exchange-lib/internal/symbolmap"
+)
+
+const (
+	PublicWebsocketURL        = "wss://ws.okx.com:8443/ws/v5/public"    // 公共频道 Websocket 地址
+	PublicTestnetWebsocketURL = "wss://wspap.okx.com:8443/ws/v5/public" // 模拟盘公共频道 Websocket 地址
+
+	WsPingMessage  = "ping" // 心跳消息，服务端返回 pong
+	WsPingInterval = 20     // 心跳间隔（秒），OKX 30 秒无消息会断开连接
+	WsMaxArgs      = 100    // 单次订阅最大参数数量
+)
+
+// okxWebsocket OKX Websocket实例
+type okxWebsocket struct {
+	spotURL    string
+	futuresURL string
+	rest       *okx          // 公共接口实例，获取产品规格与行情，共享产品规格缓存与限频器
+	group      *client.Group // 全部连接的生命周期
+	mux        sync.Mutex
+
+	tickerPools    map[exchange.Channel]*subscription.Pool              // 行情订阅连接池
+	tickerHandlers map[exchange.Channel]exchange.WebsocketTickerHandler // 行情回调
+}
+
+// NewOKXWebsocket 创建OKX Websocket实例，现货与合约默认使用同一公共频道地址，交易对映射由公开接口的产品列表驱动
+func NewOKXWebsocket(opts ...exchange.Option) exchange.Websocket {
+	ws := newOKXWebsocket(opts...)
+	return symbolmap.WrapWebsocket(ws, ws.rest.ListInstruments, symbolFormatter{})
+}
+
+// newOKXWebsocket 创建OKX Websocket实例
+func newOKXWebsocket(opts ...exchange.Option) *okxWebsocket {
+	options := exchange.NewOptions(opts...)
+
+	o := &okxWebsocket{
+		spotURL:    PublicWebsocketURL,
+		futuresURL: PublicWebsocketURL,
+		rest:       newOKX("", "", "", opts...),
+		group:      client.NewGroup(),
+
+		tickerPools:    make(map[exchange.Channel]*subscription.Pool),
+		tickerHandlers: make(map[exchange.Channel]exchange.WebsocketTickerHandler),
+	}
+	if options.IsTestnet() {
+		o.spotURL = PublicTestnetWebsocketURL
+		o.futuresURL = PublicTestnetWebsocketURL
+	}
+	if options.SpotWebsocketURL != "" {
+		o.spotURL = options.SpotWebsocketURL
+	}
+	if options.FuturesWebsocketURL != "" {
+		o.futuresURL = options.FuturesWebsocketURL
+	}
+	return o
+}
+
+// SetStateHandler 设置连接状态回调，为 nil 时清除回调
+func (o *okxWebsocket) SetStateHandler(handler exchange.ConnectionStateHandler) {
+	if handler == nil {
+		o.group.SetStateHandler(nil)
+		return
+	}
+	o.group.SetStateHandler(func(url string, state client.State, err error) {
+		handler(url, exchange.ConnectionState(state), err)
+	})
+}
+
+// Errors 异步错误通道
+func (o *okxWebsocket) Errors() <-chan error {
+	return o.group.Errors()
+}
+
+// Done 关闭完成后关闭的通道
+func (o *okxWebsocket) Done() <-chan struct{} {
+	return o.group.Done()
+}
+
+// Close 关闭全部连接并停止后台任务
+func (o *okxWebsocket) Close() error {
+	o.group.Close()
+	return nil
+}
+
+// newWebsocket 创建使用 OKX 心跳的连接
+func newWebsocket(dialURL string, handler client.MessageHandler) *client.Websocket {
+	config := client.DefaultConfig()
+	config.PingMessage = WsPingMessage
+	config.PingInterval = WsPingInterval
+	return client.NewWebsocket(dialURL, handler).SetConfig(config)
+}
+
+// writeWsRequest 发送订阅请求，参数过多时分批发送
+func writeWsRequest(ws subscription.Conn, op string, args ...WsArg) error {
+	for start := 0; start < len(args); start += WsMaxArgs {
+		end := min(start+WsMaxArgs, len(args))
+		message, err := json.Marshal(WsRequest{Op: op, Args: args[start:end]})
+		if err != nil {
+			return err
+		}
+		if err := ws.WriteMessage(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenKlines 开始监听K线，订阅业务频道 candle<bar>
+func (o *okxWebsocket) StartListenKlines(ctx context.Context, market exchange.Market, symbol string, interval exchange.KlineInterval, handler exchange.WebsocketKlineHandler) error {
+	bar, err := toKlineBar(interval)
+	if err != nil {
+		return err
+	}
+
+	var (
+		dialURL string
+		instId  string
+		swap    bool
+	)
+	switch market {
+	case exchange.MarketSpot:
+		dialURL = businessURL(o.spotURL)
+		instId = formatSpotInstId(symbol)
+	case exchange.MarketFutures:
+		dialURL = businessURL(o.futuresURL)
+		instId = formatSwapInstId(symbol)
+		swap = true
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	arg := WsArg{Channel: "candle" + bar, InstId: instId}
+	var ws *client.Websocket
+	ws = newWebsocket(dialURL, func(message []byte) {
+		push := &WsPush{}
+		if err := json.Unmarshal(message, push); err != nil || push.Arg != arg || len(push.Data) == 0 {
+			return
+		}
+		var rows [][]string
+		if err := json.Unmarshal(push.Data, &rows); err != nil {
+			return
+		}
+		for _, row := range rows {
+			k, err := toKline(instId, interval, row, swap)
+			if err != nil {
+				continue
+			}
+			if handler != nil {
+				handler(k)
+			}
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		return writeWsRequest(ws, "subscribe", arg)
+	})
+	return o.group.Start(ctx, ws)
+}
+
+// businessURL 公共频道地址转换为业务频道地址，K线等频道仅在业务频道提供
+func businessURL(publicURL string) string {
+	if strings.HasSuffix(publicURL, "/public") {
+		return strings.TrimSuffix(publicURL, "/public") + "/business"
+	}
+	return publicURL
+}
@@ -0,0 +1,131 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenMarkPrice 开始监听合约标记价格，订阅公共频道 mark-price、index-tickers 与 funding-rate，
+// 按产品合并推送，收到标记价格后任一频道更新时回调最新数据
+func (o *okxWebsocket) StartListenMarkPrice(ctx context.Context, symbols []string, handler exchange.WebsocketMarkPriceHandler) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("交易对不能为空")
+	}
+
+	args := make([]WsArg, 0, len(symbols)*3)
+	for _, symbol := range symbols {
+		instId := formatSwapInstId(symbol)
+		args = append(args,
+			WsArg{Channel: "mark-price", InstId: instId},
+			WsArg{Channel: "index-tickers", InstId: toIndexId(instId)},
+			WsArg{Channel: "funding-rate", InstId: instId},
+		)
+	}
+
+	state := newMarkPriceState()
+	var ws *client.Websocket
+	ws = newWebsocket(o.futuresURL, func(message []byte) {
+		push := &WsPush{}
+		if err := json.Unmarshal(message, push); err != nil || push.Event != "" || len(push.Data) == 0 {
+			return
+		}
+		for _, markPrice := range state.update(push.Arg.Channel, push.Data) {
+			if handler != nil {
+				handler(markPrice)
+			}
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		return writeWsRequest(ws, "subscribe", args...)
+	})
+	return o.group.Start(ctx, ws)
+}
+
+// markPriceState 按产品合并标记价格、指数价格与资金费率
+type markPriceState struct {
+	mu          sync.Mutex
+	markPrices  map[string]*exchange.MarkPrice // 按永续合约产品ID索引
+	indexPrices map[string]string              // 按指数ID索引
+}
+
+// newMarkPriceState 创建标记价格合并状态
+func newMarkPriceState() *markPriceState {
+	return &markPriceState{
+		markPrices:  make(map[string]*exchange.MarkPrice),
+		indexPrices: make(map[string]string),
+	}
+}
+
+// update 合并频道推送，返回已收到标记价格且发生更新的产品的最新数据副本
+func (s *markPriceState) update(channel string, data json.RawMessage) []*exchange.MarkPrice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := make([]*exchange.MarkPrice, 0, 1)
+	switch channel {
+	case "mark-price":
+		var items []*okxMarkPrice
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil
+		}
+		for _, item := range items {
+			markPrice := s.markPrice(item.InstId)
+			markPrice.MarkPrice = item.MarkPx
+			markPrice.IndexPrice = s.indexPrices[toIndexId(item.InstId)]
+			markPrice.Time, _ = strconv.ParseInt(item.Ts, 10, 64)
+			changed = append(changed, markPrice)
+		}
+	case "index-tickers":
+		var items []*okxIndexTicker
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil
+		}
+		for _, item := range items {
+			s.indexPrices[item.InstId] = item.IdxPx
+			for instId, markPrice := range s.markPrices {
+				if toIndexId(instId) == item.InstId {
+					markPrice.IndexPrice = item.IdxPx
+					changed = append(changed, markPrice)
+				}
+			}
+		}
+	case "funding-rate":
+		var items []*okxFundingRate
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil
+		}
+		for _, item := range items {
+			markPrice := s.markPrice(item.InstId)
+			markPrice.FundingRate = item.FundingRate
+			markPrice.NextFundingTime, _ = strconv.ParseInt(item.FundingTime, 10, 64)
+			changed = append(changed, markPrice)
+		}
+	}
+
+	result := make([]*exchange.MarkPrice, 0, len(changed))
+	for _, markPrice := range changed {
+		if markPrice.MarkPrice == "" {
+			continue
+		}
+		copied := *markPrice
+		result = append(result, &copied)
+	}
+	return result
+}
+
+// markPrice 获取产品的合并数据，不存在时创建
+func (s *markPriceState) markPrice(instId string) *exchange.MarkPrice {
+	markPrice, ok := s.markPrices[instId]
+	if !ok {
+		markPrice = &exchange.MarkPrice{Symbol: instId}
+		s.markPrices[instId] = markPrice
+	}
+	return markPrice
+}
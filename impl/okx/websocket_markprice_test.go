@@ -0,0 +1,37 @@
+package okx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestMarkPriceState 合并标记价格、指数价格与资金费率推送，未收到标记价格前不回调
+// go test -v ./impl/okx -run "^TestMarkPriceState$"
+func TestMarkPriceState(t *testing.T) {
+	state := newMarkPriceState()
+
+	if got := state.update("index-tickers", json.RawMessage(`[{"instId":"BTC-USDT","idxPx":"100.2","ts":"1700000000000"}]`)); len(got) != 0 {
+		t.Fatalf("未收到标记价格时不应回调: %+v", got)
+	}
+	if got := state.update("funding-rate", json.RawMessage(`[{"instId":"BTC-USDT-SWAP","fundingRate":"0.0001","fundingTime":"1700028800000"}]`)); len(got) != 0 {
+		t.Fatalf("未收到标记价格时不应回调: %+v", got)
+	}
+
+	got := state.update("mark-price", json.RawMessage(`[{"instId":"BTC-USDT-SWAP","markPx":"100.5","ts":"1700000001000"}]`))
+	if len(got) != 1 {
+		t.Fatalf("标记价格回调数量错误: %d", len(got))
+	}
+	markPrice := got[0]
+	if markPrice.Symbol != "BTC-USDT-SWAP" || markPrice.MarkPrice != "100.5" || markPrice.IndexPrice != "100.2" ||
+		markPrice.FundingRate != "0.0001" || markPrice.NextFundingTime != 1700028800000 || markPrice.Time != 1700000001000 {
+		t.Errorf("合并结果错误: %+v", markPrice)
+	}
+
+	got = state.update("index-tickers", json.RawMessage(`[{"instId":"BTC-USDT","idxPx":"101","ts":"1700000002000"}]`))
+	if len(got) != 1 || got[0].IndexPrice != "101" {
+		t.Fatalf("指数价格更新错误: %+v", got)
+	}
+	if markPrice.IndexPrice != "100.2" {
+		t.Errorf("回调数据应为副本: %+v", markPrice)
+	}
+}
@@ -0,0 +1,163 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/orderbook"
+)
+
+const (
+	ChecksumDepth = 25 // 校验和计算档位数量
+)
+
+// StartListenOrderBook 开始维护本地深度
+// 同步流程：订阅 books 频道，首条推送为全量快照，之后为增量；校验 prevSeqId 连续性和 checksum，失败时重新订阅获取快照
+func (o *okxWebsocket) StartListenOrderBook(ctx context.Context, market exchange.Market, symbol string, depth int, handler exchange.WebsocketOrderBookHandler) (exchange.LocalOrderBook, error) {
+	var (
+		dialURL    string
+		instId     string
+		multiplier string
+	)
+	switch market {
+	case exchange.MarketSpot:
+		dialURL = o.spotURL
+		instId = formatSpotInstId(symbol)
+	case exchange.MarketFutures:
+		// 合约数量为张数，本地深度保留原始张数用于校验和，输出时按合约面值转换
+		dialURL = o.futuresURL
+		instId = formatSwapInstId(symbol)
+		spec, err := o.rest.getInstrumentSpec(ctx, InstTypeSwap, instId)
+		if err != nil {
+			return nil, fmt.Errorf("获取产品规格失败: %w", err)
+		}
+		multiplier = spec.CtVal
+	default:
+		return nil, fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	arg := WsArg{Channel: "books", InstId: instId}
+	syncer := newBookSyncer(orderbook.NewBook(instId, multiplier), func(book *orderbook.Book) {
+		if handler != nil {
+			handler(book.Snapshot(depth))
+		}
+	})
+	ws := newWebsocket(dialURL, func(message []byte) {
+		push := &WsPush{}
+		if err := json.Unmarshal(message, push); err != nil || push.Arg != arg || len(push.Data) == 0 {
+			return
+		}
+		var books []*WsBook
+		if err := json.Unmarshal(push.Data, &books); err != nil {
+			return
+		}
+		for _, book := range books {
+			syncer.push(push.Action, book)
+		}
+	})
+	// 重新订阅获取全量快照
+	syncer.resubscribe = func() error {
+		if err := writeWsRequest(ws, "unsubscribe", arg); err != nil {
+			return err
+		}
+		return writeWsRequest(ws, "subscribe", arg)
+	}
+	ws.SetAfterConnectionHandler(func() error {
+		syncer.book.Invalidate()
+		return writeWsRequest(ws, "subscribe", arg)
+	})
+	if err := o.group.Start(ctx, ws); err != nil {
+		return nil, err
+	}
+	return syncer.book, nil
+}
+
+// bookSyncer books 频道深度同步器
+type bookSyncer struct {
+	book        *orderbook.Book
+	onChange    orderbook.ChangeHandler
+	resubscribe func() error // 重新订阅
+	logger      *slog.Logger
+	mux         sync.Mutex
+}
+
+// newBookSyncer 创建深度同步器
+func newBookSyncer(book *orderbook.Book, onChange orderbook.ChangeHandler) *bookSyncer {
+	return &bookSyncer{
+		book:     book,
+		onChange: onChange,
+		logger:   slog.Default(),
+	}
+}
+
+// push 处理深度推送
+func (s *bookSyncer) push(action string, data *WsBook) {
+	s.mux.Lock()
+
+	ts, _ := strconv.ParseInt(data.Ts, 10, 64)
+	switch {
+	case action == "snapshot":
+		s.book.Reset(&exchange.OrderBook{
+			Symbol:       s.book.Symbol(),
+			Bids:         toOrderBookLevels(data.Bids, ""),
+			Asks:         toOrderBookLevels(data.Asks, ""),
+			LastUpdateID: data.SeqId,
+			Time:         ts,
+		})
+	case !s.book.Synced():
+		// 等待重新订阅后的快照
+		s.mux.Unlock()
+		return
+	case data.PrevSeqId != s.book.LastUpdateID():
+		s.logger.Warn("OKX OrderBook seqId gap, resubscribe", "instId", s.book.Symbol(), "seqId", s.book.LastUpdateID(), "prevSeqId", data.PrevSeqId)
+		s.invalidate()
+		return
+	default:
+		s.book.Update(toOrderBookLevels(data.Bids, ""), toOrderBookLevels(data.Asks, ""), data.SeqId, ts)
+	}
+
+	if checksum := bookChecksum(s.book); checksum != data.Checksum {
+		s.logger.Warn("OKX OrderBook checksum mismatch, resubscribe", "instId", s.book.Symbol(), "checksum", checksum, "expected", data.Checksum)
+		s.invalidate()
+		return
+	}
+	s.mux.Unlock()
+
+	if s.onChange != nil {
+		s.onChange(s.book)
+	}
+}
+
+// invalidate 标记为未同步并重新订阅，调用方需持有锁，返回时释放锁
+func (s *bookSyncer) invalidate() {
+	s.book.Invalidate()
+	s.mux.Unlock()
+
+	if s.resubscribe != nil {
+		if err := s.resubscribe(); err != nil {
+			s.logger.Error("OKX OrderBook resubscribe failed", "instId", s.book.Symbol(), "error", err.Error())
+		}
+	}
+}
+
+// bookChecksum 计算前 25 档校验和，格式为 买1价:买1量:卖1价:卖1量:买2价:...，某一侧不足时只拼接另一侧
+func bookChecksum(book *orderbook.Book) int32 {
+	bids, asks := book.RawLevels(ChecksumDepth)
+	parts := make([]string, 0, (len(bids)+len(asks))*2)
+	for i := 0; i < ChecksumDepth; i++ {
+		if i < len(bids) {
+			parts = append(parts, bids[i][0], bids[i][1])
+		}
+		if i < len(asks) {
+			parts = append(parts, asks[i][0], asks[i][1])
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(strings.Join(parts, ":"))))
+}
@@ -0,0 +1,103 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/orderbook"
+)
+
+// 录制的 books 频道消息：快照、增量、seqId 缺口、重新订阅后的快照、校验和错误、再次快照与增量
+var testBooksMessages = []string{
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"snapshot","data":[{"asks":[["8476.98","415","0","13"],["8477","7","0","2"]],"bids":[["8476.97","256","0","12"],["8475.55","101","0","1"]],"ts":"1597026383085","checksum":2123921068,"prevSeqId":-1,"seqId":100}]}`,
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"update","data":[{"asks":[["8477","0","0","0"]],"bids":[["8476.97","300","0","13"]],"ts":"1597026383185","checksum":-1005304900,"prevSeqId":100,"seqId":101}]}`,
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"update","data":[{"asks":[],"bids":[["8476.97","310","0","13"]],"ts":"1597026383285","checksum":0,"prevSeqId":105,"seqId":106}]}`,
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"update","data":[{"asks":[],"bids":[["8476.97","320","0","13"]],"ts":"1597026383385","checksum":0,"prevSeqId":106,"seqId":107}]}`,
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"snapshot","data":[{"asks":[["8471","2","0","1"]],"bids":[["8470","1","0","1"]],"ts":"1597026384085","checksum":-884272785,"prevSeqId":-1,"seqId":200}]}`,
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"update","data":[{"asks":[],"bids":[["8470.5","3","0","1"]],"ts":"1597026384185","checksum":12345,"prevSeqId":200,"seqId":201}]}`,
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"snapshot","data":[{"asks":[["8471","2","0","1"]],"bids":[["8470","1","0","1"]],"ts":"1597026385085","checksum":-884272785,"prevSeqId":-1,"seqId":300}]}`,
+	`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"update","data":[{"asks":[],"bids":[["8470.5","3","0","1"]],"ts":"1597026385185","checksum":-982439159,"prevSeqId":300,"seqId":301}]}`,
+}
+
+// TestBookSyncerReplay 回放录制的 books 消息，seqId 缺口和校验和错误时重新订阅
+// go test -v ./impl/okx -run "^TestBookSyncerReplay$"
+func TestBookSyncerReplay(t *testing.T) {
+	changes := 0
+	syncer := newBookSyncer(orderbook.NewBook("BTC-USDT-SWAP", "0.01"), func(book *orderbook.Book) {
+		changes++
+	})
+	resubscribes := 0
+	syncer.resubscribe = func() error {
+		resubscribes++
+		return nil
+	}
+
+	for i, message := range testBooksMessages {
+		push := &WsPush{}
+		if err := json.Unmarshal([]byte(message), push); err != nil {
+			t.Fatalf("解析第 %d 条消息失败: %v", i, err)
+		}
+		var books []*WsBook
+		if err := json.Unmarshal(push.Data, &books); err != nil {
+			t.Fatalf("解析第 %d 条深度失败: %v", i, err)
+		}
+		for _, book := range books {
+			syncer.push(push.Action, book)
+		}
+
+		switch i {
+		case 1:
+			if bid, _ := syncer.book.BestBid(); bid.Quantity != "3" {
+				t.Fatalf("增量后买一数量错误（300 张 * 0.01）: %+v", bid)
+			}
+		case 2, 3, 5:
+			if syncer.book.Synced() {
+				t.Fatalf("第 %d 条消息后应为未同步", i)
+			}
+		}
+	}
+
+	book := syncer.book
+	if resubscribes != 2 || changes != 5 {
+		t.Errorf("重新订阅或回调次数错误: resubscribes=%d changes=%d", resubscribes, changes)
+	}
+	if !book.Synced() || book.LastUpdateID() != 301 {
+		t.Fatalf("同步状态错误: synced=%v seqId=%d", book.Synced(), book.LastUpdateID())
+	}
+	snapshot := book.Snapshot(0)
+	if len(snapshot.Bids) != 2 || snapshot.Bids[0].Price != "8470.5" || snapshot.Bids[0].Quantity != "0.03" {
+		t.Errorf("买盘错误: %+v", snapshot.Bids)
+	}
+	if snapshot.Time != 1597026385185 {
+		t.Errorf("时间错误: %d", snapshot.Time)
+	}
+}
+
+// TestOrderBookInstrumentSpec 合约深度使用 Websocket 实例共享的公共接口实例获取合约面值，产品规格缓存后不再请求，模拟盘上下文请求模拟盘
+// go test -v ./impl/okx -run "^TestOrderBookInstrumentSpec$"
+func TestOrderBookInstrumentSpec(t *testing.T) {
+	ws := newOKXWebsocket()
+	defer ws.Close()
+	var ts *testServer
+	ws.rest, ts = newTestOKX(t, map[string]string{"GET /api/v5/public/instruments": testSwapInstrument})
+	ws.futuresURL = "ws://127.0.0.1:1" // 连接失败，只检查产品规格请求
+
+	ctx := exchange.WithTestnet(context.Background())
+	for range 2 {
+		_, _ = ws.StartListenOrderBook(ctx, exchange.MarketFutures, "BTC-USDT-SWAP", 10, nil)
+	}
+	count := 0
+	for _, req := range ts.requests {
+		if req.Path == "/api/v5/public/instruments" {
+			count++
+			if !req.Simulated || req.Query["instType"] != InstTypeSwap {
+				t.Errorf("产品规格请求错误: %+v", req)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("产品规格应只请求一次: %d", count)
+	}
+}
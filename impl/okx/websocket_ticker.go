@@ -0,0 +1,187 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/subscription"
+)
+
+// StartListenSpotTickers 开始监听现货全部交易对行情，ctx 结束时取消该频道的全部订阅
+func (o *okxWebsocket) StartListenSpotTickers(ctx context.Context, handler exchange.WebsocketSpotTickerHandler) error {
+	o.SetTickerHandler(exchange.ChannelSpotTicker, exchange.WebsocketTickerHandler(handler))
+	if err := o.subscribe(ctx, exchange.ChannelSpotTicker); err != nil {
+		return err
+	}
+	o.group.AfterFunc(ctx, func() {
+		_ = o.Unsubscribe(exchange.ChannelSpotTicker)
+	})
+	return nil
+}
+
+// StartListenFuturesTickers 开始监听合约全部交易对行情，ctx 结束时取消该频道的全部订阅
+func (o *okxWebsocket) StartListenFuturesTickers(ctx context.Context, handler exchange.WebsocketFuturesTickerHandler) error {
+	o.SetTickerHandler(exchange.ChannelFuturesTicker, exchange.WebsocketTickerHandler(handler))
+	if err := o.subscribe(ctx, exchange.ChannelFuturesTicker); err != nil {
+		return err
+	}
+	o.group.AfterFunc(ctx, func() {
+		_ = o.Unsubscribe(exchange.ChannelFuturesTicker)
+	})
+	return nil
+}
+
+// SetTickerHandler 设置行情频道的回调
+func (o *okxWebsocket) SetTickerHandler(channel exchange.Channel, handler exchange.WebsocketTickerHandler) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	o.tickerHandlers[channel] = handler
+}
+
+// Subscribe 订阅 tickers 频道，symbols 为空时获取一次全部 USDT 产品并订阅，重连后按已订阅列表恢复
+func (o *okxWebsocket) Subscribe(channel exchange.Channel, symbols ...string) error {
+	return o.subscribe(context.Background(), channel, symbols...)
+}
+
+// subscribe 订阅 tickers 频道，ctx 用于获取产品列表
+func (o *okxWebsocket) subscribe(ctx context.Context, channel exchange.Channel, symbols ...string) error {
+	pool, err := o.tickerPool(channel)
+	if err != nil {
+		return err
+	}
+	if len(symbols) > 0 {
+		return pool.Subscribe(toInstIds(channel, symbols)...)
+	}
+
+	instType := InstTypeSpot
+	suffix := "-" + Settle
+	if channel == exchange.ChannelFuturesTicker {
+		instType = InstTypeSwap
+		suffix += "-" + InstTypeSwap
+	}
+	tickers, err := o.rest.getTickers(ctx, instType)
+	if err != nil {
+		return err
+	}
+	instIds := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		if strings.HasSuffix(ticker.InstId, suffix) {
+			instIds = append(instIds, ticker.InstId)
+		}
+	}
+	return pool.Subscribe(instIds...)
+}
+
+// Unsubscribe 取消订阅 tickers 频道，symbols 为空时取消该频道的全部订阅
+func (o *okxWebsocket) Unsubscribe(channel exchange.Channel, symbols ...string) error {
+	pool, err := o.tickerPool(channel)
+	if err != nil {
+		return err
+	}
+	if len(symbols) == 0 {
+		return pool.Unsubscribe(pool.Streams()...)
+	}
+	return pool.Unsubscribe(toInstIds(channel, symbols)...)
+}
+
+// tickerPool 获取行情订阅连接池，不存在时创建，OKX 不限制单个连接的订阅数量
+func (o *okxWebsocket) tickerPool(channel exchange.Channel) (*subscription.Pool, error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	if pool, ok := o.tickerPools[channel]; ok {
+		return pool, nil
+	}
+	if o.group.Context().Err() != nil {
+		return nil, exchange.ErrClosed
+	}
+
+	var (
+		dialURL  string
+		toTicker func(ticker *okxTicker) *exchange.Ticker
+	)
+	switch channel {
+	case exchange.ChannelSpotTicker:
+		dialURL, toTicker = o.spotURL, toSpotTicker
+	case exchange.ChannelFuturesTicker:
+		dialURL, toTicker = o.futuresURL, toSwapTicker
+	default:
+		return nil, fmt.Errorf("不支持的订阅频道: %s", channel)
+	}
+
+	pool := subscription.NewPool(subscription.Config{
+		NewConn: func(onConnect func() error, onDisconnect func()) subscription.Conn {
+			ws := newWebsocket(dialURL, func(message []byte) {
+				handler := o.tickerHandler(channel)
+				if handler == nil {
+					return
+				}
+				for _, ticker := range parseTickers(message) {
+					handler(toTicker(ticker))
+				}
+			})
+			ws.SetBeforeConnectionHandler(func() error {
+				onDisconnect()
+				return nil
+			})
+			ws.SetAfterConnectionHandler(onConnect)
+			// 已关闭时关闭连接，启动将失败
+			if err := o.group.Add(ws); err != nil {
+				ws.Close()
+			}
+			return ws
+		},
+		Subscribe: func(conn subscription.Conn, instIds []string) error {
+			return writeWsRequest(conn, "subscribe", tickerArgs(instIds)...)
+		},
+		Unsubscribe: func(conn subscription.Conn, instIds []string) error {
+			return writeWsRequest(conn, "unsubscribe", tickerArgs(instIds)...)
+		},
+	})
+	o.tickerPools[channel] = pool
+	return pool, nil
+}
+
+// tickerHandler 获取行情频道的回调
+func (o *okxWebsocket) tickerHandler(channel exchange.Channel) exchange.WebsocketTickerHandler {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	return o.tickerHandlers[channel]
+}
+
+// toInstIds 交易对转换为产品ID
+func toInstIds(channel exchange.Channel, symbols []string) []string {
+	instIds := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		if channel == exchange.ChannelFuturesTicker {
+			instIds = append(instIds, formatSwapInstId(symbol))
+		} else {
+			instIds = append(instIds, formatSpotInstId(symbol))
+		}
+	}
+	return instIds
+}
+
+// tickerArgs 产品ID转换为 tickers 频道订阅参数
+func tickerArgs(instIds []string) []WsArg {
+	args := make([]WsArg, 0, len(instIds))
+	for _, instId := range instIds {
+		args = append(args, WsArg{Channel: "tickers", InstId: instId})
+	}
+	return args
+}
+
+// parseTickers 解析 tickers 频道推送，事件响应等其他消息忽略
+func parseTickers(message []byte) []*okxTicker {
+	push := &WsPush{}
+	if err := json.Unmarshal(message, push); err != nil || push.Event != "" || push.Arg.Channel != "tickers" || len(push.Data) == 0 {
+		return nil
+	}
+	var tickers []*okxTicker
+	if err := json.Unmarshal(push.Data, &tickers); err != nil {
+		return nil
+	}
+	return tickers
+}
@@ -0,0 +1,65 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+)
+
+// StartListenTrades 开始监听公开成交，订阅公共频道 trades
+func (o *okxWebsocket) StartListenTrades(ctx context.Context, market exchange.Market, symbols []string, handler exchange.WebsocketTradeHandler) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("交易对不能为空")
+	}
+
+	var (
+		dialURL string
+		args    = make([]WsArg, 0, len(symbols))
+		ctVals  = make(map[string]string, len(symbols)) // 合约面值，现货为空
+	)
+	switch market {
+	case exchange.MarketSpot:
+		dialURL = o.spotURL
+		for _, symbol := range symbols {
+			args = append(args, WsArg{Channel: "trades", InstId: formatSpotInstId(symbol)})
+		}
+	case exchange.MarketFutures:
+		dialURL = o.futuresURL
+		for _, symbol := range symbols {
+			instId := formatSwapInstId(symbol)
+			spec, err := o.rest.getInstrumentSpec(ctx, InstTypeSwap, instId)
+			if err != nil {
+				return fmt.Errorf("获取产品规格失败: %w", err)
+			}
+			ctVals[instId] = spec.CtVal
+			args = append(args, WsArg{Channel: "trades", InstId: instId})
+		}
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	var ws *client.Websocket
+	ws = newWebsocket(dialURL, func(message []byte) {
+		push := &WsPush{}
+		if err := json.Unmarshal(message, push); err != nil || push.Arg.Channel != "trades" || len(push.Data) == 0 {
+			return
+		}
+		trades, err := parseTrades(push.Data, ctVals[push.Arg.InstId])
+		if err != nil {
+			return
+		}
+		for _, trade := range trades {
+			if handler != nil {
+				handler(trade)
+			}
+		}
+	})
+	// 连接成功后订阅，重连后重新订阅
+	ws.SetAfterConnectionHandler(func() error {
+		return writeWsRequest(ws, "subscribe", args...)
+	})
+	return o.group.Start(ctx, ws)
+}
@@ -1 +1,62 @@
 package okx
+
+import "encoding/json"
+
+// WsRequest 订阅请求
+type WsRequest struct {
+	Op   string  `json:"op"`   // subscribe unsubscribe
+	Args []WsArg `json:"args"` // 订阅参数
+}
+
+// WsArg 订阅参数
+type WsArg struct {
+	Channel  string `json:"channel"`            // 频道
+	InstType string `json:"instType,omitempty"` // 产品类型，私有频道使用
+	InstId   string `json:"instId,omitempty"`   // 产品ID
+}
+
+// WsLoginRequest 登录请求
+type WsLoginRequest struct {
+	Op   string       `json:"op"`   // login
+	Args []WsLoginArg `json:"args"` // 登录参数
+}
+
+// WsLoginArg 登录参数
+type WsLoginArg struct {
+	ApiKey     string `json:"apiKey"`     // API Key
+	Passphrase string `json:"passphrase"` // API 密码
+	Timestamp  string `json:"timestamp"`  // 时间戳，秒
+	Sign       string `json:"sign"`       // 签名
+}
+
+// WsOrder 订单频道推送，包含最近一次成交信息
+type WsOrder struct {
+	okxOrder
+	TradeId    string `json:"tradeId"`    // 最新成交ID
+	FillPx     string `json:"fillPx"`     // 最新成交价格
+	FillSz     string `json:"fillSz"`     // 最新成交数量
+	FillTime   string `json:"fillTime"`   // 最新成交时间
+	FillFee    string `json:"fillFee"`    // 最新成交手续费，负数表示扣除
+	FillFeeCcy string `json:"fillFeeCcy"` // 最新成交手续费币种
+	ExecType   string `json:"execType"`   // 最新成交流动性方向 T：taker M：maker
+}
+
+// WsPush 推送消息，事件响应包含 event，数据推送包含 data
+type WsPush struct {
+	Event  string          `json:"event"`  // 事件：subscribe unsubscribe error
+	Code   string          `json:"code"`   // 错误码
+	Msg    string          `json:"msg"`    // 错误信息
+	Arg    WsArg           `json:"arg"`    // 订阅参数
+	Action string          `json:"action"` // 深度推送类型：snapshot update
+	Data   json.RawMessage `json:"data"`   // 数据
+}
+
+// WsBook 深度推送，档位格式 [价格, 数量, 已废弃, 订单数量]
+type WsBook struct {
+	Asks      [][]string `json:"asks"`      // 卖盘
+	Bids      [][]string `json:"bids"`      // 买盘
+	Ts        string     `json:"ts"`        // 时间戳，毫秒
+	Checksum  int32      `json:"checksum"`  // 前 25 档校验和
+	PrevSeqId int64      `json:"prevSeqId"` // 上一次推送的序列号，快照为 -1
+	SeqId     int64      `json:"seqId"`     // 序列号
+}
@@ -0,0 +1,258 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/socket/client"
+	"github.com/so68/exchange-lib/internal/symbolmap"
+)
+
+const (
+	WsLoginPath = "/users/self/verify" // 登录签名路径
+)
+
+// okxUserDataStream OKX 私有数据流实例
+type okxUserDataStream struct {
+	ws   *okxWebsocket
+	rest *okx
+}
+
+// NewOKXUserDataStream 创建 OKX 私有数据流实例，连接私有频道并登录
+func NewOKXUserDataStream(apiKey, secretKey, passphrase string, opts ...exchange.Option) exchange.UserDataStream {
+	stream := newOKXUserDataStream(apiKey, secretKey, passphrase, opts...)
+	return symbolmap.WrapUserDataStream(stream, stream.rest.ListInstruments, symbolFormatter{})
+}
+
+// newOKXUserDataStream 创建 OKX 私有数据流实例
+func newOKXUserDataStream(apiKey, secretKey, passphrase string, opts ...exchange.Option) *okxUserDataStream {
+	return &okxUserDataStream{
+		ws:   newOKXWebsocket(opts...),
+		rest: newOKX(apiKey, secretKey, passphrase, opts...),
+	}
+}
+
+// StartListenUserData 开始监听账户私有数据
+// 现货订阅 orders(SPOT)、account，合约订阅 orders(SWAP)、positions(SWAP)、account，
+// 每次连接成功后重新登录，登录成功后订阅
+func (o *okxUserDataStream) StartListenUserData(ctx context.Context, market exchange.Market, handler exchange.UserDataHandler) error {
+	var (
+		dialURL string
+		args    []WsArg
+		ctVal   func(instId string) string // 合约面值，现货为空
+	)
+	switch market {
+	case exchange.MarketSpot:
+		dialURL = privateURL(o.ws.spotURL)
+		args = []WsArg{
+			{Channel: "orders", InstType: InstTypeSpot},
+			{Channel: "account"},
+		}
+		ctVal = func(string) string { return "" }
+	case exchange.MarketFutures:
+		dialURL = privateURL(o.ws.futuresURL)
+		args = []WsArg{
+			{Channel: "orders", InstType: InstTypeSwap},
+			{Channel: "positions", InstType: InstTypeSwap},
+			{Channel: "account"},
+		}
+		ctVal = func(instId string) string {
+			spec, err := o.rest.getInstrumentSpec(context.Background(), InstTypeSwap, instId)
+			if err != nil {
+				return ""
+			}
+			return spec.CtVal
+		}
+	default:
+		return fmt.Errorf("不支持的市场类型: %s", market)
+	}
+
+	var ws *client.Websocket
+	ws = newWebsocket(dialURL, func(message []byte) {
+		push := &WsPush{}
+		if err := json.Unmarshal(message, push); err != nil {
+			return
+		}
+		switch push.Event {
+		case "login":
+			// 登录成功后订阅，失败时断开连接重新登录
+			if push.Code != "0" {
+				slog.Error("OKX websocket login failed", "code", push.Code, "msg", push.Msg)
+				o.ws.group.ReportError(fmt.Errorf("登录失败: %w", newAPIError(push.Code, push.Msg)))
+				ws.Reconnect()
+				return
+			}
+			if err := writeWsRequest(ws, "subscribe", args...); err != nil {
+				slog.Error("OKX user data subscribe failed", "error", err.Error())
+				o.ws.group.ReportError(fmt.Errorf("订阅失败: %w", err))
+				ws.Reconnect()
+			}
+			return
+		case "error":
+			slog.Error("OKX user data error", "code", push.Code, "msg", push.Msg)
+			o.ws.group.ReportError(fmt.Errorf("私有频道错误: %s %s", push.Code, push.Msg))
+			return
+		case "":
+		default:
+			return
+		}
+		if err := handleUserDataPush(push, market, handler, ctVal); err != nil {
+			slog.Warn("OKX user data parse failed", "channel", push.Arg.Channel, "error", err.Error())
+		}
+	})
+	// 连接成功后登录，重连后重新登录
+	ws.SetAfterConnectionHandler(func() error {
+		message, err := json.Marshal(o.newLoginRequest(time.Now()))
+		if err != nil {
+			return err
+		}
+		return ws.WriteMessage(message)
+	})
+	return o.ws.group.Start(ctx, ws)
+}
+
+// SetStateHandler 设置连接状态回调
+func (o *okxUserDataStream) SetStateHandler(handler exchange.ConnectionStateHandler) {
+	o.ws.SetStateHandler(handler)
+}
+
+// Errors 异步错误通道
+func (o *okxUserDataStream) Errors() <-chan error {
+	return o.ws.Errors()
+}
+
+// Done 关闭完成后关闭的通道
+func (o *okxUserDataStream) Done() <-chan struct{} {
+	return o.ws.Done()
+}
+
+// Close 关闭全部连接
+func (o *okxUserDataStream) Close() error {
+	return o.ws.Close()
+}
+
+// newLoginRequest 创建登录请求，签名内容为 timestamp + GET + /users/self/verify，时间戳单位为秒
+func (o *okxUserDataStream) newLoginRequest(now time.Time) WsLoginRequest {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	return WsLoginRequest{
+		Op: "login",
+		Args: []WsLoginArg{{
+			ApiKey:     o.rest.apiKey,
+			Passphrase: o.rest.passphrase,
+			Timestamp:  timestamp,
+			Sign:       o.rest.generateSignature(timestamp, "GET", WsLoginPath, ""),
+		}},
+	}
+}
+
+// handleUserDataPush 解析私有频道推送并回调，合约数量按面值转换为币的数量
+func handleUserDataPush(push *WsPush, market exchange.Market, handler exchange.UserDataHandler, ctVal func(instId string) string) error {
+	if len(push.Data) == 0 {
+		return nil
+	}
+
+	switch push.Arg.Channel {
+	case "orders":
+		var orders []*WsOrder
+		if err := json.Unmarshal(push.Data, &orders); err != nil {
+			return err
+		}
+		for _, order := range orders {
+			val := ctVal(order.InstId)
+			if handler.OnOrder != nil {
+				updateTime, _ := strconv.ParseInt(order.UTime, 10, 64)
+				handler.OnOrder(&exchange.OrderUpdate{
+					Market: market,
+					Order:  toExchangeOrder(&order.okxOrder, val),
+					Time:   updateTime,
+				})
+			}
+			if order.TradeId != "" && handler.OnFill != nil {
+				handler.OnFill(toFill(order, market, val))
+			}
+		}
+	case "positions":
+		var positions []*okxPosition
+		if err := json.Unmarshal(push.Data, &positions); err != nil {
+			return err
+		}
+		if handler.OnPosition != nil {
+			update := &exchange.PositionUpdate{
+				Market:    market,
+				Positions: make([]*exchange.PositionRisk, 0, len(positions)),
+			}
+			for _, p := range positions {
+				update.Positions = append(update.Positions, toPositionRisk(p, ctVal(p.InstId)))
+				if t, _ := strconv.ParseInt(p.UTime, 10, 64); t > update.Time {
+					update.Time = t
+				}
+			}
+			handler.OnPosition(update)
+		}
+	case "account":
+		var accounts []*okxBalance
+		if err := json.Unmarshal(push.Data, &accounts); err != nil {
+			return err
+		}
+		if handler.OnBalance != nil {
+			for _, account := range accounts {
+				updateTime, _ := strconv.ParseInt(account.UTime, 10, 64)
+				update := &exchange.BalanceUpdate{
+					Market:   market,
+					Balances: make([]exchange.Balance, 0, len(account.Details)),
+					Time:     updateTime,
+				}
+				for _, detail := range account.Details {
+					if market == exchange.MarketFutures {
+						update.Balances = append(update.Balances, toFuturesBalance(detail))
+					} else {
+						update.Balances = append(update.Balances, toSpotBalance(detail))
+					}
+				}
+				handler.OnBalance(update)
+			}
+		}
+	}
+	return nil
+}
+
+// toFill 转换订单推送中的最新成交，OKX 手续费负数表示扣除，转换为正数表示支出
+func toFill(order *WsOrder, market exchange.Market, ctVal string) *exchange.Fill {
+	quantity := order.FillSz
+	if ctVal != "" {
+		quantity = mulDecimal(order.FillSz, ctVal)
+	}
+	fee := order.FillFee
+	if value, err := decimal.NewFromString(order.FillFee); err == nil {
+		fee = value.Neg().String()
+	}
+	fillTime, _ := strconv.ParseInt(order.FillTime, 10, 64)
+	return &exchange.Fill{
+		Market:   market,
+		Symbol:   order.InstId,
+		OrderID:  order.OrdId,
+		TradeID:  order.TradeId,
+		Side:     exchange.OrderSide(strings.ToUpper(order.Side)),
+		Price:    order.FillPx,
+		Quantity: quantity,
+		Fee:      fee,
+		FeeAsset: order.FillFeeCcy,
+		IsMaker:  order.ExecType == "M",
+		Time:     fillTime,
+	}
+}
+
+// privateURL 公共频道地址转换为私有频道地址
+func privateURL(publicURL string) string {
+	if strings.HasSuffix(publicURL, "/public") {
+		return strings.TrimSuffix(publicURL, "/public") + "/private"
+	}
+	return publicURL
+}
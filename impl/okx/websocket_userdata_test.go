@@ -0,0 +1,107 @@
+package okx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestNewLoginRequest 登录请求签名
+// go test -v ./impl/okx -run "^TestNewLoginRequest$"
+func TestNewLoginRequest(t *testing.T) {
+	stream := newOKXUserDataStream("key", "secret", "pass")
+	request := stream.newLoginRequest(time.Unix(1700000000, 0))
+	if request.Op != "login" || len(request.Args) != 1 {
+		t.Fatalf("登录请求错误: %+v", request)
+	}
+
+	h := hmac.New(sha256.New, []byte("secret"))
+	h.Write([]byte("1700000000GET/users/self/verify"))
+	want := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	if arg := request.Args[0]; arg.ApiKey != "key" || arg.Passphrase != "pass" || arg.Timestamp != "1700000000" || arg.Sign != want {
+		t.Errorf("登录参数错误: %+v", arg)
+	}
+}
+
+// TestPrivateURL 私有频道地址
+// go test -v ./impl/okx -run "^TestPrivateURL$"
+func TestPrivateURL(t *testing.T) {
+	if got := privateURL(PublicTestnetWebsocketURL); got != "wss://wspap.okx.com:8443/ws/v5/private" {
+		t.Errorf("privateURL() = %s", got)
+	}
+}
+
+// TestHandleUserDataPush 解析私有频道推送，合约数量按面值转换
+// go test -v ./impl/okx -run "^TestHandleUserDataPush$"
+func TestHandleUserDataPush(t *testing.T) {
+	var (
+		orders    []*exchange.OrderUpdate
+		fills     []*exchange.Fill
+		balances  []*exchange.BalanceUpdate
+		positions []*exchange.PositionUpdate
+	)
+	handler := exchange.UserDataHandler{
+		OnOrder:    func(update *exchange.OrderUpdate) { orders = append(orders, update) },
+		OnFill:     func(fill *exchange.Fill) { fills = append(fills, fill) },
+		OnBalance:  func(update *exchange.BalanceUpdate) { balances = append(balances, update) },
+		OnPosition: func(update *exchange.PositionUpdate) { positions = append(positions, update) },
+	}
+	ctVal := func(string) string { return "0.01" }
+
+	order := `{"arg":{"channel":"orders","instType":"SWAP","uid":"1"},"data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","ordId":"312269865356374016","clOrdId":"","px":"30000","sz":"10","ordType":"limit","side":"buy","posSide":"long","tdMode":"cross","accFillSz":"4","avgPx":"30000","state":"partially_filled","fee":"-0.006","feeCcy":"USDT","cTime":"1700000000000","uTime":"1700000000099","tradeId":"242589207","fillPx":"30000","fillSz":"4","fillTime":"1700000000099","fillFee":"-0.006","fillFeeCcy":"USDT","execType":"M"}]}`
+	if err := handleUserDataPush(parsePush(t, order), exchange.MarketFutures, handler, ctVal); err != nil {
+		t.Fatalf("解析订单推送失败: %v", err)
+	}
+	if len(orders) != 1 || len(fills) != 1 {
+		t.Fatalf("回调次数错误: orders=%d fills=%d", len(orders), len(fills))
+	}
+	if o := orders[0].Order; o.OrderID != "312269865356374016" || o.Status != exchange.OrderStatusPartiallyFilled || o.Quantity != "0.1" || o.ExecutedQty != "0.04" || orders[0].Time != 1700000000099 {
+		t.Errorf("订单数据错误: %+v", o)
+	}
+	if f := fills[0]; f.TradeID != "242589207" || f.Quantity != "0.04" || f.Fee != "0.006" || f.FeeAsset != "USDT" || !f.IsMaker || f.Side != exchange.OrderSideBuy || f.Time != 1700000000099 {
+		t.Errorf("成交数据错误: %+v", f)
+	}
+
+	position := `{"arg":{"channel":"positions","instType":"SWAP"},"data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","mgnMode":"isolated","posSide":"short","pos":"5","avgPx":"30000","markPx":"30100","upl":"-0.5","lever":"10","liqPx":"33000","margin":"15","notionalUsd":"1505","uTime":"1700000000200"}]}`
+	if err := handleUserDataPush(parsePush(t, position), exchange.MarketFutures, handler, ctVal); err != nil {
+		t.Fatalf("解析持仓推送失败: %v", err)
+	}
+	if len(positions) != 1 || positions[0].Time != 1700000000200 {
+		t.Fatalf("持仓回调错误: %+v", positions)
+	}
+	if p := positions[0].Positions[0]; p.PositionSide != exchange.PositionSideShort || p.PositionAmt != "-0.05" || p.MarginType != string(exchange.MarginModeIsolated) {
+		t.Errorf("持仓数据错误: %+v", p)
+	}
+
+	account := `{"arg":{"channel":"account","uid":"1"},"data":[{"uTime":"1700000000300","totalEq":"1000","details":[{"ccy":"USDT","eq":"1000","cashBal":"990","availBal":"900","availEq":"950","frozenBal":"90"}]}]}`
+	if err := handleUserDataPush(parsePush(t, account), exchange.MarketFutures, handler, ctVal); err != nil {
+		t.Fatalf("解析账户推送失败: %v", err)
+	}
+	if err := handleUserDataPush(parsePush(t, account), exchange.MarketSpot, handler, ctVal); err != nil {
+		t.Fatalf("解析账户推送失败: %v", err)
+	}
+	if len(balances) != 2 || balances[0].Time != 1700000000300 {
+		t.Fatalf("余额回调错误: %+v", balances)
+	}
+	if b := balances[0].Balances[0]; b.Free != "950" || b.Total != "1000" {
+		t.Errorf("合约余额错误: %+v", b)
+	}
+	if b := balances[1].Balances[0]; b.Free != "900" || b.Locked != "90" || b.Total != "990" {
+		t.Errorf("现货余额错误: %+v", b)
+	}
+}
+
+// parsePush 解析推送消息
+func parsePush(t *testing.T, message string) *WsPush {
+	t.Helper()
+	push := &WsPush{}
+	if err := json.Unmarshal([]byte(message), push); err != nil {
+		t.Fatalf("解析推送消息失败: %v", err)
+	}
+	return push
+}
@@ -0,0 +1,92 @@
+// Package batch 批量下单与撤单的分批发送，结果按请求顺序返回，单个订单失败不影响其他订单
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// Do 逐个转换请求后按 size 分批发送。
+// prepare 转换失败的请求直接记录错误且不发送；send 返回与该批一一对应的结果，返回 error 时该批全部记录为该错误。
+// 上下文取消后不再发送剩余批次，未发送的请求记录为上下文错误并返回该错误
+func Do[Req, Native any](ctx context.Context, reqs []Req, size int, prepare func(Req) (Native, error), send func([]Native) ([]exchange.OrderResult, error)) ([]exchange.OrderResult, error) {
+	results := make([]exchange.OrderResult, len(reqs))
+	natives := make([]Native, 0, len(reqs))
+	indexes := make([]int, 0, len(reqs))
+	for i, req := range reqs {
+		native, err := prepare(req)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		natives = append(natives, native)
+		indexes = append(indexes, i)
+	}
+
+	for start := 0; start < len(natives); start += size {
+		end := min(start+size, len(natives))
+		if err := ctx.Err(); err != nil {
+			for _, i := range indexes[start:] {
+				results[i].Err = err
+			}
+			return results, err
+		}
+
+		chunk, err := send(natives[start:end])
+		if err == nil && len(chunk) != end-start {
+			err = fmt.Errorf("批量结果数量 %d 与请求数量 %d 不一致", len(chunk), end-start)
+		}
+		for j, i := range indexes[start:end] {
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i] = chunk[j]
+		}
+	}
+	return results, nil
+}
+
+// SplitMarket 按市场类型拆分下单请求分别处理，结果按原请求顺序合并；
+// place 返回 error 时不再处理后续市场，没有结果的请求记录为该错误
+func SplitMarket(reqs []exchange.OrderRequest, place func(market exchange.Market, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error)) ([]exchange.OrderResult, error) {
+	results := make([]exchange.OrderResult, len(reqs))
+	for i, req := range reqs {
+		if req.Market != exchange.MarketSpot && req.Market != exchange.MarketFutures {
+			results[i].Err = fmt.Errorf("%w: 不支持的市场类型 %s", exchange.ErrInvalidOrder, req.Market)
+		}
+	}
+
+	for _, market := range []exchange.Market{exchange.MarketSpot, exchange.MarketFutures} {
+		var subset []exchange.OrderRequest
+		var indexes []int
+		for i, req := range reqs {
+			if req.Market == market {
+				subset = append(subset, req)
+				indexes = append(indexes, i)
+			}
+		}
+		if len(subset) == 0 {
+			continue
+		}
+
+		marketResults, err := place(market, subset)
+		for j, i := range indexes {
+			if j < len(marketResults) {
+				results[i] = marketResults[j]
+			}
+		}
+		if err != nil {
+			// 未返回结果的请求与未发送市场的请求记录为该错误
+			for i, req := range reqs {
+				if (req.Market == exchange.MarketSpot || req.Market == exchange.MarketFutures) && results[i].Order == nil && results[i].Err == nil {
+					results[i].Err = err
+				}
+			}
+			return results, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestDo 分批发送，转换失败与发送失败的请求记录对应错误
+// go test -v ./internal/batch -run "^TestDo$"
+func TestDo(t *testing.T) {
+	errPrepare := errors.New("prepare")
+	errSend := errors.New("send")
+
+	var chunks [][]int
+	prepare := func(n int) (int, error) {
+		if n == 2 {
+			return 0, errPrepare
+		}
+		return n, nil
+	}
+	send := func(items []int) ([]exchange.OrderResult, error) {
+		chunks = append(chunks, items)
+		if items[len(items)-1] == 5 {
+			return nil, errSend
+		}
+		results := make([]exchange.OrderResult, len(items))
+		for i, n := range items {
+			results[i].Order = &exchange.Order{OrderID: strconv.Itoa(n)}
+		}
+		return results, nil
+	}
+
+	results, err := Do(context.Background(), []int{1, 2, 3, 4, 5, 6}, 2, prepare, send)
+	if err != nil {
+		t.Fatalf("批量发送失败: %v", err)
+	}
+	if len(chunks) != 3 || len(chunks[0]) != 2 || chunks[0][1] != 3 {
+		t.Errorf("分批错误: %v", chunks)
+	}
+	for i, want := range []string{"1", "", "3", "", "", "6"} {
+		if want == "" {
+			continue
+		}
+		if results[i].Err != nil || results[i].Order.OrderID != want {
+			t.Errorf("第 %d 个结果错误: %+v", i+1, results[i])
+		}
+	}
+	if !errors.Is(results[1].Err, errPrepare) {
+		t.Errorf("转换失败应记录错误: %v", results[1].Err)
+	}
+	if !errors.Is(results[3].Err, errSend) || !errors.Is(results[4].Err, errSend) {
+		t.Errorf("发送失败应记录到整批: %v, %v", results[3].Err, results[4].Err)
+	}
+}
+
+// TestDoContextCanceled 上下文取消后不再发送剩余批次
+// go test -v ./internal/batch -run "^TestDoContextCanceled$"
+func TestDoContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	prepare := func(n int) (int, error) { return n, nil }
+	sent := 0
+	send := func(items []int) ([]exchange.OrderResult, error) {
+		sent++
+		cancel()
+		return make([]exchange.OrderResult, len(items)), nil
+	}
+
+	results, err := Do(ctx, []int{1, 2, 3}, 1, prepare, send)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("应返回上下文错误: %v", err)
+	}
+	if sent != 1 || results[0].Err != nil || !errors.Is(results[1].Err, context.Canceled) || !errors.Is(results[2].Err, context.Canceled) {
+		t.Errorf("取消后的结果错误: sent=%d, %+v", sent, results)
+	}
+}
+
+// TestSplitMarket 按市场拆分后结果保持请求顺序
+// go test -v ./internal/batch -run "^TestSplitMarket$"
+func TestSplitMarket(t *testing.T) {
+	reqs := []exchange.OrderRequest{
+		{Market: exchange.MarketFutures, Symbol: "A"},
+		{Market: exchange.MarketSpot, Symbol: "B"},
+		{Market: "unknown", Symbol: "C"},
+		{Market: exchange.MarketFutures, Symbol: "D"},
+	}
+	results, err := SplitMarket(reqs, func(market exchange.Market, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+		results := make([]exchange.OrderResult, len(reqs))
+		for i, req := range reqs {
+			results[i].Order = &exchange.Order{Symbol: req.Symbol}
+		}
+		return results, nil
+	})
+	if err != nil {
+		t.Fatalf("拆分下单失败: %v", err)
+	}
+	for i, want := range []string{"A", "B", "", "D"} {
+		if want == "" {
+			if !errors.Is(results[i].Err, exchange.ErrInvalidOrder) {
+				t.Errorf("不支持的市场应返回 ErrInvalidOrder: %v", results[i].Err)
+			}
+			continue
+		}
+		if results[i].Order == nil || results[i].Order.Symbol != want {
+			t.Errorf("第 %d 个结果错误: %+v", i+1, results[i])
+		}
+	}
+}
+
+// TestSplitMarketError 现货下单返回错误时不再发送合约请求，未发送的请求记录为该错误
+// go test -v ./internal/batch -run "^TestSplitMarketError$"
+func TestSplitMarketError(t *testing.T) {
+	reqs := []exchange.OrderRequest{
+		{Market: exchange.MarketFutures, Symbol: "A"},
+		{Market: exchange.MarketSpot, Symbol: "B"},
+		{Market: exchange.MarketSpot, Symbol: "C"},
+	}
+	var markets []exchange.Market
+	results, err := SplitMarket(reqs, func(market exchange.Market, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+		markets = append(markets, market)
+		// 第一个请求已发送成功，之后上下文取消
+		results := make([]exchange.OrderResult, len(reqs))
+		results[0].Order = &exchange.Order{Symbol: reqs[0].Symbol}
+		results[1].Err = context.Canceled
+		return results, context.Canceled
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("应返回上下文错误: %v", err)
+	}
+	if len(markets) != 1 || markets[0] != exchange.MarketSpot {
+		t.Fatalf("现货失败后不应发送合约请求: %v", markets)
+	}
+	if !errors.Is(results[0].Err, context.Canceled) || results[0].Order != nil {
+		t.Errorf("未发送的合约请求应记录错误: %+v", results[0])
+	}
+	if results[1].Order == nil || results[1].Order.Symbol != "B" || !errors.Is(results[2].Err, context.Canceled) {
+		t.Errorf("现货结果错误: %+v", results[1:])
+	}
+}
@@ -0,0 +1,81 @@
+package idempotent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	RetryBackoff = 200 * time.Millisecond // 首次重试等待时间，之后每次翻倍
+)
+
+// ClientOrderID 下单使用的客户端订单ID，优先使用请求指定的ID，其次为上下文设置的ID，都未设置时生成新的ID
+func ClientOrderID(ctx context.Context, clientOrderID string) string {
+	if clientOrderID != "" {
+		return clientOrderID
+	}
+	if clientOrderID := exchange.GetClientOrderID(ctx); clientOrderID != "" {
+		return clientOrderID
+	}
+	return exchange.NewClientOrderID()
+}
+
+// IsTemporary 是否结果未知的临时错误：网络错误、超时与交易所临时错误，限频器拒绝的请求未发送不属于临时错误
+func IsTemporary(err error) bool {
+	if err == nil || errors.Is(err, exchange.ErrRateLimited) {
+		return false
+	}
+	if errors.Is(err, exchange.ErrTemporary) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// PlaceOrder 使用同一客户端订单ID幂等下单。
+// 遇到临时错误时等待后按客户端订单ID查询：订单已存在则返回该订单，确认不存在后重新下单，查询结果仍未知时继续查询；
+// 交易所返回客户端订单ID重复时直接查询返回已存在的订单
+func PlaceOrder(ctx context.Context, maxRetries int, place, lookup func() (*exchange.Order, error)) (*exchange.Order, error) {
+	order, err := place()
+	for attempt := 0; err != nil; attempt++ {
+		if errors.Is(err, exchange.ErrDuplicateOrder) {
+			return lookup()
+		}
+		if !IsTemporary(err) || attempt >= maxRetries || !sleep(ctx, RetryBackoff<<attempt) {
+			return nil, err
+		}
+
+		existing, lookupErr := lookup()
+		switch {
+		case lookupErr == nil:
+			return existing, nil
+		case errors.Is(lookupErr, exchange.ErrOrderNotFound):
+			order, err = place()
+		case !IsTemporary(lookupErr):
+			return nil, lookupErr
+		}
+	}
+	return order, nil
+}
+
+// sleep 等待 d，ctx 结束时返回 false
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,96 @@
+package idempotent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// TestPlaceOrder 临时错误后按客户端订单ID对账：已存在时返回已有订单，不存在时重试，非临时错误直接返回
+// go test -v ./internal/idempotent -run "^TestPlaceOrder$"
+func TestPlaceOrder(t *testing.T) {
+	ctx := context.Background()
+	timeout := &url.Error{Op: "Post", URL: "https://api.example.com/order", Err: errors.New("timeout")}
+	placed := &exchange.Order{OrderID: "1", ClientOrderID: "abc"}
+
+	// 超时但订单已提交成功，对账返回已有订单，不重复下单
+	places := 0
+	order, err := PlaceOrder(ctx, 2, func() (*exchange.Order, error) {
+		places++
+		return nil, timeout
+	}, func() (*exchange.Order, error) {
+		return placed, nil
+	})
+	if err != nil || order != placed || places != 1 {
+		t.Fatalf("已提交的订单不应重复下单: places=%d, order=%v, err=%v", places, order, err)
+	}
+
+	// 订单不存在时重试下单
+	places = 0
+	order, err = PlaceOrder(ctx, 2, func() (*exchange.Order, error) {
+		places++
+		if places == 1 {
+			return nil, fmt.Errorf("下单失败: %w", exchange.NewAPIError(exchange.ErrTemporary, "-1007", "timeout", nil))
+		}
+		return placed, nil
+	}, func() (*exchange.Order, error) {
+		return nil, exchange.ErrOrderNotFound
+	})
+	if err != nil || order != placed || places != 2 {
+		t.Fatalf("订单不存在时应重试: places=%d, order=%v, err=%v", places, order, err)
+	}
+
+	// 重试次数用尽后返回原错误
+	places = 0
+	_, err = PlaceOrder(ctx, 1, func() (*exchange.Order, error) {
+		places++
+		return nil, timeout
+	}, func() (*exchange.Order, error) {
+		return nil, exchange.ErrOrderNotFound
+	})
+	if !errors.Is(err, timeout) || places != 2 {
+		t.Fatalf("重试用尽应返回原错误: places=%d, err=%v", places, err)
+	}
+
+	// 非临时错误不重试
+	places = 0
+	_, err = PlaceOrder(ctx, 2, func() (*exchange.Order, error) {
+		places++
+		return nil, exchange.ErrInsufficientBalance
+	}, func() (*exchange.Order, error) {
+		t.Fatal("非临时错误不应查询")
+		return nil, nil
+	})
+	if !errors.Is(err, exchange.ErrInsufficientBalance) || places != 1 {
+		t.Fatalf("非临时错误不应重试: places=%d, err=%v", places, err)
+	}
+
+	// 客户端订单ID重复时返回已有订单
+	order, err = PlaceOrder(ctx, 0, func() (*exchange.Order, error) {
+		return nil, exchange.ErrDuplicateOrder
+	}, func() (*exchange.Order, error) {
+		return placed, nil
+	})
+	if err != nil || order != placed {
+		t.Fatalf("客户端订单ID重复应返回已有订单: order=%v, err=%v", order, err)
+	}
+}
+
+// TestClientOrderID 优先使用请求指定的客户端订单ID，其次为上下文中的ID
+// go test -v ./internal/idempotent -run "^TestClientOrderID$"
+func TestClientOrderID(t *testing.T) {
+	ctx := exchange.WithClientOrderID(context.Background(), "my-order")
+	if id := ClientOrderID(ctx, "req-order"); id != "req-order" {
+		t.Errorf("应使用请求指定的客户端订单ID: %s", id)
+	}
+	if id := ClientOrderID(ctx, ""); id != "my-order" {
+		t.Errorf("应使用上下文中的客户端订单ID: %s", id)
+	}
+	if id := ClientOrderID(context.Background(), ""); len(id) != 24 {
+		t.Errorf("生成的客户端订单ID长度应为 24: %s", id)
+	}
+}
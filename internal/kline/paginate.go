@@ -0,0 +1,118 @@
+package kline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+	"github.com/so68/exchange-lib/internal/utils"
+)
+
+// FetchFunc 获取 [start, end] 区间内最多 limit 根K线，按开盘时间升序返回
+type FetchFunc func(start, end time.Time, limit int) ([]*exchange.Kline, error)
+
+// Direction 分页方向
+type Direction int
+
+const (
+	Forward  Direction = iota // 从区间起点向后分页，交易所返回区间内最早的K线
+	Backward                  // 从区间终点向前分页，交易所返回区间内最新的K线
+)
+
+// Paginate 按交易所单页上限分页获取K线，返回 [start, end] 内按开盘时间升序的K线
+// end 为零值时取当前时间；start 为零值时返回最近 limit 根（limit 为 0 时为一页）；
+// start 不为零值且 limit 大于 0 时返回从 start 开始的 limit 根
+func Paginate(fetch FetchFunc, interval exchange.KlineInterval, start, end time.Time, limit, pageSize int, direction Direction) ([]*exchange.Kline, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("无效的K线数量: %d", limit)
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	fromStart := !start.IsZero()
+	if !fromStart {
+		count := limit
+		if count == 0 {
+			count = pageSize
+		}
+		start = end.Add(-time.Duration(count) * interval.Duration())
+	} else if limit > 0 {
+		// 按周期估算区间终点，减少不必要的请求
+		if e := start.Add(time.Duration(limit) * interval.Duration()); e.Before(end) {
+			end = e
+		}
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("K线结束时间不能早于开始时间")
+	}
+
+	var (
+		klines  []*exchange.Kline
+		startMs = start.UnixMilli()
+		endMs   = end.UnixMilli()
+	)
+	switch direction {
+	case Forward:
+		cursor := startMs
+		for i := 0; i < utils.MaxPages && cursor <= endMs; i++ {
+			page, err := fetch(time.UnixMilli(cursor), end, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			full := len(page) >= pageSize
+			page = filter(page, cursor, endMs)
+			if len(page) == 0 {
+				break
+			}
+			klines = append(klines, page...)
+			cursor = page[len(page)-1].OpenTime + 1
+			if !full || (fromStart && limit > 0 && len(klines) >= limit) {
+				break
+			}
+		}
+	case Backward:
+		cursor := endMs
+		for i := 0; i < utils.MaxPages && cursor >= startMs; i++ {
+			page, err := fetch(start, time.UnixMilli(cursor), pageSize)
+			if err != nil {
+				return nil, err
+			}
+			full := len(page) >= pageSize
+			page = filter(page, startMs, cursor)
+			if len(page) == 0 {
+				break
+			}
+			klines = append(page, klines...)
+			cursor = page[0].OpenTime - 1
+			if !full || (!fromStart && limit > 0 && len(klines) >= limit) {
+				break
+			}
+		}
+	default:
+		return nil, fmt.Errorf("无效的分页方向: %d", direction)
+	}
+
+	if limit > 0 && len(klines) > limit {
+		if fromStart {
+			klines = klines[:limit]
+		} else {
+			klines = klines[len(klines)-limit:]
+		}
+	}
+	return klines, nil
+}
+
+// filter 过滤开盘时间不在 [startMs, endMs] 内的K线
+func filter(klines []*exchange.Kline, startMs, endMs int64) []*exchange.Kline {
+	res := make([]*exchange.Kline, 0, len(klines))
+	for _, k := range klines {
+		if k.OpenTime >= startMs && k.OpenTime <= endMs {
+			res = append(res, k)
+		}
+	}
+	return res
+}
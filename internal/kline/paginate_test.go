@@ -0,0 +1,95 @@
+package kline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// venue 模拟交易所K线接口，每分钟一根K线，开盘时间为 [0, count) 分钟
+type venue struct {
+	count     int
+	direction Direction
+	calls     int
+}
+
+// fetch 返回区间内最早或最新的 limit 根K线
+func (v *venue) fetch(start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	v.calls++
+	var res []*exchange.Kline
+	for i := 0; i < v.count; i++ {
+		openTime := int64(i) * time.Minute.Milliseconds()
+		if openTime >= start.UnixMilli() && openTime <= end.UnixMilli() {
+			res = append(res, &exchange.Kline{OpenTime: openTime})
+		}
+	}
+	if len(res) > limit {
+		if v.direction == Forward {
+			res = res[:limit]
+		} else {
+			res = res[len(res)-limit:]
+		}
+	}
+	return res, nil
+}
+
+// assertRange 校验K线为连续的 [from, to) 分钟
+func assertRange(t *testing.T, klines []*exchange.Kline, from, to int) {
+	t.Helper()
+	if len(klines) != to-from {
+		t.Fatalf("K线数量错误: got %d, want %d", len(klines), to-from)
+	}
+	for i, k := range klines {
+		if want := int64(from+i) * time.Minute.Milliseconds(); k.OpenTime != want {
+			t.Fatalf("第 %d 根K线开盘时间错误: got %d, want %d", i, k.OpenTime, want)
+		}
+	}
+}
+
+// TestPaginate 正向与反向分页
+// go test -v ./internal/kline -run "^TestPaginate$"
+func TestPaginate(t *testing.T) {
+	minute := func(n int) time.Time { return time.UnixMilli(int64(n) * time.Minute.Milliseconds()) }
+
+	for _, direction := range []Direction{Forward, Backward} {
+		// 区间内全部K线
+		v := &venue{count: 250, direction: direction}
+		klines, err := Paginate(v.fetch, exchange.KlineInterval1m, minute(10), minute(239), 0, 100, direction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRange(t, klines, 10, 240)
+		if v.calls != 3 {
+			t.Fatalf("方向 %d 请求次数错误: got %d, want 3", direction, v.calls)
+		}
+
+		// 从 start 开始的 limit 根
+		v = &venue{count: 250, direction: direction}
+		klines, err = Paginate(v.fetch, exchange.KlineInterval1m, minute(10), minute(249), 150, 100, direction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRange(t, klines, 10, 160)
+
+		// 截止 end 的最近 limit 根
+		v = &venue{count: 250, direction: direction}
+		klines, err = Paginate(v.fetch, exchange.KlineInterval1m, time.Time{}, minute(249), 150, 100, direction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRange(t, klines, 100, 250)
+	}
+}
+
+// TestPaginateInvalid 无效参数
+// go test -v ./internal/kline -run "^TestPaginateInvalid$"
+func TestPaginateInvalid(t *testing.T) {
+	v := &venue{count: 10}
+	if _, err := Paginate(v.fetch, "2m", time.Time{}, time.Time{}, 0, 100, Forward); err == nil {
+		t.Fatal("无效周期应返回错误")
+	}
+	if _, err := Paginate(v.fetch, exchange.KlineInterval1m, time.UnixMilli(2000000), time.UnixMilli(1000000), 0, 100, Forward); err == nil {
+		t.Fatal("结束时间早于开始时间应返回错误")
+	}
+}
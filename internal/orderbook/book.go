@@ -0,0 +1,209 @@
+package orderbook
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/shopspring/decimal"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// level 深度档位，保留交易所原始字符串用于输出和校验和
+type level struct {
+	price    decimal.Decimal // 价格，用于排序
+	rawPrice string          // 原始价格
+	quantity string          // 原始数量
+}
+
+// Book 本地维护的深度，并发安全
+type Book struct {
+	symbol       string
+	multiplier   decimal.Decimal // 输出数量乘数，如合约面值，零表示不转换
+	bids         []level         // 买盘，价格从高到低
+	asks         []level         // 卖盘，价格从低到高
+	lastUpdateID int64           // 最后更新ID
+	time         int64           // 最后更新时间，毫秒
+	synced       bool            // 是否已同步
+	mux          sync.RWMutex
+}
+
+// NewBook 创建本地深度，multiplier 不为空时输出数量乘以该值
+func NewBook(symbol string, multiplier string) *Book {
+	b := &Book{symbol: symbol}
+	if multiplier != "" {
+		b.multiplier, _ = decimal.NewFromString(multiplier)
+	}
+	return b
+}
+
+// Symbol 交易对
+func (b *Book) Symbol() string {
+	return b.symbol
+}
+
+// Synced 是否已与交易所同步
+func (b *Book) Synced() bool {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	return b.synced
+}
+
+// LastUpdateID 最后更新ID
+func (b *Book) LastUpdateID() int64 {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	return b.lastUpdateID
+}
+
+// Reset 使用快照重置深度并标记为已同步
+func (b *Book) Reset(snapshot *exchange.OrderBook) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	for _, l := range snapshot.Bids {
+		b.bids = setLevel(b.bids, l, true)
+	}
+	for _, l := range snapshot.Asks {
+		b.asks = setLevel(b.asks, l, false)
+	}
+	b.lastUpdateID = snapshot.LastUpdateID
+	b.time = snapshot.Time
+	b.synced = true
+}
+
+// Update 应用增量档位，数量为 0 表示删除该档位
+func (b *Book) Update(bids, asks []exchange.OrderBookLevel, lastUpdateID, time int64) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	for _, l := range bids {
+		b.bids = setLevel(b.bids, l, true)
+	}
+	for _, l := range asks {
+		b.asks = setLevel(b.asks, l, false)
+	}
+	b.lastUpdateID = lastUpdateID
+	if time > 0 {
+		b.time = time
+	}
+}
+
+// Invalidate 标记为未同步，等待重新同步
+func (b *Book) Invalidate() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.synced = false
+}
+
+// Snapshot 获取前 depth 档深度，depth <= 0 表示全部档位
+func (b *Book) Snapshot(depth int) *exchange.OrderBook {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	return &exchange.OrderBook{
+		Symbol:       b.symbol,
+		Bids:         b.toLevels(b.bids, depth),
+		Asks:         b.toLevels(b.asks, depth),
+		LastUpdateID: b.lastUpdateID,
+		Time:         b.time,
+	}
+}
+
+// BestBid 获取买一档位
+func (b *Book) BestBid() (exchange.OrderBookLevel, bool) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if len(b.bids) == 0 {
+		return exchange.OrderBookLevel{}, false
+	}
+	return b.toLevel(b.bids[0]), true
+}
+
+// BestAsk 获取卖一档位
+func (b *Book) BestAsk() (exchange.OrderBookLevel, bool) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if len(b.asks) == 0 {
+		return exchange.OrderBookLevel{}, false
+	}
+	return b.toLevel(b.asks[0]), true
+}
+
+// RawLevels 获取前 depth 档原始价格和数量，用于计算校验和
+func (b *Book) RawLevels(depth int) (bids, asks [][2]string) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	raw := func(levels []level) [][2]string {
+		if depth > 0 && len(levels) > depth {
+			levels = levels[:depth]
+		}
+		res := make([][2]string, 0, len(levels))
+		for _, l := range levels {
+			res = append(res, [2]string{l.rawPrice, l.quantity})
+		}
+		return res
+	}
+	return raw(b.bids), raw(b.asks)
+}
+
+// toLevels 转换为输出档位
+func (b *Book) toLevels(levels []level, depth int) []exchange.OrderBookLevel {
+	if depth > 0 && len(levels) > depth {
+		levels = levels[:depth]
+	}
+	res := make([]exchange.OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		res = append(res, b.toLevel(l))
+	}
+	return res
+}
+
+// toLevel 转换为输出档位，按乘数转换数量
+func (b *Book) toLevel(l level) exchange.OrderBookLevel {
+	quantity := l.quantity
+	if !b.multiplier.IsZero() {
+		if q, err := decimal.NewFromString(quantity); err == nil {
+			quantity = q.Mul(b.multiplier).String()
+		}
+	}
+	return exchange.OrderBookLevel{Price: l.rawPrice, Quantity: quantity}
+}
+
+// setLevel 在有序档位中设置价格对应的数量，数量为 0 时删除
+func setLevel(levels []level, l exchange.OrderBookLevel, desc bool) []level {
+	price, err := decimal.NewFromString(l.Price)
+	if err != nil {
+		return levels
+	}
+	quantity, err := decimal.NewFromString(l.Quantity)
+	if err != nil {
+		return levels
+	}
+
+	index := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].price.LessThanOrEqual(price)
+		}
+		return levels[i].price.GreaterThanOrEqual(price)
+	})
+	exists := index < len(levels) && levels[index].price.Equal(price)
+
+	switch {
+	case quantity.IsZero() && exists:
+		return append(levels[:index], levels[index+1:]...)
+	case quantity.IsZero():
+		return levels
+	case exists:
+		levels[index].rawPrice = l.Price
+		levels[index].quantity = l.Quantity
+		return levels
+	}
+
+	levels = append(levels, level{})
+	copy(levels[index+1:], levels[index:])
+	levels[index] = level{price: price, rawPrice: l.Price, quantity: l.Quantity}
+	return levels
+}
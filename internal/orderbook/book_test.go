@@ -0,0 +1,76 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// levels 创建档位列表，参数为 价格, 数量, 价格, 数量...
+func levels(values ...string) []exchange.OrderBookLevel {
+	res := make([]exchange.OrderBookLevel, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		res = append(res, exchange.OrderBookLevel{Price: values[i], Quantity: values[i+1]})
+	}
+	return res
+}
+
+// assertLevels 校验档位
+func assertLevels(t *testing.T, name string, got []exchange.OrderBookLevel, want ...string) {
+	t.Helper()
+	expected := levels(want...)
+	if len(got) != len(expected) {
+		t.Fatalf("%s 档位数量错误: got %v, want %v", name, got, expected)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("%s 第 %d 档错误: got %v, want %v", name, i, got, expected)
+		}
+	}
+}
+
+// TestBookUpdate 档位新增、修改、删除与排序
+// go test -v ./internal/orderbook -run "^TestBookUpdate$"
+func TestBookUpdate(t *testing.T) {
+	book := NewBook("BTCUSDT", "")
+	book.Reset(&exchange.OrderBook{
+		Bids:         levels("99", "2", "100", "1"),
+		Asks:         levels("102", "3", "101", "1"),
+		LastUpdateID: 10,
+	})
+
+	book.Update(levels("100.0", "0", "99.5", "4"), levels("101.50", "2", "101", "5", "103", "0"), 11, 1700000000000)
+
+	snapshot := book.Snapshot(0)
+	assertLevels(t, "bids", snapshot.Bids, "99.5", "4", "99", "2")
+	assertLevels(t, "asks", snapshot.Asks, "101", "5", "101.50", "2", "102", "3")
+	if snapshot.LastUpdateID != 11 || snapshot.Time != 1700000000000 {
+		t.Errorf("更新ID或时间错误: %+v", snapshot)
+	}
+
+	top := book.Snapshot(1)
+	assertLevels(t, "top bids", top.Bids, "99.5", "4")
+	assertLevels(t, "top asks", top.Asks, "101", "5")
+
+	if bid, ok := book.BestBid(); !ok || bid.Price != "99.5" {
+		t.Errorf("买一错误: %+v", bid)
+	}
+	if ask, ok := book.BestAsk(); !ok || ask.Price != "101" {
+		t.Errorf("卖一错误: %+v", ask)
+	}
+}
+
+// TestBookMultiplier 输出数量按乘数转换，原始档位保持不变
+// go test -v ./internal/orderbook -run "^TestBookMultiplier$"
+func TestBookMultiplier(t *testing.T) {
+	book := NewBook("BTC-USDT-SWAP", "0.01")
+	book.Reset(&exchange.OrderBook{Bids: levels("100.0", "25"), Asks: levels("100.1", "3")})
+
+	if bid, _ := book.BestBid(); bid.Price != "100.0" || bid.Quantity != "0.25" {
+		t.Errorf("买一数量转换错误: %+v", bid)
+	}
+	bids, asks := book.RawLevels(25)
+	if bids[0] != [2]string{"100.0", "25"} || asks[0] != [2]string{"100.1", "3"} {
+		t.Errorf("原始档位错误: %v %v", bids, asks)
+	}
+}
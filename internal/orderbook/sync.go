@@ -0,0 +1,237 @@
+package orderbook
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	DefaultRetryDelay = time.Second // 默认重新同步间隔
+	DefaultMaxBuffer  = 10000       // 默认最大缓存事件数量
+)
+
+// Update 增量深度事件
+type Update struct {
+	FirstUpdateID int64                     // 第一个更新ID（U）
+	LastUpdateID  int64                     // 最后一个更新ID（u）
+	PrevUpdateID  int64                     // 上一个事件的最后更新ID（pu），交易所不提供时为 0
+	Bids          []exchange.OrderBookLevel // 买盘变化
+	Asks          []exchange.OrderBookLevel // 卖盘变化
+	Time          int64                     // 事件时间，毫秒
+}
+
+// SnapshotFunc 获取深度快照
+type SnapshotFunc func(ctx context.Context) (*exchange.OrderBook, error)
+
+// ChangeHandler 深度变化回调
+type ChangeHandler func(book *Book)
+
+// Syncer 快照加增量的深度同步器
+// 同步流程：缓存增量事件 -> 获取快照 -> 丢弃已包含在快照中的事件 -> 校验首个事件 U <= lastUpdateId+1 <= u -> 顺序应用，
+// 之后每个事件必须与上一个事件连续（U == 上一个 u+1，或 pu == 上一个 u），出现缺口时自动重新同步。
+type Syncer struct {
+	book       *Book
+	snapshot   SnapshotFunc
+	onChange   ChangeHandler
+	buffer     []*Update     // 同步期间缓存的事件
+	syncing    bool          // 是否正在同步
+	first      bool          // 下一个事件是否为快照后的首个事件
+	retryDelay time.Duration // 重新同步间隔
+	maxBuffer  int           // 最大缓存事件数量
+	run        func(func())  // 执行同步任务，默认新建 goroutine
+	logger     *slog.Logger
+	ctx        context.Context
+	cancel     context.CancelFunc
+	mux        sync.Mutex
+}
+
+// NewSyncer 创建深度同步器
+func NewSyncer(book *Book, snapshot SnapshotFunc, onChange ChangeHandler) *Syncer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Syncer{
+		book:       book,
+		snapshot:   snapshot,
+		onChange:   onChange,
+		retryDelay: DefaultRetryDelay,
+		maxBuffer:  DefaultMaxBuffer,
+		run:        func(f func()) { go f() },
+		logger:     slog.Default(),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// SetRetryDelay 设置重新同步间隔
+func (s *Syncer) SetRetryDelay(retryDelay time.Duration) *Syncer {
+	s.retryDelay = retryDelay
+	return s
+}
+
+// SetRunner 设置同步任务的执行方式，默认新建 goroutine，测试中可同步执行
+func (s *Syncer) SetRunner(run func(func())) *Syncer {
+	s.run = run
+	return s
+}
+
+// Book 本地深度
+func (s *Syncer) Book() *Book {
+	return s.book
+}
+
+// Push 处理增量事件，需按接收顺序调用
+func (s *Syncer) Push(update *Update) {
+	s.mux.Lock()
+
+	if !s.book.Synced() {
+		start := s.bufferUpdate(update)
+		s.mux.Unlock()
+		if start {
+			s.run(s.resync)
+		}
+		return
+	}
+
+	last := s.book.LastUpdateID()
+	switch {
+	case update.LastUpdateID <= last:
+		// 重复或过期事件
+		s.mux.Unlock()
+		return
+	case s.first && (update.FirstUpdateID > last+1):
+		s.logger.Warn("OrderBook first update gap, resync", "symbol", s.book.Symbol(), "lastUpdateId", last, "U", update.FirstUpdateID)
+		s.book.Invalidate()
+		start := s.bufferUpdate(update)
+		s.mux.Unlock()
+		if start {
+			s.run(s.resync)
+		}
+		return
+	case !s.first && !continuous(last, update):
+		s.logger.Warn("OrderBook update gap, resync", "symbol", s.book.Symbol(), "lastUpdateId", last, "U", update.FirstUpdateID, "pu", update.PrevUpdateID)
+		s.book.Invalidate()
+		start := s.bufferUpdate(update)
+		s.mux.Unlock()
+		if start {
+			s.run(s.resync)
+		}
+		return
+	}
+
+	s.first = false
+	s.book.Update(update.Bids, update.Asks, update.LastUpdateID, update.Time)
+	s.mux.Unlock()
+	s.notify()
+}
+
+// Reset 重置同步状态，连接重建后调用，下一个事件将触发重新同步
+func (s *Syncer) Reset() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.book.Invalidate()
+	s.buffer = nil
+}
+
+// Close 停止同步
+func (s *Syncer) Close() {
+	s.cancel()
+}
+
+// bufferUpdate 缓存事件，返回是否需要启动同步任务，调用方需持有锁
+func (s *Syncer) bufferUpdate(update *Update) bool {
+	if len(s.buffer) >= s.maxBuffer {
+		// 缓存溢出，丢弃旧事件，同步时会重新获取快照
+		s.buffer = s.buffer[:0]
+	}
+	s.buffer = append(s.buffer, update)
+	if s.syncing {
+		return false
+	}
+	s.syncing = true
+	return true
+}
+
+// resync 获取快照并应用缓存事件，直到同步成功或停止
+func (s *Syncer) resync() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		default:
+		}
+
+		snapshot, err := s.snapshot(s.ctx)
+		if err == nil {
+			s.mux.Lock()
+			synced := s.applySnapshot(snapshot)
+			if synced {
+				s.syncing = false
+			}
+			s.mux.Unlock()
+
+			if synced {
+				s.notify()
+				return
+			}
+		} else {
+			s.logger.Error("OrderBook snapshot failed", "symbol", s.book.Symbol(), "error", err.Error())
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(s.retryDelay):
+		}
+	}
+}
+
+// applySnapshot 应用快照和缓存事件，返回是否同步成功，调用方需持有锁
+func (s *Syncer) applySnapshot(snapshot *exchange.OrderBook) bool {
+	id := snapshot.LastUpdateID
+
+	// 丢弃已包含在快照中的事件
+	updates := make([]*Update, 0, len(s.buffer))
+	for _, update := range s.buffer {
+		if update.LastUpdateID > id {
+			updates = append(updates, update)
+		}
+	}
+
+	// 快照落后于缓存的首个事件，需要重新获取快照
+	if len(updates) > 0 && updates[0].FirstUpdateID > id+1 {
+		s.buffer = updates
+		return false
+	}
+
+	s.book.Reset(snapshot)
+	s.first = len(updates) == 0
+	for i, update := range updates {
+		if i > 0 && !continuous(updates[i-1].LastUpdateID, update) {
+			// 缓存事件中存在缺口，保留缺口之后的事件重新同步
+			s.book.Invalidate()
+			s.buffer = updates[i:]
+			return false
+		}
+		s.book.Update(update.Bids, update.Asks, update.LastUpdateID, update.Time)
+	}
+	s.buffer = nil
+	return true
+}
+
+// notify 触发深度变化回调
+func (s *Syncer) notify() {
+	if s.onChange != nil {
+		s.onChange(s.book)
+	}
+}
+
+// continuous 事件是否与上一个事件连续
+func continuous(last int64, update *Update) bool {
+	if update.PrevUpdateID != 0 {
+		return update.PrevUpdateID == last
+	}
+	return update.FirstUpdateID == last+1
+}
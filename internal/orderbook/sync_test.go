@@ -0,0 +1,161 @@
+package orderbook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// replay 回放快照与增量事件，during 为获取第 N 次快照期间到达的事件
+type replay struct {
+	t         *testing.T
+	syncer    *Syncer
+	snapshots []*exchange.OrderBook
+	during    map[int][]*Update
+	calls     int
+	changes   int
+}
+
+// newReplay 创建同步执行的回放同步器
+func newReplay(t *testing.T, snapshots []*exchange.OrderBook, during map[int][]*Update) *replay {
+	r := &replay{t: t, snapshots: snapshots, during: during}
+	r.syncer = NewSyncer(NewBook("BTCUSDT", ""), r.snapshot, func(book *Book) { r.changes++ }).
+		SetRetryDelay(0).
+		SetRunner(func(f func()) { f() })
+	return r
+}
+
+// snapshot 返回下一个快照，并模拟获取期间到达的事件
+func (r *replay) snapshot(ctx context.Context) (*exchange.OrderBook, error) {
+	if r.calls >= len(r.snapshots) {
+		r.t.Fatalf("快照请求次数过多: %d", r.calls+1)
+	}
+	for _, update := range r.during[r.calls] {
+		r.syncer.Push(update)
+	}
+	snapshot := r.snapshots[r.calls]
+	r.calls++
+	if snapshot == nil {
+		return nil, fmt.Errorf("snapshot unavailable")
+	}
+	return snapshot, nil
+}
+
+// update 创建增量事件
+func update(first, last int64, bids, asks []exchange.OrderBookLevel) *Update {
+	return &Update{FirstUpdateID: first, LastUpdateID: last, Bids: bids, Asks: asks}
+}
+
+// TestSyncerBufferedUpdates 缓存事件中已包含在快照中的被丢弃，跨越快照的首个事件被应用
+// go test -v ./internal/orderbook -run "^TestSyncerBufferedUpdates$"
+func TestSyncerBufferedUpdates(t *testing.T) {
+	r := newReplay(t, []*exchange.OrderBook{
+		{Bids: levels("100", "1", "99", "2"), Asks: levels("101", "1", "102", "3"), LastUpdateID: 100},
+	}, map[int][]*Update{
+		0: {
+			update(97, 99, levels("98", "9"), nil),                     // 已包含在快照中
+			update(100, 102, levels("100", "0"), levels("101.5", "2")), // U <= 101 <= u
+		},
+	})
+
+	r.syncer.Push(update(95, 96, nil, nil))
+	r.syncer.Push(update(103, 105, levels("99.5", "4"), nil))
+
+	book := r.syncer.Book()
+	if !book.Synced() || book.LastUpdateID() != 105 {
+		t.Fatalf("同步状态错误: synced=%v lastUpdateId=%d", book.Synced(), book.LastUpdateID())
+	}
+	snapshot := book.Snapshot(0)
+	assertLevels(t, "bids", snapshot.Bids, "99.5", "4", "99", "2")
+	assertLevels(t, "asks", snapshot.Asks, "101", "1", "101.5", "2", "102", "3")
+	if r.calls != 1 || r.changes != 2 {
+		t.Errorf("快照次数或回调次数错误: calls=%d changes=%d", r.calls, r.changes)
+	}
+}
+
+// TestSyncerGap 增量事件出现缺口时重新获取快照
+// go test -v ./internal/orderbook -run "^TestSyncerGap$"
+func TestSyncerGap(t *testing.T) {
+	r := newReplay(t, []*exchange.OrderBook{
+		{Bids: levels("100", "1"), Asks: levels("101", "1"), LastUpdateID: 10},
+		{Bids: levels("100", "5"), Asks: levels("101", "6"), LastUpdateID: 14},
+	}, nil)
+
+	r.syncer.Push(update(9, 10, nil, nil))
+	r.syncer.Push(update(11, 11, levels("100", "2"), nil))
+	r.syncer.Push(update(11, 11, levels("100", "3"), nil)) // 重复事件被忽略
+	r.syncer.Push(update(13, 13, levels("100", "4"), nil)) // 缺少 12，重新同步
+	r.syncer.Push(update(15, 15, nil, levels("101", "7")))
+
+	book := r.syncer.Book()
+	if r.calls != 2 || !book.Synced() || book.LastUpdateID() != 15 {
+		t.Fatalf("重新同步错误: calls=%d synced=%v lastUpdateId=%d", r.calls, book.Synced(), book.LastUpdateID())
+	}
+	snapshot := book.Snapshot(0)
+	assertLevels(t, "bids", snapshot.Bids, "100", "5")
+	assertLevels(t, "asks", snapshot.Asks, "101", "7")
+}
+
+// TestSyncerStaleSnapshot 快照落后于缓存事件或获取失败时重试
+// go test -v ./internal/orderbook -run "^TestSyncerStaleSnapshot$"
+func TestSyncerStaleSnapshot(t *testing.T) {
+	r := newReplay(t, []*exchange.OrderBook{
+		nil, // 获取失败
+		{Bids: levels("100", "1"), LastUpdateID: 5}, // 落后于首个缓存事件 U=20
+		{Bids: levels("100", "2"), LastUpdateID: 21},
+	}, nil)
+
+	r.syncer.Push(update(20, 22, levels("100", "3"), nil))
+
+	book := r.syncer.Book()
+	if r.calls != 3 || !book.Synced() || book.LastUpdateID() != 22 {
+		t.Fatalf("重试同步错误: calls=%d synced=%v lastUpdateId=%d", r.calls, book.Synced(), book.LastUpdateID())
+	}
+	if bid, _ := book.BestBid(); bid.Quantity != "3" {
+		t.Errorf("买一错误: %+v", bid)
+	}
+}
+
+// TestSyncerPrevUpdateID 使用 pu 校验连续性（币安合约）
+// go test -v ./internal/orderbook -run "^TestSyncerPrevUpdateID$"
+func TestSyncerPrevUpdateID(t *testing.T) {
+	r := newReplay(t, []*exchange.OrderBook{
+		{Bids: levels("100", "1"), LastUpdateID: 50},
+		{Bids: levels("100", "9"), LastUpdateID: 80},
+	}, nil)
+
+	r.syncer.Push(&Update{FirstUpdateID: 48, LastUpdateID: 55, PrevUpdateID: 47, Bids: levels("100", "2")})
+	r.syncer.Push(&Update{FirstUpdateID: 60, LastUpdateID: 62, PrevUpdateID: 55, Bids: levels("100", "3")}) // U 不连续但 pu 连续
+	r.syncer.Push(&Update{FirstUpdateID: 70, LastUpdateID: 75, PrevUpdateID: 66, Bids: levels("100", "4")}) // pu 缺口
+	r.syncer.Push(&Update{FirstUpdateID: 76, LastUpdateID: 81, PrevUpdateID: 75, Bids: levels("100", "5")})
+
+	book := r.syncer.Book()
+	if r.calls != 2 || book.LastUpdateID() != 81 {
+		t.Fatalf("重新同步错误: calls=%d lastUpdateId=%d", r.calls, book.LastUpdateID())
+	}
+	if bid, _ := book.BestBid(); bid.Quantity != "5" {
+		t.Errorf("买一错误: %+v", bid)
+	}
+}
+
+// TestSyncerReset 连接重建后下一个事件触发重新同步
+// go test -v ./internal/orderbook -run "^TestSyncerReset$"
+func TestSyncerReset(t *testing.T) {
+	r := newReplay(t, []*exchange.OrderBook{
+		{Bids: levels("100", "1"), LastUpdateID: 1},
+		{Bids: levels("100", "8"), LastUpdateID: 30},
+	}, nil)
+
+	r.syncer.Push(update(2, 2, nil, nil))
+	r.syncer.Reset()
+	if r.syncer.Book().Synced() {
+		t.Fatal("重置后应为未同步")
+	}
+	r.syncer.Push(update(31, 31, nil, levels("101", "1")))
+
+	if r.calls != 2 || !r.syncer.Book().Synced() || r.syncer.Book().LastUpdateID() != 31 {
+		t.Fatalf("重置后同步错误: calls=%d lastUpdateId=%d", r.calls, r.syncer.Book().LastUpdateID())
+	}
+}
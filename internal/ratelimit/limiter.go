@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	DefaultPause = time.Second // 交易所返回 429 且未给出 Retry-After 时暂停请求的时长
+)
+
+// Rule 限频规则，每个 Interval 固定窗口内最多消耗 Limit 额度
+type Rule struct {
+	Key      string        // 规则键，相同键的规则共用计数
+	Limit    int           // 窗口内额度上限
+	Interval time.Duration // 窗口长度
+}
+
+// Cost 请求在某条规则上消耗的额度
+type Cost struct {
+	Rule   Rule
+	Weight int
+}
+
+// Model 交易所限频模型，计算请求消耗的额度并根据响应同步已用额度
+type Model interface {
+	// Costs 请求消耗的额度
+	Costs(req *http.Request) []Cost
+	// Sync 根据响应同步已用额度
+	Sync(limiter *Limiter, req *http.Request, resp *http.Response)
+}
+
+// window 固定窗口计数
+type window struct {
+	start time.Time
+	used  int
+}
+
+// Limiter 固定窗口限频器，按规则键计数，并发安全
+type Limiter struct {
+	policy      exchange.RateLimitPolicy
+	model       Model
+	windows     map[string]*window
+	pausedUntil time.Time
+	mux         sync.Mutex
+}
+
+// New 创建限频器，policy 为空时等待额度恢复
+func New(policy exchange.RateLimitPolicy, model Model) *Limiter {
+	if policy == "" {
+		policy = exchange.RateLimitPolicyWait
+	}
+	return &Limiter{
+		policy:  policy,
+		model:   model,
+		windows: make(map[string]*window),
+	}
+}
+
+// Acquire 请求发送前获取额度
+func (l *Limiter) Acquire(ctx context.Context, req *http.Request) error {
+	return l.Wait(ctx, l.model.Costs(req)...)
+}
+
+// Update 收到响应后同步额度，429/418 响应按 Retry-After 暂停全部请求
+func (l *Limiter) Update(req *http.Request, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot {
+		pause := DefaultPause
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			pause = time.Duration(seconds) * time.Second
+		}
+		l.Pause(pause)
+	}
+	l.model.Sync(l, req, resp)
+}
+
+// Wait 同时获取全部规则的额度，任一规则超限时按策略等待或返回包装 ErrRateLimited 的错误。
+// 窗口内尚无消耗时允许单次请求超过上限，避免大权重请求永远无法发送
+func (l *Limiter) Wait(ctx context.Context, costs ...Cost) error {
+	for {
+		delay := l.reserve(costs)
+		if delay <= 0 {
+			return nil
+		}
+		if l.policy == exchange.RateLimitPolicyFailFast {
+			return fmt.Errorf("%w: 预计 %s 后恢复", exchange.ErrRateLimited, delay)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve 额度充足时扣减并返回 0，否则返回需要等待的时长
+func (l *Limiter) reserve(costs []Cost) time.Duration {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	now := time.Now()
+	delay := l.pausedUntil.Sub(now)
+	for _, c := range costs {
+		w := l.window(c.Rule, now)
+		if w.used > 0 && w.used+c.Weight > c.Rule.Limit {
+			if d := w.start.Add(c.Rule.Interval).Sub(now); d > delay {
+				delay = d
+			}
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+	for _, c := range costs {
+		l.window(c.Rule, now).used += c.Weight
+	}
+	return 0
+}
+
+// Sync 按交易所统计的已用额度校准规则计数，只会调高本地计数
+func (l *Limiter) Sync(rule Rule, used int) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	w := l.window(rule, time.Now())
+	if used > w.used {
+		w.used = used
+	}
+}
+
+// Used 规则在当前窗口内已用额度
+func (l *Limiter) Used(rule Rule) int {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.window(rule, time.Now()).used
+}
+
+// Pause 暂停全部请求直到 d 之后
+func (l *Limiter) Pause(d time.Duration) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// window 获取规则当前窗口，窗口按 Interval 对齐整点，过期时重置
+func (l *Limiter) window(rule Rule, now time.Time) *window {
+	start := now.Truncate(rule.Interval)
+	w, ok := l.windows[rule.Key]
+	if !ok {
+		w = &window{start: start}
+		l.windows[rule.Key] = w
+	} else if w.start.Before(start) {
+		w.start, w.used = start, 0
+	}
+	return w
+}
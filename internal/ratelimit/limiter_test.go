@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// testModel 每个请求在同一规则上消耗 1 额度
+type testModel struct {
+	rule Rule
+}
+
+func (m testModel) Costs(req *http.Request) []Cost {
+	return []Cost{{Rule: m.rule, Weight: 1}}
+}
+
+func (m testModel) Sync(limiter *Limiter, req *http.Request, resp *http.Response) {}
+
+// TestLimiterFailFast 超限立即返回 ErrRateLimited，窗口为空时允许超过上限的单次请求，同步只调高计数
+// go test -v ./internal/ratelimit -run "^TestLimiterFailFast$"
+func TestLimiterFailFast(t *testing.T) {
+	ctx := context.Background()
+	rule := Rule{Key: "weight", Limit: 10, Interval: time.Hour}
+	limiter := New(exchange.RateLimitPolicyFailFast, testModel{rule: rule})
+
+	if err := limiter.Wait(ctx, Cost{Rule: rule, Weight: 20}); err != nil {
+		t.Fatalf("空窗口内大权重请求应放行: %v", err)
+	}
+	if err := limiter.Wait(ctx, Cost{Rule: rule, Weight: 1}); !errors.Is(err, exchange.ErrRateLimited) {
+		t.Fatalf("超限应返回 ErrRateLimited: %v", err)
+	}
+
+	other := Rule{Key: "orders", Limit: 2, Interval: time.Hour}
+	limiter.Sync(other, 1)
+	limiter.Sync(other, 0)
+	if used := limiter.Used(other); used != 1 {
+		t.Errorf("同步后已用额度应为 1: %d", used)
+	}
+	if err := limiter.Wait(ctx, Cost{Rule: other, Weight: 1}); err != nil {
+		t.Fatalf("额度充足时应放行: %v", err)
+	}
+
+	// 多条规则任一超限时不扣减其他规则
+	free := Rule{Key: "free", Limit: 10, Interval: time.Hour}
+	if err := limiter.Wait(ctx, Cost{Rule: free, Weight: 1}, Cost{Rule: other, Weight: 1}); !errors.Is(err, exchange.ErrRateLimited) {
+		t.Fatalf("任一规则超限应返回 ErrRateLimited: %v", err)
+	}
+	if used := limiter.Used(free); used != 0 {
+		t.Errorf("超限时不应扣减其他规则: %d", used)
+	}
+}
+
+// TestLimiterWait 等待策略下额度恢复后放行，ctx 结束时返回 ctx 错误
+// go test -v ./internal/ratelimit -run "^TestLimiterWait$"
+func TestLimiterWait(t *testing.T) {
+	ctx := context.Background()
+	limiter := New(exchange.RateLimitPolicyWait, testModel{})
+
+	rule := Rule{Key: "fast", Limit: 1, Interval: 50 * time.Millisecond}
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, Cost{Rule: rule, Weight: 1}); err != nil {
+			t.Fatalf("等待额度恢复失败: %v", err)
+		}
+	}
+
+	slow := Rule{Key: "slow", Limit: 1, Interval: time.Hour}
+	if err := limiter.Wait(ctx, Cost{Rule: slow, Weight: 1}); err != nil {
+		t.Fatalf("获取额度失败: %v", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(timeoutCtx, Cost{Rule: slow, Weight: 1}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ctx 结束应返回 DeadlineExceeded: %v", err)
+	}
+}
+
+// TestWrapClient 限频客户端在请求前获取额度，429 响应按 Retry-After 暂停后续请求
+// go test -v ./internal/ratelimit -run "^TestWrapClient$"
+func TestWrapClient(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	limiter := New(exchange.RateLimitPolicyFailFast, testModel{rule: Rule{Key: "endpoint", Limit: 2, Interval: time.Hour}})
+	client := WrapClient(&http.Client{Timeout: time.Second}, limiter)
+	if client.Timeout != time.Second {
+		t.Errorf("应保留原客户端配置: %s", client.Timeout)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	resp.Body.Close()
+
+	status = http.StatusTooManyRequests
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	resp.Body.Close()
+
+	// 额度仍有剩余，但 429 后应暂停请求
+	limiter.model = testModel{rule: Rule{Key: "other", Limit: 2, Interval: time.Hour}}
+	if _, err = client.Get(server.URL); !errors.Is(err, exchange.ErrRateLimited) {
+		t.Fatalf("429 后应暂停请求: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"net/http"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// transport 限频传输层，请求前获取额度，响应后同步额度
+type transport struct {
+	base    http.RoundTripper
+	limiter exchange.RateLimiter
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Acquire(req.Context(), req); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.limiter.Update(req, resp)
+	}
+	return resp, err
+}
+
+// WrapClient 返回经过限频的 HTTP 客户端副本，client 为空时基于默认配置创建，limiter 为空时原样返回
+func WrapClient(client *http.Client, limiter exchange.RateLimiter) *http.Client {
+	if limiter == nil {
+		return client
+	}
+	wrapped := &http.Client{}
+	if client != nil {
+		*wrapped = *client
+	}
+	base := wrapped.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped.Transport = &transport{base: base, limiter: limiter}
+	return wrapped
+}
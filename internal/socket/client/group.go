@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	GroupErrorBufferSize = 64 // 错误通道缓冲大小，缓冲满时丢弃新的错误
+)
+
+// GroupStateHandler 连接组的连接状态回调，url 为连接地址
+type GroupStateHandler func(url string, state State, err error)
+
+// Group 管理一组连接及其后台任务的生命周期，关闭时统一停止
+type Group struct {
+	conns        map[*Websocket][]func() // 连接 -> 连接关闭后执行的清理函数
+	stateHandler GroupStateHandler       // 连接状态回调
+	errors       chan error              // 异步错误通道
+	ctx          context.Context         // 连接组关闭时取消
+	cancel       context.CancelFunc      // 取消函数
+	done         chan struct{}           // 连接组关闭后关闭
+	mux          sync.Mutex
+}
+
+// NewGroup 创建连接组
+func NewGroup() *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{
+		conns:  make(map[*Websocket][]func()),
+		errors: make(chan error, GroupErrorBufferSize),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+// Add 加入连接，由调用方启动，连接组关闭时关闭连接并依次执行 onClose
+func (g *Group) Add(ws *Websocket, onClose ...func()) error {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	if g.ctx.Err() != nil {
+		return exchange.ErrClosed
+	}
+	ws.SetStateHandler(func(state State, err error) {
+		g.notifyState(ws.GetDialURL(), state, err)
+	})
+	g.conns[ws] = onClose
+	return nil
+}
+
+// Start 加入并启动连接，启动失败时执行 onClose；ctx 结束时关闭该连接并执行 onClose
+func (g *Group) Start(ctx context.Context, ws *Websocket, onClose ...func()) error {
+	if err := ctx.Err(); err != nil {
+		runAll(onClose)
+		return err
+	}
+	if err := g.Add(ws, onClose...); err != nil {
+		runAll(onClose)
+		return err
+	}
+	if err := ws.Start(); err != nil {
+		g.Remove(ws)
+		return err
+	}
+
+	g.AfterFunc(ctx, func() {
+		g.Remove(ws)
+	})
+	return nil
+}
+
+// AfterFunc ctx 结束时执行 fn，连接组先关闭时不执行
+func (g *Group) AfterFunc(ctx context.Context, fn func()) {
+	if ctx.Done() == nil {
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			fn()
+		case <-g.ctx.Done():
+		}
+	}()
+}
+
+// Remove 移除并关闭连接，执行其清理函数
+func (g *Group) Remove(ws *Websocket) {
+	g.mux.Lock()
+	onClose, ok := g.conns[ws]
+	delete(g.conns, ws)
+	g.mux.Unlock()
+	if !ok {
+		return
+	}
+
+	ws.Close()
+	runAll(onClose)
+}
+
+// Context 连接组的上下文，连接组关闭时取消，用于停止后台任务
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+// SetStateHandler 设置连接状态回调
+func (g *Group) SetStateHandler(handler GroupStateHandler) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	g.stateHandler = handler
+}
+
+// ReportError 发送异步错误，通道缓冲满时丢弃
+func (g *Group) ReportError(err error) {
+	select {
+	case g.errors <- err:
+	default:
+	}
+}
+
+// Errors 异步错误通道，连接组关闭后不再写入
+func (g *Group) Errors() <-chan error {
+	return g.errors
+}
+
+// Done 连接组关闭后关闭的通道
+func (g *Group) Done() <-chan struct{} {
+	return g.done
+}
+
+// Close 关闭全部连接并停止后台任务，可重复调用
+func (g *Group) Close() {
+	g.mux.Lock()
+	if g.ctx.Err() != nil {
+		g.mux.Unlock()
+		<-g.done
+		return
+	}
+	g.cancel()
+	conns := g.conns
+	g.conns = make(map[*Websocket][]func())
+	g.mux.Unlock()
+
+	var wg sync.WaitGroup
+	for ws, onClose := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ws.Close()
+			runAll(onClose)
+		}()
+	}
+	wg.Wait()
+	close(g.done)
+}
+
+// notifyState 回调连接状态，断开原因同时写入错误通道
+func (g *Group) notifyState(url string, state State, err error) {
+	g.mux.Lock()
+	handler := g.stateHandler
+	closed := g.ctx.Err() != nil
+	g.mux.Unlock()
+
+	if handler != nil {
+		handler(url, state, err)
+	}
+	if err != nil && !closed {
+		g.ReportError(fmt.Errorf("%s %s: %w", url, state, err))
+	}
+}
+
+// runAll 依次执行函数
+func runAll(fns []func()) {
+	for _, fn := range fns {
+		fn()
+	}
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// newTestServer 创建本地 Websocket 服务，drop 写入后断开当前全部连接
+func newTestServer(t *testing.T) (string, chan struct{}) {
+	t.Helper()
+	drop := make(chan struct{}, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		go func() {
+			<-drop
+			conn.Close()
+		}()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), drop
+}
+
+// stateRecorder 记录连接状态
+type stateRecorder struct {
+	states []State
+	mux    sync.Mutex
+}
+
+func (r *stateRecorder) handle(_ string, state State, _ error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.states = append(r.states, state)
+}
+
+// wait 等待出现指定状态
+func (r *stateRecorder) wait(t *testing.T, state State) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mux.Lock()
+		for _, s := range r.states {
+			if s == state {
+				r.mux.Unlock()
+				return
+			}
+		}
+		r.mux.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("等待状态 %s 超时: %v", state, r.states)
+}
+
+// TestGroupContextCancel ctx 结束时关闭该连接并执行清理函数
+// go test -v ./internal/socket/client -run "^TestGroupContextCancel$"
+func TestGroupContextCancel(t *testing.T) {
+	url, _ := newTestServer(t)
+	group := NewGroup()
+	defer group.Close()
+	recorder := &stateRecorder{}
+	group.SetStateHandler(recorder.handle)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	closed := make(chan struct{})
+	ws := NewWebsocket(url, func([]byte) {})
+	if err := group.Start(ctx, ws, func() { close(closed) }); err != nil {
+		t.Fatalf("启动失败: %v", err)
+	}
+	recorder.wait(t, StateConnected)
+
+	cancel()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ctx 结束后未执行清理函数")
+	}
+	recorder.wait(t, StateDisconnected)
+	if ws.IsConnected() {
+		t.Error("ctx 结束后连接未关闭")
+	}
+	select {
+	case <-group.Done():
+		t.Error("单个连接关闭不应关闭连接组")
+	default:
+	}
+}
+
+// TestGroupClose 关闭连接组后关闭全部连接，不能再加入连接
+// go test -v ./internal/socket/client -run "^TestGroupClose$"
+func TestGroupClose(t *testing.T) {
+	url, _ := newTestServer(t)
+	group := NewGroup()
+
+	var cleaned atomic.Int32
+	for i := 0; i < 3; i++ {
+		if err := group.Start(context.Background(), NewWebsocket(url, func([]byte) {}), func() { cleaned.Add(1) }); err != nil {
+			t.Fatalf("启动失败: %v", err)
+		}
+	}
+	group.Close()
+	group.Close()
+
+	select {
+	case <-group.Done():
+	default:
+		t.Fatal("关闭后 Done 未关闭")
+	}
+	if cleaned.Load() != 3 {
+		t.Errorf("清理函数执行次数错误: %d", cleaned.Load())
+	}
+	if err := group.Start(context.Background(), NewWebsocket(url, func([]byte) {})); !errors.Is(err, exchange.ErrClosed) {
+		t.Errorf("关闭后启动应返回 ErrClosed: %v", err)
+	}
+}
+
+// TestGroupReconnect 连接断开时回调重连状态并写入错误通道，重连成功后回调已连接
+// go test -v ./internal/socket/client -run "^TestGroupReconnect$"
+func TestGroupReconnect(t *testing.T) {
+	url, drop := newTestServer(t)
+	group := NewGroup()
+	defer group.Close()
+	recorder := &stateRecorder{}
+	group.SetStateHandler(recorder.handle)
+
+	config := DefaultConfig()
+	config.RetryDelay = 0
+	ws := NewWebsocket(url, func([]byte) {}).SetConfig(config)
+	if err := group.Start(context.Background(), ws); err != nil {
+		t.Fatalf("启动失败: %v", err)
+	}
+
+	drop <- struct{}{}
+	recorder.wait(t, StateReconnecting)
+	select {
+	case err := <-group.Errors():
+		if err == nil {
+			t.Error("错误为空")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("未收到断开错误")
+	}
+
+	// 重连成功后最后一个状态为已连接
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		recorder.mux.Lock()
+		states := append([]State(nil), recorder.states...)
+		recorder.mux.Unlock()
+		if len(states) >= 3 && states[len(states)-1] == StateConnected {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("重连状态错误: %v", recorder.states)
+}
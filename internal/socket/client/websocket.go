@@ -21,6 +21,18 @@ type BeforeConnectionHandler func() error
 // AfterConnectionHandler 连接成功后的回调函数
 type AfterConnectionHandler func() error
 
+// State 连接状态
+type State string
+
+const (
+	StateConnected    State = "CONNECTED"    // 已连接
+	StateReconnecting State = "RECONNECTING" // 连接断开，正在重连
+	StateDisconnected State = "DISCONNECTED" // 已关闭或重试次数用尽
+)
+
+// StateHandler 连接状态回调，err 为断开或重连失败的原因
+type StateHandler func(state State, err error)
+
 // Websocket 通用 WebSocket 管理器
 type Websocket struct {
 	conn              *websocket.Conn         // 连接
@@ -29,6 +41,7 @@ type Websocket struct {
 	messageHandler    MessageHandler          // 消息处理器
 	beforeConnHandler BeforeConnectionHandler // 连接前的回调处理器
 	afterConnHandler  AfterConnectionHandler  // 连接成功后的回调处理器
+	stateHandler      StateHandler            // 连接状态回调处理器
 	logger            *slog.Logger            // 日志记录器
 	metrics           Metrics                 // 性能指标
 	ctx               context.Context         // 上下文
@@ -63,7 +76,7 @@ func NewWebsocket(dialURL string, messageHandler MessageHandler) *Websocket {
 
 // Start 运行WebSocket
 func (m *Websocket) Start() error {
-	if err := m.connect(m.dialURL); err != nil {
+	if err := m.connect(); err != nil {
 		return err
 	}
 
@@ -116,6 +129,19 @@ func (m *Websocket) SetAfterConnectionHandler(handler AfterConnectionHandler) *W
 	return m
 }
 
+// SetStateHandler 设置连接状态回调处理器
+func (m *Websocket) SetStateHandler(handler StateHandler) *Websocket {
+	m.stateHandler = handler
+	return m
+}
+
+// notifyState 回调连接状态
+func (m *Websocket) notifyState(state State, err error) {
+	if m.stateHandler != nil {
+		m.stateHandler(state, err)
+	}
+}
+
 // WriteMessage 发送消息
 func (m *Websocket) WriteMessage(message []byte) error {
 	m.mux.Lock()
@@ -129,14 +155,16 @@ func (m *Websocket) WriteMessage(message []byte) error {
 	return nil
 }
 
-// connect 连接或重连
-func (m *Websocket) connect(dialURL string) error {
+// connect 连接或重连，连接前的回调可通过 SetDialURL 更新本次连接地址
+func (m *Websocket) connect() error {
 	// 执行连接前的回调
 	if m.beforeConnHandler != nil {
 		if err := m.beforeConnHandler(); err != nil {
+			m.incrementRetryCount()
 			return fmt.Errorf("WebSocket before connection handler failed: %w", err)
 		}
 	}
+	dialURL := m.GetDialURL()
 
 	reqHeader := http.Header{}
 
@@ -158,7 +186,7 @@ func (m *Websocket) connect(dialURL string) error {
 
 	conn, _, err := dialer.DialContext(ctx, dialURL, reqHeader)
 	if err != nil {
-		m.retryCount++
+		m.incrementRetryCount()
 		if m.shouldRetry() {
 			// 不在这里递归调用connect，让调用者处理重试逻辑
 			return fmt.Errorf("WebSocket connection failed: %w", err)
@@ -168,6 +196,12 @@ func (m *Websocket) connect(dialURL string) error {
 
 	// 更新连接状态（需要加锁保护）
 	m.mux.Lock()
+	// 已关闭时放弃本次连接，避免关闭后重连成功的连接泄漏
+	if m.ctx.Err() != nil {
+		m.mux.Unlock()
+		conn.Close()
+		return fmt.Errorf("WebSocket closed: %w", m.ctx.Err())
+	}
 	// 关闭旧连接（如果存在）
 	if m.conn != nil {
 		m.conn.Close()
@@ -190,6 +224,7 @@ func (m *Websocket) connect(dialURL string) error {
 		if err := m.afterConnHandler(); err != nil {
 			// 连接回调失败，关闭连接
 			conn.Close()
+			m.incrementRetryCount()
 			return fmt.Errorf("WebSocket after connection handler failed: %w", err)
 		}
 	}
@@ -197,17 +232,28 @@ func (m *Websocket) connect(dialURL string) error {
 	m.metrics.IncrementCounter("websocket.connections.established", map[string]string{
 		"url": dialURL,
 	})
+	m.notifyState(StateConnected, nil)
 
 	return nil
 }
 
 // shouldRetry 判断是否重试
 func (m *Websocket) shouldRetry() bool {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
 	return m.config.MaxRetries == 0 || m.retryCount < m.config.MaxRetries
 }
 
+// incrementRetryCount 增加重试次数
+func (m *Websocket) incrementRetryCount() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.retryCount++
+}
+
 // listenLoop 监听消息，支持上下文取消
 func (m *Websocket) listenLoop() {
+	var readErr error
 	defer func() {
 		// 安全地关闭连接
 		m.mux.Lock()
@@ -217,31 +263,54 @@ func (m *Websocket) listenLoop() {
 		}
 		m.mux.Unlock()
 
+		// 主动关闭时不重连，由 Close 回调状态
+		if m.ctx.Err() != nil {
+			return
+		}
+
 		// 检查是否需要重连
 		if m.shouldRetry() {
-			m.logger.Info("WebSocket Reconnecting...", "attempt", m.retryCount+1)
-			// 使用延迟重连，避免立即递归
+			m.logger.Info("WebSocket Reconnecting...", "attempt", m.GetRetryCount()+1)
+			m.notifyState(StateReconnecting, readErr)
+			// 使用延迟重连，避免立即递归，失败后按重试次数继续重连
 			m.goroutines.Add(1)
 			go func() {
 				defer m.goroutines.Done()
-				time.Sleep(time.Duration(m.config.RetryDelay) * time.Second)
-				if err := m.connect(m.dialURL); err == nil {
-					// 重新启动监听循环
-					m.goroutines.Add(2)
-					go func() {
-						defer m.goroutines.Done()
-						m.listenLoop()
-					}()
-					go func() {
-						defer m.goroutines.Done()
-						m.pingLoop()
-					}()
-				} else {
+				for {
+					select {
+					case <-m.ctx.Done():
+						return
+					case <-time.After(time.Duration(m.config.RetryDelay) * time.Second):
+					}
+					err := m.connect()
+					if err == nil {
+						// 重新启动监听循环
+						m.goroutines.Add(2)
+						go func() {
+							defer m.goroutines.Done()
+							m.listenLoop()
+						}()
+						go func() {
+							defer m.goroutines.Done()
+							m.pingLoop()
+						}()
+						return
+					}
+					if m.ctx.Err() != nil {
+						return
+					}
 					m.logger.Error("WebSocket Reconnect failed", "error", err.Error())
+					if !m.shouldRetry() {
+						m.logger.Info("WebSocket permanently closed after retries", "retry_count", m.GetRetryCount())
+						m.notifyState(StateDisconnected, err)
+						return
+					}
+					m.notifyState(StateReconnecting, err)
 				}
 			}()
 		} else {
-			m.logger.Info("WebSocket permanently closed after retries", "retry_count", m.retryCount)
+			m.logger.Info("WebSocket permanently closed after retries", "retry_count", m.GetRetryCount())
+			m.notifyState(StateDisconnected, readErr)
 		}
 	}()
 
@@ -262,32 +331,38 @@ func (m *Websocket) listenLoop() {
 
 			_, message, err := conn.ReadMessage()
 			if err != nil {
-				m.logger.Error("WebSocket ReadMessage error", "error", err.Error())
+				if m.ctx.Err() == nil {
+					m.logger.Error("WebSocket ReadMessage error", "error", err.Error())
+				}
+				readErr = err
 				return
 			}
 
 			// 记录消息计数（原子操作，无需锁）
 			atomic.AddInt64(&m.messageCount, 1)
 			m.metrics.IncrementCounter("websocket.messages.received", map[string]string{
-				"url": m.dialURL,
+				"url": m.GetDialURL(),
 			})
 
-			// 异步处理消息，避免阻塞读取循环
-			go func() {
-				defer func() {
-					if r := recover(); r != nil {
-						m.metrics.IncrementCounter("websocket.handler.panic", map[string]string{
-							"url": m.dialURL,
-						})
-						m.logger.Error("WebSocket Handler panic", "error", r)
-					}
-				}()
-				m.messageHandler(message)
-			}()
+			// 按接收顺序处理消息，深度增量等数据依赖消息顺序
+			m.handleMessage(message)
 		}
 	}
 }
 
+// handleMessage 处理消息，捕获处理器异常
+func (m *Websocket) handleMessage(message []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.metrics.IncrementCounter("websocket.handler.panic", map[string]string{
+				"url": m.GetDialURL(),
+			})
+			m.logger.Error("WebSocket Handler panic", "error", r)
+		}
+	}()
+	m.messageHandler(message)
+}
+
 // pingLoop 心跳循环（支持标准ping/pong和自定义JSON消息）
 func (m *Websocket) pingLoop() {
 	ticker := time.NewTicker(time.Duration(m.config.PingInterval) * time.Second)
@@ -350,14 +425,27 @@ func (m *Websocket) GetUptime() time.Duration {
 
 // GetDialURL 获取拨号URL
 func (m *Websocket) GetDialURL() string {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
 	return m.dialURL
 }
 
-// SetDialURL 设置拨号URL
+// SetDialURL 设置拨号URL，下次连接或重连时生效
 func (m *Websocket) SetDialURL(dialURL string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
 	m.dialURL = dialURL
 }
 
+// Reconnect 断开当前连接，由监听循环按重连流程重新连接
+func (m *Websocket) Reconnect() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.conn != nil {
+		m.conn.Close()
+	}
+}
+
 // GetStats 获取统计信息
 func (m *Websocket) GetStats() map[string]interface{} {
 	m.mux.RLock()
@@ -372,20 +460,18 @@ func (m *Websocket) GetStats() map[string]interface{} {
 	}
 }
 
-// Close 关闭连接
+// Close 关闭连接并等待后台goroutine退出，关闭后不再重连，不能在消息处理器中调用
 func (m *Websocket) Close() {
 	m.mux.Lock()
-	if !m.isRunning {
-		m.mux.Unlock()
-		return
-	}
-
-	// 取消上下文，停止所有goroutine
+	wasRunning := m.isRunning
+	// 取消上下文，停止所有goroutine，未启动的连接关闭后也无法再启动
 	m.cancel()
 	m.isRunning = false
+	conn := m.conn
+	m.conn = nil
 	m.mux.Unlock()
 
-	if m.conn != nil {
+	if conn != nil {
 		// 安全地发送关闭帧
 		func() {
 			defer func() {
@@ -393,29 +479,22 @@ func (m *Websocket) Close() {
 					m.logger.Error("WebSocket Close frame send panic (ignored)")
 				}
 			}()
-			// 设置关闭超时
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			// 尝试发送关闭帧
-			select {
-			case <-ctx.Done():
-				m.logger.Error("WebSocket Close frame timeout")
-			default:
-				if err := m.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")); err != nil {
-					m.logger.Error("WebSocket Failed to send close frame", "error", err.Error())
-				}
+			deadline := time.Now().Add(5 * time.Second)
+			if err := conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline); err != nil {
+				m.logger.Error("WebSocket Failed to send close frame", "error", err.Error())
 			}
 		}()
 
 		// 关闭连接
-		if err := m.conn.Close(); err != nil {
+		if err := conn.Close(); err != nil {
 			m.logger.Error("WebSocket Failed to close connection", "error", err.Error())
 		}
-		m.conn = nil
 	}
 
 	// 等待所有goroutine完成
 	m.goroutines.Wait()
-	m.logger.Info("WebSocket connection closed")
+	if wasRunning {
+		m.notifyState(StateDisconnected, nil)
+		m.logger.Info("WebSocket connection closed")
+	}
 }
@@ -0,0 +1,175 @@
+package subscription
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Conn 订阅连接
+type Conn interface {
+	// Start 建立连接
+	Start() error
+	// WriteMessage 发送消息
+	WriteMessage(message []byte) error
+}
+
+// Config 订阅连接池配置
+type Config struct {
+	MaxStreams  int                                                    // 单个连接最大订阅数量，0 表示不限制
+	NewConn     func(onConnect func() error, onDisconnect func()) Conn // 创建连接，连接成功后调用 onConnect，连接断开重连前调用 onDisconnect
+	Subscribe   func(conn Conn, streams []string) error                // 发送订阅请求
+	Unsubscribe func(conn Conn, streams []string) error                // 发送取消订阅请求
+}
+
+// conn 连接及其订阅
+type conn struct {
+	conn    Conn
+	streams []string // 已订阅的数据流，按订阅顺序
+	ready   bool     // 连接是否已建立并恢复订阅
+}
+
+// Pool 订阅连接池，记录订阅状态，重连后自动恢复订阅，超过单个连接订阅上限时新建连接
+type Pool struct {
+	config Config
+	conns  []*conn
+	owner  map[string]*conn // 数据流 -> 所在连接
+	mux    sync.Mutex
+}
+
+// NewPool 创建订阅连接池
+func NewPool(config Config) *Pool {
+	return &Pool{
+		config: config,
+		owner:  make(map[string]*conn),
+	}
+}
+
+// Subscribe 订阅数据流，已订阅的数据流忽略，连接已建立时立即发送订阅请求，否则在连接成功后订阅
+func (p *Pool) Subscribe(streams ...string) error {
+	p.mux.Lock()
+	pending := make(map[*conn][]string)
+	var started []*conn
+	for _, stream := range streams {
+		if _, ok := p.owner[stream]; ok || stream == "" {
+			continue
+		}
+		c := p.available()
+		if c == nil {
+			c = &conn{}
+			p.conns = append(p.conns, c)
+			started = append(started, c)
+		}
+		c.streams = append(c.streams, stream)
+		p.owner[stream] = c
+		if c.ready {
+			pending[c] = append(pending[c], stream)
+		}
+	}
+	p.mux.Unlock()
+
+	for c, streams := range pending {
+		if err := p.config.Subscribe(c.conn, streams); err != nil {
+			return fmt.Errorf("订阅失败: %w", err)
+		}
+	}
+	for _, c := range started {
+		c.conn = p.config.NewConn(func() error {
+			return p.replay(c)
+		}, func() {
+			p.mux.Lock()
+			c.ready = false
+			p.mux.Unlock()
+		})
+		if err := c.conn.Start(); err != nil {
+			p.remove(c)
+			return err
+		}
+	}
+	return nil
+}
+
+// Unsubscribe 取消订阅数据流，未订阅的数据流忽略
+func (p *Pool) Unsubscribe(streams ...string) error {
+	p.mux.Lock()
+	pending := make(map[*conn][]string)
+	for _, stream := range streams {
+		c, ok := p.owner[stream]
+		if !ok {
+			continue
+		}
+		delete(p.owner, stream)
+		for i, s := range c.streams {
+			if s == stream {
+				c.streams = append(c.streams[:i], c.streams[i+1:]...)
+				break
+			}
+		}
+		if c.ready {
+			pending[c] = append(pending[c], stream)
+		}
+	}
+	p.mux.Unlock()
+
+	for c, streams := range pending {
+		if err := p.config.Unsubscribe(c.conn, streams); err != nil {
+			return fmt.Errorf("取消订阅失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// Streams 获取已订阅的数据流
+func (p *Pool) Streams() []string {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	streams := make([]string, 0, len(p.owner))
+	for _, c := range p.conns {
+		streams = append(streams, c.streams...)
+	}
+	return streams
+}
+
+// Conns 获取连接数量
+func (p *Pool) Conns() int {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return len(p.conns)
+}
+
+// available 获取未达到订阅上限的连接，调用方需持有锁
+func (p *Pool) available() *conn {
+	for _, c := range p.conns {
+		if p.config.MaxStreams <= 0 || len(c.streams) < p.config.MaxStreams {
+			return c
+		}
+	}
+	return nil
+}
+
+// replay 连接成功后恢复该连接的全部订阅
+func (p *Pool) replay(c *conn) error {
+	p.mux.Lock()
+	streams := append([]string(nil), c.streams...)
+	c.ready = true
+	p.mux.Unlock()
+
+	if len(streams) == 0 {
+		return nil
+	}
+	return p.config.Subscribe(c.conn, streams)
+}
+
+// remove 移除连接及其订阅
+func (p *Pool) remove(c *conn) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	for _, stream := range c.streams {
+		delete(p.owner, stream)
+	}
+	for i := range p.conns {
+		if p.conns[i] == c {
+			p.conns = append(p.conns[:i], p.conns[i+1:]...)
+			break
+		}
+	}
+}
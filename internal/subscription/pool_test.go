@@ -0,0 +1,183 @@
+package subscription
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// fakeConn 模拟连接，记录发送的订阅请求
+type fakeConn struct {
+	onConnect    func() error
+	onDisconnect func()
+	startErr     error
+	subscribed   [][]string
+	unsubscribed [][]string
+	mux          sync.Mutex
+}
+
+func (c *fakeConn) Start() error {
+	if c.startErr != nil {
+		return c.startErr
+	}
+	return c.onConnect()
+}
+
+func (c *fakeConn) WriteMessage([]byte) error {
+	return nil
+}
+
+// reconnect 模拟断线重连
+func (c *fakeConn) reconnect() error {
+	c.onDisconnect()
+	return c.onConnect()
+}
+
+// newTestPool 创建使用模拟连接的连接池
+func newTestPool(maxStreams int, startErr error) (*Pool, *[]*fakeConn) {
+	conns := &[]*fakeConn{}
+	pool := NewPool(Config{
+		MaxStreams: maxStreams,
+		NewConn: func(onConnect func() error, onDisconnect func()) Conn {
+			conn := &fakeConn{onConnect: onConnect, onDisconnect: onDisconnect, startErr: startErr}
+			*conns = append(*conns, conn)
+			return conn
+		},
+		Subscribe: func(conn Conn, streams []string) error {
+			c := conn.(*fakeConn)
+			c.mux.Lock()
+			defer c.mux.Unlock()
+			c.subscribed = append(c.subscribed, streams)
+			return nil
+		},
+		Unsubscribe: func(conn Conn, streams []string) error {
+			c := conn.(*fakeConn)
+			c.mux.Lock()
+			defer c.mux.Unlock()
+			c.unsubscribed = append(c.unsubscribed, streams)
+			return nil
+		},
+	})
+	return pool, conns
+}
+
+// TestPoolSubscribe 订阅去重，连接中订阅立即发送
+// go test -v ./internal/subscription -run "^TestPoolSubscribe$"
+func TestPoolSubscribe(t *testing.T) {
+	pool, conns := newTestPool(0, nil)
+
+	if err := pool.Subscribe("a", "b", "a"); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	if len(*conns) != 1 {
+		t.Fatalf("连接数量错误: %d", len(*conns))
+	}
+	conn := (*conns)[0]
+	if !reflect.DeepEqual(conn.subscribed, [][]string{{"a", "b"}}) {
+		t.Errorf("连接成功后订阅错误: %v", conn.subscribed)
+	}
+
+	if err := pool.Subscribe("b", "c"); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	if !reflect.DeepEqual(conn.subscribed[1], []string{"c"}) {
+		t.Errorf("连接中订阅应只发送新增数据流: %v", conn.subscribed)
+	}
+	if got := pool.Streams(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Streams() = %v", got)
+	}
+}
+
+// TestPoolUnsubscribeAndReplay 取消订阅后重连只恢复剩余订阅
+// go test -v ./internal/subscription -run "^TestPoolUnsubscribeAndReplay$"
+func TestPoolUnsubscribeAndReplay(t *testing.T) {
+	pool, conns := newTestPool(0, nil)
+	if err := pool.Subscribe("a", "b", "c"); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	if err := pool.Unsubscribe("b", "x"); err != nil {
+		t.Fatalf("取消订阅失败: %v", err)
+	}
+	conn := (*conns)[0]
+	if !reflect.DeepEqual(conn.unsubscribed, [][]string{{"b"}}) {
+		t.Errorf("取消订阅错误: %v", conn.unsubscribed)
+	}
+
+	if err := conn.reconnect(); err != nil {
+		t.Fatalf("重连失败: %v", err)
+	}
+	if got := conn.subscribed[len(conn.subscribed)-1]; !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("重连后恢复订阅错误: %v", got)
+	}
+}
+
+// TestPoolSharding 超过单个连接订阅上限时新建连接
+// go test -v ./internal/subscription -run "^TestPoolSharding$"
+func TestPoolSharding(t *testing.T) {
+	pool, conns := newTestPool(2, nil)
+	if err := pool.Subscribe("a", "b", "c", "d", "e"); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	if pool.Conns() != 3 || len(*conns) != 3 {
+		t.Fatalf("连接数量错误: %d", pool.Conns())
+	}
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	for i, conn := range *conns {
+		if !reflect.DeepEqual(conn.subscribed, [][]string{want[i]}) {
+			t.Errorf("连接 %d 订阅错误: %v", i, conn.subscribed)
+		}
+	}
+
+	// 取消订阅后空出的位置优先复用
+	if err := pool.Unsubscribe("a"); err != nil {
+		t.Fatalf("取消订阅失败: %v", err)
+	}
+	if err := pool.Subscribe("f"); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	if pool.Conns() != 3 || !reflect.DeepEqual((*conns)[0].subscribed[1], []string{"f"}) {
+		t.Errorf("未复用已有连接: %v", (*conns)[0].subscribed)
+	}
+}
+
+// TestPoolSubscribeWhileDisconnected 断线期间的订阅在重连后恢复
+// go test -v ./internal/subscription -run "^TestPoolSubscribeWhileDisconnected$"
+func TestPoolSubscribeWhileDisconnected(t *testing.T) {
+	pool, conns := newTestPool(0, nil)
+	if err := pool.Subscribe("a"); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	conn := (*conns)[0]
+	conn.onDisconnect()
+
+	if err := pool.Subscribe("b"); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	if err := pool.Unsubscribe("a"); err != nil {
+		t.Fatalf("取消订阅失败: %v", err)
+	}
+	if len(conn.subscribed) != 1 || len(conn.unsubscribed) != 0 {
+		t.Fatalf("断线期间不应发送请求: %v %v", conn.subscribed, conn.unsubscribed)
+	}
+
+	if err := conn.onConnect(); err != nil {
+		t.Fatalf("重连失败: %v", err)
+	}
+	if got := conn.subscribed[1]; !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("重连后恢复订阅错误: %v", got)
+	}
+}
+
+// TestPoolStartFailed 连接失败时移除该连接的订阅
+// go test -v ./internal/subscription -run "^TestPoolStartFailed$"
+func TestPoolStartFailed(t *testing.T) {
+	startErr := errors.New("dial failed")
+	pool, _ := newTestPool(0, startErr)
+	if err := pool.Subscribe("a"); !errors.Is(err, startErr) {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if pool.Conns() != 0 || len(pool.Streams()) != 0 {
+		t.Errorf("连接失败后应移除订阅: conns=%d streams=%v", pool.Conns(), pool.Streams())
+	}
+}
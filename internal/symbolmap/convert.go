@@ -0,0 +1,116 @@
+package symbolmap
+
+import "github.com/so68/exchange-lib/exchange"
+
+// canonicalTicker 行情交易对转换为统一格式
+func (r *Registry) canonicalTicker(market exchange.Market, ticker *exchange.Ticker) {
+	if ticker != nil {
+		ticker.Symbol = r.Canonical(market, ticker.Symbol)
+	}
+}
+
+// canonicalTickers 行情列表交易对转换为统一格式
+func (r *Registry) canonicalTickers(market exchange.Market, tickers *exchange.Tickers) {
+	if tickers == nil {
+		return
+	}
+	for _, ticker := range tickers.Tickers {
+		r.canonicalTicker(market, ticker)
+	}
+}
+
+// canonicalOrderBook 深度交易对转换为统一格式
+func (r *Registry) canonicalOrderBook(market exchange.Market, orderBook *exchange.OrderBook) {
+	if orderBook != nil {
+		orderBook.Symbol = r.Canonical(market, orderBook.Symbol)
+	}
+}
+
+// canonicalKline K线交易对转换为统一格式
+func (r *Registry) canonicalKline(market exchange.Market, kline *exchange.Kline) {
+	if kline != nil {
+		kline.Symbol = r.Canonical(market, kline.Symbol)
+	}
+}
+
+// canonicalKlines K线列表交易对转换为统一格式
+func (r *Registry) canonicalKlines(market exchange.Market, klines []*exchange.Kline) {
+	for _, kline := range klines {
+		r.canonicalKline(market, kline)
+	}
+}
+
+// canonicalTrade 成交交易对转换为统一格式
+func (r *Registry) canonicalTrade(market exchange.Market, trade *exchange.Trade) {
+	if trade != nil {
+		trade.Symbol = r.Canonical(market, trade.Symbol)
+	}
+}
+
+// canonicalTrades 成交列表交易对转换为统一格式
+func (r *Registry) canonicalTrades(market exchange.Market, trades []*exchange.Trade) {
+	for _, trade := range trades {
+		r.canonicalTrade(market, trade)
+	}
+}
+
+// canonicalOrder 订单交易对转换为统一格式
+func (r *Registry) canonicalOrder(market exchange.Market, order *exchange.Order) {
+	if order != nil {
+		order.Symbol = r.Canonical(market, order.Symbol)
+	}
+}
+
+// canonicalFills 成交明细交易对转换为统一格式
+func (r *Registry) canonicalFills(market exchange.Market, fills ...*exchange.Fill) {
+	for _, fill := range fills {
+		if fill != nil {
+			fill.Symbol = r.Canonical(market, fill.Symbol)
+		}
+	}
+}
+
+// canonicalIncomes 资金流水交易对转换为统一格式
+func (r *Registry) canonicalIncomes(market exchange.Market, incomes ...*exchange.Income) {
+	for _, income := range incomes {
+		if income != nil {
+			income.Symbol = r.Canonical(market, income.Symbol)
+		}
+	}
+}
+
+// canonicalFundingRates 资金费率交易对转换为统一格式
+func (r *Registry) canonicalFundingRates(market exchange.Market, rates ...*exchange.FundingRate) {
+	for _, rate := range rates {
+		if rate != nil {
+			rate.Symbol = r.Canonical(market, rate.Symbol)
+		}
+	}
+}
+
+// canonicalMarkPrices 标记价格交易对转换为统一格式
+func (r *Registry) canonicalMarkPrices(market exchange.Market, markPrices ...*exchange.MarkPrice) {
+	for _, markPrice := range markPrices {
+		if markPrice != nil {
+			markPrice.Symbol = r.Canonical(market, markPrice.Symbol)
+		}
+	}
+}
+
+// canonicalConditionalOrders 条件单交易对转换为统一格式
+func (r *Registry) canonicalConditionalOrders(market exchange.Market, orders ...*exchange.ConditionalOrder) {
+	for _, order := range orders {
+		if order != nil {
+			order.Symbol = r.Canonical(market, order.Symbol)
+		}
+	}
+}
+
+// canonicalPositions 持仓交易对转换为统一格式
+func (r *Registry) canonicalPositions(market exchange.Market, positions []*exchange.PositionRisk) {
+	for _, position := range positions {
+		if position != nil {
+			position.Symbol = r.Canonical(market, position.Symbol)
+		}
+	}
+}
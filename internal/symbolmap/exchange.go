@@ -0,0 +1,344 @@
+package symbolmap
+
+import (
+	"context"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// symbolExchange 在交易所实现外层转换交易对，参数转换为原生交易对，返回值转换为统一格式
+type symbolExchange struct {
+	exchange.Exchange
+	registry *Registry
+}
+
+// WrapExchange 包装交易所实现，交易对映射由 ex.ListInstruments 驱动
+func WrapExchange(ex exchange.Exchange, formatter Formatter) exchange.Exchange {
+	return &symbolExchange{
+		Exchange: ex,
+		registry: NewRegistry(ex.ListInstruments, formatter),
+	}
+}
+
+// native 交易对转换为交易所原生交易对
+func (e *symbolExchange) native(ctx context.Context, market exchange.Market, symbol string) string {
+	return e.registry.Native(ctx, market, symbol)
+}
+
+// GetInstrument 获取交易对元数据
+func (e *symbolExchange) GetInstrument(ctx context.Context, market exchange.Market, symbol string) (*exchange.Instrument, error) {
+	return e.Exchange.GetInstrument(ctx, market, e.native(ctx, market, symbol))
+}
+
+// GetSpotSymbolTickers 获取现货交易对行情
+func (e *symbolExchange) GetSpotSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
+	tickers, err := e.Exchange.GetSpotSymbolTickers(ctx, e.registry.Natives(ctx, exchange.MarketSpot, symbols)...)
+	e.registry.canonicalTickers(exchange.MarketSpot, tickers)
+	return tickers, err
+}
+
+// GetSpotOrderBook 获取现货深度快照
+func (e *symbolExchange) GetSpotOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	orderBook, err := e.Exchange.GetSpotOrderBook(ctx, e.native(ctx, exchange.MarketSpot, symbol), limit)
+	e.registry.canonicalOrderBook(exchange.MarketSpot, orderBook)
+	return orderBook, err
+}
+
+// GetSpotKlines 获取现货K线
+func (e *symbolExchange) GetSpotKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	klines, err := e.Exchange.GetSpotKlines(ctx, e.native(ctx, exchange.MarketSpot, symbol), interval, start, end, limit)
+	e.registry.canonicalKlines(exchange.MarketSpot, klines)
+	return klines, err
+}
+
+// GetSpotRecentTrades 获取现货最近成交
+func (e *symbolExchange) GetSpotRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	trades, err := e.Exchange.GetSpotRecentTrades(ctx, e.native(ctx, exchange.MarketSpot, symbol), limit)
+	e.registry.canonicalTrades(exchange.MarketSpot, trades)
+	return trades, err
+}
+
+// GetSpotAggTrades 获取现货历史成交
+func (e *symbolExchange) GetSpotAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	trades, err := e.Exchange.GetSpotAggTrades(ctx, e.native(ctx, exchange.MarketSpot, symbol), start, end, limit)
+	e.registry.canonicalTrades(exchange.MarketSpot, trades)
+	return trades, err
+}
+
+// PlaceOrder 下单
+func (e *symbolExchange) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	market := req.Market
+	req.Symbol = e.native(ctx, market, req.Symbol)
+	order, err := e.Exchange.PlaceOrder(ctx, req)
+	e.registry.canonicalOrder(market, order)
+	return order, err
+}
+
+// PlaceOrders 批量下单
+func (e *symbolExchange) PlaceOrders(ctx context.Context, reqs []exchange.OrderRequest) ([]exchange.OrderResult, error) {
+	nativeReqs := make([]exchange.OrderRequest, len(reqs))
+	for i, req := range reqs {
+		req.Symbol = e.native(ctx, req.Market, req.Symbol)
+		nativeReqs[i] = req
+	}
+	results, err := e.Exchange.PlaceOrders(ctx, nativeReqs)
+	for i := range results {
+		if i < len(reqs) {
+			e.registry.canonicalOrder(reqs[i].Market, results[i].Order)
+		}
+	}
+	return results, err
+}
+
+// CancelOrders 批量撤单
+func (e *symbolExchange) CancelOrders(ctx context.Context, market exchange.Market, symbol string, orderIDs []string) ([]exchange.OrderResult, error) {
+	results, err := e.Exchange.CancelOrders(ctx, market, e.native(ctx, market, symbol), orderIDs)
+	for _, result := range results {
+		e.registry.canonicalOrder(market, result.Order)
+	}
+	return results, err
+}
+
+// AmendOrder 修改订单
+func (e *symbolExchange) AmendOrder(ctx context.Context, market exchange.Market, symbol, orderID, newPrice, newQty string) (*exchange.Order, error) {
+	order, err := e.Exchange.AmendOrder(ctx, market, e.native(ctx, market, symbol), orderID, newPrice, newQty)
+	e.registry.canonicalOrder(market, order)
+	return order, err
+}
+
+// ListOpenOrders 获取交易对未完成的订单
+func (e *symbolExchange) ListOpenOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.Order, error) {
+	orders, err := e.Exchange.ListOpenOrders(ctx, market, e.native(ctx, market, symbol))
+	for _, order := range orders {
+		e.registry.canonicalOrder(market, order)
+	}
+	return orders, err
+}
+
+// CancelAllOrders 撤销交易对全部挂单
+func (e *symbolExchange) CancelAllOrders(ctx context.Context, market exchange.Market, symbol string) error {
+	return e.Exchange.CancelAllOrders(ctx, market, e.native(ctx, market, symbol))
+}
+
+// ListOrderHistory 分页查询历史订单
+func (e *symbolExchange) ListOrderHistory(ctx context.Context, query exchange.OrderQuery) (*exchange.OrderPage, error) {
+	query.Symbol = e.native(ctx, query.Market, query.Symbol)
+	page, err := e.Exchange.ListOrderHistory(ctx, query)
+	if page != nil {
+		for _, order := range page.Orders {
+			e.registry.canonicalOrder(query.Market, order)
+		}
+	}
+	return page, err
+}
+
+// ListMyTrades 分页查询成交明细
+func (e *symbolExchange) ListMyTrades(ctx context.Context, market exchange.Market, query exchange.FillQuery) (*exchange.FillPage, error) {
+	query.Symbol = e.native(ctx, market, query.Symbol)
+	page, err := e.Exchange.ListMyTrades(ctx, market, query)
+	if page != nil {
+		e.registry.canonicalFills(market, page.Fills...)
+	}
+	return page, err
+}
+
+// PlaceConditionalOrder 下条件单
+func (e *symbolExchange) PlaceConditionalOrder(ctx context.Context, req exchange.ConditionalOrderRequest) (*exchange.ConditionalOrder, error) {
+	market := req.Market
+	req.Symbol = e.native(ctx, market, req.Symbol)
+	order, err := e.Exchange.PlaceConditionalOrder(ctx, req)
+	e.registry.canonicalConditionalOrders(market, order)
+	return order, err
+}
+
+// ListConditionalOrders 获取交易对未触发的条件单
+func (e *symbolExchange) ListConditionalOrders(ctx context.Context, market exchange.Market, symbol string) ([]*exchange.ConditionalOrder, error) {
+	orders, err := e.Exchange.ListConditionalOrders(ctx, market, e.native(ctx, market, symbol))
+	e.registry.canonicalConditionalOrders(market, orders...)
+	return orders, err
+}
+
+// GetConditionalOrder 获取条件单
+func (e *symbolExchange) GetConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) (*exchange.ConditionalOrder, error) {
+	order, err := e.Exchange.GetConditionalOrder(ctx, market, e.native(ctx, market, symbol), id)
+	e.registry.canonicalConditionalOrders(market, order)
+	return order, err
+}
+
+// CancelConditionalOrder 撤销未触发的条件单
+func (e *symbolExchange) CancelConditionalOrder(ctx context.Context, market exchange.Market, symbol string, id string) error {
+	return e.Exchange.CancelConditionalOrder(ctx, market, e.native(ctx, market, symbol), id)
+}
+
+// CreateSpotOrder 现货下单
+func (e *symbolExchange) CreateSpotOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
+	order, err := e.Exchange.CreateSpotOrder(ctx, e.native(ctx, exchange.MarketSpot, symbol), side, limitPrice, quantity)
+	e.registry.canonicalOrder(exchange.MarketSpot, order)
+	return order, err
+}
+
+// GetSpotOrder 获取现货订单
+func (e *symbolExchange) GetSpotOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.GetSpotOrder(ctx, e.native(ctx, exchange.MarketSpot, symbol), orderID)
+	e.registry.canonicalOrder(exchange.MarketSpot, order)
+	return order, err
+}
+
+// GetSpotOrderByClientID 按客户端订单ID获取现货订单
+func (e *symbolExchange) GetSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.GetSpotOrderByClientID(ctx, e.native(ctx, exchange.MarketSpot, symbol), clientOrderID)
+	e.registry.canonicalOrder(exchange.MarketSpot, order)
+	return order, err
+}
+
+// CancelSpotOrder 现货取消订单
+func (e *symbolExchange) CancelSpotOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.CancelSpotOrder(ctx, e.native(ctx, exchange.MarketSpot, symbol), orderID)
+	e.registry.canonicalOrder(exchange.MarketSpot, order)
+	return order, err
+}
+
+// CancelSpotOrderByClientID 按客户端订单ID取消现货订单
+func (e *symbolExchange) CancelSpotOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.CancelSpotOrderByClientID(ctx, e.native(ctx, exchange.MarketSpot, symbol), clientOrderID)
+	e.registry.canonicalOrder(exchange.MarketSpot, order)
+	return order, err
+}
+
+// GetFuturesSymbolTickers 获取合约交易对行情
+func (e *symbolExchange) GetFuturesSymbolTickers(ctx context.Context, symbols ...string) (*exchange.Tickers, error) {
+	tickers, err := e.Exchange.GetFuturesSymbolTickers(ctx, e.registry.Natives(ctx, exchange.MarketFutures, symbols)...)
+	e.registry.canonicalTickers(exchange.MarketFutures, tickers)
+	return tickers, err
+}
+
+// GetFuturesOrderBook 获取合约深度快照
+func (e *symbolExchange) GetFuturesOrderBook(ctx context.Context, symbol string, limit int) (*exchange.OrderBook, error) {
+	orderBook, err := e.Exchange.GetFuturesOrderBook(ctx, e.native(ctx, exchange.MarketFutures, symbol), limit)
+	e.registry.canonicalOrderBook(exchange.MarketFutures, orderBook)
+	return orderBook, err
+}
+
+// GetFuturesKlines 获取合约K线
+func (e *symbolExchange) GetFuturesKlines(ctx context.Context, symbol string, interval exchange.KlineInterval, start, end time.Time, limit int) ([]*exchange.Kline, error) {
+	klines, err := e.Exchange.GetFuturesKlines(ctx, e.native(ctx, exchange.MarketFutures, symbol), interval, start, end, limit)
+	e.registry.canonicalKlines(exchange.MarketFutures, klines)
+	return klines, err
+}
+
+// GetFuturesRecentTrades 获取合约最近成交
+func (e *symbolExchange) GetFuturesRecentTrades(ctx context.Context, symbol string, limit int) ([]*exchange.Trade, error) {
+	trades, err := e.Exchange.GetFuturesRecentTrades(ctx, e.native(ctx, exchange.MarketFutures, symbol), limit)
+	e.registry.canonicalTrades(exchange.MarketFutures, trades)
+	return trades, err
+}
+
+// GetFuturesAggTrades 获取合约历史成交
+func (e *symbolExchange) GetFuturesAggTrades(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.Trade, error) {
+	trades, err := e.Exchange.GetFuturesAggTrades(ctx, e.native(ctx, exchange.MarketFutures, symbol), start, end, limit)
+	e.registry.canonicalTrades(exchange.MarketFutures, trades)
+	return trades, err
+}
+
+// GetFundingRate 获取合约当前资金费率
+func (e *symbolExchange) GetFundingRate(ctx context.Context, symbol string) (*exchange.FundingRate, error) {
+	rate, err := e.Exchange.GetFundingRate(ctx, e.native(ctx, exchange.MarketFutures, symbol))
+	e.registry.canonicalFundingRates(exchange.MarketFutures, rate)
+	return rate, err
+}
+
+// GetFundingRateHistory 获取合约历史资金费率
+func (e *symbolExchange) GetFundingRateHistory(ctx context.Context, symbol string, start, end time.Time, limit int) ([]*exchange.FundingRate, error) {
+	rates, err := e.Exchange.GetFundingRateHistory(ctx, e.native(ctx, exchange.MarketFutures, symbol), start, end, limit)
+	e.registry.canonicalFundingRates(exchange.MarketFutures, rates...)
+	return rates, err
+}
+
+// GetMarkPrice 获取合约标记价格
+func (e *symbolExchange) GetMarkPrice(ctx context.Context, symbols ...string) ([]*exchange.MarkPrice, error) {
+	markPrices, err := e.Exchange.GetMarkPrice(ctx, e.registry.Natives(ctx, exchange.MarketFutures, symbols)...)
+	e.registry.canonicalMarkPrices(exchange.MarketFutures, markPrices...)
+	return markPrices, err
+}
+
+// GetIncomeHistory 分页查询合约资金流水
+func (e *symbolExchange) GetIncomeHistory(ctx context.Context, query exchange.IncomeQuery) (*exchange.IncomePage, error) {
+	if query.Symbol != "" {
+		query.Symbol = e.native(ctx, exchange.MarketFutures, query.Symbol)
+	}
+	page, err := e.Exchange.GetIncomeHistory(ctx, query)
+	if page != nil {
+		e.registry.canonicalIncomes(exchange.MarketFutures, page.Incomes...)
+	}
+	return page, err
+}
+
+// CreateFuturesOrder 合约下单
+func (e *symbolExchange) CreateFuturesOrder(ctx context.Context, symbol string, side exchange.OrderSide, limitPrice, quantity string) (*exchange.Order, error) {
+	order, err := e.Exchange.CreateFuturesOrder(ctx, e.native(ctx, exchange.MarketFutures, symbol), side, limitPrice, quantity)
+	e.registry.canonicalOrder(exchange.MarketFutures, order)
+	return order, err
+}
+
+// GetFuturesOrder 获取合约订单
+func (e *symbolExchange) GetFuturesOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.GetFuturesOrder(ctx, e.native(ctx, exchange.MarketFutures, symbol), orderID)
+	e.registry.canonicalOrder(exchange.MarketFutures, order)
+	return order, err
+}
+
+// GetFuturesOrderByClientID 按客户端订单ID获取合约订单
+func (e *symbolExchange) GetFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.GetFuturesOrderByClientID(ctx, e.native(ctx, exchange.MarketFutures, symbol), clientOrderID)
+	e.registry.canonicalOrder(exchange.MarketFutures, order)
+	return order, err
+}
+
+// GetFuturesPositionRisk 获取合约持仓风险
+func (e *symbolExchange) GetFuturesPositionRisk(ctx context.Context, symbol string) (*exchange.SymbolPositionRisk, error) {
+	positionRisk, err := e.Exchange.GetFuturesPositionRisk(ctx, e.native(ctx, exchange.MarketFutures, symbol))
+	if positionRisk != nil {
+		e.registry.canonicalPositions(exchange.MarketFutures, positionRisk.Data)
+	}
+	return positionRisk, err
+}
+
+// CloseFuturesPositionRisk 平仓合约持仓风险
+func (e *symbolExchange) CloseFuturesPositionRisk(ctx context.Context, symbol string, positionSide exchange.PositionSide) error {
+	return e.Exchange.CloseFuturesPositionRisk(ctx, e.native(ctx, exchange.MarketFutures, symbol), positionSide)
+}
+
+// SetFuturesSLTP 设置合约止损止盈
+func (e *symbolExchange) SetFuturesSLTP(ctx context.Context, symbol string, positionSide exchange.PositionSide, stopPrice string, takeProfitPrice string) error {
+	return e.Exchange.SetFuturesSLTP(ctx, e.native(ctx, exchange.MarketFutures, symbol), positionSide, stopPrice, takeProfitPrice)
+}
+
+// SetFuturesLeverage 设置合约杠杆
+func (e *symbolExchange) SetFuturesLeverage(ctx context.Context, symbol string, leverage int) error {
+	return e.Exchange.SetFuturesLeverage(ctx, e.native(ctx, exchange.MarketFutures, symbol), leverage)
+}
+
+// SetFuturesMarginMode 设置合约保证金模式
+func (e *symbolExchange) SetFuturesMarginMode(ctx context.Context, symbol string, marginMode exchange.MarginMode) error {
+	return e.Exchange.SetFuturesMarginMode(ctx, e.native(ctx, exchange.MarketFutures, symbol), marginMode)
+}
+
+// CancelFuturesSLTP 撤销合约止损止盈
+func (e *symbolExchange) CancelFuturesSLTP(ctx context.Context, symbol string) error {
+	return e.Exchange.CancelFuturesSLTP(ctx, e.native(ctx, exchange.MarketFutures, symbol))
+}
+
+// CancelFuturesOrder 撤销合约订单
+func (e *symbolExchange) CancelFuturesOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.CancelFuturesOrder(ctx, e.native(ctx, exchange.MarketFutures, symbol), orderID)
+	e.registry.canonicalOrder(exchange.MarketFutures, order)
+	return order, err
+}
+
+// CancelFuturesOrderByClientID 按客户端订单ID撤销合约订单
+func (e *symbolExchange) CancelFuturesOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*exchange.Order, error) {
+	order, err := e.Exchange.CancelFuturesOrderByClientID(ctx, e.native(ctx, exchange.MarketFutures, symbol), clientOrderID)
+	e.registry.canonicalOrder(exchange.MarketFutures, order)
+	return order, err
+}
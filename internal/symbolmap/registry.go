@@ -0,0 +1,183 @@
+package symbolmap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+const (
+	ReloadInterval = time.Hour   // 交易对映射有效期，与交易对规格缓存一致
+	RetryInterval  = time.Minute // 加载失败或交易对不存在时重新加载的最小间隔
+)
+
+// Loader 获取市场全部交易对元数据，Instrument.ID 为交易所原生交易对，Instrument.Symbol 为统一格式交易对
+type Loader func(ctx context.Context, market exchange.Market) ([]*exchange.Instrument, error)
+
+// Formatter 交易所交易对命名规则，交易对不在交易对列表中时使用
+type Formatter interface {
+	// Native 统一交易对转换为交易所原生交易对
+	Native(market exchange.Market, symbol exchange.Symbol) string
+	// Canonical 交易所原生交易对转换为统一交易对，无法识别时返回 false
+	Canonical(market exchange.Market, native string) (exchange.Symbol, bool)
+}
+
+// table 单个市场的交易对映射
+type table struct {
+	toNative    map[string]string // 统一格式 -> 原生
+	toCanonical map[string]string // 原生 -> 统一格式
+	loadTime    time.Time         // 最近一次加载成功的时间
+	tryTime     time.Time         // 最近一次尝试加载的时间
+}
+
+// Registry 交易对映射，由交易所的交易对列表驱动，按市场懒加载。
+// 映射由实例的全部调用共享，按实例配置的环境加载，忽略上下文的测试网设置
+type Registry struct {
+	load      Loader
+	formatter Formatter
+	tables    map[exchange.Market]*table
+	mux       sync.RWMutex
+	loadMux   sync.Mutex // 同一时间只加载一次
+}
+
+// NewRegistry 创建交易对映射，load 为空时仅使用命名规则
+func NewRegistry(load Loader, formatter Formatter) *Registry {
+	return &Registry{
+		load:      load,
+		formatter: formatter,
+		tables:    make(map[exchange.Market]*table),
+	}
+}
+
+// Load 加载市场的交易对映射，已加载且未过期时直接返回
+func (r *Registry) Load(ctx context.Context, market exchange.Market) error {
+	if r.load == nil {
+		return nil
+	}
+	r.mux.RLock()
+	t := r.tables[market]
+	r.mux.RUnlock()
+	if t != nil && time.Since(t.loadTime) < ReloadInterval {
+		return nil
+	}
+	return r.reload(ctx, market, t)
+}
+
+// reload 重新加载交易对映射，距离上次尝试不足 RetryInterval 时跳过，stale 为调用方看到的旧映射
+func (r *Registry) reload(ctx context.Context, market exchange.Market, stale *table) error {
+	r.loadMux.Lock()
+	defer r.loadMux.Unlock()
+
+	// 等待期间已被其他调用方重新加载
+	r.mux.RLock()
+	t := r.tables[market]
+	r.mux.RUnlock()
+	if t != stale {
+		return nil
+	}
+	if t != nil && time.Since(t.tryTime) < RetryInterval {
+		return nil
+	}
+
+	instruments, err := r.load(exchange.WithoutTestnet(ctx), market)
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if err != nil {
+		if t == nil {
+			t = &table{}
+			r.tables[market] = t
+		}
+		t.tryTime = time.Now()
+		return err
+	}
+
+	loaded := &table{
+		toNative:    make(map[string]string, len(instruments)),
+		toCanonical: make(map[string]string, len(instruments)),
+		loadTime:    time.Now(),
+		tryTime:     time.Now(),
+	}
+	for _, instrument := range instruments {
+		if instrument.ID == "" || instrument.Symbol == "" {
+			continue
+		}
+		loaded.toNative[instrument.Symbol] = instrument.ID
+		loaded.toCanonical[instrument.ID] = instrument.Symbol
+	}
+	r.tables[market] = loaded
+	return nil
+}
+
+// Native 交易对转换为交易所原生交易对，非统一格式原样返回，不在交易对列表中时按命名规则转换
+func (r *Registry) Native(ctx context.Context, market exchange.Market, symbol string) string {
+	if !exchange.IsCanonicalSymbol(symbol) {
+		return symbol
+	}
+	parsed, err := exchange.ParseSymbol(symbol)
+	if err != nil {
+		return symbol
+	}
+	symbol = parsed.String()
+
+	_ = r.Load(ctx, market)
+	if native, ok := r.lookup(market, symbol, true); ok {
+		return native
+	}
+	// 新上线的交易对，重新加载一次
+	r.mux.RLock()
+	t := r.tables[market]
+	r.mux.RUnlock()
+	if t != nil && r.load != nil {
+		_ = r.reload(ctx, market, t)
+		if native, ok := r.lookup(market, symbol, true); ok {
+			return native
+		}
+	}
+	return r.formatter.Native(market, parsed)
+}
+
+// Natives 批量转换为交易所原生交易对
+func (r *Registry) Natives(ctx context.Context, market exchange.Market, symbols []string) []string {
+	if len(symbols) == 0 {
+		return symbols
+	}
+	natives := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		natives = append(natives, r.Native(ctx, market, symbol))
+	}
+	return natives
+}
+
+// Canonical 交易所原生交易对转换为统一格式，不会发起请求，不在已加载的交易对列表中时按命名规则解析，无法解析时原样返回
+func (r *Registry) Canonical(market exchange.Market, native string) string {
+	if native == "" || exchange.IsCanonicalSymbol(native) {
+		return native
+	}
+	if symbol, ok := r.lookup(market, native, false); ok {
+		return symbol
+	}
+	if symbol, ok := r.formatter.Canonical(market, native); ok {
+		return symbol.String()
+	}
+	return native
+}
+
+// lookup 查找已加载的映射，toNative 为 true 时按统一格式查找原生交易对
+func (r *Registry) lookup(market exchange.Market, key string, toNative bool) (string, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	t := r.tables[market]
+	if t == nil {
+		return "", false
+	}
+	var value string
+	var ok bool
+	if toNative {
+		value, ok = t.toNative[key]
+	} else {
+		value, ok = t.toCanonical[key]
+	}
+	return value, ok
+}
@@ -0,0 +1,145 @@
+package symbolmap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// testFormatter 测试命名规则，原生交易对为 BTC_USDT
+type testFormatter struct{}
+
+func (testFormatter) Native(market exchange.Market, symbol exchange.Symbol) string {
+	return symbol.Base + "_" + symbol.Quote
+}
+
+func (testFormatter) Canonical(market exchange.Market, native string) (exchange.Symbol, bool) {
+	base, quote, ok := strings.Cut(native, "_")
+	if !ok {
+		return exchange.Symbol{}, false
+	}
+	return exchange.NewSpotSymbol(base, quote), true
+}
+
+// testLoader 返回预设交易对列表并记录加载次数
+type testLoader struct {
+	instruments []*exchange.Instrument
+	err         error
+	calls       int
+	testnet     bool // 最近一次加载时上下文是否设置了测试网
+}
+
+func (l *testLoader) load(ctx context.Context, market exchange.Market) ([]*exchange.Instrument, error) {
+	l.calls++
+	l.testnet = exchange.IsTestnet(ctx)
+	return l.instruments, l.err
+}
+
+// TestRegistry 交易对映射由交易对列表驱动，原生交易对原样返回，未知交易对按命名规则转换
+// go test -v ./internal/symbolmap -run "^TestRegistry$"
+func TestRegistry(t *testing.T) {
+	loader := &testLoader{instruments: []*exchange.Instrument{
+		{Symbol: "1000PEPE/USDT", ID: "PEPE1000_USDT"},
+	}}
+	r := NewRegistry(loader.load, testFormatter{})
+	ctx := context.Background()
+
+	if got := r.Native(ctx, exchange.MarketSpot, "BTC_USDT"); got != "BTC_USDT" || loader.calls != 0 {
+		t.Errorf("原生交易对应原样返回且不加载: %s %d", got, loader.calls)
+	}
+	if got := r.Native(ctx, exchange.MarketSpot, "1000pepe/usdt"); got != "PEPE1000_USDT" {
+		t.Errorf("Native() = %s", got)
+	}
+	if got := r.Canonical(exchange.MarketSpot, "PEPE1000_USDT"); got != "1000PEPE/USDT" {
+		t.Errorf("Canonical() = %s", got)
+	}
+
+	// 距离上次加载不足 RetryInterval 时不重新加载，按命名规则转换
+	if got := r.Native(ctx, exchange.MarketSpot, "ETH/USDT"); got != "ETH_USDT" || loader.calls != 1 {
+		t.Errorf("Native() = %s, 加载次数 %d", got, loader.calls)
+	}
+	// 超过 RetryInterval 后，不在列表中的交易对重新加载一次
+	loader.instruments = append(loader.instruments, &exchange.Instrument{Symbol: "SOL/USDT", ID: "SOL_USDT_NEW"})
+	r.tables[exchange.MarketSpot].tryTime = time.Now().Add(-RetryInterval)
+	if got := r.Native(ctx, exchange.MarketSpot, "SOL/USDT"); got != "SOL_USDT_NEW" || loader.calls != 2 {
+		t.Errorf("Native() = %s, 加载次数 %d", got, loader.calls)
+	}
+	if got := r.Canonical(exchange.MarketSpot, "ETH_USDT"); got != "ETH/USDT" {
+		t.Errorf("Canonical() = %s", got)
+	}
+	if got := r.Canonical(exchange.MarketSpot, "ETHUSDT"); got != "ETHUSDT" {
+		t.Errorf("无法解析的原生交易对应原样返回: %s", got)
+	}
+	if got := r.Natives(ctx, exchange.MarketSpot, []string{"BTC/USDT", "ETH_USDT"}); strings.Join(got, ",") != "BTC_USDT,ETH_USDT" {
+		t.Errorf("Natives() = %v", got)
+	}
+}
+
+// TestRegistryLoadError 加载失败时按命名规则转换，且不会在 RetryInterval 内重复加载
+// go test -v ./internal/symbolmap -run "^TestRegistryLoadError$"
+func TestRegistryLoadError(t *testing.T) {
+	loader := &testLoader{err: errors.New("network error")}
+	r := NewRegistry(loader.load, testFormatter{})
+	ctx := context.Background()
+
+	if err := r.Load(ctx, exchange.MarketFutures); err == nil {
+		t.Error("加载失败应返回错误")
+	}
+	if got := r.Native(ctx, exchange.MarketFutures, "BTC/USDT:USDT"); got != "BTC_USDT" || loader.calls != 1 {
+		t.Errorf("Native() = %s, 加载次数 %d", got, loader.calls)
+	}
+}
+
+// TestRegistryTestnet 映射按实例配置的环境加载，首次调用设置了测试网时不影响共享的映射
+// go test -v ./internal/symbolmap -run "^TestRegistryTestnet$"
+func TestRegistryTestnet(t *testing.T) {
+	loader := &testLoader{instruments: []*exchange.Instrument{{Symbol: "1000PEPE/USDT", ID: "PEPE1000_USDT"}}}
+	r := NewRegistry(loader.load, testFormatter{})
+	ctx := exchange.WithTestnet(context.Background())
+
+	if got := r.Native(ctx, exchange.MarketSpot, "1000PEPE/USDT"); got != "PEPE1000_USDT" || loader.testnet {
+		t.Errorf("Native() = %s, 加载时测试网设置 %v", got, loader.testnet)
+	}
+	if !exchange.IsTestnet(ctx) {
+		t.Errorf("不应修改调用方的上下文")
+	}
+}
+
+// testExchange 测试交易所，仅实现用到的方法
+type testExchange struct {
+	exchange.Exchange
+	symbol string // 最近一次请求的交易对
+}
+
+func (e *testExchange) ListInstruments(ctx context.Context, market exchange.Market) ([]*exchange.Instrument, error) {
+	return []*exchange.Instrument{{Symbol: "BTC/USDT:USDT", ID: "BTC_USDT"}}, nil
+}
+
+func (e *testExchange) GetFuturesOrder(ctx context.Context, symbol string, orderID string) (*exchange.Order, error) {
+	e.symbol = symbol
+	return &exchange.Order{Symbol: symbol, OrderID: orderID}, nil
+}
+
+// TestWrapExchange 参数转换为原生交易对，返回值转换为统一格式
+// go test -v ./internal/symbolmap -run "^TestWrapExchange$"
+func TestWrapExchange(t *testing.T) {
+	inner := &testExchange{}
+	ex := WrapExchange(inner, testFormatter{})
+
+	order, err := ex.GetFuturesOrder(context.Background(), "BTC/USDT:USDT", "1")
+	if err != nil {
+		t.Fatalf("获取订单失败: %v", err)
+	}
+	if inner.symbol != "BTC_USDT" || order.Symbol != "BTC/USDT:USDT" {
+		t.Errorf("交易对转换错误: %s %s", inner.symbol, order.Symbol)
+	}
+
+	// 原生交易对参数保持兼容
+	if order, _ := ex.GetFuturesOrder(context.Background(), "BTC_USDT", "1"); inner.symbol != "BTC_USDT" || order.Symbol != "BTC/USDT:USDT" {
+		t.Errorf("交易对转换错误: %s %s", inner.symbol, order.Symbol)
+	}
+}
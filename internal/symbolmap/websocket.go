@@ -0,0 +1,176 @@
+package symbolmap
+
+import (
+	"context"
+
+	"github.com/so68/exchange-lib/exchange"
+)
+
+// symbolWebsocket 在 Websocket 实现外层转换交易对
+type symbolWebsocket struct {
+	exchange.Websocket
+	registry *Registry
+}
+
+// WrapWebsocket 包装 Websocket 实现，load 为空时仅使用命名规则转换
+func WrapWebsocket(ws exchange.Websocket, load Loader, formatter Formatter) exchange.Websocket {
+	return &symbolWebsocket{
+		Websocket: ws,
+		registry:  NewRegistry(load, formatter),
+	}
+}
+
+// channelMarket 频道所属市场
+func channelMarket(channel exchange.Channel) exchange.Market {
+	if channel == exchange.ChannelSpotTicker {
+		return exchange.MarketSpot
+	}
+	return exchange.MarketFutures
+}
+
+// tickerHandler 包装行情回调，交易对转换为统一格式
+func (w *symbolWebsocket) tickerHandler(market exchange.Market, handler func(ticker *exchange.Ticker)) func(ticker *exchange.Ticker) {
+	if handler == nil {
+		return nil
+	}
+	return func(ticker *exchange.Ticker) {
+		w.registry.canonicalTicker(market, ticker)
+		handler(ticker)
+	}
+}
+
+// SetTickerHandler 设置行情频道的回调
+func (w *symbolWebsocket) SetTickerHandler(channel exchange.Channel, handler exchange.WebsocketTickerHandler) {
+	w.Websocket.SetTickerHandler(channel, w.tickerHandler(channelMarket(channel), handler))
+}
+
+// Subscribe 订阅频道，订阅前加载交易对映射
+func (w *symbolWebsocket) Subscribe(channel exchange.Channel, symbols ...string) error {
+	return w.Websocket.Subscribe(channel, w.registry.Natives(context.Background(), channelMarket(channel), symbols)...)
+}
+
+// Unsubscribe 取消订阅频道
+func (w *symbolWebsocket) Unsubscribe(channel exchange.Channel, symbols ...string) error {
+	return w.Websocket.Unsubscribe(channel, w.registry.Natives(context.Background(), channelMarket(channel), symbols)...)
+}
+
+// StartListenSpotTickers 开始监听现货全部交易对行情
+func (w *symbolWebsocket) StartListenSpotTickers(ctx context.Context, handler exchange.WebsocketSpotTickerHandler) error {
+	_ = w.registry.Load(ctx, exchange.MarketSpot)
+	return w.Websocket.StartListenSpotTickers(ctx, w.tickerHandler(exchange.MarketSpot, handler))
+}
+
+// StartListenFuturesTickers 开始监听合约全部交易对行情
+func (w *symbolWebsocket) StartListenFuturesTickers(ctx context.Context, handler exchange.WebsocketFuturesTickerHandler) error {
+	_ = w.registry.Load(ctx, exchange.MarketFutures)
+	return w.Websocket.StartListenFuturesTickers(ctx, w.tickerHandler(exchange.MarketFutures, handler))
+}
+
+// StartListenOrderBook 开始维护本地深度
+func (w *symbolWebsocket) StartListenOrderBook(ctx context.Context, market exchange.Market, symbol string, depth int, handler exchange.WebsocketOrderBookHandler) (exchange.LocalOrderBook, error) {
+	if handler != nil {
+		next := handler
+		handler = func(orderBook *exchange.OrderBook) {
+			w.registry.canonicalOrderBook(market, orderBook)
+			next(orderBook)
+		}
+	}
+	book, err := w.Websocket.StartListenOrderBook(ctx, market, w.registry.Native(ctx, market, symbol), depth, handler)
+	if err != nil || book == nil {
+		return book, err
+	}
+	return &symbolOrderBook{LocalOrderBook: book, market: market, registry: w.registry}, nil
+}
+
+// StartListenKlines 开始监听K线
+func (w *symbolWebsocket) StartListenKlines(ctx context.Context, market exchange.Market, symbol string, interval exchange.KlineInterval, handler exchange.WebsocketKlineHandler) error {
+	if handler != nil {
+		next := handler
+		handler = func(kline *exchange.Kline) {
+			w.registry.canonicalKline(market, kline)
+			next(kline)
+		}
+	}
+	return w.Websocket.StartListenKlines(ctx, market, w.registry.Native(ctx, market, symbol), interval, handler)
+}
+
+// StartListenTrades 开始监听公开成交
+func (w *symbolWebsocket) StartListenTrades(ctx context.Context, market exchange.Market, symbols []string, handler exchange.WebsocketTradeHandler) error {
+	if handler != nil {
+		next := handler
+		handler = func(trade *exchange.Trade) {
+			w.registry.canonicalTrade(market, trade)
+			next(trade)
+		}
+	}
+	return w.Websocket.StartListenTrades(ctx, market, w.registry.Natives(ctx, market, symbols), handler)
+}
+
+// StartListenMarkPrice 开始监听合约标记价格
+func (w *symbolWebsocket) StartListenMarkPrice(ctx context.Context, symbols []string, handler exchange.WebsocketMarkPriceHandler) error {
+	if handler != nil {
+		next := handler
+		handler = func(markPrice *exchange.MarkPrice) {
+			w.registry.canonicalMarkPrices(exchange.MarketFutures, markPrice)
+			next(markPrice)
+		}
+	}
+	return w.Websocket.StartListenMarkPrice(ctx, w.registry.Natives(ctx, exchange.MarketFutures, symbols), handler)
+}
+
+// symbolOrderBook 本地深度的交易对转换为统一格式
+type symbolOrderBook struct {
+	exchange.LocalOrderBook
+	market   exchange.Market
+	registry *Registry
+}
+
+// Symbol 交易对
+func (b *symbolOrderBook) Symbol() string {
+	return b.registry.Canonical(b.market, b.LocalOrderBook.Symbol())
+}
+
+// Snapshot 获取前 depth 档深度
+func (b *symbolOrderBook) Snapshot(depth int) *exchange.OrderBook {
+	orderBook := b.LocalOrderBook.Snapshot(depth)
+	b.registry.canonicalOrderBook(b.market, orderBook)
+	return orderBook
+}
+
+// symbolUserDataStream 在私有数据流实现外层转换交易对
+type symbolUserDataStream struct {
+	exchange.UserDataStream
+	registry *Registry
+}
+
+// WrapUserDataStream 包装私有数据流实现，load 为空时仅使用命名规则转换
+func WrapUserDataStream(stream exchange.UserDataStream, load Loader, formatter Formatter) exchange.UserDataStream {
+	return &symbolUserDataStream{
+		UserDataStream: stream,
+		registry:       NewRegistry(load, formatter),
+	}
+}
+
+// StartListenUserData 开始监听账户私有数据，订单、成交与持仓的交易对转换为统一格式
+func (s *symbolUserDataStream) StartListenUserData(ctx context.Context, market exchange.Market, handler exchange.UserDataHandler) error {
+	_ = s.registry.Load(ctx, market)
+	if next := handler.OnOrder; next != nil {
+		handler.OnOrder = func(update *exchange.OrderUpdate) {
+			s.registry.canonicalOrder(market, update.Order)
+			next(update)
+		}
+	}
+	if next := handler.OnFill; next != nil {
+		handler.OnFill = func(fill *exchange.Fill) {
+			fill.Symbol = s.registry.Canonical(market, fill.Symbol)
+			next(fill)
+		}
+	}
+	if next := handler.OnPosition; next != nil {
+		handler.OnPosition = func(update *exchange.PositionUpdate) {
+			s.registry.canonicalPositions(market, update.Positions)
+			next(update)
+		}
+	}
+	return s.UserDataStream.StartListenUserData(ctx, market, handler)
+}
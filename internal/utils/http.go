@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 // HTTPClient HTTP客户端结构体
 type HTTPClient struct {
 	client  *http.Client
+	ctx     context.Context
 	baseURL string
 	headers map[string]string
 }
@@ -32,17 +34,34 @@ func NewHTTPClient(baseURL string) *HTTPClient {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		ctx:     context.Background(),
 		baseURL: baseURL,
 		headers: make(map[string]string),
 	}
 }
 
+// SetContext 设置请求上下文（链式调用）
+func (c *HTTPClient) SetContext(ctx context.Context) *HTTPClient {
+	if ctx != nil {
+		c.ctx = ctx
+	}
+	return c
+}
+
 // SetTimeout 设置请求超时时间（链式调用）
 func (c *HTTPClient) SetTimeout(timeout time.Duration) *HTTPClient {
 	c.client.Timeout = timeout
 	return c
 }
 
+// SetHTTPClient 设置底层 HTTP 客户端（链式调用）
+func (c *HTTPClient) SetHTTPClient(client *http.Client) *HTTPClient {
+	if client != nil {
+		c.client = client
+	}
+	return c
+}
+
 // SetHeader 设置请求头（链式调用）
 func (c *HTTPClient) SetHeader(key, value string) *HTTPClient {
 	c.headers[key] = value
@@ -127,7 +146,7 @@ func (c *HTTPClient) request(method, path string, params map[string]string, data
 	}
 
 	// 创建请求
-	req, err := http.NewRequest(method, fullURL, body)
+	req, err := http.NewRequestWithContext(c.ctx, method, fullURL, body)
 	if err != nil {
 		return &HTTPResponse{Error: fmt.Errorf("create request error: %w", err)}
 	}
@@ -168,7 +187,7 @@ func (c *HTTPClient) requestForm(method, path string, formData map[string]string
 	body := strings.NewReader(values.Encode())
 
 	// 创建请求
-	req, err := http.NewRequest(method, fullURL, body)
+	req, err := http.NewRequestWithContext(c.ctx, method, fullURL, body)
 	if err != nil {
 		return &HTTPResponse{Error: fmt.Errorf("create request error: %w", err)}
 	}
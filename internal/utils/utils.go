@@ -2,19 +2,26 @@ package utils
 
 import (
 	"fmt"
-	"math/big"
 	"regexp"
 	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
+// MaxPages 分页查询最大请求次数，防止区间过大时无限请求
+const MaxPages = 1000
+
 // AmountWithPriceToQuantity 金额与价格转换为数量
-func AmountWithPriceToQuantity(amount float64, price string, prec int) string {
-	quantity := big.NewFloat(0)
-	if _, ok := quantity.SetString(price); !ok {
+func AmountWithPriceToQuantity(amount, price string, prec int) string {
+	amountDec, err := decimal.NewFromString(amount)
+	if err != nil {
+		return "0"
+	}
+	priceDec, err := decimal.NewFromString(price)
+	if err != nil || priceDec.Sign() <= 0 {
 		return "0"
 	}
-	quantity = quantity.Quo(big.NewFloat(1), quantity).Mul(quantity, big.NewFloat(amount))
-	return quantity.Text('f', prec)
+	return amountDec.DivRound(priceDec, int32(prec)).StringFixed(int32(prec))
 }
 
 // GetNumberPrecision 从 number 字符串中提取小数位数
@@ -43,10 +50,11 @@ func FormatSymbol(symbol string, ft string) string {
 	return symbol
 }
 
-// FormatSymbols 格式化交易对列表
+// FormatSymbols 格式化交易对列表，返回新的列表
 func FormatSymbols(symbols []string, ft string) []string {
+	formatted := make([]string, 0, len(symbols))
 	for _, symbol := range symbols {
-		symbols = append(symbols, FormatSymbol(symbol, ft))
+		formatted = append(formatted, FormatSymbol(symbol, ft))
 	}
-	return symbols
+	return formatted
 }